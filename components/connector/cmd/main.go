@@ -15,14 +15,23 @@ import (
 	"github.com/kyma-incubator/compass/components/connector/internal/api"
 	"github.com/kyma-incubator/compass/components/connector/internal/authentication"
 	"github.com/kyma-incubator/compass/components/connector/internal/certificates"
+	"github.com/kyma-incubator/compass/components/connector/internal/graphqlmetrics"
+	"github.com/kyma-incubator/compass/components/connector/internal/graphqltracing"
+	"github.com/kyma-incubator/compass/components/connector/internal/healthz"
+	"github.com/kyma-incubator/compass/components/connector/internal/metrics"
 	"github.com/kyma-incubator/compass/components/connector/internal/namespacedname"
+	"github.com/kyma-incubator/compass/components/connector/internal/pairing"
+	"github.com/kyma-incubator/compass/components/connector/internal/readiness"
 	"github.com/kyma-incubator/compass/components/connector/internal/revocation"
 	"github.com/kyma-incubator/compass/components/connector/internal/secrets"
 	"github.com/kyma-incubator/compass/components/connector/internal/tokens"
+	"github.com/kyma-incubator/compass/components/connector/internal/tracing"
 	"github.com/kyma-incubator/compass/components/connector/pkg/graphql/externalschema"
 	"github.com/kyma-incubator/compass/components/connector/pkg/graphql/internalschema"
 	"github.com/kyma-incubator/compass/components/connector/pkg/oathkeeper"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/vrischmann/envconfig"
 	"k8s.io/client-go/kubernetes"
@@ -32,6 +41,8 @@ import (
 	"k8s.io/client-go/util/homedir"
 )
 
+const readinessCheckTimeout = 5 * time.Second
+
 type config struct {
 	ExternalAddress       string `envconfig:"default=127.0.0.1:3000"`
 	InternalAddress       string `envconfig:"default=127.0.0.1:3001"`
@@ -39,6 +50,7 @@ type config struct {
 	PlaygroundAPIEndpoint string `envconfig:"default=/graphql"`
 
 	HydratorAddress string `envconfig:"default=127.0.0.1:8080"`
+	MetricsAddress  string `envconfig:"default=127.0.0.1:9090"`
 
 	CSRSubject struct {
 		Country            string `envconfig:"default=PL"`
@@ -63,25 +75,32 @@ type config struct {
 
 	DirectorURL                    string `envconfig:"default=127.0.0.1:3003"`
 	CertificateSecuredConnectorURL string `envconfig:"default=https://compass-gateway-mtls.kyma.local"`
+
+	DirectorPairingStatusURL string        `envconfig:"optional"`
+	DirectorRequestTimeout   time.Duration `envconfig:"default=10s"`
+
+	Tracing tracing.Config
 }
 
 func (c *config) String() string {
-	return fmt.Sprintf("ExternalAddress: %s, InternalAddress: %s, APIEndpoint: %s, HydratorAddress: %s, "+
+	return fmt.Sprintf("ExternalAddress: %s, InternalAddress: %s, APIEndpoint: %s, HydratorAddress: %s, MetricsAddress: %s, "+
 		"CSRSubjectCountry: %s, CSRSubjectOrganization: %s, CSRSubjectOrganizationalUnit: %s, "+
 		"CSRSubjectLocality: %s, CSRSubjectProvince: %s, "+
 		"CertificateValidityTime: %s, CASecretName: %s, RootCACertificateSecretName: %s, CertificateDataHeader: %s, "+
 		"CertificateSecuredConnectorURL: %s, "+
 		"RevocationConfigMapName: %s, "+
 		"TokenLength: %d, TokenRuntimeExpiration: %s, TokenApplicationExpiration: %s, TokenCSRExpiration: %s, "+
-		"DirectorURL: %s",
-		c.ExternalAddress, c.InternalAddress, c.APIEndpoint, c.HydratorAddress,
+		"DirectorURL: %s, DirectorPairingStatusURL: %s, DirectorRequestTimeout: %s, "+
+		"TracingExporter: %s, TracingOTLPEndpoint: %s",
+		c.ExternalAddress, c.InternalAddress, c.APIEndpoint, c.HydratorAddress, c.MetricsAddress,
 		c.CSRSubject.Country, c.CSRSubject.Organization, c.CSRSubject.OrganizationalUnit,
 		c.CSRSubject.Locality, c.CSRSubject.Province,
 		c.CertificateValidityTime, c.CASecretName, c.RootCACertificateSecretName, c.CertificateDataHeader,
 		c.CertificateSecuredConnectorURL,
 		c.RevocationConfigMapName,
 		c.Token.Length, c.Token.RuntimeExpiration.String(), c.Token.ApplicationExpiration.String(), c.Token.CSRExpiration.String(),
-		c.DirectorURL)
+		c.DirectorURL, c.DirectorPairingStatusURL, c.DirectorRequestTimeout.String(),
+		c.Tracing.Exporter, c.Tracing.OTLPEndpoint)
 }
 
 func main() {
@@ -92,24 +111,41 @@ func main() {
 	log.Println("Starting Connector Service")
 	log.Printf("Config: %s", cfg.String())
 
+	shutdownTracing, err := tracing.Configure(cfg.Tracing, "connector", func(err error) {
+		logrus.Warnf("Failed to export spans: %s", err.Error())
+	})
+	exitOnError(err, "Error while configuring tracing")
+	defer shutdownTracing()
+
+	metricsCollector := metrics.NewCollector()
+	prometheus.MustRegister(metricsCollector)
+
 	tokenCache := tokens.NewTokenCache(cfg.Token.ApplicationExpiration, cfg.Token.RuntimeExpiration, cfg.Token.CSRExpiration)
-	tokenService := tokens.NewTokenService(tokenCache, tokens.NewTokenGenerator(cfg.Token.Length))
+	tokenService := metrics.NewTokenService(tokens.NewTokenService(tokenCache, tokens.NewTokenGenerator(cfg.Token.Length)), metricsCollector)
 	coreClientSet, appErr := newCoreClientSet()
 	exitOnError(appErr, "Failed to initialize Kubernetes client.")
 	revokedCertsRepository := newRevokedCertsRepository(coreClientSet, namespacedname.Parse(cfg.RevocationConfigMapName))
+	revocationListChecker := readiness.NewRevocationListChecker(revokedCertsRepository)
+	revokedCertsRepository = metrics.NewRevocationListRepository(revokedCertsRepository, metricsCollector)
 
 	authenticator := authentication.NewAuthenticator()
 
+	pairingReporter := pairing.NewReporter(&http.Client{Timeout: cfg.DirectorRequestTimeout}, cfg.DirectorPairingStatusURL)
+
 	tokenResolver := api.NewTokenResolver(tokenService)
 
 	secretsRepository := newSecretsRepository(coreClientSet)
+	readinessHandler := healthz.NewReadinessHandler(readinessCheckTimeout,
+		healthz.Check{Name: "caSecret", Checker: readiness.NewSecretChecker(secretsRepository, namespacedname.Parse(cfg.CASecretName))},
+		healthz.Check{Name: "revocationList", Checker: revocationListChecker},
+	)
 	certificateUtility := certificates.NewCertificateUtility(cfg.CertificateValidityTime)
-	certificateService := certificates.NewCertificateService(
+	certificateService := metrics.NewCertificateService(certificates.NewCertificateService(
 		secretsRepository,
 		certificateUtility,
 		namespacedname.Parse(cfg.CASecretName),
 		namespacedname.Parse(cfg.RootCACertificateSecretName),
-	)
+	), metricsCollector)
 	csrSubjectConsts := certificates.CSRSubjectConsts{
 		Country:            cfg.CSRSubject.Country,
 		Organization:       cfg.CSRSubject.Organization,
@@ -125,11 +161,13 @@ func main() {
 		csrSubjectConsts,
 		cfg.DirectorURL,
 		cfg.CertificateSecuredConnectorURL,
-		revokedCertsRepository)
+		revokedCertsRepository,
+		pairingReporter)
 
-	externalGqlServer := prepareExternalGraphQLServer(cfg, certificateResolver)
-	internalGqlServer := prepareInternalGraphQLServer(cfg, tokenResolver)
-	hydratorServer := prepareHydratorServer(cfg, tokenService, csrSubjectConsts, revokedCertsRepository)
+	externalGqlServer := prepareExternalGraphQLServer(cfg, certificateResolver, metricsCollector)
+	internalGqlServer := prepareInternalGraphQLServer(cfg, tokenResolver, metricsCollector)
+	hydratorServer := prepareHydratorServer(cfg, tokenService, csrSubjectConsts, revokedCertsRepository, pairingReporter, readinessHandler)
+	metricsServer := prepareMetricsServer(cfg)
 
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
@@ -155,25 +193,32 @@ func main() {
 		}
 	}()
 
+	go func() {
+		log.Printf("Metrics listening on %s...", cfg.MetricsAddress)
+		if err := metricsServer.ListenAndServe(); err != nil {
+			panic(err)
+		}
+	}()
+
 	wg.Wait()
 }
 
-func prepareExternalGraphQLServer(cfg config, certResolver api.CertificateResolver) *http.Server {
+func prepareExternalGraphQLServer(cfg config, certResolver api.CertificateResolver, metricsCollector *metrics.Collector) *http.Server {
 	externalResolver := api.ExternalResolver{CertificateResolver: certResolver}
 
 	gqlInternalCfg := externalschema.Config{
 		Resolvers: &externalResolver,
 	}
 
-	externalExecutableSchema := externalschema.NewExecutableSchema(gqlInternalCfg)
+	externalExecutableSchema := graphqlmetrics.NewExecutableSchema(externalschema.NewExecutableSchema(gqlInternalCfg), metricsCollector)
 
 	externalRouter := mux.NewRouter()
 	externalRouter.HandleFunc("/", handler.Playground("Dataloader", cfg.PlaygroundAPIEndpoint))
-	externalRouter.HandleFunc(cfg.APIEndpoint, handler.GraphQL(externalExecutableSchema))
+	externalRouter.HandleFunc(cfg.APIEndpoint, handler.GraphQL(externalExecutableSchema, handler.ResolverMiddleware(graphqltracing.ResolverMiddleware)))
 
 	authContextMiddleware := authentication.NewAuthenticationContextMiddleware()
 
-	externalRouter.Use(authContextMiddleware.PropagateAuthentication)
+	externalRouter.Use(tracing.NewMiddleware(), authContextMiddleware.PropagateAuthentication)
 
 	return &http.Server{
 		Addr:    cfg.ExternalAddress,
@@ -181,18 +226,19 @@ func prepareExternalGraphQLServer(cfg config, certResolver api.CertificateResolv
 	}
 }
 
-func prepareInternalGraphQLServer(cfg config, tokenResolver api.TokenResolver) *http.Server {
+func prepareInternalGraphQLServer(cfg config, tokenResolver api.TokenResolver, metricsCollector *metrics.Collector) *http.Server {
 	internalResolver := api.InternalResolver{TokenResolver: tokenResolver}
 
 	gqlInternalCfg := internalschema.Config{
 		Resolvers: &internalResolver,
 	}
 
-	internalExecutableSchema := internalschema.NewExecutableSchema(gqlInternalCfg)
+	internalExecutableSchema := graphqlmetrics.NewExecutableSchema(internalschema.NewExecutableSchema(gqlInternalCfg), metricsCollector)
 
 	internalRouter := mux.NewRouter()
+	internalRouter.Use(tracing.NewMiddleware())
 	internalRouter.HandleFunc("/", handler.Playground("Dataloader", cfg.PlaygroundAPIEndpoint))
-	internalRouter.HandleFunc(cfg.APIEndpoint, handler.GraphQL(internalExecutableSchema))
+	internalRouter.HandleFunc(cfg.APIEndpoint, handler.GraphQL(internalExecutableSchema, handler.ResolverMiddleware(graphqltracing.ResolverMiddleware)))
 
 	return &http.Server{
 		Addr:    cfg.InternalAddress,
@@ -200,15 +246,18 @@ func prepareInternalGraphQLServer(cfg config, tokenResolver api.TokenResolver) *
 	}
 }
 
-func prepareHydratorServer(cfg config, tokenService tokens.Service, subjectConsts certificates.CSRSubjectConsts, revokedCertsRepository revocation.RevocationListRepository) *http.Server {
+func prepareHydratorServer(cfg config, tokenService tokens.Service, subjectConsts certificates.CSRSubjectConsts, revokedCertsRepository revocation.RevocationListRepository, pairingReporter pairing.Reporter, readinessHandler http.HandlerFunc) *http.Server {
 	certHeaderParser := oathkeeper.NewHeaderParser(cfg.CertificateDataHeader, subjectConsts)
 
-	validationHydrator := oathkeeper.NewValidationHydrator(tokenService, certHeaderParser, revokedCertsRepository)
+	validationHydrator := oathkeeper.NewValidationHydrator(tokenService, certHeaderParser, revokedCertsRepository, pairingReporter)
 
 	router := mux.NewRouter()
+	router.Use(tracing.NewMiddleware())
 	router.Path("/health").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	router.Path("/livez").HandlerFunc(healthz.NewLivenessHandler())
+	router.Path("/readyz").HandlerFunc(readinessHandler)
 
 	v1Router := router.PathPrefix("/v1").Subrouter()
 	v1Router.HandleFunc("/tokens/resolve", validationHydrator.ResolveConnectorTokenHeader)
@@ -220,6 +269,16 @@ func prepareHydratorServer(cfg config, tokenService tokens.Service, subjectConst
 	}
 }
 
+func prepareMetricsServer(cfg config) *http.Server {
+	router := mux.NewRouter()
+	router.Handle("/metrics", promhttp.Handler())
+
+	return &http.Server{
+		Addr:    cfg.MetricsAddress,
+		Handler: router,
+	}
+}
+
 func exitOnError(err error, context string) {
 	if err != nil {
 		wrappedError := errors.Wrap(err, context)
@@ -7,6 +7,7 @@ import (
 	"github.com/kyma-incubator/compass/components/connector/internal/apperrors"
 	"github.com/kyma-incubator/compass/components/connector/internal/authentication"
 	"github.com/kyma-incubator/compass/components/connector/internal/certificates"
+	"github.com/kyma-incubator/compass/components/connector/internal/pairing"
 	"github.com/kyma-incubator/compass/components/connector/internal/revocation"
 	"github.com/kyma-incubator/compass/components/connector/internal/tokens"
 	"github.com/kyma-incubator/compass/components/connector/pkg/graphql/externalschema"
@@ -28,6 +29,7 @@ type certificateResolver struct {
 	directorURL                    string
 	certificateSecuredConnectorURL string
 	revocationList                 revocation.RevocationListRepository
+	pairingReporter                pairing.Reporter
 	log                            *logrus.Entry
 }
 
@@ -38,7 +40,8 @@ func NewCertificateResolver(
 	csrSubjectConsts certificates.CSRSubjectConsts,
 	directorURL string,
 	certificateSecuredConnectorURL string,
-	revocationList revocation.RevocationListRepository) CertificateResolver {
+	revocationList revocation.RevocationListRepository,
+	pairingReporter pairing.Reporter) CertificateResolver {
 	return &certificateResolver{
 		authenticator:                  authenticator,
 		tokenService:                   tokenService,
@@ -47,6 +50,7 @@ func NewCertificateResolver(
 		directorURL:                    directorURL,
 		certificateSecuredConnectorURL: certificateSecuredConnectorURL,
 		revocationList:                 revocationList,
+		pairingReporter:                pairingReporter,
 		log:                            logrus.WithField("Resolver", "Certificate"),
 	}
 }
@@ -79,6 +83,10 @@ func (r *certificateResolver) SignCertificateSigningRequest(ctx context.Context,
 
 	certificationResult := certificates.ToCertificationResult(encodedCertificates)
 
+	if appErr := r.pairingReporter.Report(clientId, pairing.StatusPaired); appErr != nil {
+		r.log.Warnf("Failed to report pairing of %s client: %s", clientId, appErr.Error())
+	}
+
 	r.log.Infof("Certificate Signing Request signed.")
 	return &certificationResult, nil
 }
@@ -12,6 +12,8 @@ import (
 	authenticationMocks "github.com/kyma-incubator/compass/components/connector/internal/authentication/mocks"
 	"github.com/kyma-incubator/compass/components/connector/internal/certificates"
 	certificatesMocks "github.com/kyma-incubator/compass/components/connector/internal/certificates/mocks"
+	"github.com/kyma-incubator/compass/components/connector/internal/pairing"
+	pairingMocks "github.com/kyma-incubator/compass/components/connector/internal/pairing/mocks"
 	revocationMocks "github.com/kyma-incubator/compass/components/connector/internal/revocation/mocks"
 	"github.com/kyma-incubator/compass/components/connector/internal/tokens"
 	tokensMocks "github.com/kyma-incubator/compass/components/connector/internal/tokens/mocks"
@@ -62,7 +64,10 @@ func TestCertificateResolver_SignCertificateSigningRequest(t *testing.T) {
 		certService := &certificatesMocks.Service{}
 		certService.On("SignCSR", decodedCSR, subject).Return(encodedChain, nil)
 
-		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		pairingReporter := &pairingMocks.Reporter{}
+		pairingReporter.On("Report", clientId, pairing.StatusPaired).Return(nil)
+
+		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, pairingReporter)
 
 		// when
 		certificationResult, err := certificateResolver.SignCertificateSigningRequest(context.TODO(), CSR)
@@ -72,7 +77,37 @@ func TestCertificateResolver_SignCertificateSigningRequest(t *testing.T) {
 		assert.Equal(t, certChainBase64, certificationResult.CertificateChain)
 		assert.Equal(t, caCertificate, certificationResult.CaCertificate)
 		assert.Equal(t, clientCertificate, certificationResult.ClientCertificate)
-		mock.AssertExpectationsForObjects(t, tokenService, authenticator)
+		mock.AssertExpectationsForObjects(t, tokenService, authenticator, pairingReporter)
+	})
+
+	t.Run("should sign client certificate when failed to report pairing status", func(t *testing.T) {
+		// given
+		encodedChain := certificates.EncodedCertificateChain{
+			CertificateChain:  "certChainBase64",
+			CaCertificate:     "caCertificate",
+			ClientCertificate: "clientCertificate",
+		}
+
+		tokenService := &tokensMocks.Service{}
+		revocationList := &revocationMocks.RevocationListRepository{}
+		authenticator := &authenticationMocks.Authenticator{}
+		authenticator.On("Authenticate", context.TODO()).Return(clientId, nil)
+
+		certService := &certificatesMocks.Service{}
+		certService.On("SignCSR", decodedCSR, subject).Return(encodedChain, nil)
+
+		pairingReporter := &pairingMocks.Reporter{}
+		pairingReporter.On("Report", clientId, pairing.StatusPaired).Return(apperrors.UpstreamServerCallFailed("error"))
+
+		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, pairingReporter)
+
+		// when
+		certificationResult, err := certificateResolver.SignCertificateSigningRequest(context.TODO(), CSR)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "certChainBase64", certificationResult.CertificateChain)
+		mock.AssertExpectationsForObjects(t, authenticator, pairingReporter)
 	})
 
 	t.Run("should return error when unauthenticated call", func(t *testing.T) {
@@ -95,7 +130,7 @@ func TestCertificateResolver_SignCertificateSigningRequest(t *testing.T) {
 		certService := &certificatesMocks.Service{}
 		certService.On("SignCSR", decodedCSR, subject).Return(encodedChain, nil)
 
-		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		_, err := certificateResolver.SignCertificateSigningRequest(context.TODO(), CSR)
@@ -125,7 +160,7 @@ func TestCertificateResolver_SignCertificateSigningRequest(t *testing.T) {
 		certService := &certificatesMocks.Service{}
 		certService.On("SignCSR", decodedCSR, subject).Return(encodedChain, nil)
 
-		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		_, err := certificateResolver.SignCertificateSigningRequest(context.TODO(), "not base 64 csr")
@@ -145,7 +180,7 @@ func TestCertificateResolver_SignCertificateSigningRequest(t *testing.T) {
 		certService := &certificatesMocks.Service{}
 		certService.On("SignCSR", decodedCSR, subject).Return(certificates.EncodedCertificateChain{}, apperrors.Internal("error"))
 
-		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, tokenService, certService, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		_, err := certificateResolver.SignCertificateSigningRequest(context.TODO(), CSR)
@@ -165,7 +200,7 @@ func TestCertificateResolver_RevokeCertificate(t *testing.T) {
 		revocationList := &revocationMocks.RevocationListRepository{}
 		revocationList.On("Insert", certificateHash).Return(nil)
 
-		certificateResolver := NewCertificateResolver(authenticator, nil, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, nil, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		revocationResult, err := certificateResolver.RevokeCertificate(context.Background())
@@ -182,7 +217,7 @@ func TestCertificateResolver_RevokeCertificate(t *testing.T) {
 		revocationList := &revocationMocks.RevocationListRepository{}
 		revocationList.On("Insert", certificateHash).Return(nil)
 
-		certificateResolver := NewCertificateResolver(authenticator, nil, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, nil, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		revocationResult, err := certificateResolver.RevokeCertificate(context.Background())
@@ -199,7 +234,7 @@ func TestCertificateResolver_RevokeCertificate(t *testing.T) {
 		revocationList := &revocationMocks.RevocationListRepository{}
 		revocationList.On("Insert", certificateHash).Return(errors.Errorf("error"))
 
-		certificateResolver := NewCertificateResolver(authenticator, nil, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, nil, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		revocationResult, err := certificateResolver.RevokeCertificate(context.Background())
@@ -220,7 +255,7 @@ func TestCertificateResolver_Configuration(t *testing.T) {
 		tokenService.On("CreateToken", subject.CommonName, tokens.CSRToken).Return(token, nil)
 		revocationList := &revocationMocks.RevocationListRepository{}
 
-		certificateResolver := NewCertificateResolver(authenticator, tokenService, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, tokenService, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		configurationResult, err := certificateResolver.Configuration(context.Background())
@@ -243,7 +278,7 @@ func TestCertificateResolver_Configuration(t *testing.T) {
 		tokenService.On("CreateToken", subject.CommonName, tokens.CSRToken).Return("", apperrors.Internal("error"))
 		revocationList := &revocationMocks.RevocationListRepository{}
 
-		certificateResolver := NewCertificateResolver(authenticator, tokenService, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, tokenService, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		configurationResult, err := certificateResolver.Configuration(context.Background())
@@ -261,7 +296,7 @@ func TestCertificateResolver_Configuration(t *testing.T) {
 		tokenService := &tokensMocks.Service{}
 		revocationList := &revocationMocks.RevocationListRepository{}
 
-		certificateResolver := NewCertificateResolver(authenticator, tokenService, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList)
+		certificateResolver := NewCertificateResolver(authenticator, tokenService, nil, subject.CSRSubjectConsts, directorURL, certSecuredConnectorURL, revocationList, nil)
 
 		// when
 		configurationResult, err := certificateResolver.Configuration(context.Background())
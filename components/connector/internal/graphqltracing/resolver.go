@@ -0,0 +1,26 @@
+package graphqltracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/kyma-incubator/compass/components/connector/internal/tracing"
+)
+
+// ResolverMiddleware starts a span for every resolver, skipping the fields which are read from the resolved objects
+func ResolverMiddleware(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	rctx := graphql.GetResolverContext(ctx)
+	if rctx == nil || !rctx.IsMethod {
+		return next(ctx)
+	}
+
+	ctx, span := tracing.StartSpan(ctx, fmt.Sprintf("%s.%s", rctx.Object, rctx.Field.Name), tracing.SpanKindInternal)
+	defer span.End()
+
+	res, err := next(ctx)
+	span.RecordError(err)
+
+	return res, err
+}
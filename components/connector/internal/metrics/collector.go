@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Namespace = "compass"
+	Subsystem = "connector"
+
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+
+	unknownTokenType = "Unknown"
+)
+
+// Collector holds the metrics of the Connector
+type Collector struct {
+	graphQLOperations        *prometheus.CounterVec
+	graphQLOperationDuration *prometheus.HistogramVec
+	certificatesSigned       *prometheus.CounterVec
+	certificatesRevoked      *prometheus.CounterVec
+	tokenResolutions         *prometheus.CounterVec
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		graphQLOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "graphql_operations_total",
+			Help:      "Number of executed GraphQL operations by operation name and outcome",
+		}, []string{"operation", "outcome"}),
+		graphQLOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "graphql_operation_duration_seconds",
+			Help:      "Duration of GraphQL operations by operation name and outcome",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "outcome"}),
+		certificatesSigned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "certificates_signed_total",
+			Help:      "Number of Certificate Signing Requests by outcome",
+		}, []string{"outcome"}),
+		certificatesRevoked: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "certificates_revoked_total",
+			Help:      "Number of certificate revocations by outcome",
+		}, []string{"outcome"}),
+		tokenResolutions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "token_resolutions_total",
+			Help:      "Number of one-time token resolutions by token type and outcome",
+		}, []string{"type", "outcome"}),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.graphQLOperations.Describe(ch)
+	c.graphQLOperationDuration.Describe(ch)
+	c.certificatesSigned.Describe(ch)
+	c.certificatesRevoked.Describe(ch)
+	c.tokenResolutions.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.graphQLOperations.Collect(ch)
+	c.graphQLOperationDuration.Collect(ch)
+	c.certificatesSigned.Collect(ch)
+	c.certificatesRevoked.Collect(ch)
+	c.tokenResolutions.Collect(ch)
+}
+
+func (c *Collector) ObserveGraphQLOperation(operation, outcome string, duration time.Duration) {
+	c.graphQLOperations.WithLabelValues(operation, outcome).Inc()
+	c.graphQLOperationDuration.WithLabelValues(operation, outcome).Observe(duration.Seconds())
+}
+
+func (c *Collector) IncrementCertificatesSigned(outcome string) {
+	c.certificatesSigned.WithLabelValues(outcome).Inc()
+}
+
+func (c *Collector) IncrementCertificatesRevoked(outcome string) {
+	c.certificatesRevoked.WithLabelValues(outcome).Inc()
+}
+
+func (c *Collector) IncrementTokenResolutions(tokenType, outcome string) {
+	c.tokenResolutions.WithLabelValues(tokenType, outcome).Inc()
+}
+
+func outcomeOf(err error) string {
+	if err != nil {
+		return OutcomeError
+	}
+
+	return OutcomeSuccess
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/compass/components/connector/internal/apperrors"
+	"github.com/kyma-incubator/compass/components/connector/internal/certificates"
+	"github.com/kyma-incubator/compass/components/connector/internal/revocation"
+	"github.com/kyma-incubator/compass/components/connector/internal/tokens"
+)
+
+type certificateService struct {
+	certificates.Service
+	collector *Collector
+}
+
+// NewCertificateService returns the certificates Service which counts the signed certificates
+func NewCertificateService(service certificates.Service, collector *Collector) certificates.Service {
+	return &certificateService{Service: service, collector: collector}
+}
+
+func (s *certificateService) SignCSR(encodedCSR []byte, subject certificates.CSRSubject) (certificates.EncodedCertificateChain, apperrors.AppError) {
+	chain, appErr := s.Service.SignCSR(encodedCSR, subject)
+	if appErr != nil {
+		s.collector.IncrementCertificatesSigned(OutcomeError)
+		return chain, appErr
+	}
+	s.collector.IncrementCertificatesSigned(OutcomeSuccess)
+
+	return chain, nil
+}
+
+type revocationListRepository struct {
+	revocation.RevocationListRepository
+	collector *Collector
+}
+
+// NewRevocationListRepository returns the RevocationListRepository which counts the revoked certificates
+func NewRevocationListRepository(repository revocation.RevocationListRepository, collector *Collector) revocation.RevocationListRepository {
+	return &revocationListRepository{RevocationListRepository: repository, collector: collector}
+}
+
+func (r *revocationListRepository) Insert(hash string) error {
+	err := r.RevocationListRepository.Insert(hash)
+	r.collector.IncrementCertificatesRevoked(outcomeOf(err))
+
+	return err
+}
+
+type tokenService struct {
+	tokens.Service
+	collector *Collector
+}
+
+// NewTokenService returns the tokens Service which counts the token resolutions by token type
+func NewTokenService(service tokens.Service, collector *Collector) tokens.Service {
+	return &tokenService{Service: service, collector: collector}
+}
+
+func (s *tokenService) Resolve(token string) (tokens.TokenData, apperrors.AppError) {
+	tokenData, appErr := s.Service.Resolve(token)
+	if appErr != nil {
+		s.collector.IncrementTokenResolutions(unknownTokenType, OutcomeError)
+		return tokenData, appErr
+	}
+	s.collector.IncrementTokenResolutions(string(tokenData.Type), OutcomeSuccess)
+
+	return tokenData, nil
+}
@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/connector/internal/apperrors"
+	"github.com/kyma-incubator/compass/components/connector/internal/certificates"
+	certificatesMocks "github.com/kyma-incubator/compass/components/connector/internal/certificates/mocks"
+	revocationMocks "github.com/kyma-incubator/compass/components/connector/internal/revocation/mocks"
+	"github.com/kyma-incubator/compass/components/connector/internal/tokens"
+	tokensMocks "github.com/kyma-incubator/compass/components/connector/internal/tokens/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token = "token"
+	hash  = "hash"
+)
+
+func TestCertificateService_SignCSR(t *testing.T) {
+	t.Run("should count signed certificates by outcome", func(t *testing.T) {
+		// given
+		collector := NewCollector()
+		subject := certificates.CSRSubject{CommonName: "app"}
+		chain := certificates.EncodedCertificateChain{CertificateChain: "chain"}
+
+		service := &certificatesMocks.Service{}
+		service.On("SignCSR", []byte("csr"), subject).Return(chain, nil).Once()
+		service.On("SignCSR", []byte("invalid"), subject).Return(certificates.EncodedCertificateChain{}, apperrors.BadRequest("invalid CSR")).Once()
+
+		certificateService := NewCertificateService(service, collector)
+
+		// when
+		signedChain, appErr := certificateService.SignCSR([]byte("csr"), subject)
+		_, invalidErr := certificateService.SignCSR([]byte("invalid"), subject)
+
+		// then
+		require.NoError(t, appErr)
+		assert.Equal(t, chain, signedChain)
+		require.Error(t, invalidErr)
+		service.AssertExpectations(t)
+		assertMetrics(t, collector, "compass_connector_certificates_signed_total", `
+# HELP compass_connector_certificates_signed_total Number of Certificate Signing Requests by outcome
+# TYPE compass_connector_certificates_signed_total counter
+compass_connector_certificates_signed_total{outcome="error"} 1
+compass_connector_certificates_signed_total{outcome="success"} 1
+`)
+	})
+}
+
+func TestRevocationListRepository_Insert(t *testing.T) {
+	t.Run("should count revoked certificates by outcome", func(t *testing.T) {
+		// given
+		collector := NewCollector()
+
+		repository := &revocationMocks.RevocationListRepository{}
+		repository.On("Insert", hash).Return(nil).Once()
+		repository.On("Insert", hash).Return(errors.New("test error")).Once()
+
+		revocationList := NewRevocationListRepository(repository, collector)
+
+		// when
+		err := revocationList.Insert(hash)
+		failedErr := revocationList.Insert(hash)
+
+		// then
+		require.NoError(t, err)
+		require.Error(t, failedErr)
+		repository.AssertExpectations(t)
+		assertMetrics(t, collector, "compass_connector_certificates_revoked_total", `
+# HELP compass_connector_certificates_revoked_total Number of certificate revocations by outcome
+# TYPE compass_connector_certificates_revoked_total counter
+compass_connector_certificates_revoked_total{outcome="error"} 1
+compass_connector_certificates_revoked_total{outcome="success"} 1
+`)
+	})
+}
+
+func TestTokenService_Resolve(t *testing.T) {
+	t.Run("should count token resolutions by token type and outcome", func(t *testing.T) {
+		// given
+		collector := NewCollector()
+		tokenData := tokens.TokenData{Type: tokens.RuntimeToken, ClientId: "runtime"}
+
+		service := &tokensMocks.Service{}
+		service.On("Resolve", token).Return(tokenData, nil).Once()
+		service.On("Resolve", "invalid").Return(tokens.TokenData{}, apperrors.NotFound("token not found")).Once()
+
+		tokenService := NewTokenService(service, collector)
+
+		// when
+		resolved, appErr := tokenService.Resolve(token)
+		_, invalidErr := tokenService.Resolve("invalid")
+
+		// then
+		require.NoError(t, appErr)
+		assert.Equal(t, tokenData, resolved)
+		require.Error(t, invalidErr)
+		service.AssertExpectations(t)
+		assertMetrics(t, collector, "compass_connector_token_resolutions_total", `
+# HELP compass_connector_token_resolutions_total Number of one-time token resolutions by token type and outcome
+# TYPE compass_connector_token_resolutions_total counter
+compass_connector_token_resolutions_total{outcome="error",type="Unknown"} 1
+compass_connector_token_resolutions_total{outcome="success",type="Runtime"} 1
+`)
+	})
+}
+
+func assertMetrics(t *testing.T, collector *Collector, metricName, expected string) {
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), metricName)
+	assert.NoError(t, err)
+}
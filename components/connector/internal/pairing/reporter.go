@@ -0,0 +1,74 @@
+package pairing
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/compass/components/connector/internal/apperrors"
+	"github.com/kyma-incubator/compass/components/connector/internal/httputils"
+	"github.com/sirupsen/logrus"
+)
+
+type Status string
+
+const (
+	StatusConsumed Status = "CONSUMED"
+	StatusPaired   Status = "PAIRED"
+)
+
+type statusReport struct {
+	ClientId string `json:"clientId"`
+	Status   Status `json:"status"`
+}
+
+//go:generate mockery -name=Reporter
+type Reporter interface {
+	Report(clientId string, status Status) apperrors.AppError
+}
+
+type reporter struct {
+	httpClient *http.Client
+	url        string
+	log        *logrus.Entry
+}
+
+// NewReporter returns a Reporter notifying the Director about the pairing progress of its clients.
+// Reporting is disabled if the url is empty.
+func NewReporter(httpClient *http.Client, url string) Reporter {
+	if url == "" {
+		return &noopReporter{}
+	}
+
+	return &reporter{
+		httpClient: httpClient,
+		url:        url,
+		log:        logrus.WithField("Reporter", "PairingStatus"),
+	}
+}
+
+func (r *reporter) Report(clientId string, status Status) apperrors.AppError {
+	body, err := json.Marshal(statusReport{ClientId: clientId, Status: status})
+	if err != nil {
+		return apperrors.Internal("Failed to marshal pairing status report: %s", err.Error())
+	}
+
+	resp, err := r.httpClient.Post(r.url, httputils.ContentTypeApplicationJSON, bytes.NewReader(body))
+	if err != nil {
+		return apperrors.UpstreamServerCallFailed("Failed to report pairing status: %s", err.Error())
+	}
+	defer httputils.Close(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return apperrors.UpstreamServerCallFailed("Failed to report pairing status, received status code %d", resp.StatusCode)
+	}
+
+	r.log.Infof("Pairing status of %s client reported as %s", clientId, status)
+	return nil
+}
+
+type noopReporter struct{}
+
+func (r *noopReporter) Report(clientId string, status Status) apperrors.AppError {
+	return nil
+}
@@ -0,0 +1,64 @@
+package pairing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clientId = "abcd-client-id"
+
+func TestReporter_Report(t *testing.T) {
+	t.Run("should send pairing status to the Director", func(t *testing.T) {
+		// given
+		var received statusReport
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			err := json.NewDecoder(r.Body).Decode(&received)
+			require.NoError(t, err)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		reporter := NewReporter(server.Client(), server.URL)
+
+		// when
+		err := reporter.Report(clientId, StatusPaired)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, statusReport{ClientId: clientId, Status: StatusPaired}, received)
+	})
+
+	t.Run("should return error when the Director responds with unexpected status", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		reporter := NewReporter(server.Client(), server.URL)
+
+		// when
+		err := reporter.Report(clientId, StatusConsumed)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+	})
+
+	t.Run("should not report anything when url is empty", func(t *testing.T) {
+		// given
+		reporter := NewReporter(nil, "")
+
+		// when
+		err := reporter.Report(clientId, StatusConsumed)
+
+		// then
+		require.NoError(t, err)
+	})
+}
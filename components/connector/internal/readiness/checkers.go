@@ -0,0 +1,33 @@
+package readiness
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/compass/components/connector/internal/healthz"
+	"github.com/kyma-incubator/compass/components/connector/internal/revocation"
+	"github.com/kyma-incubator/compass/components/connector/internal/secrets"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// NewSecretChecker returns the Checker which fails if the secret cannot be read
+func NewSecretChecker(repository secrets.Repository, name types.NamespacedName) healthz.Checker {
+	return healthz.CheckerFunc(func(ctx context.Context) error {
+		if _, appErr := repository.Get(name); appErr != nil {
+			return errors.Wrapf(appErr, "while reading %s secret", name)
+		}
+
+		return nil
+	})
+}
+
+// NewRevocationListChecker returns the Checker which fails if the revocation list cannot be read
+func NewRevocationListChecker(repository revocation.RevocationListRepository) healthz.Checker {
+	return healthz.CheckerFunc(func(ctx context.Context) error {
+		if _, err := repository.Contains(""); err != nil {
+			return errors.Wrap(err, "while reading revocation list")
+		}
+
+		return nil
+	})
+}
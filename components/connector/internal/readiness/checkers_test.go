@@ -0,0 +1,83 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/connector/internal/apperrors"
+	revocationMocks "github.com/kyma-incubator/compass/components/connector/internal/revocation/mocks"
+	secretsMocks "github.com/kyma-incubator/compass/components/connector/internal/secrets/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var caSecretName = types.NamespacedName{Name: "connector-service-app-ca", Namespace: "kyma-integration"}
+
+func TestSecretChecker(t *testing.T) {
+
+	t.Run("should succeed when secret is readable", func(t *testing.T) {
+		// given
+		repository := &secretsMocks.Repository{}
+		repository.On("Get", caSecretName).Return(map[string][]byte{"ca.crt": []byte("cert")}, nil)
+
+		checker := NewSecretChecker(repository, caSecretName)
+
+		// when
+		err := checker.Check(context.Background())
+
+		// then
+		require.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("should fail when secret cannot be read", func(t *testing.T) {
+		// given
+		repository := &secretsMocks.Repository{}
+		repository.On("Get", caSecretName).Return(nil, apperrors.NotFound("secret not found"))
+
+		checker := NewSecretChecker(repository, caSecretName)
+
+		// when
+		err := checker.Check(context.Background())
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while reading kyma-integration/connector-service-app-ca secret: secret not found")
+		repository.AssertExpectations(t)
+	})
+}
+
+func TestRevocationListChecker(t *testing.T) {
+
+	t.Run("should succeed when revocation list is readable", func(t *testing.T) {
+		// given
+		repository := &revocationMocks.RevocationListRepository{}
+		repository.On("Contains", "").Return(false, nil)
+
+		checker := NewRevocationListChecker(repository)
+
+		// when
+		err := checker.Check(context.Background())
+
+		// then
+		require.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("should fail when revocation list cannot be read", func(t *testing.T) {
+		// given
+		repository := &revocationMocks.RevocationListRepository{}
+		repository.On("Contains", "").Return(false, errors.New("forbidden"))
+
+		checker := NewRevocationListChecker(repository)
+
+		// when
+		err := checker.Check(context.Background())
+
+		// then
+		require.EqualError(t, err, "while reading revocation list: forbidden")
+		repository.AssertExpectations(t)
+	})
+}
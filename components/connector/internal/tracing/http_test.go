@@ -0,0 +1,59 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/connector/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	// given
+	var spanCtx tracing.SpanContext
+	handler := tracing.NewMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spanCtx = tracing.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// when
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// then
+	require.True(t, spanCtx.IsValid())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanCtx.TraceID.String())
+	assert.NotEqual(t, "00f067aa0ba902b7", spanCtx.SpanID.String())
+}
+
+func TestTransport(t *testing.T) {
+	// given
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(tracing.TraceparentHeader)
+	}))
+	defer server.Close()
+
+	ctx, span := tracing.StartSpan(context.Background(), "test", tracing.SpanKindInternal)
+	defer span.End()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	client := &http.Client{Transport: tracing.NewTransport(http.DefaultTransport)}
+
+	// when
+	resp, err := client.Do(req.WithContext(ctx))
+
+	// then
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.NotEmpty(t, received)
+	assert.Contains(t, received, span.Context().TraceID.String())
+	assert.NotContains(t, received, span.Context().SpanID.String())
+	assert.Empty(t, req.Header.Get(tracing.TraceparentHeader))
+}
@@ -0,0 +1,202 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+type TraceID [16]byte
+
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type SpanID [8]byte
+
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext identifies the span across the components
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// SpanKind values are the same as in OpenTelemetry
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// Span measures a single operation. Spans of not sampled traces only propagate their context and are not exported.
+type Span struct {
+	tracer       *tracer
+	context      SpanContext
+	parentSpanID SpanID
+	name         string
+	kind         SpanKind
+	start        time.Time
+
+	mu         sync.Mutex
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+func (s *Span) Context() SpanContext {
+	return s.context
+}
+
+func (s *Span) SetAttribute(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.attributes == nil {
+		s.attributes = make(map[string]string)
+	}
+	s.attributes[key] = value
+}
+
+// RecordError marks the span as failed, unless the error is nil
+func (s *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// End finishes the span and passes it to the exporter if the trace is sampled
+func (s *Span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	data := SpanData{
+		Name:         s.name,
+		Kind:         s.kind,
+		TraceID:      s.context.TraceID,
+		SpanID:       s.context.SpanID,
+		ParentSpanID: s.parentSpanID,
+		Start:        s.start,
+		End:          time.Now(),
+		Attributes:   s.attributes,
+	}
+	if s.err != nil {
+		data.Error = s.err.Error()
+	}
+	s.mu.Unlock()
+
+	if s.context.Sampled && s.tracer.processor != nil {
+		s.tracer.processor.enqueue(data)
+	}
+}
+
+// SpanData is the snapshot of the ended span passed to the exporters
+type SpanData struct {
+	Name         string            `json:"name"`
+	Kind         SpanKind          `json:"kind"`
+	TraceID      TraceID           `json:"-"`
+	SpanID       SpanID            `json:"-"`
+	ParentSpanID SpanID            `json:"-"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+type tracer struct {
+	processor *batchProcessor
+}
+
+var (
+	globalMu     sync.RWMutex
+	globalTracer = &tracer{}
+)
+
+func getTracer() *tracer {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return globalTracer
+}
+
+func setTracer(t *tracer) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	globalTracer = t
+}
+
+type spanCtxKey struct{}
+
+type remoteSpanCtxKey struct{}
+
+// StartSpan starts the child span of the span in the context, or of the span propagated from the caller
+func StartSpan(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	t := getTracer()
+	parent := SpanContextFromContext(ctx)
+
+	span := &Span{tracer: t, name: name, kind: kind, start: time.Now()}
+	if parent.IsValid() {
+		span.context.TraceID = parent.TraceID
+		span.context.Sampled = parent.Sampled
+		span.parentSpanID = parent.SpanID
+	} else {
+		span.context.TraceID = newTraceID()
+		span.context.Sampled = t.processor != nil
+	}
+	span.context.SpanID = newSpanID()
+
+	return context.WithValue(ctx, spanCtxKey{}, span), span
+}
+
+// SpanContextFromContext returns the context of the current span, or of the span propagated from the caller
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span, ok := ctx.Value(spanCtxKey{}).(*Span); ok {
+		return span.context
+	}
+	if sc, ok := ctx.Value(remoteSpanCtxKey{}).(SpanContext); ok {
+		return sc
+	}
+
+	return SpanContext{}
+}
+
+func contextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteSpanCtxKey{}, sc)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
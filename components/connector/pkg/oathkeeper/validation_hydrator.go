@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/kyma-incubator/compass/components/connector/internal/httputils"
+	"github.com/kyma-incubator/compass/components/connector/internal/pairing"
 	"github.com/kyma-incubator/compass/components/connector/internal/revocation"
 	"github.com/kyma-incubator/compass/components/connector/internal/tokens"
 	"github.com/pkg/errors"
@@ -20,14 +21,16 @@ type validationHydrator struct {
 	tokenService     tokens.Service
 	certHeaderParser CertificateHeaderParser
 	revocationList   revocation.RevocationListRepository
+	pairingReporter  pairing.Reporter
 	log              *logrus.Entry
 }
 
-func NewValidationHydrator(tokenService tokens.Service, certHeaderParser CertificateHeaderParser, revocationList revocation.RevocationListRepository) ValidationHydrator {
+func NewValidationHydrator(tokenService tokens.Service, certHeaderParser CertificateHeaderParser, revocationList revocation.RevocationListRepository, pairingReporter pairing.Reporter) ValidationHydrator {
 	return &validationHydrator{
 		tokenService:     tokenService,
 		certHeaderParser: certHeaderParser,
 		revocationList:   revocationList,
+		pairingReporter:  pairingReporter,
 		log:              logrus.WithField("Handler", "ValidationHydrator"),
 	}
 }
@@ -66,6 +69,12 @@ func (tvh *validationHydrator) ResolveConnectorTokenHeader(w http.ResponseWriter
 
 	tvh.tokenService.Delete(connectorToken)
 
+	if tokenData.Type != tokens.CSRToken {
+		if appErr := tvh.pairingReporter.Report(tokenData.ClientId, pairing.StatusConsumed); appErr != nil {
+			tvh.log.Warnf("Failed to report consumed token for %s: %s", tokenData.ClientId, appErr.Error())
+		}
+	}
+
 	tvh.log.Infof("Token for %s resolved successfully", tokenData.ClientId)
 	respondWithAuthSession(w, authSession)
 }
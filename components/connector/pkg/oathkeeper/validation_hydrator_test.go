@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/kyma-incubator/compass/components/connector/internal/pairing"
+	pairingMocks "github.com/kyma-incubator/compass/components/connector/internal/pairing/mocks"
 	revocationMocks "github.com/kyma-incubator/compass/components/connector/internal/revocation/mocks"
 	mocks2 "github.com/kyma-incubator/compass/components/connector/pkg/oathkeeper/mocks"
 
@@ -57,7 +59,10 @@ func TestValidationHydrator_ResolveConnectorTokenHeader(t *testing.T) {
 		tokenService.On("Resolve", token).Return(tokenData, nil)
 		tokenService.On("Delete", token).Return(nil)
 
-		validator := NewValidationHydrator(tokenService, nil, nil)
+		pairingReporter := &pairingMocks.Reporter{}
+		pairingReporter.On("Report", clientId, pairing.StatusConsumed).Return(nil)
+
+		validator := NewValidationHydrator(tokenService, nil, nil, pairingReporter)
 
 		// when
 		validator.ResolveConnectorTokenHeader(rr, req)
@@ -70,7 +75,56 @@ func TestValidationHydrator_ResolveConnectorTokenHeader(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.Equal(t, []string{clientId}, authSession.Header[ClientIdFromTokenHeader])
-		mock.AssertExpectationsForObjects(t, tokenService)
+		mock.AssertExpectationsForObjects(t, tokenService, pairingReporter)
+	})
+
+	t.Run("should resolve token when failed to report pairing status", func(t *testing.T) {
+		// given
+		req := createAuthRequestWithToken(t)
+		rr := httptest.NewRecorder()
+
+		tokenService := &mocks.Service{}
+		tokenService.On("Resolve", token).Return(tokenData, nil)
+		tokenService.On("Delete", token).Return(nil)
+
+		pairingReporter := &pairingMocks.Reporter{}
+		pairingReporter.On("Report", clientId, pairing.StatusConsumed).Return(apperrors.UpstreamServerCallFailed("error"))
+
+		validator := NewValidationHydrator(tokenService, nil, nil, pairingReporter)
+
+		// when
+		validator.ResolveConnectorTokenHeader(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var authSession AuthenticationSession
+		err = json.NewDecoder(rr.Body).Decode(&authSession)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{clientId}, authSession.Header[ClientIdFromTokenHeader])
+		mock.AssertExpectationsForObjects(t, tokenService, pairingReporter)
+	})
+
+	t.Run("should not report pairing status for CSR token", func(t *testing.T) {
+		// given
+		req := createAuthRequestWithToken(t)
+		rr := httptest.NewRecorder()
+
+		tokenService := &mocks.Service{}
+		tokenService.On("Resolve", token).Return(tokens.TokenData{Type: tokens.CSRToken, ClientId: clientId}, nil)
+		tokenService.On("Delete", token).Return(nil)
+
+		pairingReporter := &pairingMocks.Reporter{}
+
+		validator := NewValidationHydrator(tokenService, nil, nil, pairingReporter)
+
+		// when
+		validator.ResolveConnectorTokenHeader(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mock.AssertExpectationsForObjects(t, tokenService, pairingReporter)
 	})
 
 	t.Run("should not modify authentication session if failed to resolved token", func(t *testing.T) {
@@ -81,7 +135,7 @@ func TestValidationHydrator_ResolveConnectorTokenHeader(t *testing.T) {
 		tokenService := &mocks.Service{}
 		tokenService.On("Resolve", token).Return(tokens.TokenData{}, apperrors.NotFound("error"))
 
-		validator := NewValidationHydrator(tokenService, nil, nil)
+		validator := NewValidationHydrator(tokenService, nil, nil, nil)
 
 		// when
 		validator.ResolveConnectorTokenHeader(rr, req)
@@ -105,7 +159,7 @@ func TestValidationHydrator_ResolveConnectorTokenHeader(t *testing.T) {
 
 		tokenService := &mocks.Service{}
 
-		validator := NewValidationHydrator(tokenService, nil, nil)
+		validator := NewValidationHydrator(tokenService, nil, nil, nil)
 
 		// when
 		validator.ResolveConnectorTokenHeader(rr, req)
@@ -127,7 +181,7 @@ func TestValidationHydrator_ResolveConnectorTokenHeader(t *testing.T) {
 		require.NoError(t, err)
 		rr := httptest.NewRecorder()
 
-		validator := NewValidationHydrator(nil, nil, nil)
+		validator := NewValidationHydrator(nil, nil, nil, nil)
 
 		// when
 		validator.ResolveConnectorTokenHeader(rr, req)
@@ -152,7 +206,7 @@ func TestValidationHydrator_ResolveIstioCertHeader(t *testing.T) {
 		revocationList := &revocationMocks.RevocationListRepository{}
 		revocationList.On("Contains", hash).Return(false, nil)
 
-		validator := NewValidationHydrator(nil, certHeaderParser, revocationList)
+		validator := NewValidationHydrator(nil, certHeaderParser, revocationList, nil)
 
 		// when
 		validator.ResolveIstioCertHeader(rr, req)
@@ -177,7 +231,7 @@ func TestValidationHydrator_ResolveIstioCertHeader(t *testing.T) {
 		certHeaderParser := &mocks2.CertificateHeaderParser{}
 		certHeaderParser.On("GetCertificateData", req).Return("", "", false)
 
-		validator := NewValidationHydrator(nil, certHeaderParser, nil)
+		validator := NewValidationHydrator(nil, certHeaderParser, nil, nil)
 
 		// when
 		validator.ResolveIstioCertHeader(rr, req)
@@ -204,7 +258,7 @@ func TestValidationHydrator_ResolveIstioCertHeader(t *testing.T) {
 		revocationList := &revocationMocks.RevocationListRepository{}
 		revocationList.On("Contains", hash).Return(true, nil)
 
-		validator := NewValidationHydrator(nil, certHeaderParser, revocationList)
+		validator := NewValidationHydrator(nil, certHeaderParser, revocationList, nil)
 
 		// when
 		validator.ResolveIstioCertHeader(rr, req)
@@ -231,7 +285,7 @@ func TestValidationHydrator_ResolveIstioCertHeader(t *testing.T) {
 		revocationList := &revocationMocks.RevocationListRepository{}
 		revocationList.On("Contains", hash).Return(false, errors.Errorf("some error"))
 
-		validator := NewValidationHydrator(nil, certHeaderParser, revocationList)
+		validator := NewValidationHydrator(nil, certHeaderParser, revocationList, nil)
 
 		// when
 		validator.ResolveIstioCertHeader(rr, req)
@@ -253,7 +307,7 @@ func TestValidationHydrator_ResolveIstioCertHeader(t *testing.T) {
 		require.NoError(t, err)
 		rr := httptest.NewRecorder()
 
-		validator := NewValidationHydrator(nil, nil, nil)
+		validator := NewValidationHydrator(nil, nil, nil, nil)
 
 		// when
 		validator.ResolveIstioCertHeader(rr, req)
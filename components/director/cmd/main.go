@@ -10,6 +10,8 @@ import (
 
 	"github.com/kyma-incubator/compass/components/director/internal/domain/auth"
 	"github.com/kyma-incubator/compass/components/director/internal/domain/oauth20"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox"
+	"github.com/kyma-incubator/compass/components/director/internal/model"
 
 	"github.com/kyma-incubator/compass/components/director/internal/domain/onetimetoken"
 	"github.com/kyma-incubator/compass/components/director/internal/domain/systemauth"
@@ -17,8 +19,15 @@ import (
 	"github.com/kyma-incubator/compass/components/director/internal/uid"
 
 	"github.com/kyma-incubator/compass/components/director/internal/authenticator"
+	"github.com/kyma-incubator/compass/components/director/internal/filewatcher"
+	"github.com/kyma-incubator/compass/components/director/internal/metrics"
+	"github.com/kyma-incubator/compass/components/director/internal/querylimit"
+	"github.com/kyma-incubator/compass/components/director/internal/readiness"
+	"github.com/kyma-incubator/compass/components/director/pkg/tracing"
 	"github.com/kyma-project/kyma/components/console-backend-service/pkg/executor"
 	"github.com/kyma-project/kyma/components/console-backend-service/pkg/signal"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/kyma-incubator/compass/components/director/pkg/scope"
 
@@ -33,14 +42,21 @@ import (
 	"github.com/99designs/gqlgen/handler"
 	"github.com/gorilla/mux"
 	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+	"github.com/kyma-incubator/compass/components/director/pkg/graphqlmetrics"
+	"github.com/kyma-incubator/compass/components/director/pkg/graphqltracing"
+	"github.com/kyma-incubator/compass/components/director/pkg/healthz"
 	"github.com/vrischmann/envconfig"
 )
 
 const connStringf string = "host=%s port=%s user=%s password=%s dbname=%s sslmode=%s"
 
+const readinessCheckTimeout = 5 * time.Second
+
 type config struct {
-	Address  string `envconfig:"default=127.0.0.1:3000"`
-	Database struct {
+	Address         string `envconfig:"default=127.0.0.1:3000"`
+	InternalAddress string `envconfig:"default=127.0.0.1:3002"`
+	MetricsAddress  string `envconfig:"default=127.0.0.1:9090"`
+	Database        struct {
 		User     string `envconfig:"default=postgres,APP_DB_USER"`
 		Password string `envconfig:"default=pgsql@12345,APP_DB_PASSWORD"`
 		Host     string `envconfig:"default=localhost,APP_DB_HOST"`
@@ -51,6 +67,7 @@ type config struct {
 
 	APIEndpoint                   string `envconfig:"default=/graphql"`
 	TenantMappingEndpoint         string `envconfig:"default=/tenant-mapping"`
+	PairingStatusEndpoint         string `envconfig:"default=/pairing-status"`
 	PlaygroundAPIEndpoint         string `envconfig:"default=/graphql"`
 	ScopesConfigurationFile       string
 	ScopesConfigurationFileReload time.Duration `envconfig:"default=1m"`
@@ -58,12 +75,17 @@ type config struct {
 	JWKSEndpoint        string        `envconfig:"default=file://hack/default-jwks.json"`
 	JWKSSyncPeriod      time.Duration `envconfig:"default=5m"`
 	AllowJWTSigningNone bool          `envconfig:"default=true"`
+	TrustedIssuersSrc   string        `envconfig:"optional"`
 
-	StaticUsersSrc string `envconfig:"default=/data/static-users.yaml"`
+	StaticUsersSrc       string        `envconfig:"default=/data/static-users.yaml"`
+	StaticUsersSrcReload time.Duration `envconfig:"default=1m"`
 
 	OneTimeToken onetimetoken.Config
 	OAuth20      oauth20.Config
+	Outbox       outbox.Config
 	Event        event.Config
+	QueryLimits  querylimit.Config
+	Tracing      tracing.Config
 }
 
 func main() {
@@ -73,6 +95,12 @@ func main() {
 
 	configureLogger()
 
+	shutdownTracing, err := tracing.Configure(cfg.Tracing, "director", func(err error) {
+		log.Warnf("Failed to export spans: %s", err.Error())
+	})
+	exitOnError(err, "Error while configuring tracing")
+	defer shutdownTracing()
+
 	connString := fmt.Sprintf(connStringf, cfg.Database.Host, cfg.Database.Port, cfg.Database.User,
 		cfg.Database.Password, cfg.Database.Name, cfg.Database.SSLMode)
 	transact, closeFunc, err := persistence.Configure(log.StandardLogger(), connString)
@@ -83,22 +111,34 @@ func main() {
 		exitOnError(err, "Error while closing the connection to the database")
 	}()
 
+	databaseChecker := readiness.NewDatabaseChecker(transact)
+
+	metricsCollector := metrics.NewCollector()
+	prometheus.MustRegister(metricsCollector)
+	transact = metrics.NewTransactioner(transact, metricsCollector)
+
 	stopCh := signal.SetupChannel()
-	scopeCfgProvider := createAndRunScopeConfigProvider(stopCh, cfg)
+	scopeCfgProvider := createAndRunScopeConfigProvider(stopCh, cfg, metricsCollector)
+
+	rootResolver := domain.NewRootResolver(transact, scopeCfgProvider, cfg.OneTimeToken, cfg.OAuth20, cfg.Event)
 
 	gqlCfg := graphql.Config{
-		Resolvers: domain.NewRootResolver(transact, scopeCfgProvider, cfg.OneTimeToken, cfg.OAuth20, cfg.Event),
+		Resolvers: rootResolver,
 		Directives: graphql.DirectiveRoot{
 			HasScopes: scope.NewDirective(scopeCfgProvider).VerifyScopes,
 		},
+		Complexity: querylimit.NewComplexityRoot(),
 	}
 
-	executableSchema := graphql.NewExecutableSchema(gqlCfg)
+	executableSchema := querylimit.NewExecutableSchema(graphql.NewExecutableSchema(gqlCfg), cfg.QueryLimits)
+	executableSchema = graphqlmetrics.NewExecutableSchema(executableSchema, metricsCollector)
 
 	mainRouter := mux.NewRouter()
+	mainRouter.Use(tracing.NewMiddleware())
 
 	log.Infof("Registering GraphQL endpoint on %s...", cfg.APIEndpoint)
-	authMiddleware := authenticator.New(cfg.JWKSEndpoint, cfg.AllowJWTSigningNone)
+	authMiddleware, err := newAuthenticator(cfg)
+	exitOnError(err, "Error while configuring authenticator")
 
 	if cfg.JWKSSyncPeriod != 0 {
 		log.Infof("JWKS synchronization enabled. Sync period: %v", cfg.JWKSSyncPeriod)
@@ -115,25 +155,97 @@ func main() {
 
 	gqlAPIRouter := mainRouter.PathPrefix(cfg.APIEndpoint).Subrouter()
 	gqlAPIRouter.Use(authMiddleware.Handler())
-	gqlAPIRouter.HandleFunc("", handler.GraphQL(executableSchema))
+	gqlAPIRouter.HandleFunc("", handler.GraphQL(executableSchema,
+		handler.ResolverMiddleware(graphqltracing.ResolverMiddleware),
+		handler.ResolverMiddleware(rootResolver.Ownership().Handle)))
+
+	uidSvc := uid.NewService()
+	authConverter := auth.NewConverter()
+	systemAuthConverter := systemauth.NewConverter(authConverter)
+	systemAuthRepo := systemauth.NewRepository(systemAuthConverter)
+	systemAuthSvc := systemauth.NewService(systemAuthRepo, uidSvc)
 
 	log.Infof("Registering Tenant Mapping endpoint on %s...", cfg.TenantMappingEndpoint)
-	tenantMappingHandlerFunc, err := getTenantMappingHanderFunc(transact, cfg.StaticUsersSrc, scopeCfgProvider)
+	tenantMappingHandlerFunc, err := getTenantMappingHanderFunc(stopCh, transact, cfg.StaticUsersSrc, cfg.StaticUsersSrcReload, scopeCfgProvider, systemAuthSvc, metricsCollector)
 	exitOnError(err, "Error while configuring tenant mapping handler")
 
 	mainRouter.HandleFunc(cfg.TenantMappingEndpoint, tenantMappingHandlerFunc)
 
-	mainRouter.HandleFunc("/healthz", func(writer http.ResponseWriter, request *http.Request) {
-		writer.WriteHeader(200)
-		_, err := writer.Write([]byte("ok"))
-		if err != nil {
-			log.Errorf(errors.Wrapf(err, "while writing to response body").Error())
-		}
-	})
+	internalRouter := mux.NewRouter()
+	log.Infof("Registering Pairing Status endpoint on %s...", cfg.PairingStatusEndpoint)
+	internalRouter.Handle(cfg.PairingStatusEndpoint, onetimetoken.NewPairingStatusHandler(transact, systemAuthSvc))
+
+	if cfg.OneTimeToken.CleanupPeriod != 0 {
+		log.Infof("Expired one-time tokens cleanup enabled. Cleanup period: %v", cfg.OneTimeToken.CleanupPeriod)
+		cleanupJob := onetimetoken.NewCleanupJob(transact, systemAuthSvc)
+		periodicExecutor := executor.NewPeriodic(cfg.OneTimeToken.CleanupPeriod, func(stopCh <-chan struct{}) {
+			err := cleanupJob.Execute(context.Background())
+			if err != nil {
+				log.Error(errors.Wrap(err, "while deleting expired one-time tokens"))
+			}
+		})
+		go periodicExecutor.Run(stopCh)
+	}
+
+	outboxRepo := outbox.NewRepository(outbox.NewConverter())
+	oAuth20Svc := oauth20.NewService(scopeCfgProvider, uidSvc, outbox.NewService(outboxRepo, uidSvc), cfg.OAuth20)
+
+	if cfg.Outbox.ProcessingPeriod != 0 {
+		log.Infof("Outbox processing enabled. Processing period: %v", cfg.Outbox.ProcessingPeriod)
+		processor := outbox.NewProcessor(transact, outboxRepo, map[model.OutboxOperationType]outbox.Handler{
+			model.OutboxDeleteOAuth20Client: outbox.HandlerFunc(oAuth20Svc.HandleClientDeletion),
+		}, cfg.Outbox, metricsCollector)
+		periodicExecutor := executor.NewPeriodic(cfg.Outbox.ProcessingPeriod, func(stopCh <-chan struct{}) {
+			err := processor.Execute(context.Background())
+			if err != nil {
+				log.Error(errors.Wrap(err, "while processing outbox operations"))
+			}
+		})
+		go periodicExecutor.Run(stopCh)
+	}
+
+	if cfg.OAuth20.ReconciliationPeriod != 0 {
+		log.Infof("OAuth 2.0 clients reconciliation enabled. Reconciliation period: %v", cfg.OAuth20.ReconciliationPeriod)
+		reconciliationJob := oauth20.NewReconciliationJob(transact, oAuth20Svc, systemAuthSvc, cfg.OAuth20.ReconciliationGracePeriod)
+		periodicExecutor := executor.NewPeriodic(cfg.OAuth20.ReconciliationPeriod, func(stopCh <-chan struct{}) {
+			err := reconciliationJob.Execute(context.Background())
+			if err != nil {
+				log.Error(errors.Wrap(err, "while reconciling OAuth 2.0 clients"))
+			}
+		})
+		go periodicExecutor.Run(stopCh)
+	}
+
+	livenessHandler := healthz.NewLivenessHandler()
+	mainRouter.HandleFunc("/healthz", livenessHandler)
+	mainRouter.HandleFunc("/livez", livenessHandler)
+	mainRouter.HandleFunc("/readyz", healthz.NewReadinessHandler(readinessCheckTimeout,
+		healthz.Check{Name: "database", Checker: databaseChecker},
+		healthz.Check{Name: "jwks", Checker: readiness.NewJWKSChecker(authMiddleware, 3*cfg.JWKSSyncPeriod)},
+		healthz.Check{Name: "scopes", Checker: readiness.NewScopesChecker(scopeCfgProvider)},
+	))
 
 	examplesServer := http.FileServer(http.Dir("./examples/"))
 	mainRouter.PathPrefix("/examples/").Handler(http.StripPrefix("/examples/", examplesServer))
 
+	metricsRouter := mux.NewRouter()
+	metricsRouter.Handle("/metrics", promhttp.Handler())
+	metricsSrv := &http.Server{Addr: cfg.MetricsAddress, Handler: metricsRouter}
+	log.Infof("Exposing metrics on %s...", cfg.MetricsAddress)
+	go func() {
+		if err := metricsSrv.ListenAndServe(); err != http.ErrServerClosed {
+			log.Errorf("Metrics HTTP server ListenAndServe: %v", err)
+		}
+	}()
+
+	internalSrv := &http.Server{Addr: cfg.InternalAddress, Handler: internalRouter}
+	log.Infof("Exposing internal API on %s...", cfg.InternalAddress)
+	go func() {
+		if err := internalSrv.ListenAndServe(); err != http.ErrServerClosed {
+			log.Errorf("Internal HTTP server ListenAndServe: %v", err)
+		}
+	}()
+
 	srv := &http.Server{Addr: cfg.Address, Handler: mainRouter}
 	log.Infof("Listening on %s...", cfg.Address)
 	go func() {
@@ -142,6 +254,12 @@ func main() {
 		if err := srv.Shutdown(context.Background()); err != nil {
 			log.Errorf("HTTP server Shutdown: %v", err)
 		}
+		if err := internalSrv.Shutdown(context.Background()); err != nil {
+			log.Errorf("Internal HTTP server Shutdown: %v", err)
+		}
+		if err := metricsSrv.Shutdown(context.Background()); err != nil {
+			log.Errorf("Metrics HTTP server Shutdown: %v", err)
+		}
 	}()
 
 	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
@@ -149,21 +267,36 @@ func main() {
 	}
 }
 
-func createAndRunScopeConfigProvider(stopCh <-chan struct{}, cfg config) *scope.Provider {
+func createAndRunScopeConfigProvider(stopCh <-chan struct{}, cfg config, metricsCollector *metrics.Collector) *scope.Provider {
 	provider := scope.NewProvider(cfg.ScopesConfigurationFile)
 	err := provider.Load()
 	exitOnError(err, "Error on loading scopes config file")
-	executor.NewPeriodic(cfg.ScopesConfigurationFileReload, func(stopCh <-chan struct{}) {
-		if err := provider.Load(); err != nil {
-			exitOnError(err, "Error from Reloader watch")
-		}
-		log.Infof("Successfully reloaded scopes configuration")
 
+	reload := newConfigReloadFunc("scopes", provider.Load, metricsCollector)
+	err = filewatcher.Watch(stopCh, cfg.ScopesConfigurationFile, reload)
+	exitOnError(err, "Error while watching scopes config file")
+
+	// the periodic reload covers the file systems on which the changes are not notified
+	executor.NewPeriodic(cfg.ScopesConfigurationFileReload, func(stopCh <-chan struct{}) {
+		reload()
 	}).Run(stopCh)
 
 	return provider
 }
 
+// newConfigReloadFunc returns the function reloading the configuration. If the new configuration is invalid,
+// the error is logged and counted, and the last valid configuration stays in use.
+func newConfigReloadFunc(name string, load func() error, metricsCollector *metrics.Collector) func() {
+	return func() {
+		if err := load(); err != nil {
+			metricsCollector.IncrementConfigReloadErrors(name)
+			log.Error(errors.Wrapf(err, "while reloading %s configuration, keeping the last valid one", name))
+			return
+		}
+		log.Infof("Successfully reloaded %s configuration", name)
+	}
+}
+
 func exitOnError(err error, context string) {
 	if err != nil {
 		wrappedError := errors.Wrap(err, context)
@@ -178,17 +311,36 @@ func configureLogger() {
 	log.SetReportCaller(true)
 }
 
-func getTenantMappingHanderFunc(transact persistence.Transactioner, staticUsersSrc string, scopeProvider *scope.Provider) (func(writer http.ResponseWriter, request *http.Request), error) {
-	uidSvc := uid.NewService()
-	authConverter := auth.NewConverter()
-	systemAuthConverter := systemauth.NewConverter(authConverter)
-	systemAuthRepo := systemauth.NewRepository(systemAuthConverter)
-	systemAuthSvc := systemauth.NewService(systemAuthRepo, uidSvc)
+// newAuthenticator returns the authenticator of the trusted issuers, or of any issuer signing with the keys from JWKSEndpoint if no issuers are configured
+func newAuthenticator(cfg config) (*authenticator.Authenticator, error) {
+	if cfg.TrustedIssuersSrc == "" {
+		return authenticator.New(cfg.JWKSEndpoint, cfg.AllowJWTSigningNone), nil
+	}
+
+	issuers, err := authenticator.LoadTrustedIssuers(cfg.TrustedIssuersSrc)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading trusted issuers")
+	}
+
+	return authenticator.NewForTrustedIssuers(issuers, cfg.AllowJWTSigningNone), nil
+}
+
+func getTenantMappingHanderFunc(stopCh <-chan struct{}, transact persistence.Transactioner, staticUsersSrc string, staticUsersReload time.Duration, scopeProvider *scope.Provider, systemAuthSvc systemauth.SystemAuthService, metricsCollector *metrics.Collector) (func(writer http.ResponseWriter, request *http.Request), error) {
 	staticUsersRepo, err := tenantmapping.NewStaticUserRepository(staticUsersSrc)
 	if err != nil {
 		return nil, errors.Wrap(err, "while creating StaticUser repository instance")
 	}
 
+	reload := newConfigReloadFunc("static-users", staticUsersRepo.Load, metricsCollector)
+	if err := filewatcher.Watch(stopCh, staticUsersSrc, reload); err != nil {
+		return nil, errors.Wrap(err, "while watching static users file")
+	}
+
+	// the periodic reload covers the file systems on which the changes are not notified
+	executor.NewPeriodic(staticUsersReload, func(stopCh <-chan struct{}) {
+		reload()
+	}).Run(stopCh)
+
 	mapperForUser := tenantmapping.NewMapperForUser(staticUsersRepo)
 	mapperForSystemAuth := tenantmapping.NewMapperForSystemAuth(systemAuthSvc, scopeProvider)
 
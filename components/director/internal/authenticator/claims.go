@@ -2,14 +2,13 @@ package authenticator
 
 import (
 	"errors"
-
-	"github.com/dgrijalva/jwt-go"
 )
 
 type Claims struct {
-	Tenant string `json:"tenant"`
-	Scopes string `json:"scopes"`
-	*jwt.StandardClaims
+	Tenant     string `json:"tenant"`
+	Scopes     string `json:"scopes"`
+	ObjectID   string `json:"objectID"`
+	ObjectType string `json:"objectType"`
 }
 
 func (c Claims) Valid() error {
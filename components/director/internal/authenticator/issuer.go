@@ -0,0 +1,204 @@
+package authenticator
+
+import (
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/ghodss/yaml"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultTenantClaim = "tenant"
+	defaultScopesClaim = "scopes"
+
+	objectIDClaim   = "objectID"
+	objectTypeClaim = "objectType"
+	issuerClaim     = "iss"
+)
+
+// TrustedIssuer is the identity provider whose tokens are accepted by the Director.
+// The issuer with empty Issuer accepts the tokens of all issuers which are not configured explicitly.
+type TrustedIssuer struct {
+	Issuer  string        `json:"issuer"`
+	JWKSURL string        `json:"jwksURL"`
+	Claims  ClaimsMapping `json:"claims"`
+}
+
+// ClaimsMapping defines the names of the token claims holding the tenant and the scopes
+type ClaimsMapping struct {
+	Tenant string `json:"tenant"`
+	Scopes string `json:"scopes"`
+}
+
+// LoadTrustedIssuers reads the list of trusted issuers from the YAML file
+func LoadTrustedIssuers(src string) ([]TrustedIssuer, error) {
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading file %s", src)
+	}
+
+	var issuers []TrustedIssuer
+	if err := yaml.Unmarshal(b, &issuers); err != nil {
+		return nil, errors.Wrap(err, "while unmarshalling trusted issuers")
+	}
+
+	if len(issuers) == 0 {
+		return nil, errors.Errorf("no trusted issuers defined in %s", src)
+	}
+
+	names := make(map[string]struct{})
+	for _, issuer := range issuers {
+		if issuer.JWKSURL == "" {
+			return nil, errors.Errorf("JWKS URL of issuer '%s' cannot be empty", issuer.Issuer)
+		}
+		if _, exists := names[issuer.Issuer]; exists {
+			return nil, errors.Errorf("issuer '%s' is defined more than once", issuer.Issuer)
+		}
+		names[issuer.Issuer] = struct{}{}
+	}
+
+	return issuers, nil
+}
+
+type trustedIssuer struct {
+	TrustedIssuer
+
+	mux                 sync.RWMutex
+	jwks                *jwk.Set
+	lastSync            time.Time
+	lastOnDemandRefresh time.Time
+}
+
+func newTrustedIssuer(issuer TrustedIssuer) *trustedIssuer {
+	if issuer.Claims.Tenant == "" {
+		issuer.Claims.Tenant = defaultTenantClaim
+	}
+	if issuer.Claims.Scopes == "" {
+		issuer.Claims.Scopes = defaultScopesClaim
+	}
+
+	return &trustedIssuer{TrustedIssuer: issuer}
+}
+
+func (i *trustedIssuer) synchronizeJWKS() error {
+	jwks, err := FetchJWK(i.JWKSURL)
+	if err != nil {
+		return errors.Wrapf(err, "while fetching JWKS from endpoint %s", i.JWKSURL)
+	}
+
+	i.mux.Lock()
+	defer i.mux.Unlock()
+	i.jwks = jwks
+	i.lastSync = time.Now()
+
+	return nil
+}
+
+func (i *trustedIssuer) lastSynchronization() time.Time {
+	i.mux.RLock()
+	defer i.mux.RUnlock()
+
+	return i.lastSync
+}
+
+// getKey returns the key with the kid from the token header. If the kid is unknown, the JWKS is refreshed,
+// but not more often than minRefreshInterval. Tokens without kid are verified with the first key of their algorithm.
+func (i *trustedIssuer) getKey(kid, alg string, minRefreshInterval time.Duration) (interface{}, error) {
+	if key, found := i.lookupKey(kid, alg); found {
+		return key.Materialize()
+	}
+	if kid == "" || !i.markOnDemandRefresh(minRefreshInterval) {
+		return nil, errors.Errorf("unable to find key %s for algorithm %s", kid, alg)
+	}
+
+	if err := i.synchronizeJWKS(); err != nil {
+		return nil, errors.Wrapf(err, "while refreshing JWKS for unknown key %s", kid)
+	}
+
+	if key, found := i.lookupKey(kid, alg); found {
+		return key.Materialize()
+	}
+
+	return nil, errors.Errorf("unable to find key %s for algorithm %s", kid, alg)
+}
+
+func (i *trustedIssuer) lookupKey(kid, alg string) (jwk.Key, bool) {
+	i.mux.RLock()
+	defer i.mux.RUnlock()
+
+	if i.jwks == nil {
+		return nil, false
+	}
+
+	keys := i.jwks.Keys
+	if kid != "" {
+		keys = i.jwks.LookupKeyID(kid)
+	}
+	for _, key := range keys {
+		if key.Algorithm() == alg || (kid != "" && key.Algorithm() == "") {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+func (i *trustedIssuer) markOnDemandRefresh(minRefreshInterval time.Duration) bool {
+	i.mux.Lock()
+	defer i.mux.Unlock()
+
+	if time.Since(i.lastOnDemandRefresh) < minRefreshInterval {
+		return false
+	}
+	i.lastOnDemandRefresh = time.Now()
+
+	return true
+}
+
+func (i *trustedIssuer) mapClaims(claims jwt.MapClaims) (Claims, error) {
+	scopes, err := scopesClaim(claims[i.Claims.Scopes])
+	if err != nil {
+		return Claims{}, errors.Wrapf(err, "while reading %s claim", i.Claims.Scopes)
+	}
+
+	mapped := Claims{
+		Tenant:     stringClaim(claims[i.Claims.Tenant]),
+		Scopes:     scopes,
+		ObjectID:   stringClaim(claims[objectIDClaim]),
+		ObjectType: stringClaim(claims[objectTypeClaim]),
+	}
+
+	return mapped, mapped.Valid()
+}
+
+func stringClaim(value interface{}) string {
+	str, _ := value.(string)
+	return str
+}
+
+// scopesClaim accepts the scopes as a space-delimited string or as a list of strings
+func scopesClaim(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []interface{}:
+		scopes := make([]string, 0, len(v))
+		for _, item := range v {
+			scope, ok := item.(string)
+			if !ok {
+				return "", errors.Errorf("unexpected scope type %T", item)
+			}
+			scopes = append(scopes, scope)
+		}
+		return strings.Join(scopes, " "), nil
+	}
+
+	return "", errors.Errorf("unexpected scopes type %T", value)
+}
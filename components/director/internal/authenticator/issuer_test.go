@@ -0,0 +1,63 @@
+package authenticator_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/director/internal/authenticator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTrustedIssuers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		//when
+		issuers, err := authenticator.LoadTrustedIssuers("testdata/trusted-issuers.yaml")
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []authenticator.TrustedIssuer{
+			{
+				Issuer:  "https://oathkeeper.kyma.local",
+				JWKSURL: "http://ory-oathkeeper-api.kyma-system.svc.cluster.local:4456/.well-known/jwks.json",
+			},
+			{
+				Issuer:  "https://idp.example.com",
+				JWKSURL: "https://idp.example.com/.well-known/jwks.json",
+				Claims:  authenticator.ClaimsMapping{Tenant: "zid", Scopes: "scp"},
+			},
+		}, issuers)
+	})
+
+	testCases := []struct {
+		Name        string
+		Src         string
+		ExpectedErr string
+	}{
+		{
+			Name:        "Error when file does not exist",
+			Src:         "testdata/not-existing.yaml",
+			ExpectedErr: "while reading file testdata/not-existing.yaml",
+		},
+		{
+			Name:        "Error when issuer is duplicated",
+			Src:         "testdata/trusted-issuers-duplicated.yaml",
+			ExpectedErr: "issuer 'https://idp.example.com' is defined more than once",
+		},
+		{
+			Name:        "Error when JWKS URL is missing",
+			Src:         "testdata/trusted-issuers-without-jwks.yaml",
+			ExpectedErr: "JWKS URL of issuer 'https://idp.example.com' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			//when
+			_, err := authenticator.LoadTrustedIssuers(testCase.Src)
+
+			//then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.ExpectedErr)
+		})
+	}
+}
@@ -5,39 +5,75 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/kyma-incubator/compass/components/director/internal/tenant"
+	"github.com/kyma-incubator/compass/components/director/pkg/consumer"
 	"github.com/kyma-incubator/compass/components/director/pkg/scope"
 
 	"github.com/dgrijalva/jwt-go"
-	"github.com/lestrrat-go/jwx/jwk"
 )
 
 const AuthorizationHeaderKey = "Authorization"
 
+// minJWKSRefreshInterval limits how often the JWKS of an issuer is refreshed because of tokens signed with unknown keys
+const minJWKSRefreshInterval = time.Minute
+
 type Authenticator struct {
-	jwksEndpoint        string
+	issuers             []*trustedIssuer
 	allowJWTSigningNone bool
-	Jwks                *jwk.Set
 }
 
+// New returns the Authenticator which accepts the tokens of any issuer, verified with the keys from jwksEndpoint
 func New(jwksEndpoint string, allowJWTSigningNone bool) *Authenticator {
-	return &Authenticator{jwksEndpoint: jwksEndpoint, allowJWTSigningNone: allowJWTSigningNone}
+	return NewForTrustedIssuers([]TrustedIssuer{{JWKSURL: jwksEndpoint}}, allowJWTSigningNone)
+}
+
+// NewForTrustedIssuers returns the Authenticator which accepts only the tokens of the trusted issuers
+func NewForTrustedIssuers(issuers []TrustedIssuer, allowJWTSigningNone bool) *Authenticator {
+	trusted := make([]*trustedIssuer, 0, len(issuers))
+	for _, issuer := range issuers {
+		trusted = append(trusted, newTrustedIssuer(issuer))
+	}
+
+	return &Authenticator{issuers: trusted, allowJWTSigningNone: allowJWTSigningNone}
 }
 
 func (a *Authenticator) SynchronizeJWKS() error {
-	jwks, err := FetchJWK(a.jwksEndpoint)
-	if err != nil {
-		return errors.Wrapf(err, "while fetching JWKS from endpoint %s", a.jwksEndpoint)
+	var errs []string
+	for _, issuer := range a.issuers {
+		if err := issuer.synchronizeJWKS(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
 	}
 
-	a.Jwks = jwks
 	return nil
 }
 
+// LastJWKSSynchronization returns the time of the oldest of the last successful JWKS synchronizations of all issuers,
+// or zero time if any of them has never succeeded
+func (a *Authenticator) LastJWKSSynchronization() time.Time {
+	var oldest time.Time
+	for _, issuer := range a.issuers {
+		lastSync := issuer.lastSynchronization()
+		if lastSync.IsZero() {
+			return time.Time{}
+		}
+		if oldest.IsZero() || lastSync.Before(oldest) {
+			oldest = lastSync
+		}
+	}
+
+	return oldest
+}
+
 func (a *Authenticator) Handler() func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -48,9 +84,9 @@ func (a *Authenticator) Handler() func(next http.Handler) http.Handler {
 				return
 			}
 
-			claims := Claims{}
+			tokenClaims := jwt.MapClaims{}
 
-			token, err := jwt.ParseWithClaims(bearerToken, &claims, a.getKeyFunc())
+			token, err := jwt.ParseWithClaims(bearerToken, tokenClaims, a.getKeyFunc())
 			if err != nil {
 				wrappedErr := errors.Wrap(err, "while parsing token")
 				log.Error(wrappedErr)
@@ -63,6 +99,14 @@ func (a *Authenticator) Handler() func(next http.Handler) http.Handler {
 				return
 			}
 
+			claims, err := a.mapClaims(tokenClaims)
+			if err != nil {
+				wrappedErr := errors.Wrap(err, "while mapping token claims")
+				log.Error(wrappedErr)
+				http.Error(w, wrappedErr.Error(), http.StatusUnauthorized)
+				return
+			}
+
 			ctx := a.contextWithClaims(r.Context(), claims)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -84,7 +128,9 @@ func (a *Authenticator) contextWithClaims(ctx context.Context, claims Claims) co
 	ctxWithTenant := tenant.SaveToContext(ctx, claims.Tenant)
 	scopesArray := strings.Split(claims.Scopes, " ")
 	ctxWithScopes := scope.SaveToContext(ctxWithTenant, scopesArray)
-	return ctxWithScopes
+	apiConsumer := consumer.Consumer{ConsumerID: claims.ObjectID, ConsumerType: consumer.ConsumerType(claims.ObjectType)}
+	ctxWithConsumer := consumer.SaveToContext(ctxWithScopes, apiConsumer)
+	return ctxWithConsumer
 }
 
 func (a *Authenticator) getKeyFunc() func(token *jwt.Token) (interface{}, error) {
@@ -92,15 +138,18 @@ func (a *Authenticator) getKeyFunc() func(token *jwt.Token) (interface{}, error)
 		unsupportedErr := fmt.Errorf("unexpected signing method: %v", token.Method.Alg())
 
 		switch token.Method.Alg() {
-		case jwt.SigningMethodRS256.Name:
-			keys := a.Jwks.Keys
-			for _, key := range keys {
-				if key.Algorithm() == token.Method.Alg() {
-					return key.Materialize()
-				}
+		case jwt.SigningMethodRS256.Name, jwt.SigningMethodES256.Name:
+			claims, ok := token.Claims.(jwt.MapClaims)
+			if !ok {
+				return nil, errors.New("unexpected claims type")
+			}
+			issuer, err := a.issuerFor(claims)
+			if err != nil {
+				return nil, err
 			}
 
-			return nil, fmt.Errorf("unable to find key for algorithm %s", token.Method.Alg())
+			kid, _ := token.Header["kid"].(string)
+			return issuer.getKey(kid, token.Method.Alg(), minJWKSRefreshInterval)
 		case jwt.SigningMethodNone.Alg():
 			if !a.allowJWTSigningNone {
 				return nil, unsupportedErr
@@ -111,3 +160,33 @@ func (a *Authenticator) getKeyFunc() func(token *jwt.Token) (interface{}, error)
 		return nil, unsupportedErr
 	}
 }
+
+func (a *Authenticator) mapClaims(claims jwt.MapClaims) (Claims, error) {
+	issuer, err := a.issuerFor(claims)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	return issuer.mapClaims(claims)
+}
+
+// issuerFor returns the issuer configured for the iss claim, or the issuer accepting all tokens if there is no such issuer
+func (a *Authenticator) issuerFor(claims jwt.MapClaims) (*trustedIssuer, error) {
+	iss := stringClaim(claims[issuerClaim])
+
+	var fallback *trustedIssuer
+	for _, issuer := range a.issuers {
+		if issuer.Issuer == iss {
+			return issuer, nil
+		}
+		if issuer.Issuer == "" {
+			fallback = issuer
+		}
+	}
+
+	if fallback == nil {
+		return nil, errors.Errorf("issuer '%s' is not trusted", iss)
+	}
+
+	return fallback, nil
+}
@@ -1,14 +1,22 @@
 package authenticator_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/lestrrat-go/jwx/jwk"
 
+	"github.com/kyma-incubator/compass/components/director/pkg/consumer"
 	"github.com/kyma-incubator/compass/components/director/pkg/scope"
 
 	"github.com/dgrijalva/jwt-go"
@@ -101,6 +109,41 @@ func TestAuthenticator_Handler(t *testing.T) {
 
 	})
 
+	t.Run("Success - consumer is read from token", func(t *testing.T) {
+		//given
+		middleware := createMiddleware(t, true)
+		rr := httptest.NewRecorder()
+
+		req, err := http.NewRequest("GET", "/", nil)
+		require.NoError(t, err)
+
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, jwtTokenClaims{
+			Tenant:     tnt,
+			Scopes:     scopes,
+			ObjectID:   "ba9d5c4e-07b6-4e93-9c0e-3d9a3b3cf9f5",
+			ObjectType: "Application",
+		})
+		signedToken, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", signedToken))
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiConsumer, err := consumer.LoadFromContext(r.Context())
+			require.NoError(t, err)
+			assert.Equal(t, consumer.Consumer{ConsumerID: "ba9d5c4e-07b6-4e93-9c0e-3d9a3b3cf9f5", ConsumerType: consumer.Application}, apiConsumer)
+
+			_, err = w.Write([]byte("OK"))
+			require.NoError(t, err)
+		})
+
+		//when
+		middleware(handler).ServeHTTP(rr, req)
+
+		//then
+		assert.Equal(t, "OK", rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
 	t.Run("Error - token with no signing method when it's not allowed", func(t *testing.T) {
 		//given
 		middleware := createMiddleware(t, false)
@@ -166,9 +209,231 @@ func TestAuthenticator_Handler(t *testing.T) {
 	})
 }
 
+func TestAuthenticator_Handler_KeySelection(t *testing.T) {
+	//given
+	scopes := "scope-a scope-b"
+
+	privateJWKS, err := authenticator.FetchJWK(PrivateJWKSURL)
+	require.NoError(t, err)
+	privateJWKS2, err := authenticator.FetchJWK(PrivateJWKS2URL)
+	require.NoError(t, err)
+
+	rsaKey1 := privateJWKS.Keys[0]
+	rsaKey2 := privateJWKS2.Keys[0]
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "jwks")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	t.Run("Success - key selected by kid during key rotation", func(t *testing.T) {
+		//given
+		jwksURL := writeJWKS(t, dir, publicJWK(t, rsaKey1, "key-1", "RS256"), publicJWK(t, rsaKey2, "key-2", "RS256"))
+		auth := authenticator.New(jwksURL, false)
+		require.NoError(t, auth.SynchronizeJWKS())
+
+		token := createSignedToken(t, jwt.SigningMethodRS256, "key-2", materialize(t, rsaKey2), jwtTokenClaims{Tenant: tnt, Scopes: scopes})
+
+		//when
+		rr := serve(t, auth, token, testHandler(t, tnt, scopes))
+
+		//then
+		assert.Equal(t, "OK", rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Success - JWKS refreshed when kid is unknown", func(t *testing.T) {
+		//given
+		jwksURL := writeJWKS(t, dir, publicJWK(t, rsaKey1, "key-1", "RS256"))
+		auth := authenticator.New(jwksURL, false)
+		require.NoError(t, auth.SynchronizeJWKS())
+		overwriteJWKS(t, jwksURL, publicJWK(t, rsaKey1, "key-1", "RS256"), publicJWK(t, rsaKey2, "key-2", "RS256"))
+
+		token := createSignedToken(t, jwt.SigningMethodRS256, "key-2", materialize(t, rsaKey2), jwtTokenClaims{Tenant: tnt, Scopes: scopes})
+
+		//when
+		rr := serve(t, auth, token, testHandler(t, tnt, scopes))
+
+		//then
+		assert.Equal(t, "OK", rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Error - JWKS not refreshed again before minimal interval passes", func(t *testing.T) {
+		//given
+		jwksURL := writeJWKS(t, dir, publicJWK(t, rsaKey1, "key-1", "RS256"))
+		auth := authenticator.New(jwksURL, false)
+		require.NoError(t, auth.SynchronizeJWKS())
+
+		unknownKeyToken := createSignedToken(t, jwt.SigningMethodRS256, "key-3", materialize(t, rsaKey2), jwtTokenClaims{Tenant: tnt, Scopes: scopes})
+		rr := serve(t, auth, unknownKeyToken, testHandler(t, tnt, scopes))
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		overwriteJWKS(t, jwksURL, publicJWK(t, rsaKey1, "key-1", "RS256"), publicJWK(t, rsaKey2, "key-2", "RS256"))
+		token := createSignedToken(t, jwt.SigningMethodRS256, "key-2", materialize(t, rsaKey2), jwtTokenClaims{Tenant: tnt, Scopes: scopes})
+
+		//when
+		rr = serve(t, auth, token, testHandler(t, tnt, scopes))
+
+		//then
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "while parsing token: unable to find key key-2 for algorithm RS256\n", rr.Body.String())
+	})
+
+	t.Run("Success - token signed with ES256", func(t *testing.T) {
+		//given
+		ecdsaJWK, err := jwk.New(&ecdsaKey.PublicKey)
+		require.NoError(t, err)
+		require.NoError(t, ecdsaJWK.Set(jwk.KeyIDKey, "ec-key"))
+		require.NoError(t, ecdsaJWK.Set(jwk.AlgorithmKey, "ES256"))
+
+		auth := authenticator.New(writeJWKS(t, dir, publicJWK(t, rsaKey1, "key-1", "RS256"), ecdsaJWK), false)
+		require.NoError(t, auth.SynchronizeJWKS())
+
+		token := createSignedToken(t, jwt.SigningMethodES256, "ec-key", ecdsaKey, jwtTokenClaims{Tenant: tnt, Scopes: scopes})
+
+		//when
+		rr := serve(t, auth, token, testHandler(t, tnt, scopes))
+
+		//then
+		assert.Equal(t, "OK", rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestAuthenticator_Handler_TrustedIssuers(t *testing.T) {
+	//given
+	privateJWKS, err := authenticator.FetchJWK(PrivateJWKSURL)
+	require.NoError(t, err)
+	rsaKey := privateJWKS.Keys[0]
+
+	auth := authenticator.NewForTrustedIssuers([]authenticator.TrustedIssuer{
+		{
+			Issuer:  "https://oathkeeper.kyma.local",
+			JWKSURL: PublicJWKSURL,
+		},
+		{
+			Issuer:  "https://idp.example.com",
+			JWKSURL: PublicJWKSURL,
+			Claims:  authenticator.ClaimsMapping{Tenant: "zid", Scopes: "scp"},
+		},
+	}, false)
+	require.NoError(t, auth.SynchronizeJWKS())
+
+	t.Run("Success - default claims mapping", func(t *testing.T) {
+		//given
+		token := createSignedToken(t, jwt.SigningMethodRS256, "", materialize(t, rsaKey), jwtTokenClaims{
+			Tenant:         tnt,
+			Scopes:         "scope-a scope-b",
+			StandardClaims: jwt.StandardClaims{Issuer: "https://oathkeeper.kyma.local"},
+		})
+
+		//when
+		rr := serve(t, auth, token, testHandler(t, tnt, "scope-a scope-b"))
+
+		//then
+		assert.Equal(t, "OK", rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Success - custom claims mapping with scopes list", func(t *testing.T) {
+		//given
+		token := createSignedToken(t, jwt.SigningMethodRS256, "", materialize(t, rsaKey), jwt.MapClaims{
+			"iss": "https://idp.example.com",
+			"zid": tnt,
+			"scp": []string{"scope-a", "scope-b"},
+		})
+
+		//when
+		rr := serve(t, auth, token, testHandler(t, tnt, "scope-a scope-b"))
+
+		//then
+		assert.Equal(t, "OK", rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Error - untrusted issuer", func(t *testing.T) {
+		//given
+		token := createSignedToken(t, jwt.SigningMethodRS256, "", materialize(t, rsaKey), jwtTokenClaims{
+			Tenant:         tnt,
+			Scopes:         "scope-a",
+			StandardClaims: jwt.StandardClaims{Issuer: "https://evil.example.com"},
+		})
+
+		//when
+		rr := serve(t, auth, token, testHandler(t, tnt, "scope-a"))
+
+		//then
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "while parsing token: issuer 'https://evil.example.com' is not trusted\n", rr.Body.String())
+	})
+
+	t.Run("Error - tenant claim missing", func(t *testing.T) {
+		//given
+		token := createSignedToken(t, jwt.SigningMethodRS256, "", materialize(t, rsaKey), jwtTokenClaims{
+			Tenant:         tnt,
+			Scopes:         "scope-a",
+			StandardClaims: jwt.StandardClaims{Issuer: "https://idp.example.com"},
+		})
+
+		//when
+		rr := serve(t, auth, token, testHandler(t, tnt, "scope-a"))
+
+		//then
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "while mapping token claims: Tenant cannot be empty\n", rr.Body.String())
+	})
+}
+
+func TestAuthenticator_LastJWKSSynchronization(t *testing.T) {
+	t.Run("Returns zero time before synchronization", func(t *testing.T) {
+		//given
+		auth := authenticator.New(PublicJWKSURL, false)
+
+		//when
+		lastSync := auth.LastJWKSSynchronization()
+
+		//then
+		assert.True(t, lastSync.IsZero())
+	})
+
+	t.Run("Returns zero time when any issuer has not been synchronized", func(t *testing.T) {
+		//given
+		auth := authenticator.NewForTrustedIssuers([]authenticator.TrustedIssuer{
+			{Issuer: "a", JWKSURL: PublicJWKSURL},
+			{Issuer: "b", JWKSURL: fakeJWKSURL},
+		}, false)
+		require.Error(t, auth.SynchronizeJWKS())
+
+		//when
+		lastSync := auth.LastJWKSSynchronization()
+
+		//then
+		assert.True(t, lastSync.IsZero())
+	})
+
+	t.Run("Returns time of synchronization", func(t *testing.T) {
+		//given
+		auth := authenticator.New(PublicJWKSURL, false)
+		require.NoError(t, auth.SynchronizeJWKS())
+
+		//when
+		lastSync := auth.LastJWKSSynchronization()
+
+		//then
+		assert.False(t, lastSync.IsZero())
+	})
+}
+
 type jwtTokenClaims struct {
-	Scopes string `json:"scopes"`
-	Tenant string `json:"tenant"`
+	Scopes     string `json:"scopes"`
+	Tenant     string `json:"tenant"`
+	ObjectID   string `json:"objectID,omitempty"`
+	ObjectType string `json:"objectType,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -220,3 +485,62 @@ func testHandler(t *testing.T, expectedTenant string, scopes string) http.Handle
 		require.NoError(t, err)
 	}
 }
+
+func createSignedToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.Claims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signedToken, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signedToken
+}
+
+func materialize(t *testing.T, key jwk.Key) interface{} {
+	materializedKey, err := key.Materialize()
+	require.NoError(t, err)
+
+	return materializedKey
+}
+
+func publicJWK(t *testing.T, privateKey jwk.Key, kid, alg string) jwk.Key {
+	rsaKey, ok := materialize(t, privateKey).(*rsa.PrivateKey)
+	require.True(t, ok)
+
+	key, err := jwk.New(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, kid))
+	require.NoError(t, key.Set(jwk.AlgorithmKey, alg))
+
+	return key
+}
+
+func writeJWKS(t *testing.T, dir string, keys ...jwk.Key) string {
+	f, err := ioutil.TempFile(dir, "jwks-*.json")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	jwksURL := "file://" + f.Name()
+	overwriteJWKS(t, jwksURL, keys...)
+
+	return jwksURL
+}
+
+func overwriteJWKS(t *testing.T, jwksURL string, keys ...jwk.Key) {
+	b, err := json.Marshal(jwk.Set{Keys: keys})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(strings.TrimPrefix(jwksURL, "file://"), b, 0644))
+}
+
+func serve(t *testing.T, auth *authenticator.Authenticator, token string, handler http.Handler) *httptest.ResponseRecorder {
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	rr := httptest.NewRecorder()
+	auth.Handler()(handler).ServeHTTP(rr, req)
+
+	return rr
+}
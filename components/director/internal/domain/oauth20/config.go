@@ -1,6 +1,14 @@
 package oauth20
 
+import "time"
+
 type Config struct {
 	ClientEndpoint            string `envconfig:"APP_OAUTH20_CLIENT_ENDPOINT"`
 	PublicAccessTokenEndpoint string `envconfig:"APP_OAUTH20_PUBLIC_ACCESS_TOKEN_ENDPOINT"`
+	//Timeout of the requests to the OAuth 2.0 server
+	HTTPClientTimeout time.Duration `envconfig:"default=30s,APP_OAUTH20_HTTP_CLIENT_TIMEOUT"`
+	//Period of reconciling the clients with the System Auths, 0 disables the reconciliation
+	ReconciliationPeriod time.Duration `envconfig:"default=10m,APP_OAUTH20_RECONCILIATION_PERIOD"`
+	//Minimum age of the client without System Auth deleted by the reconciliation, has to be longer than any request registering a client
+	ReconciliationGracePeriod time.Duration `envconfig:"default=10m,APP_OAUTH20_RECONCILIATION_GRACE_PERIOD"`
 }
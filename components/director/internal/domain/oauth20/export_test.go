@@ -0,0 +1,7 @@
+package oauth20
+
+import "time"
+
+func (j *ReconciliationJob) SetTimestampGen(timestampGen func() time.Time) {
+	j.timestampGen = timestampGen
+}
@@ -0,0 +1,133 @@
+package oauth20
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/internal/timestamp"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:generate mockery -name=ClientRegistry -output=automock -outpkg=automock -case=underscore
+type ClientRegistry interface {
+	ListManagedClients(ctx context.Context) ([]Client, error)
+	RestoreClientCredentials(ctx context.Context, objectType model.SystemAuthReferenceObjectType, credentials model.OAuthCredentialData) error
+	UnregisterClient(ctx context.Context, clientID string) error
+}
+
+//go:generate mockery -name=SystemAuthLister -output=automock -outpkg=automock -case=underscore
+type SystemAuthLister interface {
+	ListGlobalWithOAuthCredentials(ctx context.Context) ([]model.SystemAuth, error)
+}
+
+// ReconciliationJob repairs the drift between the clients registered in the OAuth 2.0 server and the System Auths.
+// It registers again the clients of System Auths missing in the OAuth 2.0 server, and deletes the clients registered
+// by the Director which have no System Auth. The clients younger than the grace period are never deleted,
+// as their System Auths may not be committed yet.
+type ReconciliationJob struct {
+	transact     persistence.Transactioner
+	registry     ClientRegistry
+	sysAuthSvc   SystemAuthLister
+	gracePeriod  time.Duration
+	timestampGen timestamp.Generator
+}
+
+func NewReconciliationJob(transact persistence.Transactioner, registry ClientRegistry, sysAuthSvc SystemAuthLister, gracePeriod time.Duration) *ReconciliationJob {
+	return &ReconciliationJob{
+		transact:     transact,
+		registry:     registry,
+		sysAuthSvc:   sysAuthSvc,
+		gracePeriod:  gracePeriod,
+		timestampGen: timestamp.DefaultGenerator(),
+	}
+}
+
+func (j *ReconciliationJob) Execute(ctx context.Context) error {
+	// The clients are listed before the System Auths, so that every client registered in the meantime is either
+	// listed with its System Auth, or is too young to be deleted.
+	clients, err := j.registry.ListManagedClients(ctx)
+	if err != nil {
+		return errors.Wrap(err, "while listing OAuth 2.0 clients")
+	}
+
+	sysAuths, err := j.listSystemAuths(ctx)
+	if err != nil {
+		return err
+	}
+
+	registered := make(map[string]Client, len(clients))
+	for _, client := range clients {
+		registered[client.ClientID] = client
+	}
+
+	var result *multierror.Error
+	expected := make(map[string]struct{}, len(sysAuths))
+	for _, sysAuth := range sysAuths {
+		if sysAuth.Value == nil || sysAuth.Value.Credential.Oauth == nil {
+			continue
+		}
+		credentials := sysAuth.Value.Credential.Oauth
+		expected[credentials.ClientID] = struct{}{}
+		if _, ok := registered[credentials.ClientID]; ok {
+			continue
+		}
+
+		if err := j.restoreClient(ctx, sysAuth); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	deleteCreatedBefore := j.timestampGen().Add(-j.gracePeriod)
+	for _, client := range clients {
+		if _, ok := expected[client.ClientID]; ok || client.CreatedAt.IsZero() || client.CreatedAt.After(deleteCreatedBefore) {
+			continue
+		}
+
+		log.Infof("Deleting OAuth 2.0 client with ID '%s' without System Auth", client.ClientID)
+		if err := j.registry.UnregisterClient(ctx, client.ClientID); err != nil {
+			result = multierror.Append(result, errors.Wrapf(err, "while deleting OAuth 2.0 client with ID '%s'", client.ClientID))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (j *ReconciliationJob) listSystemAuths(ctx context.Context) ([]model.SystemAuth, error) {
+	tx, err := j.transact.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "while opening the db transaction")
+	}
+	defer j.transact.RollbackUnlessCommited(tx)
+
+	sysAuths, err := j.sysAuthSvc.ListGlobalWithOAuthCredentials(persistence.SaveToContext(ctx, tx))
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "while committing the db transaction")
+	}
+
+	return sysAuths, nil
+}
+
+func (j *ReconciliationJob) restoreClient(ctx context.Context, sysAuth model.SystemAuth) error {
+	credentials := sysAuth.Value.Credential.Oauth
+	objectType, err := sysAuth.GetReferenceObjectType()
+	if err != nil {
+		return errors.Wrapf(err, "while getting reference object type of System Auth with ID '%s'", sysAuth.ID)
+	}
+
+	log.Infof("Registering again OAuth 2.0 client with ID '%s' of System Auth with ID '%s'", credentials.ClientID, sysAuth.ID)
+	err = j.registry.RestoreClientCredentials(ctx, objectType, *credentials)
+	if err != nil {
+		return errors.Wrapf(err, "while registering again OAuth 2.0 client with ID '%s'", credentials.ClientID)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package oauth20_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/domain/oauth20"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/oauth20/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence/txtest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciliationJob_Execute(t *testing.T) {
+	// given
+	testErr := errors.New("test error")
+	txGen := txtest.NewTransactionContextGenerator(testErr)
+	now := time.Date(2019, 12, 10, 12, 0, 0, 0, time.UTC)
+	gracePeriod := 10 * time.Minute
+	rtmID := "rtm"
+	appID := "app"
+
+	rtmSysAuth := *fixModelSystemAuth("rtm-client", &rtmID, nil, nil)
+	appSysAuth := *fixModelSystemAuth("app-client", nil, &appID, nil)
+	oldClient := func(clientID string) oauth20.Client {
+		return oauth20.Client{ClientID: clientID, CreatedAt: now.Add(-time.Hour)}
+	}
+
+	t.Run("Success when clients match System Auths", func(t *testing.T) {
+		persistTx, transact := txGen.ThatSucceeds()
+		registry := &automock.ClientRegistry{}
+		registry.On("ListManagedClients", mock.Anything).Return([]oauth20.Client{oldClient("rtm-client"), oldClient("app-client")}, nil).Once()
+		sysAuthSvc := &automock.SystemAuthLister{}
+		sysAuthSvc.On("ListGlobalWithOAuthCredentials", txtest.CtxWithDBMatcher()).Return([]model.SystemAuth{rtmSysAuth, appSysAuth}, nil).Once()
+
+		job := oauth20.NewReconciliationJob(transact, registry, sysAuthSvc, gracePeriod)
+		job.SetTimestampGen(func() time.Time { return now })
+
+		// when
+		err := job.Execute(context.TODO())
+
+		// then
+		require.NoError(t, err)
+		mock.AssertExpectationsForObjects(t, persistTx, transact, registry, sysAuthSvc)
+	})
+
+	t.Run("Success registers again clients missing for System Auths", func(t *testing.T) {
+		persistTx, transact := txGen.ThatSucceeds()
+		registry := &automock.ClientRegistry{}
+		registry.On("ListManagedClients", mock.Anything).Return([]oauth20.Client{oldClient("rtm-client")}, nil).Once()
+		registry.On("RestoreClientCredentials", mock.Anything, model.ApplicationReference, *appSysAuth.Value.Credential.Oauth).Return(nil).Once()
+		sysAuthSvc := &automock.SystemAuthLister{}
+		sysAuthSvc.On("ListGlobalWithOAuthCredentials", txtest.CtxWithDBMatcher()).Return([]model.SystemAuth{rtmSysAuth, appSysAuth}, nil).Once()
+
+		job := oauth20.NewReconciliationJob(transact, registry, sysAuthSvc, gracePeriod)
+		job.SetTimestampGen(func() time.Time { return now })
+
+		// when
+		err := job.Execute(context.TODO())
+
+		// then
+		require.NoError(t, err)
+		mock.AssertExpectationsForObjects(t, persistTx, transact, registry, sysAuthSvc)
+	})
+
+	t.Run("Success deletes clients without System Auths older than grace period", func(t *testing.T) {
+		persistTx, transact := txGen.ThatSucceeds()
+		youngClient := oauth20.Client{ClientID: "young-client", CreatedAt: now.Add(-time.Minute)}
+		unknownAgeClient := oauth20.Client{ClientID: "unknown-age-client"}
+		registry := &automock.ClientRegistry{}
+		registry.On("ListManagedClients", mock.Anything).Return([]oauth20.Client{oldClient("rtm-client"), oldClient("orphan-client"), youngClient, unknownAgeClient}, nil).Once()
+		registry.On("UnregisterClient", mock.Anything, "orphan-client").Return(nil).Once()
+		sysAuthSvc := &automock.SystemAuthLister{}
+		sysAuthSvc.On("ListGlobalWithOAuthCredentials", txtest.CtxWithDBMatcher()).Return([]model.SystemAuth{rtmSysAuth}, nil).Once()
+
+		job := oauth20.NewReconciliationJob(transact, registry, sysAuthSvc, gracePeriod)
+		job.SetTimestampGen(func() time.Time { return now })
+
+		// when
+		err := job.Execute(context.TODO())
+
+		// then
+		require.NoError(t, err)
+		mock.AssertExpectationsForObjects(t, persistTx, transact, registry, sysAuthSvc)
+	})
+
+	t.Run("Error when repairing continues with remaining clients", func(t *testing.T) {
+		persistTx, transact := txGen.ThatSucceeds()
+		registry := &automock.ClientRegistry{}
+		registry.On("ListManagedClients", mock.Anything).Return([]oauth20.Client{oldClient("orphan-client")}, nil).Once()
+		registry.On("RestoreClientCredentials", mock.Anything, model.RuntimeReference, *rtmSysAuth.Value.Credential.Oauth).Return(testErr).Once()
+		registry.On("RestoreClientCredentials", mock.Anything, model.ApplicationReference, *appSysAuth.Value.Credential.Oauth).Return(nil).Once()
+		registry.On("UnregisterClient", mock.Anything, "orphan-client").Return(testErr).Once()
+		sysAuthSvc := &automock.SystemAuthLister{}
+		sysAuthSvc.On("ListGlobalWithOAuthCredentials", txtest.CtxWithDBMatcher()).Return([]model.SystemAuth{rtmSysAuth, appSysAuth}, nil).Once()
+
+		job := oauth20.NewReconciliationJob(transact, registry, sysAuthSvc, gracePeriod)
+		job.SetTimestampGen(func() time.Time { return now })
+
+		// when
+		err := job.Execute(context.TODO())
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while registering again OAuth 2.0 client with ID 'rtm-client': test error")
+		assert.Contains(t, err.Error(), "while deleting OAuth 2.0 client with ID 'orphan-client': test error")
+		mock.AssertExpectationsForObjects(t, persistTx, transact, registry, sysAuthSvc)
+	})
+
+	t.Run("Error when listing clients", func(t *testing.T) {
+		registry := &automock.ClientRegistry{}
+		registry.On("ListManagedClients", mock.Anything).Return(nil, testErr).Once()
+		sysAuthSvc := &automock.SystemAuthLister{}
+
+		job := oauth20.NewReconciliationJob(nil, registry, sysAuthSvc, gracePeriod)
+
+		// when
+		err := job.Execute(context.TODO())
+
+		// then
+		require.EqualError(t, err, "while listing OAuth 2.0 clients: test error")
+		mock.AssertExpectationsForObjects(t, registry, sysAuthSvc)
+	})
+
+	t.Run("Error when listing System Auths", func(t *testing.T) {
+		persistTx, transact := txGen.ThatDoesntExpectCommit()
+		registry := &automock.ClientRegistry{}
+		registry.On("ListManagedClients", mock.Anything).Return([]oauth20.Client{oldClient("orphan-client")}, nil).Once()
+		sysAuthSvc := &automock.SystemAuthLister{}
+		sysAuthSvc.On("ListGlobalWithOAuthCredentials", txtest.CtxWithDBMatcher()).Return(nil, testErr).Once()
+
+		job := oauth20.NewReconciliationJob(transact, registry, sysAuthSvc, gracePeriod)
+
+		// when
+		err := job.Execute(context.TODO())
+
+		// then
+		require.EqualError(t, err, "test error")
+		mock.AssertExpectationsForObjects(t, persistTx, transact, registry, sysAuthSvc)
+	})
+
+	t.Run("Error when committing transaction", func(t *testing.T) {
+		persistTx, transact := txGen.ThatFailsOnCommit()
+		registry := &automock.ClientRegistry{}
+		registry.On("ListManagedClients", mock.Anything).Return(nil, nil).Once()
+		sysAuthSvc := &automock.SystemAuthLister{}
+		sysAuthSvc.On("ListGlobalWithOAuthCredentials", txtest.CtxWithDBMatcher()).Return(nil, nil).Once()
+
+		job := oauth20.NewReconciliationJob(transact, registry, sysAuthSvc, gracePeriod)
+
+		// when
+		err := job.Execute(context.TODO())
+
+		// then
+		require.EqualError(t, err, "while committing the db transaction: test error")
+		mock.AssertExpectationsForObjects(t, persistTx, transact, registry, sysAuthSvc)
+	})
+}
@@ -95,9 +95,9 @@ func (r *Resolver) generateClientCredentials(ctx context.Context, objType model.
 		return nil, errors.New("client credentials cannot be empty")
 	}
 	cleanupOnError := func(originalErr error) error {
-		cleanupErr := r.svc.DeleteClientCredentials(ctx, clientCreds.ClientID)
+		cleanupErr := r.scheduleClientDeletion(ctx, clientCreds.ClientID)
 		if cleanupErr != nil {
-			return multierror.Append(err, cleanupErr)
+			return multierror.Append(originalErr, cleanupErr)
 		}
 
 		return originalErr
@@ -130,6 +130,26 @@ func (r *Resolver) generateClientCredentials(ctx context.Context, objType model.
 	return gqlSysAuth, nil
 }
 
+// scheduleClientDeletion compensates the registration of the client which could not be stored.
+// The deletion is scheduled in a separate transaction, as the transaction of the request is rolled back.
+// If even that fails, the client is deleted later by the reconciliation.
+func (r *Resolver) scheduleClientDeletion(ctx context.Context, clientID string) error {
+	tx, err := r.transact.Begin()
+	if err != nil {
+		return errors.Wrap(err, "while opening the db transaction")
+	}
+	defer r.transact.RollbackUnlessCommited(tx)
+
+	ctx = persistence.SaveToContext(ctx, tx)
+
+	err = r.svc.DeleteClientCredentials(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *Resolver) checkObjectExist(ctx context.Context, objType model.SystemAuthReferenceObjectType, objID string) (bool, error) {
 	switch objType {
 	case model.RuntimeReference:
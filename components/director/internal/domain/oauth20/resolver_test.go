@@ -172,7 +172,7 @@ func TestResolver_CommonGenerateClientCredentialsError(t *testing.T) {
 		{
 			Name:            "Error - Transaction Commit",
 			ExpectedError:   testErr,
-			TransactionerFn: txGen.ThatFailsOnCommit,
+			TransactionerFn: txThatSchedulesCleanup(testErr, true),
 			RuntimeServiceFn: func() *automock.RuntimeService {
 				rtmSvc := &automock.RuntimeService{}
 				rtmSvc.On("Exist", txtest.CtxWithDBMatcher(), id).Return(true, nil).Once()
@@ -194,7 +194,7 @@ func TestResolver_CommonGenerateClientCredentialsError(t *testing.T) {
 		{
 			Name:            "Error - Get System Auth",
 			ExpectedError:   testErr,
-			TransactionerFn: txGen.ThatDoesntExpectCommit,
+			TransactionerFn: txThatSchedulesCleanup(nil, true),
 			RuntimeServiceFn: func() *automock.RuntimeService {
 				rtmSvc := &automock.RuntimeService{}
 				rtmSvc.On("Exist", txtest.CtxWithDBMatcher(), id).Return(true, nil).Once()
@@ -216,7 +216,7 @@ func TestResolver_CommonGenerateClientCredentialsError(t *testing.T) {
 		{
 			Name:            "Error - Create System Auth",
 			ExpectedError:   testErr,
-			TransactionerFn: txGen.ThatDoesntExpectCommit,
+			TransactionerFn: txThatSchedulesCleanup(nil, true),
 			RuntimeServiceFn: func() *automock.RuntimeService {
 				rtmSvc := &automock.RuntimeService{}
 				rtmSvc.On("Exist", txtest.CtxWithDBMatcher(), id).Return(true, nil).Once()
@@ -237,7 +237,7 @@ func TestResolver_CommonGenerateClientCredentialsError(t *testing.T) {
 		{
 			Name:            "Error - Multiple: Create System Auth and Delete Client",
 			ExpectedError:   errors.New("2 errors occurred:\n\t* test error\n\t* test error"),
-			TransactionerFn: txGen.ThatDoesntExpectCommit,
+			TransactionerFn: txThatSchedulesCleanup(nil, false),
 			RuntimeServiceFn: func() *automock.RuntimeService {
 				rtmSvc := &automock.RuntimeService{}
 				rtmSvc.On("Exist", txtest.CtxWithDBMatcher(), id).Return(true, nil).Once()
@@ -375,6 +375,25 @@ func TestResolver_CommonGenerateClientCredentialsError(t *testing.T) {
 	}
 }
 
+// txThatSchedulesCleanup returns the transactioner which expects the second transaction, in which the deletion of the client is scheduled
+func txThatSchedulesCleanup(requestCommitErr error, cleanupCommitted bool) func() (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner) {
+	return func() (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner) {
+		persistTx := &persistenceautomock.PersistenceTx{}
+		if requestCommitErr != nil {
+			persistTx.On("Commit").Return(requestCommitErr).Once()
+		}
+		if cleanupCommitted {
+			persistTx.On("Commit").Return(nil).Once()
+		}
+
+		transact := &persistenceautomock.Transactioner{}
+		transact.On("Begin").Return(persistTx, nil).Twice()
+		transact.On("RollbackUnlessCommited", persistTx).Return().Twice()
+
+		return persistTx, transact
+	}
+}
+
 func fixModelSystemAuth(clientID string, rtmID, appID, isID *string) *model.SystemAuth {
 	return &model.SystemAuth{
 		ID:                  clientID,
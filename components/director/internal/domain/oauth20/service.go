@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/internal/model"
 	"github.com/pkg/errors"
@@ -18,8 +19,19 @@ import (
 const clientCredentialScopesPrefix = "clientCredentialsRegistrationScopes"
 const applicationJSONType = "application/json"
 
+// clientsPageSize is the number of clients fetched from the OAuth 2.0 server in a single request
+const clientsPageSize = 500
+
+// The clients registered by the Director are marked with metadata, so that the reconciliation never touches clients registered by other components
+const (
+	managedByMetadataKey = "managedBy"
+	managedByDirector    = "compass-director"
+)
+
 var defaultGrantTypes = []string{"client_credentials"}
 
+var errClientAlreadyExists = errors.New("client already exists")
+
 //go:generate mockery -name=ScopeCfgProvider -output=automock -outpkg=automock -case=underscore
 type ScopeCfgProvider interface {
 	GetRequiredScopes(path string) ([]string, error)
@@ -30,21 +42,39 @@ type UIDService interface {
 	Generate() string
 }
 
+//go:generate mockery -name=OutboxService -output=automock -outpkg=automock -case=underscore
+type OutboxService interface {
+	Enqueue(ctx context.Context, operationType model.OutboxOperationType, payload interface{}) error
+}
+
+// Client is the OAuth 2.0 client registered in the OAuth 2.0 server
+type Client struct {
+	ClientID  string            `json:"client_id"`
+	Metadata  map[string]string `json:"metadata"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type deleteClientPayload struct {
+	ClientID string `json:"clientID"`
+}
+
 type service struct {
 	clientEndpoint            string
 	publicAccessTokenEndpoint string
 	scopeCfgProvider          ScopeCfgProvider
 	httpCli                   *http.Client
 	uidService                UIDService
+	outboxSvc                 OutboxService
 }
 
-func NewService(scopeCfgProvider ScopeCfgProvider, uidService UIDService, cfg Config) *service {
+func NewService(scopeCfgProvider ScopeCfgProvider, uidService UIDService, outboxSvc OutboxService, cfg Config) *service {
 	return &service{
 		scopeCfgProvider:          scopeCfgProvider,
 		clientEndpoint:            cfg.ClientEndpoint,
 		publicAccessTokenEndpoint: cfg.PublicAccessTokenEndpoint,
-		httpCli:                   &http.Client{},
+		httpCli:                   &http.Client{Timeout: cfg.HTTPClientTimeout},
 		uidService:                uidService,
+		outboxSvc:                 outboxSvc,
 	}
 }
 
@@ -55,7 +85,7 @@ func (s *service) CreateClientCredentials(ctx context.Context, objectType model.
 	}
 
 	clientID := s.uidService.Generate()
-	clientSecret, err := s.registerClient(clientID, scopes)
+	clientSecret, err := s.registerClient(ctx, clientID, "", scopes)
 	if err != nil {
 		return nil, err
 	}
@@ -69,8 +99,15 @@ func (s *service) CreateClientCredentials(ctx context.Context, objectType model.
 	return credentialData, nil
 }
 
+// DeleteClientCredentials schedules the deletion of the client in the transaction from the context.
+// The client is deleted from the OAuth 2.0 server by the outbox processor only after that transaction is committed.
 func (s *service) DeleteClientCredentials(ctx context.Context, clientID string) error {
-	return s.unregisterClient(clientID)
+	err := s.outboxSvc.Enqueue(ctx, model.OutboxDeleteOAuth20Client, deleteClientPayload{ClientID: clientID})
+	if err != nil {
+		return errors.Wrapf(err, "while scheduling deletion of client with ID '%s'", clientID)
+	}
+
+	return nil
 }
 
 func (s *service) DeleteMultipleClientCredentials(ctx context.Context, auths []model.SystemAuth) error {
@@ -89,6 +126,57 @@ func (s *service) DeleteMultipleClientCredentials(ctx context.Context, auths []m
 	return nil
 }
 
+// HandleClientDeletion deletes the client scheduled for deletion by DeleteClientCredentials
+func (s *service) HandleClientDeletion(ctx context.Context, payload []byte) error {
+	var deletion deleteClientPayload
+	if err := json.Unmarshal(payload, &deletion); err != nil {
+		return errors.Wrap(err, "while unmarshalling client deletion payload")
+	}
+
+	return s.UnregisterClient(ctx, deletion.ClientID)
+}
+
+// UnregisterClient deletes the client from the OAuth 2.0 server immediately. Deleting the client which does not exist succeeds.
+func (s *service) UnregisterClient(ctx context.Context, clientID string) error {
+	return s.unregisterClient(ctx, clientID)
+}
+
+// RestoreClientCredentials registers the client with the existing credentials again, with the scopes of the given object type
+func (s *service) RestoreClientCredentials(ctx context.Context, objectType model.SystemAuthReferenceObjectType, credentials model.OAuthCredentialData) error {
+	scopes, err := s.getClientCredentialScopes(objectType)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.registerClient(ctx, credentials.ClientID, credentials.ClientSecret, scopes)
+	if err == errClientAlreadyExists {
+		return nil
+	}
+
+	return err
+}
+
+// ListManagedClients returns all clients registered in the OAuth 2.0 server by the Director
+func (s *service) ListManagedClients(ctx context.Context) ([]Client, error) {
+	var managed []Client
+	for offset := 0; ; offset += clientsPageSize {
+		clients, err := s.listClients(ctx, offset, clientsPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, client := range clients {
+			if client.Metadata[managedByMetadataKey] == managedByDirector {
+				managed = append(managed, client)
+			}
+		}
+
+		if len(clients) < clientsPageSize {
+			return managed, nil
+		}
+	}
+}
+
 func (s *service) getClientCredentialScopes(objType model.SystemAuthReferenceObjectType) ([]string, error) {
 	scopes, err := s.scopeCfgProvider.GetRequiredScopes(s.buildPath(objType))
 	if err != nil {
@@ -99,20 +187,25 @@ func (s *service) getClientCredentialScopes(objType model.SystemAuthReferenceObj
 }
 
 type clientCredentialsRegistrationBody struct {
-	GrantTypes []string `json:"grant_types"`
-	ClientID   string   `json:"client_id"`
-	Scope      string   `json:"scope"`
+	GrantTypes   []string          `json:"grant_types"`
+	ClientID     string            `json:"client_id"`
+	ClientSecret string            `json:"client_secret,omitempty"`
+	Scope        string            `json:"scope"`
+	Metadata     map[string]string `json:"metadata"`
 }
 
 type clientCredentialsRegistrationResponse struct {
 	ClientSecret string `json:"client_secret"`
 }
 
-func (s *service) registerClient(clientID string, scopes []string) (string, error) {
+// registerClient registers the client with the given secret, or with the secret generated by the OAuth 2.0 server if the secret is empty
+func (s *service) registerClient(ctx context.Context, clientID, clientSecret string, scopes []string) (string, error) {
 	reqBody := &clientCredentialsRegistrationBody{
-		GrantTypes: defaultGrantTypes,
-		ClientID:   clientID,
-		Scope:      strings.Join(scopes, " "),
+		GrantTypes:   defaultGrantTypes,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scope:        strings.Join(scopes, " "),
+		Metadata:     map[string]string{managedByMetadataKey: managedByDirector},
 	}
 
 	buffer := &bytes.Buffer{}
@@ -121,12 +214,16 @@ func (s *service) registerClient(clientID string, scopes []string) (string, erro
 		return "", errors.Wrap(err, "while encoding body")
 	}
 
-	resp, closeBody, err := s.doRequest(http.MethodPost, s.clientEndpoint, buffer)
+	resp, closeBody, err := s.doRequest(ctx, http.MethodPost, s.clientEndpoint, buffer)
 	if err != nil {
 		return "", err
 	}
 	defer closeBody(resp.Body)
 
+	if resp.StatusCode == http.StatusConflict {
+		return "", errClientAlreadyExists
+	}
+
 	if resp.StatusCode != http.StatusCreated {
 		return "", fmt.Errorf("invalid HTTP status code: received: %d, expected %d", resp.StatusCode, http.StatusCreated)
 	}
@@ -140,15 +237,20 @@ func (s *service) registerClient(clientID string, scopes []string) (string, erro
 	return registrationResp.ClientSecret, nil
 }
 
-func (s *service) unregisterClient(clientID string) error {
+func (s *service) unregisterClient(ctx context.Context, clientID string) error {
 	endpoint := fmt.Sprintf("%s/%s", s.clientEndpoint, clientID)
 
-	resp, closeBody, err := s.doRequest(http.MethodDelete, endpoint, nil)
+	resp, closeBody, err := s.doRequest(ctx, http.MethodDelete, endpoint, nil)
 	if err != nil {
 		return err
 	}
 	defer closeBody(resp.Body)
 
+	if resp.StatusCode == http.StatusNotFound {
+		logrus.Infof("Client with ID '%s' does not exist, skipping its deletion", clientID)
+		return nil
+	}
+
 	if resp.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("invalid HTTP status code: received: %d, expected %d", resp.StatusCode, http.StatusNoContent)
 	}
@@ -156,7 +258,29 @@ func (s *service) unregisterClient(clientID string) error {
 	return nil
 }
 
-func (s *service) doRequest(method string, endpoint string, body io.Reader) (*http.Response, func(body io.ReadCloser), error) {
+func (s *service) listClients(ctx context.Context, offset, limit int) ([]Client, error) {
+	endpoint := fmt.Sprintf("%s?limit=%d&offset=%d", s.clientEndpoint, limit, offset)
+
+	resp, closeBody, err := s.doRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid HTTP status code: received: %d, expected %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var clients []Client
+	err = json.NewDecoder(resp.Body).Decode(&clients)
+	if err != nil {
+		return nil, errors.Wrap(err, "while decoding response body")
+	}
+
+	return clients, nil
+}
+
+func (s *service) doRequest(ctx context.Context, method string, endpoint string, body io.Reader) (*http.Response, func(body io.ReadCloser), error) {
 	req, err := http.NewRequest(method, endpoint, body)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "while creating new request")
@@ -165,7 +289,7 @@ func (s *service) doRequest(method string, endpoint string, body io.Reader) (*ht
 	req.Header.Set("Accept", applicationJSONType)
 	req.Header.Set("Content-Type", applicationJSONType)
 
-	resp, err := s.httpCli.Do(req)
+	resp, err := s.httpCli.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "while doing request to %s", s.clientEndpoint)
 	}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/internal/domain/oauth20"
 	"github.com/kyma-incubator/compass/components/director/internal/domain/oauth20/automock"
 	"github.com/kyma-incubator/compass/components/director/internal/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,6 +34,7 @@ func TestService_CreateClient(t *testing.T) {
 		"grant_types": []interface{}{"client_credentials"},
 		"client_id":   "foo",
 		"scope":       "foo bar baz",
+		"metadata":    map[string]interface{}{"managedBy": "compass-director"},
 	}
 	testErr := errors.New("test err")
 
@@ -160,7 +164,7 @@ func TestService_CreateClient(t *testing.T) {
 			if httpServer != nil {
 				url = httpServer.URL
 			}
-			svc := oauth20.NewService(scopeCfgProvider, uidService, oauth20.Config{ClientEndpoint: url, PublicAccessTokenEndpoint: publicEndpoint})
+			svc := oauth20.NewService(scopeCfgProvider, uidService, nil, oauth20.Config{ClientEndpoint: url, PublicAccessTokenEndpoint: publicEndpoint})
 
 			// when
 			oauthData, err := svc.CreateClientCredentials(ctx, objType)
@@ -178,7 +182,7 @@ func TestService_CreateClient(t *testing.T) {
 
 }
 
-func TestService_DeleteClientCredentials(t *testing.T) {
+func TestService_UnregisterClient(t *testing.T) {
 	// given
 	id := "foo"
 	testCases := []struct {
@@ -206,6 +210,16 @@ func TestService_DeleteClientCredentials(t *testing.T) {
 				return tc
 			},
 		},
+		{
+			Name:          "Success - Client does not exist",
+			ExpectedError: nil,
+			HTTPServerFn: func(t *testing.T) *httptest.Server {
+				tc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				}))
+				return tc
+			},
+		},
 		{
 			Name:          "Error - Response Status Code",
 			ExpectedError: errors.New("invalid HTTP status code: received: 500, expected 204"),
@@ -225,6 +239,18 @@ func TestService_DeleteClientCredentials(t *testing.T) {
 				return tc
 			},
 		},
+		{
+			Name:          "Error - HTTP call timeout",
+			ExpectedError: errors.New("Client.Timeout exceeded"),
+			Config:        oauth20.Config{HTTPClientTimeout: 50 * time.Millisecond},
+			HTTPServerFn: func(t *testing.T) *httptest.Server {
+				tc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(200 * time.Millisecond)
+					w.WriteHeader(http.StatusNoContent)
+				}))
+				return tc
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -237,10 +263,12 @@ func TestService_DeleteClientCredentials(t *testing.T) {
 			if httpServer != nil {
 				url = httpServer.URL
 			}
-			svc := oauth20.NewService(nil, nil, oauth20.Config{ClientEndpoint: url})
+			cfg := testCase.Config
+			cfg.ClientEndpoint = url
+			svc := oauth20.NewService(nil, nil, nil, cfg)
 
 			// when
-			err := svc.DeleteClientCredentials(ctx, id)
+			err := svc.UnregisterClient(ctx, id)
 
 			// then
 			if testCase.ExpectedError == nil {
@@ -254,6 +282,191 @@ func TestService_DeleteClientCredentials(t *testing.T) {
 	}
 }
 
+func TestService_DeleteClientCredentials(t *testing.T) {
+	// given
+	ctx := context.TODO()
+	id := "foo"
+	testErr := errors.New("test err")
+
+	t.Run("Success", func(t *testing.T) {
+		outboxSvc := &automock.OutboxService{}
+		outboxSvc.On("Enqueue", ctx, model.OutboxDeleteOAuth20Client, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			payload, err := json.Marshal(args.Get(2))
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"clientID":"foo"}`, string(payload))
+		}).Once()
+		defer outboxSvc.AssertExpectations(t)
+		svc := oauth20.NewService(nil, nil, outboxSvc, oauth20.Config{})
+
+		// when
+		err := svc.DeleteClientCredentials(ctx, id)
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("Error when scheduling", func(t *testing.T) {
+		outboxSvc := &automock.OutboxService{}
+		outboxSvc.On("Enqueue", ctx, model.OutboxDeleteOAuth20Client, mock.Anything).Return(testErr).Once()
+		defer outboxSvc.AssertExpectations(t)
+		svc := oauth20.NewService(nil, nil, outboxSvc, oauth20.Config{})
+
+		// when
+		err := svc.DeleteClientCredentials(ctx, id)
+
+		// then
+		require.EqualError(t, err, "while scheduling deletion of client with ID 'foo': test err")
+	})
+}
+
+func TestService_HandleClientDeletion(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// given
+		httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/foo", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer httpServer.Close()
+		svc := oauth20.NewService(nil, nil, nil, oauth20.Config{ClientEndpoint: httpServer.URL})
+
+		// when
+		err := svc.HandleClientDeletion(context.TODO(), []byte(`{"clientID":"foo"}`))
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("Error when payload is invalid", func(t *testing.T) {
+		// given
+		svc := oauth20.NewService(nil, nil, nil, oauth20.Config{})
+
+		// when
+		err := svc.HandleClientDeletion(context.TODO(), []byte(`not-json`))
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while unmarshalling client deletion payload")
+	})
+}
+
+func TestService_RestoreClientCredentials(t *testing.T) {
+	// given
+	objType := model.RuntimeReference
+	scopes := []string{"foo", "bar"}
+	credentials := model.OAuthCredentialData{ClientID: "foo", ClientSecret: "c-secret", URL: "url"}
+	expectedReqBody := map[string]interface{}{
+		"grant_types":   []interface{}{"client_credentials"},
+		"client_id":     "foo",
+		"client_secret": "c-secret",
+		"scope":         "foo bar",
+		"metadata":      map[string]interface{}{"managedBy": "compass-director"},
+	}
+
+	testCases := []struct {
+		Name          string
+		StatusCode    int
+		ExpectedError string
+	}{
+		{
+			Name:       "Success",
+			StatusCode: http.StatusCreated,
+		},
+		{
+			Name:       "Success - Client already exists",
+			StatusCode: http.StatusConflict,
+		},
+		{
+			Name:          "Error - Response Status Code",
+			StatusCode:    http.StatusInternalServerError,
+			ExpectedError: "invalid HTTP status code: received: 500, expected 201",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				var reqBody map[string]interface{}
+				err := json.NewDecoder(r.Body).Decode(&reqBody)
+				require.NoError(t, err)
+				assert.Equal(t, expectedReqBody, reqBody)
+				w.WriteHeader(testCase.StatusCode)
+				_, err = w.Write([]byte(`{"client_secret":"c-secret"}`))
+				require.NoError(t, err)
+			}))
+			defer httpServer.Close()
+			scopeCfgProvider := &automock.ScopeCfgProvider{}
+			scopeCfgProvider.On("GetRequiredScopes", "clientCredentialsRegistrationScopes.runtime").Return(scopes, nil).Once()
+			defer scopeCfgProvider.AssertExpectations(t)
+			svc := oauth20.NewService(scopeCfgProvider, nil, nil, oauth20.Config{ClientEndpoint: httpServer.URL})
+
+			// when
+			err := svc.RestoreClientCredentials(context.TODO(), objType, credentials)
+
+			// then
+			if testCase.ExpectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, testCase.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestService_ListManagedClients(t *testing.T) {
+	t.Run("Success returns only clients registered by the Director from all pages", func(t *testing.T) {
+		// given
+		createdAt := time.Date(2019, 12, 10, 12, 0, 0, 0, time.UTC)
+		var offsets []string
+		httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "500", r.URL.Query().Get("limit"))
+			offsets = append(offsets, r.URL.Query().Get("offset"))
+
+			var clients []map[string]interface{}
+			if r.URL.Query().Get("offset") == "0" {
+				for i := 0; i < 499; i++ {
+					clients = append(clients, map[string]interface{}{"client_id": fmt.Sprintf("other-%d", i)})
+				}
+				clients = append(clients, map[string]interface{}{"client_id": "foo", "metadata": map[string]string{"managedBy": "compass-director"}, "created_at": createdAt})
+			} else {
+				clients = append(clients, map[string]interface{}{"client_id": "bar", "metadata": map[string]string{"managedBy": "compass-director"}, "created_at": createdAt})
+			}
+			err := json.NewEncoder(w).Encode(clients)
+			require.NoError(t, err)
+		}))
+		defer httpServer.Close()
+		svc := oauth20.NewService(nil, nil, nil, oauth20.Config{ClientEndpoint: httpServer.URL})
+
+		// when
+		clients, err := svc.ListManagedClients(context.TODO())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0", "500"}, offsets)
+		require.Len(t, clients, 2)
+		assert.Equal(t, "foo", clients[0].ClientID)
+		assert.Equal(t, createdAt, clients[0].CreatedAt)
+		assert.Equal(t, "bar", clients[1].ClientID)
+	})
+
+	t.Run("Error - Response Status Code", func(t *testing.T) {
+		// given
+		httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer httpServer.Close()
+		svc := oauth20.NewService(nil, nil, nil, oauth20.Config{ClientEndpoint: httpServer.URL})
+
+		// when
+		_, err := svc.ListManagedClients(context.TODO())
+
+		// then
+		require.EqualError(t, err, "invalid HTTP status code: received: 500, expected 200")
+	})
+}
+
 func fixSuccessCreateClientHTTPServer(expectedReqBody map[string]interface{}) func(t *testing.T) *httptest.Server {
 	return func(t *testing.T) *httptest.Server {
 		tc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
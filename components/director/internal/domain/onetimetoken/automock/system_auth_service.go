@@ -5,29 +5,58 @@ package automock
 import context "context"
 import mock "github.com/stretchr/testify/mock"
 import model "github.com/kyma-incubator/compass/components/director/internal/model"
+import time "time"
 
 // SystemAuthService is an autogenerated mock type for the SystemAuthService type
 type SystemAuthService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, objectType, objectID, authInput
-func (_m *SystemAuthService) Create(ctx context.Context, objectType model.SystemAuthReferenceObjectType, objectID string, authInput *model.AuthInput) (string, error) {
-	ret := _m.Called(ctx, objectType, objectID, authInput)
+// CreateForOneTimeToken provides a mock function with given fields: ctx, objectType, objectID, tokenExpiresAt
+func (_m *SystemAuthService) CreateForOneTimeToken(ctx context.Context, objectType model.SystemAuthReferenceObjectType, objectID string, tokenExpiresAt time.Time) (string, error) {
+	ret := _m.Called(ctx, objectType, objectID, tokenExpiresAt)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, model.SystemAuthReferenceObjectType, string, *model.AuthInput) string); ok {
-		r0 = rf(ctx, objectType, objectID, authInput)
+	if rf, ok := ret.Get(0).(func(context.Context, model.SystemAuthReferenceObjectType, string, time.Time) string); ok {
+		r0 = rf(ctx, objectType, objectID, tokenExpiresAt)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, model.SystemAuthReferenceObjectType, string, *model.AuthInput) error); ok {
-		r1 = rf(ctx, objectType, objectID, authInput)
+	if rf, ok := ret.Get(1).(func(context.Context, model.SystemAuthReferenceObjectType, string, time.Time) error); ok {
+		r1 = rf(ctx, objectType, objectID, tokenExpiresAt)
 	} else {
 		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
+
+// DeleteExpiredOneTimeTokens provides a mock function with given fields: ctx, now
+func (_m *SystemAuthService) DeleteExpiredOneTimeTokens(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePairingStatus provides a mock function with given fields: ctx, id, status
+func (_m *SystemAuthService) UpdatePairingStatus(ctx context.Context, id string, status model.PairingStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.PairingStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
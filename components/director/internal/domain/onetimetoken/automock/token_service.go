@@ -11,20 +11,41 @@ type TokenService struct {
 	mock.Mock
 }
 
-// GenerateOneTimeToken provides a mock function with given fields: ctx, runtimeID, tokenType
-func (_m *TokenService) GenerateOneTimeToken(ctx context.Context, runtimeID string, tokenType model.SystemAuthReferenceObjectType) (model.OneTimeToken, error) {
-	ret := _m.Called(ctx, runtimeID, tokenType)
+// CreateSystemAuth provides a mock function with given fields: ctx, id, tokenType
+func (_m *TokenService) CreateSystemAuth(ctx context.Context, id string, tokenType model.SystemAuthReferenceObjectType) (string, error) {
+	ret := _m.Called(ctx, id, tokenType)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.SystemAuthReferenceObjectType) string); ok {
+		r0 = rf(ctx, id, tokenType)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.SystemAuthReferenceObjectType) error); ok {
+		r1 = rf(ctx, id, tokenType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestOneTimeToken provides a mock function with given fields: ctx, sysAuthID, tokenType
+func (_m *TokenService) RequestOneTimeToken(ctx context.Context, sysAuthID string, tokenType model.SystemAuthReferenceObjectType) (model.OneTimeToken, error) {
+	ret := _m.Called(ctx, sysAuthID, tokenType)
 
 	var r0 model.OneTimeToken
 	if rf, ok := ret.Get(0).(func(context.Context, string, model.SystemAuthReferenceObjectType) model.OneTimeToken); ok {
-		r0 = rf(ctx, runtimeID, tokenType)
+		r0 = rf(ctx, sysAuthID, tokenType)
 	} else {
 		r0 = ret.Get(0).(model.OneTimeToken)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, string, model.SystemAuthReferenceObjectType) error); ok {
-		r1 = rf(ctx, runtimeID, tokenType)
+		r1 = rf(ctx, sysAuthID, tokenType)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,46 @@
+package onetimetoken
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/internal/timestamp"
+	"github.com/pkg/errors"
+)
+
+// CleanupJob deletes System Auths created for one-time tokens which expired without being used.
+type CleanupJob struct {
+	transact     persistence.Transactioner
+	sysAuthSvc   SystemAuthService
+	timestampGen timestamp.Generator
+}
+
+func NewCleanupJob(transact persistence.Transactioner, sysAuthSvc SystemAuthService) *CleanupJob {
+	return &CleanupJob{
+		transact:     transact,
+		sysAuthSvc:   sysAuthSvc,
+		timestampGen: timestamp.DefaultGenerator(),
+	}
+}
+
+func (j *CleanupJob) Execute(ctx context.Context) error {
+	tx, err := j.transact.Begin()
+	if err != nil {
+		return errors.Wrap(err, "while opening the db transaction")
+	}
+	defer j.transact.RollbackUnlessCommited(tx)
+
+	ctx = persistence.SaveToContext(ctx, tx)
+
+	err = j.sysAuthSvc.DeleteExpiredOneTimeTokens(ctx, j.timestampGen())
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "while committing the db transaction")
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package onetimetoken_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/domain/onetimetoken"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/onetimetoken/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence/txtest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupJob_Execute(t *testing.T) {
+	// GIVEN
+	testErr := errors.New("test error")
+	txGen := txtest.NewTransactionContextGenerator(testErr)
+
+	t.Run("Success", func(t *testing.T) {
+		persistTx, transact := txGen.ThatSucceeds()
+		sysAuthSvc := &automock.SystemAuthService{}
+		sysAuthSvc.On("DeleteExpiredOneTimeTokens", txtest.CtxWithDBMatcher(), now).Return(nil).Once()
+
+		job := onetimetoken.NewCleanupJob(transact, sysAuthSvc)
+		job.SetTimestampGen(func() time.Time { return now })
+
+		// WHEN
+		err := job.Execute(context.TODO())
+
+		// THEN
+		require.NoError(t, err)
+		mock.AssertExpectationsForObjects(t, persistTx, transact, sysAuthSvc)
+	})
+
+	t.Run("Error when beginning transaction", func(t *testing.T) {
+		persistTx, transact := txGen.ThatFailsOnBegin()
+		sysAuthSvc := &automock.SystemAuthService{}
+
+		job := onetimetoken.NewCleanupJob(transact, sysAuthSvc)
+
+		// WHEN
+		err := job.Execute(context.TODO())
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+		mock.AssertExpectationsForObjects(t, persistTx, transact, sysAuthSvc)
+	})
+
+	t.Run("Error when deleting expired tokens", func(t *testing.T) {
+		persistTx, transact := txGen.ThatDoesntExpectCommit()
+		sysAuthSvc := &automock.SystemAuthService{}
+		sysAuthSvc.On("DeleteExpiredOneTimeTokens", txtest.CtxWithDBMatcher(), now).Return(testErr).Once()
+
+		job := onetimetoken.NewCleanupJob(transact, sysAuthSvc)
+		job.SetTimestampGen(func() time.Time { return now })
+
+		// WHEN
+		err := job.Execute(context.TODO())
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+		mock.AssertExpectationsForObjects(t, persistTx, transact, sysAuthSvc)
+	})
+
+	t.Run("Error when committing transaction", func(t *testing.T) {
+		persistTx, transact := txGen.ThatFailsOnCommit()
+		sysAuthSvc := &automock.SystemAuthService{}
+		sysAuthSvc.On("DeleteExpiredOneTimeTokens", txtest.CtxWithDBMatcher(), now).Return(nil).Once()
+
+		job := onetimetoken.NewCleanupJob(transact, sysAuthSvc)
+		job.SetTimestampGen(func() time.Time { return now })
+
+		// WHEN
+		err := job.Execute(context.TODO())
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+		mock.AssertExpectationsForObjects(t, persistTx, transact, sysAuthSvc)
+	})
+}
@@ -1,8 +1,16 @@
 package onetimetoken
 
+import "time"
+
 type Config struct {
 	//One time token URL
 	OneTimeTokenURL string `envconfig:"APP_ONE_TIME_TOKEN_URL"`
 	//Connector URL
 	ConnectorURL string `envconfig:"APP_CONNECTOR_URL"`
+	//Application token expiration, set from the same chart value as the Connector configuration
+	ApplicationExpiration time.Duration `envconfig:"default=5m,APP_ONE_TIME_TOKEN_APPLICATION_EXPIRATION"`
+	//Runtime token expiration, set from the same chart value as the Connector configuration
+	RuntimeExpiration time.Duration `envconfig:"default=60m,APP_ONE_TIME_TOKEN_RUNTIME_EXPIRATION"`
+	//Period of deleting System Auths with expired and unused tokens, 0 disables the cleanup
+	CleanupPeriod time.Duration `envconfig:"default=5m,APP_ONE_TIME_TOKEN_CLEANUP_PERIOD"`
 }
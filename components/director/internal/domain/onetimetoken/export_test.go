@@ -0,0 +1,11 @@
+package onetimetoken
+
+import "time"
+
+func (s *service) SetTimestampGen(timestampGen func() time.Time) {
+	s.timestampGen = timestampGen
+}
+
+func (j *CleanupJob) SetTimestampGen(timestampGen func() time.Time) {
+	j.timestampGen = timestampGen
+}
@@ -0,0 +1,99 @@
+package onetimetoken
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/pkg/apperrors"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// PairingStatusReport is sent by the Connector when a one-time token is consumed or a client certificate is issued.
+type PairingStatusReport struct {
+	ClientID string              `json:"clientId"`
+	Status   model.PairingStatus `json:"status"`
+}
+
+type PairingStatusHandler struct {
+	transact   persistence.Transactioner
+	sysAuthSvc SystemAuthService
+}
+
+func NewPairingStatusHandler(transact persistence.Transactioner, sysAuthSvc SystemAuthService) *PairingStatusHandler {
+	return &PairingStatusHandler{
+		transact:   transact,
+		sysAuthSvc: sysAuthSvc,
+	}
+}
+
+func (h *PairingStatusHandler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(writer, fmt.Sprintf("Bad request method. Got %s, expected POST", req.Method), http.StatusBadRequest)
+		return
+	}
+
+	var report PairingStatusReport
+	err := json.NewDecoder(req.Body).Decode(&report)
+	if err != nil {
+		respondWithError(writer, http.StatusBadRequest, err, "while decoding the request body")
+		return
+	}
+
+	err = report.validate()
+	if err != nil {
+		respondWithError(writer, http.StatusBadRequest, err, "while validating the request body")
+		return
+	}
+
+	tx, err := h.transact.Begin()
+	if err != nil {
+		respondWithError(writer, http.StatusInternalServerError, err, "while opening the db transaction")
+		return
+	}
+	defer h.transact.RollbackUnlessCommited(tx)
+
+	ctx := persistence.SaveToContext(req.Context(), tx)
+
+	err = h.sysAuthSvc.UpdatePairingStatus(ctx, report.ClientID, report.Status)
+	if err != nil {
+		if apperrors.IsNotFoundError(err) {
+			respondWithError(writer, http.StatusNotFound, err, "while updating pairing status")
+			return
+		}
+		respondWithError(writer, http.StatusInternalServerError, err, "while updating pairing status")
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		respondWithError(writer, http.StatusInternalServerError, err, "while committing the db transaction")
+		return
+	}
+
+	log.Infof("Pairing status of System Auth with ID %s set to %s", report.ClientID, report.Status)
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (r PairingStatusReport) validate() error {
+	if r.ClientID == "" {
+		return errors.New("clientId cannot be empty")
+	}
+
+	switch r.Status {
+	case model.PairingStatusConsumed, model.PairingStatusPaired:
+		return nil
+	}
+
+	return errors.Errorf("invalid status %s, expected one of: %s, %s", r.Status, model.PairingStatusConsumed, model.PairingStatusPaired)
+}
+
+func respondWithError(writer http.ResponseWriter, httpErrorCode int, err error, wrapperStr string) {
+	wrappedErr := errors.Wrap(err, wrapperStr)
+	log.Error(wrappedErr)
+
+	http.Error(writer, wrappedErr.Error(), httpErrorCode)
+}
@@ -0,0 +1,158 @@
+package onetimetoken_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/director/internal/domain/onetimetoken"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/onetimetoken/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	persistenceautomock "github.com/kyma-incubator/compass/components/director/internal/persistence/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence/txtest"
+	"github.com/kyma-incubator/compass/components/director/pkg/apperrors"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPairingStatusHandler_ServeHTTP(t *testing.T) {
+	// GIVEN
+	clientID := "90923fe8-91bd-4070-aa31-f2ebb07a0963"
+	testErr := errors.New("test error")
+	txGen := txtest.NewTransactionContextGenerator(testErr)
+
+	validBody := `{"clientId":"` + clientID + `","status":"CONSUMED"}`
+
+	testCases := []struct {
+		Name               string
+		Method             string
+		Body               string
+		TxFn               func() (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner)
+		SysAuthSvcFn       func() *automock.SystemAuthService
+		ExpectedStatusCode int
+	}{
+		{
+			Name:   "Success",
+			Method: http.MethodPost,
+			Body:   validBody,
+			TxFn:   txGen.ThatSucceeds,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				sysAuthSvc.On("UpdatePairingStatus", txtest.CtxWithDBMatcher(), clientID, model.PairingStatusConsumed).Return(nil).Once()
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:   "Error when method is not POST",
+			Method: http.MethodGet,
+			Body:   validBody,
+			TxFn:   txGen.ThatDoesntStartTransaction,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:   "Error when body is not valid JSON",
+			Method: http.MethodPost,
+			Body:   `{`,
+			TxFn:   txGen.ThatDoesntStartTransaction,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:   "Error when clientId is empty",
+			Method: http.MethodPost,
+			Body:   `{"status":"PAIRED"}`,
+			TxFn:   txGen.ThatDoesntStartTransaction,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:   "Error when status is not supported",
+			Method: http.MethodPost,
+			Body:   `{"clientId":"` + clientID + `","status":"ISSUED"}`,
+			TxFn:   txGen.ThatDoesntStartTransaction,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:   "Error when beginning transaction",
+			Method: http.MethodPost,
+			Body:   validBody,
+			TxFn:   txGen.ThatFailsOnBegin,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			Name:   "Error when System Auth not found",
+			Method: http.MethodPost,
+			Body:   validBody,
+			TxFn:   txGen.ThatDoesntExpectCommit,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				sysAuthSvc.On("UpdatePairingStatus", txtest.CtxWithDBMatcher(), clientID, model.PairingStatusConsumed).Return(apperrors.NewNotFoundError(clientID)).Once()
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		{
+			Name:   "Error when updating pairing status",
+			Method: http.MethodPost,
+			Body:   validBody,
+			TxFn:   txGen.ThatDoesntExpectCommit,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				sysAuthSvc.On("UpdatePairingStatus", txtest.CtxWithDBMatcher(), clientID, model.PairingStatusConsumed).Return(testErr).Once()
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			Name:   "Error when committing transaction",
+			Method: http.MethodPost,
+			Body:   validBody,
+			TxFn:   txGen.ThatFailsOnCommit,
+			SysAuthSvcFn: func() *automock.SystemAuthService {
+				sysAuthSvc := &automock.SystemAuthService{}
+				sysAuthSvc.On("UpdatePairingStatus", txtest.CtxWithDBMatcher(), clientID, model.PairingStatusConsumed).Return(nil).Once()
+				return sysAuthSvc
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			persistTx, transact := testCase.TxFn()
+			sysAuthSvc := testCase.SysAuthSvcFn()
+			handler := onetimetoken.NewPairingStatusHandler(transact, sysAuthSvc)
+
+			req := httptest.NewRequest(testCase.Method, "http://example.com/pairing-status", strings.NewReader(testCase.Body))
+			w := httptest.NewRecorder()
+
+			// WHEN
+			handler.ServeHTTP(w, req)
+
+			// THEN
+			assert.Equal(t, testCase.ExpectedStatusCode, w.Result().StatusCode)
+			mock.AssertExpectationsForObjects(t, sysAuthSvc, persistTx, transact)
+		})
+	}
+}
@@ -11,7 +11,8 @@ import (
 
 //go:generate mockery -name=TokenService -output=automock -outpkg=automock -case=underscore
 type TokenService interface {
-	GenerateOneTimeToken(ctx context.Context, runtimeID string, tokenType model.SystemAuthReferenceObjectType) (model.OneTimeToken, error)
+	CreateSystemAuth(ctx context.Context, id string, tokenType model.SystemAuthReferenceObjectType) (string, error)
+	RequestOneTimeToken(ctx context.Context, sysAuthID string, tokenType model.SystemAuthReferenceObjectType) (model.OneTimeToken, error)
 }
 
 //go:generate mockery -name=TokenConverter -output=automock -outpkg=automock -case=underscore
@@ -30,6 +31,16 @@ func NewTokenResolver(transact persistence.Transactioner, svc TokenService, conv
 }
 
 func (r *Resolver) GenerateOneTimeTokenForRuntime(ctx context.Context, id string) (*graphql.OneTimeToken, error) {
+	return r.generateOneTimeToken(ctx, id, model.RuntimeReference)
+}
+
+func (r *Resolver) GenerateOneTimeTokenForApplication(ctx context.Context, id string) (*graphql.OneTimeToken, error) {
+	return r.generateOneTimeToken(ctx, id, model.ApplicationReference)
+}
+
+// generateOneTimeToken commits the System Auth before calling the Connector, so an issued token always has
+// its System Auth persisted. If the Connector call fails, the unused System Auth expires and is removed by the cleanup job.
+func (r *Resolver) generateOneTimeToken(ctx context.Context, id string, tokenType model.SystemAuthReferenceObjectType) (*graphql.OneTimeToken, error) {
 	tx, err := r.transact.Begin()
 	if err != nil {
 		return nil, err
@@ -37,36 +48,21 @@ func (r *Resolver) GenerateOneTimeTokenForRuntime(ctx context.Context, id string
 	defer r.transact.RollbackUnlessCommited(tx)
 	ctx = persistence.SaveToContext(ctx, tx)
 
-	token, err := r.svc.GenerateOneTimeToken(ctx, id, model.RuntimeReference)
+	sysAuthID, err := r.svc.CreateSystemAuth(ctx, id, tokenType)
 	if err != nil {
 		return nil, err
 	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, errors.Wrap(err, "while commiting transaction")
 	}
 
-	gqlToken := r.conv.ToGraphQL(token)
-	return &gqlToken, nil
-}
-
-func (r *Resolver) GenerateOneTimeTokenForApplication(ctx context.Context, id string) (*graphql.OneTimeToken, error) {
-	tx, err := r.transact.Begin()
-	if err != nil {
-		return nil, err
-	}
-	defer r.transact.RollbackUnlessCommited(tx)
-	ctx = persistence.SaveToContext(ctx, tx)
-
-	token, err := r.svc.GenerateOneTimeToken(ctx, id, model.ApplicationReference)
+	token, err := r.svc.RequestOneTimeToken(ctx, sysAuthID, tokenType)
 	if err != nil {
 		return nil, err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return nil, errors.Wrap(err, "while commiting transaction")
-	}
 	gqlToken := r.conv.ToGraphQL(token)
 	return &gqlToken, nil
 }
@@ -18,13 +18,15 @@ func TestResolver_GenerateOneTimeTokenForApp(t *testing.T) {
 	testErr := errors.New("test error")
 	txGen := txtest.NewTransactionContextGenerator(testErr)
 	appID := "08d805a5-87f0-4194-adc7-277ec10de2ef"
+	sysAuthID := "90923fe8-91bd-4070-aa31-f2ebb07a0963"
 	ctx := context.TODO()
 	tokenModel := model.OneTimeToken{Token: "Token", ConnectorURL: "connectorURL"}
 	expectedToken := graphql.OneTimeToken{Token: "Token", ConnectorURL: "connectorURL"}
 	t.Run("Success", func(t *testing.T) {
 		//GIVEN
 		svc := &automock.TokenService{}
-		svc.On("GenerateOneTimeToken", txtest.CtxWithDBMatcher(), appID, model.ApplicationReference).Return(tokenModel, nil)
+		svc.On("CreateSystemAuth", txtest.CtxWithDBMatcher(), appID, model.ApplicationReference).Return(sysAuthID, nil).Once()
+		svc.On("RequestOneTimeToken", txtest.CtxWithDBMatcher(), sysAuthID, model.ApplicationReference).Return(tokenModel, nil).Once()
 		conv := &automock.TokenConverter{}
 		conv.On("ToGraphQL", tokenModel).Return(expectedToken)
 		persist, transact := txGen.ThatSucceeds()
@@ -43,10 +45,30 @@ func TestResolver_GenerateOneTimeTokenForApp(t *testing.T) {
 		conv.AssertExpectations(t)
 	})
 
+	t.Run("Error - requesting token failed after System Auth was committed", func(t *testing.T) {
+		//GIVEN
+		svc := &automock.TokenService{}
+		svc.On("CreateSystemAuth", txtest.CtxWithDBMatcher(), appID, model.ApplicationReference).Return(sysAuthID, nil).Once()
+		svc.On("RequestOneTimeToken", txtest.CtxWithDBMatcher(), sysAuthID, model.ApplicationReference).Return(model.OneTimeToken{}, testErr).Once()
+		persist, transact := txGen.ThatSucceeds()
+		conv := &automock.TokenConverter{}
+		r := onetimetoken.NewTokenResolver(transact, svc, conv)
+
+		//WHEN
+		_, err := r.GenerateOneTimeTokenForApplication(ctx, appID)
+
+		//THEN
+		require.EqualError(t, err, testErr.Error())
+		persist.AssertExpectations(t)
+		transact.AssertExpectations(t)
+		svc.AssertExpectations(t)
+		conv.AssertExpectations(t)
+	})
+
 	t.Run("Error - transaction commit failed", func(t *testing.T) {
 		//GIVEN
 		svc := &automock.TokenService{}
-		svc.On("GenerateOneTimeToken", txtest.CtxWithDBMatcher(), appID, model.ApplicationReference).Return(tokenModel, nil)
+		svc.On("CreateSystemAuth", txtest.CtxWithDBMatcher(), appID, model.ApplicationReference).Return(sysAuthID, nil).Once()
 		persist, transact := txGen.ThatFailsOnCommit()
 		conv := &automock.TokenConverter{}
 		r := onetimetoken.NewTokenResolver(transact, svc, conv)
@@ -65,7 +87,7 @@ func TestResolver_GenerateOneTimeTokenForApp(t *testing.T) {
 	t.Run("Error - service return error", func(t *testing.T) {
 		//GIVEN
 		svc := &automock.TokenService{}
-		svc.On("GenerateOneTimeToken", txtest.CtxWithDBMatcher(), appID, model.ApplicationReference).Return(tokenModel, testErr)
+		svc.On("CreateSystemAuth", txtest.CtxWithDBMatcher(), appID, model.ApplicationReference).Return("", testErr).Once()
 		persist, transact := txGen.ThatDoesntExpectCommit()
 		conv := &automock.TokenConverter{}
 		r := onetimetoken.NewTokenResolver(transact, svc, conv)
@@ -104,16 +126,18 @@ func TestResolver_GenerateOneTimeTokenForRuntime(t *testing.T) {
 	testErr := errors.New("test error")
 	txGen := txtest.NewTransactionContextGenerator(testErr)
 	runtimeID := "08d805a5-87f0-4194-adc7-277ec10de2ef"
+	sysAuthID := "90923fe8-91bd-4070-aa31-f2ebb07a0963"
 	ctx := context.TODO()
 	tokenModel := model.OneTimeToken{Token: "Token", ConnectorURL: "connectorURL"}
 	expectedToken := graphql.OneTimeToken{Token: "Token", ConnectorURL: "connectorURL"}
 	t.Run("Success", func(t *testing.T) {
 		//GIVEN
 		svc := &automock.TokenService{}
-		svc.On("GenerateOneTimeToken", txtest.CtxWithDBMatcher(), runtimeID, model.RuntimeReference).Return(tokenModel, nil)
-		persist, transact := txGen.ThatSucceeds()
+		svc.On("CreateSystemAuth", txtest.CtxWithDBMatcher(), runtimeID, model.RuntimeReference).Return(sysAuthID, nil).Once()
+		svc.On("RequestOneTimeToken", txtest.CtxWithDBMatcher(), sysAuthID, model.RuntimeReference).Return(tokenModel, nil).Once()
 		conv := &automock.TokenConverter{}
 		conv.On("ToGraphQL", tokenModel).Return(expectedToken)
+		persist, transact := txGen.ThatSucceeds()
 		r := onetimetoken.NewTokenResolver(transact, svc, conv)
 
 		//WHEN
@@ -129,10 +153,30 @@ func TestResolver_GenerateOneTimeTokenForRuntime(t *testing.T) {
 		conv.AssertExpectations(t)
 	})
 
+	t.Run("Error - requesting token failed after System Auth was committed", func(t *testing.T) {
+		//GIVEN
+		svc := &automock.TokenService{}
+		svc.On("CreateSystemAuth", txtest.CtxWithDBMatcher(), runtimeID, model.RuntimeReference).Return(sysAuthID, nil).Once()
+		svc.On("RequestOneTimeToken", txtest.CtxWithDBMatcher(), sysAuthID, model.RuntimeReference).Return(model.OneTimeToken{}, testErr).Once()
+		persist, transact := txGen.ThatSucceeds()
+		conv := &automock.TokenConverter{}
+		r := onetimetoken.NewTokenResolver(transact, svc, conv)
+
+		//WHEN
+		_, err := r.GenerateOneTimeTokenForRuntime(ctx, runtimeID)
+
+		//THEN
+		require.EqualError(t, err, testErr.Error())
+		persist.AssertExpectations(t)
+		transact.AssertExpectations(t)
+		svc.AssertExpectations(t)
+		conv.AssertExpectations(t)
+	})
+
 	t.Run("Error - transaction commit failed", func(t *testing.T) {
 		//GIVEN
 		svc := &automock.TokenService{}
-		svc.On("GenerateOneTimeToken", txtest.CtxWithDBMatcher(), runtimeID, model.RuntimeReference).Return(tokenModel, nil)
+		svc.On("CreateSystemAuth", txtest.CtxWithDBMatcher(), runtimeID, model.RuntimeReference).Return(sysAuthID, nil).Once()
 		persist, transact := txGen.ThatFailsOnCommit()
 		conv := &automock.TokenConverter{}
 		r := onetimetoken.NewTokenResolver(transact, svc, conv)
@@ -151,7 +195,7 @@ func TestResolver_GenerateOneTimeTokenForRuntime(t *testing.T) {
 	t.Run("Error - service return error", func(t *testing.T) {
 		//GIVEN
 		svc := &automock.TokenService{}
-		svc.On("GenerateOneTimeToken", txtest.CtxWithDBMatcher(), runtimeID, model.RuntimeReference).Return(tokenModel, testErr)
+		svc.On("CreateSystemAuth", txtest.CtxWithDBMatcher(), runtimeID, model.RuntimeReference).Return("", testErr).Once()
 		persist, transact := txGen.ThatDoesntExpectCommit()
 		conv := &automock.TokenConverter{}
 		r := onetimetoken.NewTokenResolver(transact, svc, conv)
@@ -3,8 +3,10 @@ package onetimetoken
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/timestamp"
 	gcli "github.com/machinebox/graphql"
 	"github.com/pkg/errors"
 )
@@ -30,24 +32,48 @@ type GraphQLClient interface {
 
 //go:generate mockery -name=SystemAuthService -output=automock -outpkg=automock -case=underscore
 type SystemAuthService interface {
-	Create(ctx context.Context, objectType model.SystemAuthReferenceObjectType, objectID string, authInput *model.AuthInput) (string, error)
+	CreateForOneTimeToken(ctx context.Context, objectType model.SystemAuthReferenceObjectType, objectID string, tokenExpiresAt time.Time) (string, error)
+	UpdatePairingStatus(ctx context.Context, id string, status model.PairingStatus) error
+	DeleteExpiredOneTimeTokens(ctx context.Context, now time.Time) error
 }
 type service struct {
-	cli          GraphQLClient
-	connectorURL string
-	sysAuthSvc   SystemAuthService
+	cli              GraphQLClient
+	connectorURL     string
+	tokenExpirations map[model.SystemAuthReferenceObjectType]time.Duration
+	sysAuthSvc       SystemAuthService
+	timestampGen     timestamp.Generator
 }
 
-func NewTokenService(gcli GraphQLClient, sysAuthSvc SystemAuthService, connectorURL string) *service {
-	return &service{cli: gcli, connectorURL: connectorURL, sysAuthSvc: sysAuthSvc}
+func NewTokenService(gcli GraphQLClient, sysAuthSvc SystemAuthService, cfg Config) *service {
+	return &service{
+		cli:          gcli,
+		connectorURL: cfg.ConnectorURL,
+		tokenExpirations: map[model.SystemAuthReferenceObjectType]time.Duration{
+			model.ApplicationReference: cfg.ApplicationExpiration,
+			model.RuntimeReference:     cfg.RuntimeExpiration,
+		},
+		sysAuthSvc:   sysAuthSvc,
+		timestampGen: timestamp.DefaultGenerator(),
+	}
 }
 
-func (s service) GenerateOneTimeToken(ctx context.Context, id string, tokenType model.SystemAuthReferenceObjectType) (model.OneTimeToken, error) {
-	sysAuthID, err := s.sysAuthSvc.Create(ctx, tokenType, id, nil)
+// CreateSystemAuth stores the System Auth the one-time token is issued for. It has to be committed before
+// the token is requested, as the Connector can use the token as soon as it is issued.
+func (s service) CreateSystemAuth(ctx context.Context, id string, tokenType model.SystemAuthReferenceObjectType) (string, error) {
+	expiration, ok := s.tokenExpirations[tokenType]
+	if !ok {
+		return "", errors.Errorf("cannot generate token for %s", tokenType)
+	}
+
+	sysAuthID, err := s.sysAuthSvc.CreateForOneTimeToken(ctx, tokenType, id, s.timestampGen().Add(expiration))
 	if err != nil {
-		return model.OneTimeToken{}, errors.Wrap(err, "while creating System Auth")
+		return "", errors.Wrap(err, "while creating System Auth")
 	}
 
+	return sysAuthID, nil
+}
+
+func (s service) RequestOneTimeToken(ctx context.Context, sysAuthID string, tokenType model.SystemAuthReferenceObjectType) (model.OneTimeToken, error) {
 	token, err := s.getOneTimeToken(ctx, sysAuthID, tokenType)
 	if err != nil {
 		return model.OneTimeToken{}, errors.Wrapf(err, "while generating onetime token for %s", tokenType)
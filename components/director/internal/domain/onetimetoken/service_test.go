@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/internal/model"
 
@@ -16,10 +17,94 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var URL = `http://localhost:3001/graphql`
+var (
+	URL = `http://localhost:3001/graphql`
+	cfg = onetimetoken.Config{
+		ConnectorURL:          URL,
+		ApplicationExpiration: 5 * time.Minute,
+		RuntimeExpiration:     60 * time.Minute,
+	}
+	now = time.Date(2019, 11, 14, 12, 0, 0, 0, time.UTC)
+)
+
+func TestTokenService_CreateSystemAuth(t *testing.T) {
+	objectID := "98cb3b05-0f27-43ea-9249-605ac74a6cf0"
+	authID := "90923fe8-91bd-4070-aa31-f2ebb07a0963"
+
+	testCases := []struct {
+		Name          string
+		TokenType     model.SystemAuthReferenceObjectType
+		Expiration    time.Duration
+		ExpectedError error
+	}{
+		{
+			Name:       "Success for runtime",
+			TokenType:  model.RuntimeReference,
+			Expiration: cfg.RuntimeExpiration,
+		},
+		{
+			Name:       "Success for application",
+			TokenType:  model.ApplicationReference,
+			Expiration: cfg.ApplicationExpiration,
+		},
+		{
+			Name:          "Error - saving auth failed",
+			TokenType:     model.RuntimeReference,
+			Expiration:    cfg.RuntimeExpiration,
+			ExpectedError: errors.New("test error"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			//GIVEN
+			ctx := context.TODO()
+			cli := &automock.GraphQLClient{}
+			sysAuthSvc := &automock.SystemAuthService{}
+			if testCase.ExpectedError != nil {
+				sysAuthSvc.On("CreateForOneTimeToken", ctx, testCase.TokenType, objectID, now.Add(testCase.Expiration)).
+					Return("", testCase.ExpectedError).Once()
+			} else {
+				sysAuthSvc.On("CreateForOneTimeToken", ctx, testCase.TokenType, objectID, now.Add(testCase.Expiration)).
+					Return(authID, nil).Once()
+			}
+			svc := onetimetoken.NewTokenService(cli, sysAuthSvc, cfg)
+			svc.SetTimestampGen(func() time.Time { return now })
+
+			//WHEN
+			sysAuthID, err := svc.CreateSystemAuth(ctx, objectID, testCase.TokenType)
+
+			//THEN
+			if testCase.ExpectedError != nil {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.ExpectedError.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, authID, sysAuthID)
+			}
+			cli.AssertExpectations(t)
+			sysAuthSvc.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Error - unsupported type", func(t *testing.T) {
+		//GIVEN
+		ctx := context.TODO()
+		cli := &automock.GraphQLClient{}
+		sysAuthSvc := &automock.SystemAuthService{}
+		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, cfg)
+
+		//WHEN
+		_, err := svc.CreateSystemAuth(ctx, objectID, model.IntegrationSystemReference)
+
+		//THEN
+		require.EqualError(t, err, "cannot generate token for Integration System")
+		cli.AssertExpectations(t)
+		sysAuthSvc.AssertExpectations(t)
+	})
+}
 
-func TestTokenService_GetOneTimeTokenForRuntime(t *testing.T) {
-	runtimeID := "98cb3b05-0f27-43ea-9249-605ac74a6cf0"
+func TestTokenService_RequestOneTimeTokenForRuntime(t *testing.T) {
 	authID := "90923fe8-91bd-4070-aa31-f2ebb07a0963"
 
 	expectedRequest := gcli.NewRequest(fmt.Sprintf(`
@@ -38,18 +123,16 @@ func TestTokenService_GetOneTimeTokenForRuntime(t *testing.T) {
 		expected := onetimetoken.ConnectorTokenModel{RuntimeToken: onetimetoken.ConnectorToken{Token: expectedToken}}
 		cli.On("Run", ctx, expectedRequest, &onetimetoken.ConnectorTokenModel{}).
 			Run(generateFakeToken(t, expected)).Return(nil).Once()
-
 		sysAuthSvc := &automock.SystemAuthService{}
-		sysAuthSvc.On("Create", ctx, model.RuntimeReference, runtimeID, (*model.AuthInput)(nil)).
-			Return(authID, nil)
-		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, URL)
+		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, cfg)
 
 		//WHEN
-		authToken, err := svc.GenerateOneTimeToken(ctx, runtimeID, model.RuntimeReference)
+		authToken, err := svc.RequestOneTimeToken(ctx, authID, model.RuntimeReference)
 
 		//THEN
 		require.NoError(t, err)
 		assert.Equal(t, expectedToken, authToken.Token)
+		assert.Equal(t, URL, authToken.ConnectorURL)
 		cli.AssertExpectations(t)
 		sysAuthSvc.AssertExpectations(t)
 	})
@@ -61,31 +144,10 @@ func TestTokenService_GetOneTimeTokenForRuntime(t *testing.T) {
 		cli.On("Run", ctx, expectedRequest, &onetimetoken.ConnectorTokenModel{}).
 			Return(testErr).Once()
 		sysAuthSvc := &automock.SystemAuthService{}
-		sysAuthSvc.On("Create", ctx, model.RuntimeReference, runtimeID, (*model.AuthInput)(nil)).
-			Return(authID, nil)
-		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, URL)
-
-		//WHEN
-		_, err := svc.GenerateOneTimeToken(ctx, runtimeID, model.RuntimeReference)
-
-		//THEN
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), testErr.Error())
-		cli.AssertExpectations(t)
-		sysAuthSvc.AssertExpectations(t)
-	})
-
-	t.Run("Error - saving auth failed", func(t *testing.T) {
-		ctx := context.TODO()
-		testErr := errors.New("test error")
-		cli := &automock.GraphQLClient{}
-		sysAuthSvc := &automock.SystemAuthService{}
-		sysAuthSvc.On("Create", ctx, model.RuntimeReference, runtimeID, (*model.AuthInput)(nil)).
-			Return("", testErr)
-		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, URL)
+		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, cfg)
 
 		//WHEN
-		_, err := svc.GenerateOneTimeToken(ctx, runtimeID, model.RuntimeReference)
+		_, err := svc.RequestOneTimeToken(ctx, authID, model.RuntimeReference)
 
 		//THEN
 		require.Error(t, err)
@@ -95,8 +157,7 @@ func TestTokenService_GetOneTimeTokenForRuntime(t *testing.T) {
 	})
 }
 
-func TestTokenService_GetOneTimeTokenForApp(t *testing.T) {
-	appID := "98cb3b05-0f27-43ea-9249-605ac74a6cf0"
+func TestTokenService_RequestOneTimeTokenForApp(t *testing.T) {
 	authID := "77cabc16-9fb8-4338-b252-7b404f2e6487"
 
 	expectedRequest := gcli.NewRequest(fmt.Sprintf(`
@@ -116,12 +177,10 @@ func TestTokenService_GetOneTimeTokenForApp(t *testing.T) {
 		cli.On("Run", ctx, expectedRequest, &onetimetoken.ConnectorTokenModel{}).
 			Run(generateFakeToken(t, expected)).Return(nil).Once()
 		sysAuthSvc := &automock.SystemAuthService{}
-		sysAuthSvc.On("Create", ctx, model.ApplicationReference, appID, (*model.AuthInput)(nil)).
-			Return(authID, nil)
-		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, URL)
+		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, cfg)
 
 		//WHEN
-		authToken, err := svc.GenerateOneTimeToken(ctx, appID, model.ApplicationReference)
+		authToken, err := svc.RequestOneTimeToken(ctx, authID, model.ApplicationReference)
 
 		//THEN
 		require.NoError(t, err)
@@ -137,12 +196,10 @@ func TestTokenService_GetOneTimeTokenForApp(t *testing.T) {
 		cli.On("Run", ctx, expectedRequest, &onetimetoken.ConnectorTokenModel{}).
 			Return(testErr).Once()
 		sysAuthSvc := &automock.SystemAuthService{}
-		sysAuthSvc.On("Create", ctx, model.ApplicationReference, appID, (*model.AuthInput)(nil)).
-			Return(authID, nil)
-		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, URL)
+		svc := onetimetoken.NewTokenService(cli, sysAuthSvc, cfg)
 
 		//WHEN
-		_, err := svc.GenerateOneTimeToken(ctx, appID, model.ApplicationReference)
+		_, err := svc.RequestOneTimeToken(ctx, authID, model.ApplicationReference)
 
 		//THEN
 		require.Error(t, err)
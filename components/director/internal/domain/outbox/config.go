@@ -0,0 +1,18 @@
+package outbox
+
+import "time"
+
+type Config struct {
+	//Period of processing the pending operations, 0 disables the processing
+	ProcessingPeriod time.Duration `envconfig:"default=10s,APP_OUTBOX_PROCESSING_PERIOD"`
+	//Maximum number of operations processed in a single processing period
+	BatchSize int `envconfig:"default=20,APP_OUTBOX_BATCH_SIZE"`
+	//Maximum duration of handling a single operation
+	HandlerTimeout time.Duration `envconfig:"default=30s,APP_OUTBOX_HANDLER_TIMEOUT"`
+	//Delay of the first retry of a failed operation, doubled after each next failure
+	RetryDelay time.Duration `envconfig:"default=10s,APP_OUTBOX_RETRY_DELAY"`
+	//Maximum delay between the retries of a failed operation
+	MaxRetryDelay time.Duration `envconfig:"default=1h,APP_OUTBOX_MAX_RETRY_DELAY"`
+	//Maximum number of attempts, after which the operation is marked as failed and no longer retried
+	MaxAttempts int `envconfig:"default=20,APP_OUTBOX_MAX_ATTEMPTS"`
+}
@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/repo"
+)
+
+type converter struct{}
+
+func NewConverter() *converter {
+	return &converter{}
+}
+
+func (c *converter) ToEntity(in model.OutboxOperation) Entity {
+	return Entity{
+		ID:            in.ID,
+		Type:          string(in.Type),
+		Payload:       string(in.Payload),
+		Attempts:      in.Attempts,
+		LastError:     repo.NewNullableString(in.LastError),
+		CreatedAt:     in.CreatedAt,
+		NextAttemptAt: in.NextAttemptAt,
+		FailedAt:      repo.NewNullableTime(in.FailedAt),
+	}
+}
+
+func (c *converter) FromEntity(in Entity) model.OutboxOperation {
+	return model.OutboxOperation{
+		ID:            in.ID,
+		Type:          model.OutboxOperationType(in.Type),
+		Payload:       []byte(in.Payload),
+		Attempts:      in.Attempts,
+		LastError:     repo.StringPtrFromNullableString(in.LastError),
+		CreatedAt:     in.CreatedAt,
+		NextAttemptAt: in.NextAttemptAt,
+		FailedAt:      repo.TimePtrFromNullableTime(in.FailedAt),
+	}
+}
@@ -0,0 +1,51 @@
+package outbox_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConverter(t *testing.T) {
+	t.Run("ToEntity and FromEntity are symmetric", func(t *testing.T) {
+		//GIVEN
+		conv := outbox.NewConverter()
+		operation := fixModelOperation(2, str("test error"), testNow)
+
+		//WHEN
+		entity := conv.ToEntity(operation)
+		actual := conv.FromEntity(entity)
+
+		//THEN
+		assert.Equal(t, fixEntity(2, str("test error"), testNow), entity)
+		assert.Equal(t, operation, actual)
+	})
+
+	t.Run("Converts operation without error", func(t *testing.T) {
+		//GIVEN
+		conv := outbox.NewConverter()
+
+		//WHEN
+		entity := conv.ToEntity(fixModelOperation(0, nil, testNow))
+
+		//THEN
+		assert.False(t, entity.LastError.Valid)
+		assert.Nil(t, conv.FromEntity(entity).LastError)
+	})
+
+	t.Run("Converts failed operation", func(t *testing.T) {
+		//GIVEN
+		conv := outbox.NewConverter()
+		operation := fixModelOperation(3, str("test error"), testNow)
+		operation.FailedAt = &testNow
+
+		//WHEN
+		entity := conv.ToEntity(operation)
+
+		//THEN
+		assert.True(t, entity.FailedAt.Valid)
+		assert.Equal(t, testNow, entity.FailedAt.Time)
+		assert.Equal(t, operation, conv.FromEntity(entity))
+	})
+}
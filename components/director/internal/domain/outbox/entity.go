@@ -0,0 +1,25 @@
+package outbox
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type Entity struct {
+	ID            string         `db:"id"`
+	Type          string         `db:"type"`
+	Payload       string         `db:"payload"`
+	Attempts      int            `db:"attempts"`
+	LastError     sql.NullString `db:"last_error"`
+	CreatedAt     time.Time      `db:"created_at"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	FailedAt      pq.NullTime    `db:"failed_at"`
+}
+
+type Collection []Entity
+
+func (c Collection) Len() int {
+	return len(c)
+}
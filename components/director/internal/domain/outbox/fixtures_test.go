@@ -0,0 +1,49 @@
+package outbox_test
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox"
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/repo"
+)
+
+var (
+	testOperationID = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	testPayload     = `{"clientID":"foo"}`
+	testNow         = time.Date(2019, 12, 10, 12, 0, 0, 0, time.UTC)
+	testColumns     = []string{"id", "type", "payload", "attempts", "last_error", "created_at", "next_attempt_at", "failed_at"}
+)
+
+func fixModelOperation(attempts int, lastError *string, nextAttemptAt time.Time) model.OutboxOperation {
+	return model.OutboxOperation{
+		ID:            testOperationID,
+		Type:          model.OutboxDeleteOAuth20Client,
+		Payload:       []byte(testPayload),
+		Attempts:      attempts,
+		LastError:     lastError,
+		CreatedAt:     testNow,
+		NextAttemptAt: nextAttemptAt,
+	}
+}
+
+func fixEntity(attempts int, lastError *string, nextAttemptAt time.Time) outbox.Entity {
+	return outbox.Entity{
+		ID:            testOperationID,
+		Type:          string(model.OutboxDeleteOAuth20Client),
+		Payload:       testPayload,
+		Attempts:      attempts,
+		LastError:     repo.NewNullableString(lastError),
+		CreatedAt:     testNow,
+		NextAttemptAt: nextAttemptAt,
+	}
+}
+
+func fixEntityArgs(entity outbox.Entity) []driver.Value {
+	return []driver.Value{entity.ID, entity.Type, entity.Payload, entity.Attempts, entity.LastError, entity.CreatedAt, entity.NextAttemptAt, entity.FailedAt}
+}
+
+func str(s string) *string {
+	return &s
+}
@@ -0,0 +1,186 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/internal/timestamp"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:generate mockery -name=Handler -output=automock -outpkg=automock -case=underscore
+type Handler interface {
+	// Handle executes the operation of a single type. Operations can be retried, so it has to be idempotent.
+	Handle(ctx context.Context, payload []byte) error
+}
+
+// HandlerFunc is an adapter allowing to use an ordinary function as a Handler
+type HandlerFunc func(ctx context.Context, payload []byte) error
+
+func (f HandlerFunc) Handle(ctx context.Context, payload []byte) error {
+	return f(ctx, payload)
+}
+
+//go:generate mockery -name=MetricsCollector -output=automock -outpkg=automock -case=underscore
+type MetricsCollector interface {
+	IncrementFailedOutboxOperations(operationType string)
+}
+
+// Processor executes the pending operations. A failed operation is retried with an exponential backoff until it reaches
+// the maximum number of attempts, after which it is marked as failed and kept for investigation.
+// Every operation is claimed, handled and recorded separately, so that no transaction is open while the handler calls external services.
+type Processor struct {
+	transact         persistence.Transactioner
+	repo             Repository
+	handlers         map[model.OutboxOperationType]Handler
+	cfg              Config
+	metricsCollector MetricsCollector
+	timestampGen     timestamp.Generator
+}
+
+func NewProcessor(transact persistence.Transactioner, repo Repository, handlers map[model.OutboxOperationType]Handler, cfg Config, metricsCollector MetricsCollector) *Processor {
+	return &Processor{
+		transact:         transact,
+		repo:             repo,
+		handlers:         handlers,
+		cfg:              cfg,
+		metricsCollector: metricsCollector,
+		timestampGen:     timestamp.DefaultGenerator(),
+	}
+}
+
+// Execute processes up to the batch size of the due operations, one by one
+func (p *Processor) Execute(ctx context.Context) error {
+	for i := 0; i < p.cfg.BatchSize; i++ {
+		operation, err := p.claim(ctx)
+		if err != nil {
+			return err
+		}
+		if operation == nil {
+			return nil
+		}
+
+		handleErr := p.handle(ctx, *operation)
+
+		if err := p.record(ctx, *operation, handleErr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// claim takes the next due operation and postpones its next attempt beyond the end of the handling in a short transaction,
+// so that no other instance of the Director processes it meanwhile. If the Director stops during the handling, the operation
+// becomes due again when the claim expires.
+func (p *Processor) claim(ctx context.Context) (*model.OutboxOperation, error) {
+	tx, err := p.transact.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "while opening the db transaction")
+	}
+	defer p.transact.RollbackUnlessCommited(tx)
+
+	ctx = persistence.SaveToContext(ctx, tx)
+
+	now := p.timestampGen()
+	operations, err := p.repo.ListDueForProcessing(ctx, now, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing outbox operations")
+	}
+	if len(operations) == 0 {
+		return nil, tx.Commit()
+	}
+
+	operation := operations[0]
+	claimed := operation
+	claimed.NextAttemptAt = now.UTC().Add(p.claimDuration())
+	if err := p.repo.Update(ctx, claimed); err != nil {
+		return nil, errors.Wrapf(err, "while claiming %s operation with ID '%s'", operation.Type, operation.ID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "while committing the db transaction")
+	}
+
+	return &operation, nil
+}
+
+// record deletes the handled operation, schedules its retry or marks it as failed in a short transaction
+func (p *Processor) record(ctx context.Context, operation model.OutboxOperation, handleErr error) error {
+	tx, err := p.transact.Begin()
+	if err != nil {
+		return errors.Wrap(err, "while opening the db transaction")
+	}
+	defer p.transact.RollbackUnlessCommited(tx)
+
+	ctx = persistence.SaveToContext(ctx, tx)
+
+	exhausted := false
+	if handleErr == nil {
+		if err := p.repo.Delete(ctx, operation.ID); err != nil {
+			return errors.Wrapf(err, "while deleting processed %s operation with ID '%s'", operation.Type, operation.ID)
+		}
+	} else {
+		errMessage := handleErr.Error()
+		now := p.timestampGen().UTC()
+		operation.Attempts++
+		operation.LastError = &errMessage
+		operation.NextAttemptAt = now.Add(p.retryDelay(operation.Attempts))
+
+		exhausted = operation.Attempts >= p.cfg.MaxAttempts
+		if exhausted {
+			operation.FailedAt = &now
+			log.Errorf("Attempt %d of %s operation with ID '%s' failed, the operation reached the maximum number of attempts and will not be retried: %s",
+				operation.Attempts, operation.Type, operation.ID, errMessage)
+		} else {
+			log.Errorf("Attempt %d of %s operation with ID '%s' failed, next attempt at %s: %s",
+				operation.Attempts, operation.Type, operation.ID, operation.NextAttemptAt.Format(time.RFC3339), errMessage)
+		}
+
+		if err := p.repo.Update(ctx, operation); err != nil {
+			return errors.Wrapf(err, "while updating failed %s operation with ID '%s'", operation.Type, operation.ID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "while committing the db transaction")
+	}
+
+	if exhausted {
+		p.metricsCollector.IncrementFailedOutboxOperations(string(operation.Type))
+	}
+
+	return nil
+}
+
+// claimDuration covers the handling bounded by the handler timeout and the transaction recording its result
+func (p *Processor) claimDuration() time.Duration {
+	return 2 * p.cfg.HandlerTimeout
+}
+
+func (p *Processor) handle(ctx context.Context, operation model.OutboxOperation) error {
+	handler, ok := p.handlers[operation.Type]
+	if !ok {
+		return errors.Errorf("no handler registered for %s operation", operation.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.HandlerTimeout)
+	defer cancel()
+
+	return handler.Handle(ctx, operation.Payload)
+}
+
+func (p *Processor) retryDelay(attempts int) time.Duration {
+	delay := p.cfg.RetryDelay
+	for i := 1; i < attempts && delay < p.cfg.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.cfg.MaxRetryDelay {
+		delay = p.cfg.MaxRetryDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,268 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	persistenceautomock "github.com/kyma-incubator/compass/components/director/internal/persistence/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence/txtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_Execute(t *testing.T) {
+	//GIVEN
+	testErr := errors.New("test error")
+	txGen := txtest.NewTransactionContextGenerator(testErr)
+	cfg := outbox.Config{BatchSize: 10, HandlerTimeout: 30 * time.Second, RetryDelay: 10 * time.Second, MaxRetryDelay: time.Minute, MaxAttempts: 10}
+	operation := fixModelOperation(0, nil, testNow)
+	claimed := fixModelOperation(0, nil, testNow.Add(time.Minute))
+
+	t.Run("Success deletes processed operation", func(t *testing.T) {
+		persistTx, transact := txThatSucceedsTimes(3)
+		repo := &automock.Repository{}
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return([]model.OutboxOperation{operation}, nil).Once()
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return(nil, nil).Once()
+		repo.On("Update", txtest.CtxWithDBMatcher(), claimed).Return(nil).Once()
+		repo.On("Delete", txtest.CtxWithDBMatcher(), testOperationID).Return(nil).Once()
+		handler := &automock.Handler{}
+		handler.On("Handle", ctxWithDeadlineWithoutDBMatcher(), []byte(testPayload)).Return(nil).Once()
+
+		processor := outbox.NewProcessor(transact, repo, map[model.OutboxOperationType]outbox.Handler{model.OutboxDeleteOAuth20Client: handler}, cfg, &automock.MetricsCollector{})
+		processor.SetTimestampGen(func() time.Time { return testNow })
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.NoError(t, err)
+		mock.AssertExpectationsForObjects(t, persistTx, transact, repo, handler)
+	})
+
+	t.Run("Success schedules retry of failed operation with backoff", func(t *testing.T) {
+		testCases := []struct {
+			Name          string
+			Attempts      int
+			ExpectedDelay time.Duration
+		}{
+			{Name: "First failure", Attempts: 0, ExpectedDelay: 10 * time.Second},
+			{Name: "Second failure", Attempts: 1, ExpectedDelay: 20 * time.Second},
+			{Name: "Failure over maximum delay", Attempts: 5, ExpectedDelay: time.Minute},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				persistTx, transact := txThatSucceedsTimes(3)
+				failed := fixModelOperation(testCase.Attempts, nil, testNow)
+				claimedFailed := fixModelOperation(testCase.Attempts, nil, testNow.Add(time.Minute))
+				expected := fixModelOperation(testCase.Attempts+1, str("test error"), testNow.Add(testCase.ExpectedDelay))
+				repo := &automock.Repository{}
+				repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return([]model.OutboxOperation{failed}, nil).Once()
+				repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return(nil, nil).Once()
+				repo.On("Update", txtest.CtxWithDBMatcher(), claimedFailed).Return(nil).Once()
+				repo.On("Update", txtest.CtxWithDBMatcher(), expected).Return(nil).Once()
+				handler := &automock.Handler{}
+				handler.On("Handle", ctxWithDeadlineWithoutDBMatcher(), []byte(testPayload)).Return(testErr).Once()
+
+				processor := outbox.NewProcessor(transact, repo, map[model.OutboxOperationType]outbox.Handler{model.OutboxDeleteOAuth20Client: handler}, cfg, &automock.MetricsCollector{})
+				processor.SetTimestampGen(func() time.Time { return testNow })
+
+				//WHEN
+				err := processor.Execute(context.TODO())
+
+				//THEN
+				require.NoError(t, err)
+				mock.AssertExpectationsForObjects(t, persistTx, transact, repo, handler)
+			})
+		}
+	})
+
+	t.Run("Success marks operation as failed when it reaches maximum attempts", func(t *testing.T) {
+		persistTx, transact := txThatSucceedsTimes(3)
+		failed := fixModelOperation(9, nil, testNow)
+		claimedFailed := fixModelOperation(9, nil, testNow.Add(time.Minute))
+		expected := fixModelOperation(10, str("test error"), testNow.Add(time.Minute))
+		expected.FailedAt = &testNow
+		repo := &automock.Repository{}
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return([]model.OutboxOperation{failed}, nil).Once()
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return(nil, nil).Once()
+		repo.On("Update", txtest.CtxWithDBMatcher(), claimedFailed).Return(nil).Once()
+		repo.On("Update", txtest.CtxWithDBMatcher(), expected).Return(nil).Once()
+		handler := &automock.Handler{}
+		handler.On("Handle", ctxWithDeadlineWithoutDBMatcher(), []byte(testPayload)).Return(testErr).Once()
+		metricsCollector := &automock.MetricsCollector{}
+		metricsCollector.On("IncrementFailedOutboxOperations", string(model.OutboxDeleteOAuth20Client)).Return().Once()
+
+		processor := outbox.NewProcessor(transact, repo, map[model.OutboxOperationType]outbox.Handler{model.OutboxDeleteOAuth20Client: handler}, cfg, metricsCollector)
+		processor.SetTimestampGen(func() time.Time { return testNow })
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.NoError(t, err)
+		mock.AssertExpectationsForObjects(t, persistTx, transact, repo, handler, metricsCollector)
+	})
+
+	t.Run("Success schedules retry of operation without handler", func(t *testing.T) {
+		persistTx, transact := txThatSucceedsTimes(3)
+		expected := fixModelOperation(1, str("no handler registered for DELETE_OAUTH20_CLIENT operation"), testNow.Add(10*time.Second))
+		repo := &automock.Repository{}
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return([]model.OutboxOperation{operation}, nil).Once()
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return(nil, nil).Once()
+		repo.On("Update", txtest.CtxWithDBMatcher(), claimed).Return(nil).Once()
+		repo.On("Update", txtest.CtxWithDBMatcher(), expected).Return(nil).Once()
+
+		processor := outbox.NewProcessor(transact, repo, nil, cfg, &automock.MetricsCollector{})
+		processor.SetTimestampGen(func() time.Time { return testNow })
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.NoError(t, err)
+		mock.AssertExpectationsForObjects(t, persistTx, transact, repo)
+	})
+
+	t.Run("Success stops after batch size of operations", func(t *testing.T) {
+		persistTx, transact := txThatSucceedsTimes(4)
+		repo := &automock.Repository{}
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return([]model.OutboxOperation{operation}, nil).Twice()
+		repo.On("Update", txtest.CtxWithDBMatcher(), claimed).Return(nil).Twice()
+		repo.On("Delete", txtest.CtxWithDBMatcher(), testOperationID).Return(nil).Twice()
+		handler := &automock.Handler{}
+		handler.On("Handle", ctxWithDeadlineWithoutDBMatcher(), []byte(testPayload)).Return(nil).Twice()
+
+		batchCfg := cfg
+		batchCfg.BatchSize = 2
+		processor := outbox.NewProcessor(transact, repo, map[model.OutboxOperationType]outbox.Handler{model.OutboxDeleteOAuth20Client: handler}, batchCfg, &automock.MetricsCollector{})
+		processor.SetTimestampGen(func() time.Time { return testNow })
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.NoError(t, err)
+		mock.AssertExpectationsForObjects(t, persistTx, transact, repo, handler)
+	})
+
+	t.Run("Error when beginning transaction", func(t *testing.T) {
+		persistTx, transact := txGen.ThatFailsOnBegin()
+		repo := &automock.Repository{}
+
+		processor := outbox.NewProcessor(transact, repo, nil, cfg, &automock.MetricsCollector{})
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+		mock.AssertExpectationsForObjects(t, persistTx, transact, repo)
+	})
+
+	t.Run("Error when listing operations", func(t *testing.T) {
+		persistTx, transact := txGen.ThatDoesntExpectCommit()
+		repo := &automock.Repository{}
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return(nil, testErr).Once()
+
+		processor := outbox.NewProcessor(transact, repo, nil, cfg, &automock.MetricsCollector{})
+		processor.SetTimestampGen(func() time.Time { return testNow })
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.EqualError(t, err, "while listing outbox operations: test error")
+		mock.AssertExpectationsForObjects(t, persistTx, transact, repo)
+	})
+
+	t.Run("Error when claiming operation", func(t *testing.T) {
+		persistTx, transact := txGen.ThatDoesntExpectCommit()
+		repo := &automock.Repository{}
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return([]model.OutboxOperation{operation}, nil).Once()
+		repo.On("Update", txtest.CtxWithDBMatcher(), claimed).Return(testErr).Once()
+		handler := &automock.Handler{}
+
+		processor := outbox.NewProcessor(transact, repo, map[model.OutboxOperationType]outbox.Handler{model.OutboxDeleteOAuth20Client: handler}, cfg, &automock.MetricsCollector{})
+		processor.SetTimestampGen(func() time.Time { return testNow })
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.EqualError(t, err, "while claiming DELETE_OAUTH20_CLIENT operation with ID 'aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa': test error")
+		mock.AssertExpectationsForObjects(t, persistTx, transact, repo, handler)
+	})
+
+	t.Run("Error when deleting processed operation", func(t *testing.T) {
+		claimTx := txtest.PersistenceContextThatExpectsCommit()
+		recordTx := txtest.PersistenceContextThatDoesntExpectCommit()
+		transact := &persistenceautomock.Transactioner{}
+		transact.On("Begin").Return(claimTx, nil).Once()
+		transact.On("Begin").Return(recordTx, nil).Once()
+		transact.On("RollbackUnlessCommited", claimTx).Return().Once()
+		transact.On("RollbackUnlessCommited", recordTx).Return().Once()
+		repo := &automock.Repository{}
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return([]model.OutboxOperation{operation}, nil).Once()
+		repo.On("Update", txtest.CtxWithDBMatcher(), claimed).Return(nil).Once()
+		repo.On("Delete", txtest.CtxWithDBMatcher(), testOperationID).Return(testErr).Once()
+		handler := &automock.Handler{}
+		handler.On("Handle", ctxWithDeadlineWithoutDBMatcher(), []byte(testPayload)).Return(nil).Once()
+
+		processor := outbox.NewProcessor(transact, repo, map[model.OutboxOperationType]outbox.Handler{model.OutboxDeleteOAuth20Client: handler}, cfg, &automock.MetricsCollector{})
+		processor.SetTimestampGen(func() time.Time { return testNow })
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.EqualError(t, err, "while deleting processed DELETE_OAUTH20_CLIENT operation with ID 'aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa': test error")
+		mock.AssertExpectationsForObjects(t, claimTx, recordTx, transact, repo, handler)
+	})
+
+	t.Run("Error when committing claim", func(t *testing.T) {
+		persistTx, transact := txGen.ThatFailsOnCommit()
+		repo := &automock.Repository{}
+		repo.On("ListDueForProcessing", txtest.CtxWithDBMatcher(), testNow, 1).Return([]model.OutboxOperation{operation}, nil).Once()
+		repo.On("Update", txtest.CtxWithDBMatcher(), claimed).Return(nil).Once()
+		handler := &automock.Handler{}
+
+		processor := outbox.NewProcessor(transact, repo, map[model.OutboxOperationType]outbox.Handler{model.OutboxDeleteOAuth20Client: handler}, cfg, &automock.MetricsCollector{})
+		processor.SetTimestampGen(func() time.Time { return testNow })
+
+		//WHEN
+		err := processor.Execute(context.TODO())
+
+		//THEN
+		require.EqualError(t, err, "while committing the db transaction: test error")
+		mock.AssertExpectationsForObjects(t, persistTx, transact, repo, handler)
+	})
+}
+
+func txThatSucceedsTimes(times int) (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner) {
+	persistTx := &persistenceautomock.PersistenceTx{}
+	persistTx.On("Commit").Return(nil).Times(times)
+
+	transact := &persistenceautomock.Transactioner{}
+	transact.On("Begin").Return(persistTx, nil).Times(times)
+	transact.On("RollbackUnlessCommited", persistTx).Return().Times(times)
+
+	return persistTx, transact
+}
+
+// ctxWithDeadlineWithoutDBMatcher matches the context of the handler, which must be bounded and must not hold any transaction
+func ctxWithDeadlineWithoutDBMatcher() interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		_, hasDeadline := ctx.Deadline()
+		_, err := persistence.FromCtx(ctx)
+		return hasDeadline && err != nil
+	})
+}
@@ -0,0 +1,81 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/internal/repo"
+	"github.com/pkg/errors"
+)
+
+const tableName string = `public.outbox_operations`
+
+var (
+	tableColumns     = []string{"id", "type", "payload", "attempts", "last_error", "created_at", "next_attempt_at", "failed_at"}
+	updatableColumns = []string{"attempts", "last_error", "next_attempt_at", "failed_at"}
+)
+
+//go:generate mockery -name=Converter -output=automock -outpkg=automock -case=underscore
+type Converter interface {
+	ToEntity(in model.OutboxOperation) Entity
+	FromEntity(in Entity) model.OutboxOperation
+}
+
+type repository struct {
+	creator       repo.Creator
+	updaterGlobal repo.UpdaterGlobal
+	deleterGlobal repo.DeleterGlobal
+
+	conv Converter
+}
+
+func NewRepository(conv Converter) *repository {
+	return &repository{
+		creator:       repo.NewCreator(tableName, tableColumns),
+		updaterGlobal: repo.NewUpdaterGlobal(tableName, updatableColumns, []string{"id"}),
+		deleterGlobal: repo.NewDeleterGlobal(tableName),
+		conv:          conv,
+	}
+}
+
+func (r *repository) Create(ctx context.Context, item model.OutboxOperation) error {
+	return r.creator.Create(ctx, r.conv.ToEntity(item))
+}
+
+// ListDueForProcessing returns the operations which should be attempted at the given time and locks them until the end of the transaction.
+// The failed operations, which exceeded the maximum number of attempts, are never returned.
+// The operations locked by other transactions are skipped, so that multiple instances of the Director never process the same operation.
+func (r *repository) ListDueForProcessing(ctx context.Context, now time.Time, limit int) ([]model.OutboxOperation, error) {
+	persist, err := persistence.FromCtx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "while fetching DB from context")
+	}
+
+	stmt := fmt.Sprintf(`SELECT %s FROM %s WHERE failed_at IS NULL AND next_attempt_at <= $1 ORDER BY next_attempt_at LIMIT $2 FOR UPDATE SKIP LOCKED`,
+		strings.Join(tableColumns, ", "), tableName)
+
+	var entities Collection
+	err = persist.Select(&entities, stmt, now.UTC(), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "while fetching outbox operations from DB")
+	}
+
+	var items []model.OutboxOperation
+	for _, entity := range entities {
+		items = append(items, r.conv.FromEntity(entity))
+	}
+
+	return items, nil
+}
+
+func (r *repository) Update(ctx context.Context, item model.OutboxOperation) error {
+	return r.updaterGlobal.UpdateSingleGlobal(ctx, r.conv.ToEntity(item))
+}
+
+func (r *repository) Delete(ctx context.Context, id string) error {
+	return r.deleterGlobal.DeleteOneGlobal(ctx, repo.Conditions{repo.NewEqualCondition("id", id)})
+}
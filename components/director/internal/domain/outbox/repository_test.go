@@ -0,0 +1,193 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/internal/repo/testdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Create(t *testing.T) {
+	//GIVEN
+	insertQuery := `^INSERT INTO public.outbox_operations \(.+\) VALUES \(.+\)$`
+	operation := fixModelOperation(0, nil, testNow)
+	entity := fixEntity(0, nil, testNow)
+
+	t.Run("Success", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+		dbMock.ExpectExec(insertQuery).
+			WithArgs(fixEntityArgs(entity)...).
+			WillReturnResult(sqlmock.NewResult(-1, 1))
+
+		convMock := &automock.Converter{}
+		convMock.On("ToEntity", operation).Return(entity).Once()
+		pgRepository := outbox.NewRepository(convMock)
+
+		//WHEN
+		err := pgRepository.Create(ctx, operation)
+
+		//THEN
+		require.NoError(t, err)
+		dbMock.AssertExpectations(t)
+		convMock.AssertExpectations(t)
+	})
+
+	t.Run("Error when inserting", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+		dbMock.ExpectExec(insertQuery).
+			WithArgs(fixEntityArgs(entity)...).
+			WillReturnError(errors.New("test error"))
+
+		convMock := &automock.Converter{}
+		convMock.On("ToEntity", operation).Return(entity).Once()
+		pgRepository := outbox.NewRepository(convMock)
+
+		//WHEN
+		err := pgRepository.Create(ctx, operation)
+
+		//THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "test error")
+		dbMock.AssertExpectations(t)
+		convMock.AssertExpectations(t)
+	})
+}
+
+func TestRepository_ListDueForProcessing(t *testing.T) {
+	//GIVEN
+	selectQuery := `SELECT id, type, payload, attempts, last_error, created_at, next_attempt_at, failed_at FROM public.outbox_operations WHERE failed_at IS NULL AND next_attempt_at <= $1 ORDER BY next_attempt_at LIMIT $2 FOR UPDATE SKIP LOCKED`
+
+	t.Run("Success", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+		rows := sqlmock.NewRows(testColumns).
+			AddRow(testOperationID, string(model.OutboxDeleteOAuth20Client), testPayload, 1, "test error", testNow, testNow, nil)
+		dbMock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+			WithArgs(testNow, 10).
+			WillReturnRows(rows)
+		pgRepository := outbox.NewRepository(outbox.NewConverter())
+
+		//WHEN
+		actual, err := pgRepository.ListDueForProcessing(ctx, testNow, 10)
+
+		//THEN
+		require.NoError(t, err)
+		assert.Equal(t, []model.OutboxOperation{fixModelOperation(1, str("test error"), testNow)}, actual)
+		dbMock.AssertExpectations(t)
+	})
+
+	t.Run("Error when selecting", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+		dbMock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+			WithArgs(testNow, 10).
+			WillReturnError(errors.New("test error"))
+		pgRepository := outbox.NewRepository(outbox.NewConverter())
+
+		//WHEN
+		_, err := pgRepository.ListDueForProcessing(ctx, testNow, 10)
+
+		//THEN
+		require.EqualError(t, err, "while fetching outbox operations from DB: test error")
+		dbMock.AssertExpectations(t)
+	})
+
+	t.Run("Error when there is no transaction in context", func(t *testing.T) {
+		pgRepository := outbox.NewRepository(outbox.NewConverter())
+
+		//WHEN
+		_, err := pgRepository.ListDueForProcessing(context.TODO(), testNow, 10)
+
+		//THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while fetching DB from context")
+	})
+}
+
+func TestRepository_Update(t *testing.T) {
+	//GIVEN
+	updateQuery := `UPDATE public.outbox_operations SET attempts = ?, last_error = ?, next_attempt_at = ?, failed_at = ? WHERE id = ?`
+	operation := fixModelOperation(1, str("test error"), testNow)
+	entity := fixEntity(1, str("test error"), testNow)
+
+	t.Run("Success", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+		dbMock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+			WithArgs(entity.Attempts, entity.LastError, entity.NextAttemptAt, entity.FailedAt, entity.ID).
+			WillReturnResult(sqlmock.NewResult(-1, 1))
+		pgRepository := outbox.NewRepository(outbox.NewConverter())
+
+		//WHEN
+		err := pgRepository.Update(ctx, operation)
+
+		//THEN
+		require.NoError(t, err)
+		dbMock.AssertExpectations(t)
+	})
+
+	t.Run("Error when operation does not exist", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+		dbMock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+			WithArgs(entity.Attempts, entity.LastError, entity.NextAttemptAt, entity.FailedAt, entity.ID).
+			WillReturnResult(sqlmock.NewResult(-1, 0))
+		pgRepository := outbox.NewRepository(outbox.NewConverter())
+
+		//WHEN
+		err := pgRepository.Update(ctx, operation)
+
+		//THEN
+		require.EqualError(t, err, "should update single row, but updated 0 rows")
+		dbMock.AssertExpectations(t)
+	})
+}
+
+func TestRepository_Delete(t *testing.T) {
+	//GIVEN
+	deleteQuery := `DELETE FROM public.outbox_operations WHERE id = $1`
+
+	t.Run("Success", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+		dbMock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+			WithArgs(testOperationID).
+			WillReturnResult(sqlmock.NewResult(-1, 1))
+		pgRepository := outbox.NewRepository(outbox.NewConverter())
+
+		//WHEN
+		err := pgRepository.Delete(ctx, testOperationID)
+
+		//THEN
+		require.NoError(t, err)
+		dbMock.AssertExpectations(t)
+	})
+
+	t.Run("Error when deleting", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+		dbMock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+			WithArgs(testOperationID).
+			WillReturnError(errors.New("test error"))
+		pgRepository := outbox.NewRepository(outbox.NewConverter())
+
+		//WHEN
+		err := pgRepository.Delete(ctx, testOperationID)
+
+		//THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "test error")
+		dbMock.AssertExpectations(t)
+	})
+}
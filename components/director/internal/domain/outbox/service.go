@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/timestamp"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockery -name=Repository -output=automock -outpkg=automock -case=underscore
+type Repository interface {
+	Create(ctx context.Context, item model.OutboxOperation) error
+	ListDueForProcessing(ctx context.Context, now time.Time, limit int) ([]model.OutboxOperation, error)
+	Update(ctx context.Context, item model.OutboxOperation) error
+	Delete(ctx context.Context, id string) error
+}
+
+//go:generate mockery -name=UIDService -output=automock -outpkg=automock -case=underscore
+type UIDService interface {
+	Generate() string
+}
+
+type service struct {
+	repo         Repository
+	uidService   UIDService
+	timestampGen timestamp.Generator
+}
+
+func NewService(repo Repository, uidService UIDService) *service {
+	return &service{
+		repo:         repo,
+		uidService:   uidService,
+		timestampGen: timestamp.DefaultGenerator(),
+	}
+}
+
+// Enqueue records the operation in the transaction from the context. The operation is processed only if that transaction is committed.
+func (s *service) Enqueue(ctx context.Context, operationType model.OutboxOperationType, payload interface{}) error {
+	payloadMarshalled, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrapf(err, "while marshalling payload of %s operation", operationType)
+	}
+
+	now := s.timestampGen().UTC()
+	operation := model.OutboxOperation{
+		ID:            s.uidService.Generate(),
+		Type:          operationType,
+		Payload:       payloadMarshalled,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+
+	err = s.repo.Create(ctx, operation)
+	if err != nil {
+		return errors.Wrapf(err, "while creating %s operation", operationType)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Enqueue(t *testing.T) {
+	//GIVEN
+	ctx := context.TODO()
+	payload := map[string]string{"clientID": "foo"}
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		Name          string
+		RepoFn        func() *automock.Repository
+		Payload       interface{}
+		ExpectedError string
+	}{
+		{
+			Name: "Success",
+			RepoFn: func() *automock.Repository {
+				repo := &automock.Repository{}
+				repo.On("Create", ctx, fixModelOperation(0, nil, testNow)).Return(nil).Once()
+				return repo
+			},
+			Payload: payload,
+		},
+		{
+			Name: "Error when creating",
+			RepoFn: func() *automock.Repository {
+				repo := &automock.Repository{}
+				repo.On("Create", ctx, fixModelOperation(0, nil, testNow)).Return(testErr).Once()
+				return repo
+			},
+			Payload:       payload,
+			ExpectedError: "while creating DELETE_OAUTH20_CLIENT operation: test error",
+		},
+		{
+			Name: "Error when marshalling payload",
+			RepoFn: func() *automock.Repository {
+				return &automock.Repository{}
+			},
+			Payload:       make(chan int),
+			ExpectedError: "while marshalling payload of DELETE_OAUTH20_CLIENT operation: json: unsupported type: chan int",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			repo := testCase.RepoFn()
+			uidSvc := &automock.UIDService{}
+			uidSvc.On("Generate").Return(testOperationID).Maybe()
+			svc := outbox.NewService(repo, uidSvc)
+			svc.SetTimestampGen(func() time.Time { return testNow })
+
+			//WHEN
+			err := svc.Enqueue(ctx, model.OutboxDeleteOAuth20Client, testCase.Payload)
+
+			//THEN
+			if testCase.ExpectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, testCase.ExpectedError)
+			}
+			mock.AssertExpectationsForObjects(t, repo, uidSvc)
+		})
+	}
+}
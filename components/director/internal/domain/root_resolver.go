@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-incubator/compass/components/director/internal/domain/event"
 
 	"github.com/kyma-incubator/compass/components/director/internal/domain/oauth20"
+	"github.com/kyma-incubator/compass/components/director/internal/domain/outbox"
 	"github.com/kyma-incubator/compass/components/director/pkg/scope"
 
 	"github.com/kyma-incubator/compass/components/director/internal/model"
@@ -27,6 +28,7 @@ import (
 	"github.com/kyma-incubator/compass/components/director/internal/domain/version"
 	"github.com/kyma-incubator/compass/components/director/internal/domain/webhook"
 	"github.com/kyma-incubator/compass/components/director/internal/graphql_client"
+	"github.com/kyma-incubator/compass/components/director/internal/ownership"
 	"github.com/kyma-incubator/compass/components/director/internal/persistence"
 	"github.com/kyma-incubator/compass/components/director/internal/uid"
 	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
@@ -47,6 +49,7 @@ type RootResolver struct {
 	systemAuth  *systemauth.Resolver
 	oAuth20     *oauth20.Resolver
 	intSys      *integrationsystem.Resolver
+	ownership   *ownership.Middleware
 }
 
 func NewRootResolver(transact persistence.Transactioner, scopeCfgProvider *scope.Provider, oneTimeTokenCfg onetimetoken.Config, oAuth20Cfg oauth20.Config, eventCfg event.Config) *RootResolver {
@@ -79,6 +82,7 @@ func NewRootResolver(transact persistence.Transactioner, scopeCfgProvider *scope
 	fetchRequestRepo := fetchrequest.NewRepository(frConverter)
 	apiRtmAuthRepo := apiruntimeauth.NewRepository(apiRtmAuthConverter)
 	systemAuthRepo := systemauth.NewRepository(systemAuthConverter)
+	outboxRepo := outbox.NewRepository(outbox.NewConverter())
 	intSysRepo := integrationsystem.NewRepository(intSysConverter)
 
 	connectorGCLI := graphql_client.NewGraphQLClient(oneTimeTokenCfg.OneTimeTokenURL)
@@ -96,8 +100,9 @@ func NewRootResolver(transact persistence.Transactioner, scopeCfgProvider *scope
 	healthCheckSvc := healthcheck.NewService(healthcheckRepo)
 	labelDefSvc := labeldef.NewService(labelDefRepo, labelRepo, uidSvc)
 	systemAuthSvc := systemauth.NewService(systemAuthRepo, uidSvc)
-	tokenSvc := onetimetoken.NewTokenService(connectorGCLI, systemAuthSvc, oneTimeTokenCfg.ConnectorURL)
-	oAuth20Svc := oauth20.NewService(scopeCfgProvider, uidSvc, oAuth20Cfg)
+	tokenSvc := onetimetoken.NewTokenService(connectorGCLI, systemAuthSvc, oneTimeTokenCfg)
+	outboxSvc := outbox.NewService(outboxRepo, uidSvc)
+	oAuth20Svc := oauth20.NewService(scopeCfgProvider, uidSvc, outboxSvc, oAuth20Cfg)
 	intSysSvc := integrationsystem.NewService(intSysRepo, uidSvc)
 
 	return &RootResolver{
@@ -113,9 +118,15 @@ func NewRootResolver(transact persistence.Transactioner, scopeCfgProvider *scope
 		systemAuth:  systemauth.NewResolver(transact, systemAuthSvc, oAuth20Svc, systemAuthConverter),
 		oAuth20:     oauth20.NewResolver(transact, oAuth20Svc, appSvc, runtimeSvc, intSysSvc, systemAuthSvc, systemAuthConverter),
 		intSys:      integrationsystem.NewResolver(transact, intSysSvc, systemAuthSvc, oAuth20Svc, intSysConverter, systemAuthConverter),
+		ownership:   ownership.NewMiddleware(transact, appSvc, apiSvc, eventAPISvc, docSvc, webhookSvc, systemAuthSvc),
 	}
 }
 
+// Ownership returns the middleware which restricts Applications, Runtimes and Integration Systems to the objects they own
+func (r *RootResolver) Ownership() *ownership.Middleware {
+	return r.ownership
+}
+
 func (r *RootResolver) Mutation() graphql.MutationResolver {
 	return &mutationResolver{r}
 }
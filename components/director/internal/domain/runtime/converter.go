@@ -43,10 +43,28 @@ func (c *converter) InputFromGraphQL(in graphql.RuntimeInput) model.RuntimeInput
 		labels = *in.Labels
 	}
 
+	var condition *model.RuntimeStatusCondition
+	if in.StatusCondition != nil {
+		converted := c.statusConditionFromGraphQL(*in.StatusCondition)
+		condition = &converted
+	}
+
 	return model.RuntimeInput{
-		Name:        in.Name,
-		Description: in.Description,
-		Labels:      labels,
+		Name:            in.Name,
+		Description:     in.Description,
+		Labels:          labels,
+		StatusCondition: condition,
+	}
+}
+
+func (c *converter) statusConditionFromGraphQL(in graphql.RuntimeStatusCondition) model.RuntimeStatusCondition {
+	switch in {
+	case graphql.RuntimeStatusConditionReady:
+		return model.RuntimeStatusConditionReady
+	case graphql.RuntimeStatusConditionFailed:
+		return model.RuntimeStatusConditionFailed
+	default:
+		return model.RuntimeStatusConditionInitial
 	}
 }
 
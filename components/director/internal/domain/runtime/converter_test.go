@@ -80,6 +80,9 @@ func TestConverter_MultipleToGraphQL(t *testing.T) {
 
 func TestConverter_InputFromGraphQL(t *testing.T) {
 	// given
+	gqlReadyCondition := graphql.RuntimeStatusConditionReady
+	modelReadyCondition := model.RuntimeStatusConditionReady
+
 	testCases := []struct {
 		Name     string
 		Input    graphql.RuntimeInput
@@ -90,6 +93,11 @@ func TestConverter_InputFromGraphQL(t *testing.T) {
 			Input:    fixGQLRuntimeInput("foo", "Lorem ipsum"),
 			Expected: fixModelRuntimeInput("foo", "Lorem ipsum"),
 		},
+		{
+			Name:     "Status condition given",
+			Input:    graphql.RuntimeInput{Name: "foo", StatusCondition: &gqlReadyCondition},
+			Expected: model.RuntimeInput{Name: "foo", StatusCondition: &modelReadyCondition},
+		},
 		{
 			Name:     "Empty",
 			Input:    graphql.RuntimeInput{},
@@ -153,6 +153,8 @@ func (s *service) Update(ctx context.Context, id string, in model.RuntimeInput)
 
 	if rtm.Status.Condition == "" {
 		rtm.Status = currentStatuts
+	} else {
+		rtm.Status.Timestamp = time.Now()
 	}
 
 	err = s.repo.Update(ctx, rtm)
@@ -5,6 +5,7 @@ package automock
 import context "context"
 import mock "github.com/stretchr/testify/mock"
 import model "github.com/kyma-incubator/compass/components/director/internal/model"
+import time "time"
 
 // Repository is an autogenerated mock type for the Repository type
 type Repository struct {
@@ -25,13 +26,13 @@ func (_m *Repository) Create(ctx context.Context, item model.SystemAuth) error {
 	return r0
 }
 
-// Delete provides a mock function with given fields: ctx, tenant, id
-func (_m *Repository) Delete(ctx context.Context, tenant string, id string) error {
-	ret := _m.Called(ctx, tenant, id)
+// DeleteByIDForObject provides a mock function with given fields: ctx, tenant, id, objType
+func (_m *Repository) DeleteByIDForObject(ctx context.Context, tenant string, id string, objType model.SystemAuthReferenceObjectType) error {
+	ret := _m.Called(ctx, tenant, id, objType)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, tenant, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.SystemAuthReferenceObjectType) error); ok {
+		r0 = rf(ctx, tenant, id, objType)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -39,13 +40,13 @@ func (_m *Repository) Delete(ctx context.Context, tenant string, id string) erro
 	return r0
 }
 
-// DeleteByIDForObject provides a mock function with given fields: ctx, tenant, id, objType
-func (_m *Repository) DeleteByIDForObject(ctx context.Context, tenant string, id string, objType model.SystemAuthReferenceObjectType) error {
-	ret := _m.Called(ctx, tenant, id, objType)
+// DeleteExpiredOneTimeTokens provides a mock function with given fields: ctx, now
+func (_m *Repository) DeleteExpiredOneTimeTokens(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.SystemAuthReferenceObjectType) error); ok {
-		r0 = rf(ctx, tenant, id, objType)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -121,3 +122,40 @@ func (_m *Repository) ListForObject(ctx context.Context, tenant string, objectTy
 
 	return r0, r1
 }
+
+// ListGlobalWithOAuthCredentials provides a mock function with given fields: ctx
+func (_m *Repository) ListGlobalWithOAuthCredentials(ctx context.Context) ([]model.SystemAuth, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.SystemAuth
+	if rf, ok := ret.Get(0).(func(context.Context) []model.SystemAuth); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SystemAuth)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGlobal provides a mock function with given fields: ctx, item
+func (_m *Repository) UpdateGlobal(ctx context.Context, item model.SystemAuth) error {
+	ret := _m.Called(ctx, item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.SystemAuth) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -3,6 +3,7 @@ package systemauth
 import (
 	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
 
@@ -33,8 +34,9 @@ func (c *converter) ToGraphQL(in *model.SystemAuth) *graphql.SystemAuth {
 	}
 
 	return &graphql.SystemAuth{
-		ID:   in.ID,
-		Auth: c.authConverter.ToGraphQL(in.Value),
+		ID:            in.ID,
+		Auth:          c.authConverter.ToGraphQL(in.Value),
+		PairingStatus: pairingStatusToGraphQL(in.EffectivePairingStatus(time.Now())),
 	}
 }
 
@@ -56,6 +58,8 @@ func (c *converter) ToEntity(in model.SystemAuth) (Entity, error) {
 		RuntimeID:           repo.NewNullableString(in.RuntimeID),
 		IntegrationSystemID: repo.NewNullableString(in.IntegrationSystemID),
 		Value:               value,
+		PairingStatus:       pairingStatusToEntity(in.PairingStatus),
+		TokenExpiresAt:      repo.NewNullableTime(in.TokenExpiresAt),
 	}, nil
 }
 
@@ -77,5 +81,33 @@ func (c *converter) FromEntity(in Entity) (model.SystemAuth, error) {
 		RuntimeID:           repo.StringPtrFromNullableString(in.RuntimeID),
 		IntegrationSystemID: repo.StringPtrFromNullableString(in.IntegrationSystemID),
 		Value:               value,
+		PairingStatus:       pairingStatusFromEntity(in.PairingStatus),
+		TokenExpiresAt:      repo.TimePtrFromNullableTime(in.TokenExpiresAt),
 	}, nil
 }
+
+func pairingStatusToGraphQL(in *model.PairingStatus) *graphql.PairingStatus {
+	if in == nil {
+		return nil
+	}
+
+	status := graphql.PairingStatus(*in)
+	return &status
+}
+
+func pairingStatusToEntity(in *model.PairingStatus) sql.NullString {
+	if in == nil {
+		return sql.NullString{}
+	}
+
+	return repo.NewValidNullableString(string(*in))
+}
+
+func pairingStatusFromEntity(in sql.NullString) *model.PairingStatus {
+	if !in.Valid {
+		return nil
+	}
+
+	status := model.PairingStatus(in.String)
+	return &status
+}
@@ -2,6 +2,7 @@ package systemauth_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/internal/domain/apiruntimeauth/automock"
 	"github.com/kyma-incubator/compass/components/director/internal/domain/systemauth"
@@ -23,6 +24,10 @@ func TestConverter_ToGraphQL(t *testing.T) {
 	modelAppSysAuth := fixModelSystemAuth(sysAuthID, model.ApplicationReference, objectID, modelAuth)
 	modelIntSysAuth := fixModelSystemAuth(sysAuthID, model.IntegrationSystemReference, objectID, modelAuth)
 	gqlSysAuth := fixGQLSystemAuth(sysAuthID, gqlAuth)
+	modelIssuedSysAuth := fixModelOneTimeTokenSystemAuth(sysAuthID, model.ApplicationReference, objectID, model.PairingStatusIssued, time.Now().Add(time.Hour))
+	modelExpiredSysAuth := fixModelOneTimeTokenSystemAuth(sysAuthID, model.ApplicationReference, objectID, model.PairingStatusIssued, time.Now().Add(-time.Hour))
+	issued := graphql.PairingStatusIssued
+	expired := graphql.PairingStatusExpired
 
 	testCases := []struct {
 		Name           string
@@ -60,6 +65,26 @@ func TestConverter_ToGraphQL(t *testing.T) {
 			Input:          modelIntSysAuth,
 			ExpectedOutput: gqlSysAuth,
 		},
+		{
+			Name: "Success when converting one-time token auth with pairing status",
+			AuthConvFn: func() *automock.AuthConverter {
+				authConv := &automock.AuthConverter{}
+				authConv.On("ToGraphQL", (*model.Auth)(nil)).Return(nil).Once()
+				return authConv
+			},
+			Input:          modelIssuedSysAuth,
+			ExpectedOutput: &graphql.SystemAuth{ID: sysAuthID, PairingStatus: &issued},
+		},
+		{
+			Name: "Success when converting one-time token auth with expired token",
+			AuthConvFn: func() *automock.AuthConverter {
+				authConv := &automock.AuthConverter{}
+				authConv.On("ToGraphQL", (*model.Auth)(nil)).Return(nil).Once()
+				return authConv
+			},
+			Input:          modelExpiredSysAuth,
+			ExpectedOutput: &graphql.SystemAuth{ID: sysAuthID, PairingStatus: &expired},
+		},
 		{
 			Name: "Returns nil when input is nil",
 			AuthConvFn: func() *automock.AuthConverter {
@@ -100,6 +125,9 @@ func TestConverter_ToEntity(t *testing.T) {
 	entRtm := fixEntity(sysAuthID, model.RuntimeReference, objectID, true)
 	entApp := fixEntity(sysAuthID, model.ApplicationReference, objectID, true)
 	entInt := fixEntity(sysAuthID, model.IntegrationSystemReference, objectID, true)
+	expiresAt := time.Date(2019, 11, 14, 12, 0, 0, 0, time.UTC)
+	modelOTTSysAuth := *fixModelOneTimeTokenSystemAuth(sysAuthID, model.ApplicationReference, objectID, model.PairingStatusConsumed, expiresAt)
+	entOTT := fixOneTimeTokenEntity(sysAuthID, model.ApplicationReference, objectID, model.PairingStatusConsumed, expiresAt)
 
 	testCases := []struct {
 		Name           string
@@ -125,6 +153,12 @@ func TestConverter_ToEntity(t *testing.T) {
 			ExpectedOutput: entInt,
 			ExpectedError:  nil,
 		},
+		{
+			Name:           "Success when converting one-time token auth",
+			Input:          modelOTTSysAuth,
+			ExpectedOutput: entOTT,
+			ExpectedError:  nil,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -159,6 +193,9 @@ func TestConverter_FromEntity(t *testing.T) {
 	entRtm := fixEntity(sysAuthID, model.RuntimeReference, objectID, true)
 	entApp := fixEntity(sysAuthID, model.ApplicationReference, objectID, true)
 	entInt := fixEntity(sysAuthID, model.IntegrationSystemReference, objectID, true)
+	expiresAt := time.Date(2019, 11, 14, 12, 0, 0, 0, time.UTC)
+	modelOTTSysAuth := *fixModelOneTimeTokenSystemAuth(sysAuthID, model.ApplicationReference, objectID, model.PairingStatusConsumed, expiresAt)
+	entOTT := fixOneTimeTokenEntity(sysAuthID, model.ApplicationReference, objectID, model.PairingStatusConsumed, expiresAt)
 
 	testCases := []struct {
 		Name           string
@@ -184,6 +221,12 @@ func TestConverter_FromEntity(t *testing.T) {
 			ExpectedOutput: modelIntSysAuth,
 			ExpectedError:  nil,
 		},
+		{
+			Name:           "Success when converting one-time token auth",
+			Input:          entOTT,
+			ExpectedOutput: modelOTTSysAuth,
+			ExpectedError:  nil,
+		},
 	}
 
 	for _, testCase := range testCases {
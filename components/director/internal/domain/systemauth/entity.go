@@ -1,6 +1,10 @@
 package systemauth
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+)
 
 type Entity struct {
 	ID                  string         `db:"id"`
@@ -9,6 +13,8 @@ type Entity struct {
 	RuntimeID           sql.NullString `db:"runtime_id"`
 	IntegrationSystemID sql.NullString `db:"integration_system_id"`
 	Value               sql.NullString `db:"value"`
+	PairingStatus       sql.NullString `db:"pairing_status"`
+	TokenExpiresAt      pq.NullTime    `db:"token_expires_at"`
 }
 
 type Collection []Entity
@@ -2,6 +2,7 @@ package systemauth_test
 
 import (
 	"database/sql/driver"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -19,7 +20,7 @@ var (
 	testErr              = errors.New("test error")
 )
 
-var testTableColumns = []string{"id", "tenant_id", "app_id", "runtime_id", "integration_system_id", "value"}
+var testTableColumns = []string{"id", "tenant_id", "app_id", "runtime_id", "integration_system_id", "value", "pairing_status", "token_expires_at"}
 
 func fixGQLSystemAuth(id string, auth *graphql.Auth) *graphql.SystemAuth {
 	return &graphql.SystemAuth{
@@ -147,6 +148,20 @@ func fixEntity(id string, objectType model.SystemAuthReferenceObjectType, object
 	return out
 }
 
+func fixModelOneTimeTokenSystemAuth(id string, objectType model.SystemAuthReferenceObjectType, objectID string, status model.PairingStatus, expiresAt time.Time) *model.SystemAuth {
+	systemAuth := fixModelSystemAuth(id, objectType, objectID, nil)
+	systemAuth.PairingStatus = &status
+	systemAuth.TokenExpiresAt = &expiresAt
+	return systemAuth
+}
+
+func fixOneTimeTokenEntity(id string, objectType model.SystemAuthReferenceObjectType, objectID string, status model.PairingStatus, expiresAt time.Time) systemauth.Entity {
+	out := fixEntity(id, objectType, objectID, false)
+	out.PairingStatus = repo.NewValidNullableString(string(status))
+	out.TokenExpiresAt = repo.NewNullableTime(&expiresAt)
+	return out
+}
+
 type sqlRow struct {
 	id       string
 	tenant   string
@@ -158,11 +173,11 @@ type sqlRow struct {
 func fixSQLRows(rows []sqlRow) *sqlmock.Rows {
 	out := sqlmock.NewRows(testTableColumns)
 	for _, row := range rows {
-		out.AddRow(row.id, row.tenant, row.appID, row.rtmID, row.intSysID, testMarshalledSchema)
+		out.AddRow(row.id, row.tenant, row.appID, row.rtmID, row.intSysID, testMarshalledSchema, nil, nil)
 	}
 	return out
 }
 
 func fixSystemAuthCreateArgs(ent systemauth.Entity) []driver.Value {
-	return []driver.Value{ent.ID, ent.TenantID, ent.AppID, ent.RuntimeID, ent.IntegrationSystemID, ent.Value}
+	return []driver.Value{ent.ID, ent.TenantID, ent.AppID, ent.RuntimeID, ent.IntegrationSystemID, ent.Value, ent.PairingStatus, ent.TokenExpiresAt}
 }
@@ -3,6 +3,7 @@ package systemauth
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/internal/model"
 	"github.com/kyma-incubator/compass/components/director/internal/repo"
@@ -14,8 +15,9 @@ import (
 const tableName string = `public.system_auths`
 
 var (
-	tableColumns = []string{"id", "tenant_id", "app_id", "runtime_id", "integration_system_id", "value"}
-	tenantColumn = "tenant_id"
+	tableColumns     = []string{"id", "tenant_id", "app_id", "runtime_id", "integration_system_id", "value", "pairing_status", "token_expires_at"}
+	updatableColumns = []string{"value", "pairing_status", "token_expires_at"}
+	tenantColumn     = "tenant_id"
 )
 
 //go:generate mockery -name=Converter -output=automock -outpkg=automock -case=underscore
@@ -29,7 +31,10 @@ type repository struct {
 	singleGetter       repo.SingleGetter
 	singleGetterGlobal repo.SingleGetterGlobal
 	lister             repo.Lister
+	listerGlobal       repo.ListerGlobal
+	updaterGlobal      repo.UpdaterGlobal
 	deleter            repo.Deleter
+	deleterGlobal      repo.DeleterGlobal
 
 	conv Converter
 }
@@ -40,7 +45,10 @@ func NewRepository(conv Converter) *repository {
 		singleGetter:       repo.NewSingleGetter(tableName, tenantColumn, tableColumns),
 		singleGetterGlobal: repo.NewSingleGetterGlobal(tableName, tableColumns),
 		lister:             repo.NewLister(tableName, tenantColumn, tableColumns),
+		listerGlobal:       repo.NewListerGlobal(tableName, tableColumns),
+		updaterGlobal:      repo.NewUpdaterGlobal(tableName, updatableColumns, []string{"id"}),
 		deleter:            repo.NewDeleter(tableName, tenantColumn),
+		deleterGlobal:      repo.NewDeleterGlobal(tableName),
 		conv:               conv,
 	}
 }
@@ -107,6 +115,42 @@ func (r *repository) ListForObject(ctx context.Context, tenant string, objectTyp
 	return items, nil
 }
 
+func (r *repository) ListGlobalWithOAuthCredentials(ctx context.Context) ([]model.SystemAuth, error) {
+	var entities Collection
+	if err := r.listerGlobal.ListGlobal(ctx, &entities, "jsonb_typeof(value->'Credential'->'Oauth') = 'object'"); err != nil {
+		return nil, err
+	}
+
+	var items []model.SystemAuth
+
+	for _, ent := range entities {
+		m, err := r.conv.FromEntity(ent)
+		if err != nil {
+			return nil, errors.Wrap(err, "while creating system auth model from entity")
+		}
+
+		items = append(items, m)
+	}
+
+	return items, nil
+}
+
+func (r *repository) UpdateGlobal(ctx context.Context, item model.SystemAuth) error {
+	entity, err := r.conv.ToEntity(item)
+	if err != nil {
+		return errors.Wrap(err, "while converting model to entity")
+	}
+
+	return r.updaterGlobal.UpdateSingleGlobal(ctx, entity)
+}
+
+func (r *repository) DeleteExpiredOneTimeTokens(ctx context.Context, now time.Time) error {
+	return r.deleterGlobal.DeleteManyGlobal(ctx, repo.Conditions{
+		repo.NewEqualCondition("pairing_status", string(model.PairingStatusIssued)),
+		repo.NewLessThanCondition("token_expires_at", now.UTC().Format(time.RFC3339)),
+	})
+}
+
 func (r *repository) DeleteAllForObject(ctx context.Context, tenant string, objectType model.SystemAuthReferenceObjectType, objectID string) error {
 	objTypeFieldName, err := referenceObjectField(objectType)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ import (
 	"github.com/kyma-incubator/compass/components/director/internal/domain/systemauth"
 	"github.com/kyma-incubator/compass/components/director/internal/model"
 	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/internal/repo"
 	"github.com/kyma-incubator/compass/components/director/internal/repo/testdb"
 	"github.com/stretchr/testify/require"
 )
@@ -164,7 +166,7 @@ func TestRepository_GetByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "app_id", "runtime_id", "integration_system_id", "value"}).
 			AddRow(saID, testTenant, saEntity.AppID, saEntity.RuntimeID, saEntity.IntegrationSystemID, saEntity.Value)
 
-		query := "SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value FROM public.system_auths WHERE tenant_id = $1 AND id = $2"
+		query := "SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value, pairing_status, token_expires_at FROM public.system_auths WHERE tenant_id = $1 AND id = $2"
 		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(testTenant, saID).WillReturnRows(rows)
 
@@ -240,7 +242,7 @@ func TestRepository_GetByIDGlobal(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "app_id", "runtime_id", "integration_system_id", "value"}).
 			AddRow(saID, testTenant, saEntity.AppID, saEntity.RuntimeID, saEntity.IntegrationSystemID, saEntity.Value)
 
-		query := "SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value FROM public.system_auths WHERE id = $1"
+		query := "SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value, pairing_status, token_expires_at FROM public.system_auths WHERE id = $1"
 		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(saID).WillReturnRows(rows)
 
@@ -315,7 +317,7 @@ func TestRepository_ListForObject(t *testing.T) {
 			fixEntity("bar", model.RuntimeReference, objID, true),
 		}
 
-		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value FROM public.system_auths WHERE tenant_id=$1 AND runtime_id = 'bar'`
+		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value, pairing_status, token_expires_at FROM public.system_auths WHERE tenant_id=$1 AND runtime_id = 'bar'`
 		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(testTenant).
 			WillReturnRows(fixSQLRows([]sqlRow{
@@ -363,7 +365,7 @@ func TestRepository_ListForObject(t *testing.T) {
 			fixEntity("bar", model.ApplicationReference, objID, true),
 		}
 
-		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value FROM public.system_auths WHERE tenant_id=$1 AND app_id = 'bar'`
+		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value, pairing_status, token_expires_at FROM public.system_auths WHERE tenant_id=$1 AND app_id = 'bar'`
 		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(testTenant).
 			WillReturnRows(fixSQLRows([]sqlRow{
@@ -411,7 +413,7 @@ func TestRepository_ListForObject(t *testing.T) {
 			fixEntity("bar", model.IntegrationSystemReference, objID, true),
 		}
 
-		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value FROM public.system_auths WHERE tenant_id=$1 AND integration_system_id = 'bar'`
+		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value, pairing_status, token_expires_at FROM public.system_auths WHERE tenant_id=$1 AND integration_system_id = 'bar'`
 		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(model.IntegrationSystemTenant).
 			WillReturnRows(fixSQLRows([]sqlRow{
@@ -463,7 +465,7 @@ func TestRepository_ListForObject(t *testing.T) {
 		db, dbMock := testdb.MockDatabase(t)
 		ctx := persistence.SaveToContext(context.TODO(), db)
 
-		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value FROM public.system_auths WHERE tenant_id=$1 AND integration_system_id = 'bar'`
+		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value, pairing_status, token_expires_at FROM public.system_auths WHERE tenant_id=$1 AND integration_system_id = 'bar'`
 		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(model.IntegrationSystemTenant).
 			WillReturnError(testErr)
@@ -493,7 +495,7 @@ func TestRepository_ListForObject(t *testing.T) {
 			fixEntity("bar", model.IntegrationSystemReference, objID, true),
 		}
 
-		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value FROM public.system_auths WHERE tenant_id=$1 AND integration_system_id = 'bar'`
+		query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value, pairing_status, token_expires_at FROM public.system_auths WHERE tenant_id=$1 AND integration_system_id = 'bar'`
 		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
 			WithArgs(model.IntegrationSystemTenant).
 			WillReturnRows(fixSQLRows([]sqlRow{
@@ -529,6 +531,63 @@ func TestRepository_ListForObject(t *testing.T) {
 	})
 }
 
+func TestRepository_ListGlobalWithOAuthCredentials(t *testing.T) {
+	//GIVEN
+	objID := "bar"
+	modelAuth := fixModelAuth()
+	query := `SELECT id, tenant_id, app_id, runtime_id, integration_system_id, value, pairing_status, token_expires_at FROM public.system_auths WHERE jsonb_typeof(value->'Credential'->'Oauth') = 'object'`
+
+	t.Run("Success", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+
+		modelSysAuth := fixModelSystemAuth("foo", model.RuntimeReference, objID, modelAuth)
+		entSysAuth := fixEntity("foo", model.RuntimeReference, objID, true)
+
+		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
+			WillReturnRows(fixSQLRows([]sqlRow{
+				{
+					id:     modelSysAuth.ID,
+					tenant: testTenant,
+					rtmID:  modelSysAuth.RuntimeID,
+				},
+			}))
+
+		convMock := automock.Converter{}
+		convMock.On("FromEntity", entSysAuth).Return(*modelSysAuth, nil).Once()
+		pgRepository := systemauth.NewRepository(&convMock)
+
+		//WHEN
+		result, err := pgRepository.ListGlobalWithOAuthCredentials(ctx)
+
+		//THEN
+		require.NoError(t, err)
+		assert.Equal(t, []model.SystemAuth{*modelSysAuth}, result)
+		dbMock.AssertExpectations(t)
+		convMock.AssertExpectations(t)
+	})
+
+	t.Run("Error when listing", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+
+		dbMock.ExpectQuery(regexp.QuoteMeta(query)).
+			WillReturnError(testErr)
+
+		convMock := automock.Converter{}
+		pgRepository := systemauth.NewRepository(&convMock)
+
+		//WHEN
+		result, err := pgRepository.ListGlobalWithOAuthCredentials(ctx)
+
+		//THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+		require.Nil(t, result)
+		dbMock.AssertExpectations(t)
+	})
+}
+
 func TestRepository_DeleteAllForObject(t *testing.T) {
 	// GIVEN
 	sysAuthID := "foo"
@@ -681,6 +740,120 @@ func TestRepository_DeleteByIDForObject(t *testing.T) {
 	})
 }
 
+func TestRepository_UpdateGlobal(t *testing.T) {
+	// GIVEN
+	sysAuthID := "foo"
+	objID := "bar"
+
+	updateQuery := `UPDATE public.system_auths SET value = ?, pairing_status = ?, token_expires_at = ? WHERE id = ?`
+
+	t.Run("Success", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+
+		paired := model.PairingStatusPaired
+		modelSysAuth := fixModelSystemAuth(sysAuthID, model.RuntimeReference, objID, nil)
+		modelSysAuth.PairingStatus = &paired
+		entSysAuth := fixEntity(sysAuthID, model.RuntimeReference, objID, false)
+		entSysAuth.PairingStatus = repo.NewValidNullableString(string(paired))
+
+		dbMock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+			WithArgs(entSysAuth.Value, entSysAuth.PairingStatus, entSysAuth.TokenExpiresAt, sysAuthID).
+			WillReturnResult(sqlmock.NewResult(-1, 1))
+
+		convMock := automock.Converter{}
+		convMock.On("ToEntity", *modelSysAuth).Return(entSysAuth, nil).Once()
+		pgRepository := systemauth.NewRepository(&convMock)
+
+		// WHEN
+		err := pgRepository.UpdateGlobal(ctx, *modelSysAuth)
+
+		// THEN
+		require.NoError(t, err)
+		dbMock.AssertExpectations(t)
+		convMock.AssertExpectations(t)
+	})
+
+	t.Run("Error when converting", func(t *testing.T) {
+		modelSysAuth := fixModelSystemAuth(sysAuthID, model.RuntimeReference, objID, nil)
+
+		convMock := automock.Converter{}
+		convMock.On("ToEntity", *modelSysAuth).Return(systemauth.Entity{}, testErr).Once()
+		pgRepository := systemauth.NewRepository(&convMock)
+
+		// WHEN
+		err := pgRepository.UpdateGlobal(context.TODO(), *modelSysAuth)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+		convMock.AssertExpectations(t)
+	})
+
+	t.Run("Error when updating", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+
+		modelSysAuth := fixModelSystemAuth(sysAuthID, model.RuntimeReference, objID, nil)
+		entSysAuth := fixEntity(sysAuthID, model.RuntimeReference, objID, false)
+
+		dbMock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+			WithArgs(entSysAuth.Value, entSysAuth.PairingStatus, entSysAuth.TokenExpiresAt, sysAuthID).
+			WillReturnError(testErr)
+
+		convMock := automock.Converter{}
+		convMock.On("ToEntity", *modelSysAuth).Return(entSysAuth, nil).Once()
+		pgRepository := systemauth.NewRepository(&convMock)
+
+		// WHEN
+		err := pgRepository.UpdateGlobal(ctx, *modelSysAuth)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+		dbMock.AssertExpectations(t)
+		convMock.AssertExpectations(t)
+	})
+}
+
+func TestRepository_DeleteExpiredOneTimeTokens(t *testing.T) {
+	// GIVEN
+	now := time.Date(2019, 11, 14, 12, 0, 0, 0, time.UTC)
+	query := `DELETE FROM public.system_auths WHERE pairing_status = $1 AND token_expires_at < $2`
+
+	t.Run("Success", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+
+		dbMock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(string(model.PairingStatusIssued), "2019-11-14T12:00:00Z").
+			WillReturnResult(sqlmock.NewResult(-1, 3))
+
+		repo := systemauth.NewRepository(nil)
+		// WHEN
+		err := repo.DeleteExpiredOneTimeTokens(ctx, now)
+		// THEN
+		require.NoError(t, err)
+		dbMock.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		db, dbMock := testdb.MockDatabase(t)
+		ctx := persistence.SaveToContext(context.TODO(), db)
+
+		dbMock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(string(model.PairingStatusIssued), "2019-11-14T12:00:00Z").
+			WillReturnError(testErr)
+
+		repo := systemauth.NewRepository(nil)
+		// WHEN
+		err := repo.DeleteExpiredOneTimeTokens(ctx, now)
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+	})
+}
+
 func givenError() error {
 	return errors.New("some error")
 }
@@ -2,6 +2,7 @@ package systemauth
 
 import (
 	"context"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/internal/tenant"
 	"github.com/pkg/errors"
@@ -15,7 +16,10 @@ type Repository interface {
 	GetByID(ctx context.Context, tenant, id string) (*model.SystemAuth, error)
 	GetByIDGlobal(ctx context.Context, id string) (*model.SystemAuth, error)
 	ListForObject(ctx context.Context, tenant string, objectType model.SystemAuthReferenceObjectType, objectID string) ([]model.SystemAuth, error)
+	ListGlobalWithOAuthCredentials(ctx context.Context) ([]model.SystemAuth, error)
+	UpdateGlobal(ctx context.Context, item model.SystemAuth) error
 	DeleteByIDForObject(ctx context.Context, tenant string, id string, objType model.SystemAuthReferenceObjectType) error
+	DeleteExpiredOneTimeTokens(ctx context.Context, now time.Time) error
 }
 
 //go:generate mockery -name=UIDService -output=automock -outpkg=automock -case=underscore
@@ -43,17 +47,32 @@ func (s *service) CreateWithCustomID(ctx context.Context, id string, objectType
 	return s.create(ctx, id, objectType, objectID, authInput)
 }
 
-func (s *service) create(ctx context.Context, id string, objectType model.SystemAuthReferenceObjectType, objectID string, authInput *model.AuthInput) (string, error) {
-	tnt, err := tenant.LoadFromContext(ctx)
-	if err != nil {
-		return "", err
+func (s *service) CreateForOneTimeToken(ctx context.Context, objectType model.SystemAuthReferenceObjectType, objectID string, tokenExpiresAt time.Time) (string, error) {
+	issued := model.PairingStatusIssued
+	systemAuth := model.SystemAuth{
+		ID:             s.uidService.Generate(),
+		PairingStatus:  &issued,
+		TokenExpiresAt: &tokenExpiresAt,
 	}
 
+	return s.createSystemAuth(ctx, systemAuth, objectType, objectID)
+}
+
+func (s *service) create(ctx context.Context, id string, objectType model.SystemAuthReferenceObjectType, objectID string, authInput *model.AuthInput) (string, error) {
 	systemAuth := model.SystemAuth{
 		ID:    id,
 		Value: authInput.ToAuth(),
 	}
 
+	return s.createSystemAuth(ctx, systemAuth, objectType, objectID)
+}
+
+func (s *service) createSystemAuth(ctx context.Context, systemAuth model.SystemAuth, objectType model.SystemAuthReferenceObjectType, objectID string) (string, error) {
+	tnt, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	switch objectType {
 	case model.ApplicationReference:
 		systemAuth.AppID = &objectID
@@ -121,6 +140,44 @@ func (s *service) ListForObject(ctx context.Context, objectType model.SystemAuth
 	return systemAuths, nil
 }
 
+// ListGlobalWithOAuthCredentials returns the System Auths of all tenants which hold OAuth 2.0 client credentials
+func (s *service) ListGlobalWithOAuthCredentials(ctx context.Context) ([]model.SystemAuth, error) {
+	systemAuths, err := s.repo.ListGlobalWithOAuthCredentials(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing System Auths with OAuth 2.0 credentials")
+	}
+
+	return systemAuths, nil
+}
+
+func (s *service) UpdatePairingStatus(ctx context.Context, id string, status model.PairingStatus) error {
+	item, err := s.repo.GetByIDGlobal(ctx, id)
+	if err != nil {
+		return errors.Wrapf(err, "while getting SystemAuth with ID %s", id)
+	}
+
+	if item.PairingStatus == nil || !item.PairingStatus.CanTransitionTo(status) {
+		return nil
+	}
+
+	item.PairingStatus = &status
+	err = s.repo.UpdateGlobal(ctx, *item)
+	if err != nil {
+		return errors.Wrapf(err, "while updating pairing status of System Auth with ID '%s'", id)
+	}
+
+	return nil
+}
+
+func (s *service) DeleteExpiredOneTimeTokens(ctx context.Context, now time.Time) error {
+	err := s.repo.DeleteExpiredOneTimeTokens(ctx, now)
+	if err != nil {
+		return errors.Wrap(err, "while deleting System Auths with expired one-time tokens")
+	}
+
+	return nil
+}
+
 func (s *service) DeleteByIDForObject(ctx context.Context, objectType model.SystemAuthReferenceObjectType, authID string) error {
 	tnt, err := tenant.LoadFromContext(ctx)
 	if err != nil {
@@ -3,6 +3,7 @@ package systemauth_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/pkg/str"
 
@@ -177,6 +178,56 @@ func TestService_CreateWithCustomID(t *testing.T) {
 	assert.Equal(t, sysAuthID, result)
 }
 
+func TestService_CreateForOneTimeToken(t *testing.T) {
+	// GIVEN
+	ctx := tenant.SaveToContext(context.TODO(), testTenant)
+
+	sysAuthID := "bla"
+	objID := "bar"
+	expiresAt := time.Date(2019, 11, 14, 12, 0, 0, 0, time.UTC)
+	issued := model.PairingStatusIssued
+
+	expectedSysAuth := fixModelSystemAuth(sysAuthID, model.ApplicationReference, objID, nil)
+	expectedSysAuth.PairingStatus = &issued
+	expectedSysAuth.TokenExpiresAt = &expiresAt
+
+	t.Run("Success", func(t *testing.T) {
+		sysAuthRepo := &automock.Repository{}
+		sysAuthRepo.On("Create", contextThatHasTenant(testTenant), *expectedSysAuth).Return(nil).Once()
+		defer sysAuthRepo.AssertExpectations(t)
+		uidSvc := &automock.UIDService{}
+		uidSvc.On("Generate").Return(sysAuthID).Once()
+		defer uidSvc.AssertExpectations(t)
+
+		svc := systemauth.NewService(sysAuthRepo, uidSvc)
+
+		// WHEN
+		result, err := svc.CreateForOneTimeToken(ctx, model.ApplicationReference, objID, expiresAt)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, sysAuthID, result)
+	})
+
+	t.Run("Error when creating System Auth", func(t *testing.T) {
+		sysAuthRepo := &automock.Repository{}
+		sysAuthRepo.On("Create", contextThatHasTenant(testTenant), *expectedSysAuth).Return(testErr).Once()
+		defer sysAuthRepo.AssertExpectations(t)
+		uidSvc := &automock.UIDService{}
+		uidSvc.On("Generate").Return(sysAuthID).Once()
+		defer uidSvc.AssertExpectations(t)
+
+		svc := systemauth.NewService(sysAuthRepo, uidSvc)
+
+		// WHEN
+		_, err := svc.CreateForOneTimeToken(ctx, model.ApplicationReference, objID, expiresAt)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+	})
+}
+
 func TestService_ListForObject(t *testing.T) {
 	// GIVEN
 	ctx := tenant.SaveToContext(context.TODO(), testTenant)
@@ -492,6 +543,193 @@ func TestService_DeleteByIDForObject(t *testing.T) {
 	})
 }
 
+func TestService_UpdatePairingStatus(t *testing.T) {
+	// GIVEN
+	ctx := context.TODO()
+
+	sysAuthID := "foo"
+	objID := "bar"
+
+	fixSysAuthWithStatus := func(status *model.PairingStatus) *model.SystemAuth {
+		sysAuth := fixModelSystemAuth(sysAuthID, model.RuntimeReference, objID, nil)
+		sysAuth.PairingStatus = status
+		return sysAuth
+	}
+	statusPtr := func(status model.PairingStatus) *model.PairingStatus {
+		return &status
+	}
+
+	testCases := []struct {
+		Name          string
+		sysAuthRepoFn func() *automock.Repository
+		InputStatus   model.PairingStatus
+		ExpectedError error
+	}{
+		{
+			Name: "Success marking issued token as consumed",
+			sysAuthRepoFn: func() *automock.Repository {
+				sysAuthRepo := &automock.Repository{}
+				sysAuthRepo.On("GetByIDGlobal", ctx, sysAuthID).Return(fixSysAuthWithStatus(statusPtr(model.PairingStatusIssued)), nil).Once()
+				sysAuthRepo.On("UpdateGlobal", ctx, *fixSysAuthWithStatus(statusPtr(model.PairingStatusConsumed))).Return(nil).Once()
+				return sysAuthRepo
+			},
+			InputStatus: model.PairingStatusConsumed,
+		},
+		{
+			Name: "Success marking consumed token as paired",
+			sysAuthRepoFn: func() *automock.Repository {
+				sysAuthRepo := &automock.Repository{}
+				sysAuthRepo.On("GetByIDGlobal", ctx, sysAuthID).Return(fixSysAuthWithStatus(statusPtr(model.PairingStatusConsumed)), nil).Once()
+				sysAuthRepo.On("UpdateGlobal", ctx, *fixSysAuthWithStatus(statusPtr(model.PairingStatusPaired))).Return(nil).Once()
+				return sysAuthRepo
+			},
+			InputStatus: model.PairingStatusPaired,
+		},
+		{
+			Name: "Ignores System Auth without status marked as paired",
+			sysAuthRepoFn: func() *automock.Repository {
+				sysAuthRepo := &automock.Repository{}
+				sysAuthRepo.On("GetByIDGlobal", ctx, sysAuthID).Return(fixSysAuthWithStatus(nil), nil).Once()
+				return sysAuthRepo
+			},
+			InputStatus: model.PairingStatusPaired,
+		},
+		{
+			Name: "Ignores transition from paired to consumed",
+			sysAuthRepoFn: func() *automock.Repository {
+				sysAuthRepo := &automock.Repository{}
+				sysAuthRepo.On("GetByIDGlobal", ctx, sysAuthID).Return(fixSysAuthWithStatus(statusPtr(model.PairingStatusPaired)), nil).Once()
+				return sysAuthRepo
+			},
+			InputStatus: model.PairingStatusConsumed,
+		},
+		{
+			Name: "Ignores System Auth without status marked as consumed",
+			sysAuthRepoFn: func() *automock.Repository {
+				sysAuthRepo := &automock.Repository{}
+				sysAuthRepo.On("GetByIDGlobal", ctx, sysAuthID).Return(fixSysAuthWithStatus(nil), nil).Once()
+				return sysAuthRepo
+			},
+			InputStatus: model.PairingStatusConsumed,
+		},
+		{
+			Name: "Error getting System Auth",
+			sysAuthRepoFn: func() *automock.Repository {
+				sysAuthRepo := &automock.Repository{}
+				sysAuthRepo.On("GetByIDGlobal", ctx, sysAuthID).Return(nil, testErr).Once()
+				return sysAuthRepo
+			},
+			InputStatus:   model.PairingStatusConsumed,
+			ExpectedError: testErr,
+		},
+		{
+			Name: "Error updating System Auth",
+			sysAuthRepoFn: func() *automock.Repository {
+				sysAuthRepo := &automock.Repository{}
+				sysAuthRepo.On("GetByIDGlobal", ctx, sysAuthID).Return(fixSysAuthWithStatus(statusPtr(model.PairingStatusIssued)), nil).Once()
+				sysAuthRepo.On("UpdateGlobal", ctx, *fixSysAuthWithStatus(statusPtr(model.PairingStatusConsumed))).Return(testErr).Once()
+				return sysAuthRepo
+			},
+			InputStatus:   model.PairingStatusConsumed,
+			ExpectedError: testErr,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			sysAuthRepo := testCase.sysAuthRepoFn()
+			svc := systemauth.NewService(sysAuthRepo, nil)
+
+			// WHEN
+			err := svc.UpdatePairingStatus(ctx, sysAuthID, testCase.InputStatus)
+
+			// THEN
+			if testCase.ExpectedError != nil {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.ExpectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			sysAuthRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_DeleteExpiredOneTimeTokens(t *testing.T) {
+	// GIVEN
+	ctx := context.TODO()
+	now := time.Date(2019, 11, 14, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		sysAuthRepo := &automock.Repository{}
+		sysAuthRepo.On("DeleteExpiredOneTimeTokens", ctx, now).Return(nil).Once()
+		defer sysAuthRepo.AssertExpectations(t)
+		svc := systemauth.NewService(sysAuthRepo, nil)
+
+		// WHEN
+		err := svc.DeleteExpiredOneTimeTokens(ctx, now)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		sysAuthRepo := &automock.Repository{}
+		sysAuthRepo.On("DeleteExpiredOneTimeTokens", ctx, now).Return(testErr).Once()
+		defer sysAuthRepo.AssertExpectations(t)
+		svc := systemauth.NewService(sysAuthRepo, nil)
+
+		// WHEN
+		err := svc.DeleteExpiredOneTimeTokens(ctx, now)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), testErr.Error())
+	})
+}
+
+func TestService_ListGlobalWithOAuthCredentials(t *testing.T) {
+	// GIVEN
+	ctx := context.TODO()
+	sysAuths := []model.SystemAuth{
+		{
+			ID:                  "foo",
+			TenantID:            model.IntegrationSystemTenant,
+			IntegrationSystemID: str.Ptr("bar"),
+			Value:               fixModelAuth(),
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		sysAuthRepo := &automock.Repository{}
+		sysAuthRepo.On("ListGlobalWithOAuthCredentials", ctx).Return(sysAuths, nil).Once()
+		defer sysAuthRepo.AssertExpectations(t)
+		svc := systemauth.NewService(sysAuthRepo, nil)
+
+		// WHEN
+		result, err := svc.ListGlobalWithOAuthCredentials(ctx)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, sysAuths, result)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		sysAuthRepo := &automock.Repository{}
+		sysAuthRepo.On("ListGlobalWithOAuthCredentials", ctx).Return(nil, testErr).Once()
+		defer sysAuthRepo.AssertExpectations(t)
+		svc := systemauth.NewService(sysAuthRepo, nil)
+
+		// WHEN
+		_, err := svc.ListGlobalWithOAuthCredentials(ctx)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while listing System Auths with OAuth 2.0 credentials")
+	})
+}
+
 func contextThatHasTenant(expectedTenant string) interface{} {
 	return mock.MatchedBy(func(actual context.Context) bool {
 		actualTenant, err := tenant.LoadFromContext(actual)
@@ -0,0 +1,61 @@
+package filewatcher
+
+import (
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// kubernetesDataDir is the symlink swapped by the kubelet when the content of the mounted ConfigMap changes
+const kubernetesDataDir = "..data"
+
+// Watch calls the reload function every time the file is written, created or replaced.
+// The directory of the file is watched instead of the file itself, so that the atomic updates of the mounted ConfigMaps are noticed as well.
+// Watching stops when stopCh is closed.
+func Watch(stopCh <-chan struct{}, path string, reload func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "while creating file watcher")
+	}
+
+	path = filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return errors.Wrapf(err, "while watching directory of file %s", path)
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if isRelevant(event, path) {
+					reload()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Errorf("while watching file %s: %s", path, err.Error())
+			}
+		}
+	}()
+
+	return nil
+}
+
+func isRelevant(event fsnotify.Event, path string) bool {
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+		return false
+	}
+
+	name := filepath.Clean(event.Name)
+	return name == path || filepath.Base(name) == kubernetesDataDir
+}
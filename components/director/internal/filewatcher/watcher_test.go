@@ -0,0 +1,115 @@
+package filewatcher_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-incubator/compass/components/director/internal/filewatcher"
+)
+
+const eventTimeout = 5 * time.Second
+
+func TestWatch(t *testing.T) {
+	t.Run("Calls reload when file is written", func(t *testing.T) {
+		// given
+		dir, path := createFile(t, "config.yaml")
+		defer os.RemoveAll(dir)
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		reloaded := make(chan struct{}, 10)
+
+		err := filewatcher.Watch(stopCh, path, func() { reloaded <- struct{}{} })
+		require.NoError(t, err)
+
+		// when
+		err = ioutil.WriteFile(path, []byte("changed"), 0644)
+		require.NoError(t, err)
+
+		// then
+		assertReloaded(t, reloaded)
+	})
+
+	t.Run("Calls reload when file is replaced", func(t *testing.T) {
+		// given
+		dir, path := createFile(t, "config.yaml")
+		defer os.RemoveAll(dir)
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		reloaded := make(chan struct{}, 10)
+
+		err := filewatcher.Watch(stopCh, path, func() { reloaded <- struct{}{} })
+		require.NoError(t, err)
+
+		tmpPath := filepath.Join(dir, "config.yaml.tmp")
+		err = ioutil.WriteFile(tmpPath, []byte("changed"), 0644)
+		require.NoError(t, err)
+
+		// when
+		err = os.Rename(tmpPath, path)
+		require.NoError(t, err)
+
+		// then
+		assertReloaded(t, reloaded)
+	})
+
+	t.Run("Does not call reload when other file in directory changes", func(t *testing.T) {
+		// given
+		dir, path := createFile(t, "config.yaml")
+		defer os.RemoveAll(dir)
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		reloaded := make(chan struct{}, 10)
+
+		err := filewatcher.Watch(stopCh, path, func() { reloaded <- struct{}{} })
+		require.NoError(t, err)
+
+		// when
+		err = ioutil.WriteFile(filepath.Join(dir, "other.yaml"), []byte("changed"), 0644)
+		require.NoError(t, err)
+
+		// then
+		select {
+		case <-reloaded:
+			t.Fatal("reload called for unrelated file")
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	t.Run("Returns error when directory does not exist", func(t *testing.T) {
+		// given
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+
+		// when
+		err := filewatcher.Watch(stopCh, "not-existing/config.yaml", func() {})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while watching directory of file not-existing/config.yaml")
+	})
+}
+
+func createFile(t *testing.T, name string) (string, string) {
+	dir, err := ioutil.TempDir("", "filewatcher")
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+	err = ioutil.WriteFile(path, []byte("initial"), 0644)
+	require.NoError(t, err)
+
+	return dir, path
+}
+
+func assertReloaded(t *testing.T, reloaded <-chan struct{}) {
+	select {
+	case <-reloaded:
+	case <-time.After(eventTimeout):
+		t.Fatal("reload not called")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/kyma-incubator/compass/components/director/pkg/tracing"
 	gcli "github.com/machinebox/graphql"
 )
 
@@ -18,7 +19,7 @@ func newAuthorizedHTTPClient() *http.Client {
 	}
 
 	return &http.Client{
-		Transport: transport,
+		Transport: tracing.NewTransport(transport),
 		Timeout:   time.Second * 3,
 	}
 }
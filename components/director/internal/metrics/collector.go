@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Namespace = "compass"
+	Subsystem = "director"
+
+	OutcomeSuccess  = "success"
+	OutcomeError    = "error"
+	OutcomeRollback = "rollback"
+)
+
+// Collector holds the metrics of the Director
+type Collector struct {
+	graphQLOperations        *prometheus.CounterVec
+	graphQLOperationDuration *prometheus.HistogramVec
+	transactionDuration      *prometheus.HistogramVec
+	transactionRollbacks     prometheus.Counter
+	configReloadErrors       *prometheus.CounterVec
+	failedOutboxOperations   *prometheus.CounterVec
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		graphQLOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "graphql_operations_total",
+			Help:      "Number of executed GraphQL operations by operation name and outcome",
+		}, []string{"operation", "outcome"}),
+		graphQLOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "graphql_operation_duration_seconds",
+			Help:      "Duration of GraphQL operations by operation name and outcome",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "outcome"}),
+		transactionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "db_transaction_duration_seconds",
+			Help:      "Duration of database transactions by outcome",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"outcome"}),
+		transactionRollbacks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "db_transaction_rollbacks_total",
+			Help:      "Number of rolled back database transactions",
+		}),
+		configReloadErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "config_reload_errors_total",
+			Help:      "Number of failed reloads of configuration files by configuration name",
+		}, []string{"config"}),
+		failedOutboxOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "outbox_failed_operations_total",
+			Help:      "Number of outbox operations which reached the maximum number of attempts by operation type",
+		}, []string{"type"}),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.graphQLOperations.Describe(ch)
+	c.graphQLOperationDuration.Describe(ch)
+	c.transactionDuration.Describe(ch)
+	c.transactionRollbacks.Describe(ch)
+	c.configReloadErrors.Describe(ch)
+	c.failedOutboxOperations.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.graphQLOperations.Collect(ch)
+	c.graphQLOperationDuration.Collect(ch)
+	c.transactionDuration.Collect(ch)
+	c.transactionRollbacks.Collect(ch)
+	c.configReloadErrors.Collect(ch)
+	c.failedOutboxOperations.Collect(ch)
+}
+
+func (c *Collector) ObserveGraphQLOperation(operation, outcome string, duration time.Duration) {
+	c.graphQLOperations.WithLabelValues(operation, outcome).Inc()
+	c.graphQLOperationDuration.WithLabelValues(operation, outcome).Observe(duration.Seconds())
+}
+
+func (c *Collector) ObserveTransaction(outcome string, duration time.Duration) {
+	c.transactionDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+	if outcome == OutcomeRollback {
+		c.transactionRollbacks.Inc()
+	}
+}
+
+func (c *Collector) IncrementConfigReloadErrors(config string) {
+	c.configReloadErrors.WithLabelValues(config).Inc()
+}
+
+func (c *Collector) IncrementFailedOutboxOperations(operationType string) {
+	c.failedOutboxOperations.WithLabelValues(operationType).Inc()
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+)
+
+type transactioner struct {
+	persistence.Transactioner
+	collector *Collector
+}
+
+// NewTransactioner returns the Transactioner which measures the duration of transactions and counts the rollbacks
+func NewTransactioner(transact persistence.Transactioner, collector *Collector) persistence.Transactioner {
+	return &transactioner{Transactioner: transact, collector: collector}
+}
+
+func (t *transactioner) Begin() (persistence.PersistenceTx, error) {
+	tx, err := t.Transactioner.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction{PersistenceTx: tx, collector: t.collector, start: time.Now()}, nil
+}
+
+type transaction struct {
+	persistence.PersistenceTx
+	collector *Collector
+	start     time.Time
+}
+
+func (tx *transaction) Commit() error {
+	err := tx.PersistenceTx.Commit()
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeError
+	}
+	tx.collector.ObserveTransaction(outcome, time.Since(tx.start))
+
+	return err
+}
+
+// Rollback observes only the transactions which were actually rolled back, so that rolling back committed transactions is not counted
+func (tx *transaction) Rollback() error {
+	err := tx.PersistenceTx.Rollback()
+	if err == nil {
+		tx.collector.ObserveTransaction(OutcomeRollback, time.Since(tx.start))
+	}
+
+	return err
+}
@@ -0,0 +1,94 @@
+package metrics_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-incubator/compass/components/director/internal/metrics"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence/automock"
+)
+
+const rollbacksMetric = "compass_director_db_transaction_rollbacks_total"
+
+func TestTransactioner(t *testing.T) {
+	t.Run("Does not count rollback of committed transaction", func(t *testing.T) {
+		// given
+		collector := metrics.NewCollector()
+		persistTx := &automock.PersistenceTx{}
+		persistTx.On("Commit").Return(nil).Once()
+		persistTx.On("Rollback").Return(sql.ErrTxDone).Once()
+		transact := metrics.NewTransactioner(&rollingBackTransactioner{tx: persistTx}, collector)
+
+		// when
+		tx, err := transact.Begin()
+		require.NoError(t, err)
+		err = tx.Commit()
+		transact.RollbackUnlessCommited(tx)
+
+		// then
+		require.NoError(t, err)
+		persistTx.AssertExpectations(t)
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expectedRollbacks(0)), rollbacksMetric))
+	})
+
+	t.Run("Counts rollback of not committed transaction", func(t *testing.T) {
+		// given
+		collector := metrics.NewCollector()
+		persistTx := &automock.PersistenceTx{}
+		persistTx.On("Rollback").Return(nil).Once()
+		transact := metrics.NewTransactioner(&rollingBackTransactioner{tx: persistTx}, collector)
+
+		// when
+		tx, err := transact.Begin()
+		require.NoError(t, err)
+		transact.RollbackUnlessCommited(tx)
+
+		// then
+		persistTx.AssertExpectations(t)
+		assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expectedRollbacks(1)), rollbacksMetric))
+	})
+
+	t.Run("Returns error when transaction cannot be started", func(t *testing.T) {
+		// given
+		testErr := errors.New("test error")
+		inner := &automock.Transactioner{}
+		inner.On("Begin").Return(nil, testErr).Once()
+		transact := metrics.NewTransactioner(inner, metrics.NewCollector())
+
+		// when
+		_, err := transact.Begin()
+
+		// then
+		assert.Equal(t, testErr, err)
+		inner.AssertExpectations(t)
+	})
+}
+
+// rollingBackTransactioner rolls back the given transactions the same way as the database Transactioner
+type rollingBackTransactioner struct {
+	tx persistence.PersistenceTx
+}
+
+func (t *rollingBackTransactioner) Begin() (persistence.PersistenceTx, error) {
+	return t.tx, nil
+}
+
+func (t *rollingBackTransactioner) RollbackUnlessCommited(tx persistence.PersistenceTx) {
+	_ = tx.Rollback()
+}
+
+func expectedRollbacks(count int) string {
+	return fmt.Sprintf(`
+# HELP compass_director_db_transaction_rollbacks_total Number of rolled back database transactions
+# TYPE compass_director_db_transaction_rollbacks_total counter
+compass_director_db_transaction_rollbacks_total %d
+`, count)
+}
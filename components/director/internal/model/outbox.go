@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// OutboxOperation is a side effect on an external system, recorded in the same transaction as the change which causes it
+// and executed only after that transaction is committed.
+type OutboxOperation struct {
+	ID            string
+	Type          OutboxOperationType
+	Payload       []byte
+	Attempts      int
+	LastError     *string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	FailedAt      *time.Time
+}
+
+type OutboxOperationType string
+
+const (
+	OutboxDeleteOAuth20Client OutboxOperationType = "DELETE_OAUTH20_CLIENT"
+)
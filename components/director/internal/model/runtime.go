@@ -32,9 +32,10 @@ const (
 )
 
 type RuntimeInput struct {
-	Name        string
-	Description *string
-	Labels      map[string]interface{}
+	Name            string
+	Description     *string
+	Labels          map[string]interface{}
+	StatusCondition *RuntimeStatusCondition
 }
 
 func (i *RuntimeInput) ToRuntime(id string, tenant string) *Runtime {
@@ -42,12 +43,17 @@ func (i *RuntimeInput) ToRuntime(id string, tenant string) *Runtime {
 		return nil
 	}
 
+	status := &RuntimeStatus{}
+	if i.StatusCondition != nil {
+		status.Condition = *i.StatusCondition
+	}
+
 	return &Runtime{
 		ID:          id,
 		Name:        i.Name,
 		Description: i.Description,
 		Tenant:      tenant,
-		Status:      &RuntimeStatus{},
+		Status:      status,
 	}
 }
 
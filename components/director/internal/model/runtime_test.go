@@ -15,6 +15,7 @@ func TestRuntimeInput_ToRuntime(t *testing.T) {
 	desc := "Sample"
 	id := "foo"
 	tenant := "sample"
+	condition := model.RuntimeStatusConditionReady
 	testCases := []struct {
 		Name     string
 		Input    *model.RuntimeInput
@@ -37,6 +38,19 @@ func TestRuntimeInput_ToRuntime(t *testing.T) {
 				Status:      &model.RuntimeStatus{},
 			},
 		},
+		{
+			Name: "Status condition given",
+			Input: &model.RuntimeInput{
+				Name:            "Foo",
+				StatusCondition: &condition,
+			},
+			Expected: &model.Runtime{
+				Name:   "Foo",
+				ID:     id,
+				Tenant: tenant,
+				Status: &model.RuntimeStatus{Condition: model.RuntimeStatusConditionReady},
+			},
+		},
 		{
 			Name:     "Nil",
 			Input:    nil,
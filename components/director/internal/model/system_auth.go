@@ -1,6 +1,10 @@
 package model
 
-import "github.com/pkg/errors"
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 type SystemAuth struct {
 	ID                  string
@@ -9,6 +13,23 @@ type SystemAuth struct {
 	RuntimeID           *string
 	IntegrationSystemID *string
 	Value               *Auth
+	PairingStatus       *PairingStatus
+	TokenExpiresAt      *time.Time
+}
+
+// EffectivePairingStatus returns the pairing status of a SystemAuth created for a one-time token,
+// taking into account that an issued token which has not been used before its expiration time is expired.
+func (sa SystemAuth) EffectivePairingStatus(now time.Time) *PairingStatus {
+	if sa.PairingStatus == nil {
+		return nil
+	}
+
+	status := *sa.PairingStatus
+	if status == PairingStatusIssued && sa.TokenExpiresAt != nil && !now.Before(*sa.TokenExpiresAt) {
+		status = PairingStatusExpired
+	}
+
+	return &status
 }
 
 func (sa SystemAuth) GetReferenceObjectType() (SystemAuthReferenceObjectType, error) {
@@ -52,3 +73,25 @@ const (
 	ApplicationReference       SystemAuthReferenceObjectType = "Application"
 	IntegrationSystemReference SystemAuthReferenceObjectType = "Integration System"
 )
+
+type PairingStatus string
+
+const (
+	PairingStatusIssued   PairingStatus = "ISSUED"
+	PairingStatusConsumed PairingStatus = "CONSUMED"
+	PairingStatusPaired   PairingStatus = "PAIRED"
+	PairingStatusExpired  PairingStatus = "EXPIRED"
+)
+
+// CanTransitionTo reports whether the pairing status may change to the given one.
+// Pairing only moves forward, so for example a token consumed after the certificate has been issued does not revert the status.
+func (s PairingStatus) CanTransitionTo(next PairingStatus) bool {
+	switch next {
+	case PairingStatusConsumed:
+		return s == PairingStatusIssued
+	case PairingStatusPaired:
+		return s == PairingStatusIssued || s == PairingStatusConsumed || s == PairingStatusPaired
+	}
+
+	return false
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kyma-incubator/compass/components/director/pkg/str"
@@ -99,3 +100,71 @@ func TestSystemAuthGetReferenceObjectID(t *testing.T) {
 		require.EqualError(t, err, "unknown reference object ID")
 	})
 }
+
+func TestSystemAuthEffectivePairingStatus(t *testing.T) {
+	now := time.Date(2019, 11, 14, 12, 0, 0, 0, time.UTC)
+
+	t.Run("returns nil for SystemAuth not created for one-time token", func(t *testing.T) {
+		sysAuth := SystemAuth{}
+
+		status := sysAuth.EffectivePairingStatus(now)
+
+		require.Nil(t, status)
+	})
+
+	t.Run("returns ISSUED for issued token before expiration", func(t *testing.T) {
+		issued := PairingStatusIssued
+		expiresAt := now.Add(time.Minute)
+		sysAuth := SystemAuth{PairingStatus: &issued, TokenExpiresAt: &expiresAt}
+
+		status := sysAuth.EffectivePairingStatus(now)
+
+		require.NotNil(t, status)
+		require.Equal(t, PairingStatusIssued, *status)
+	})
+
+	t.Run("returns EXPIRED for issued token after expiration", func(t *testing.T) {
+		issued := PairingStatusIssued
+		expiresAt := now.Add(-time.Minute)
+		sysAuth := SystemAuth{PairingStatus: &issued, TokenExpiresAt: &expiresAt}
+
+		status := sysAuth.EffectivePairingStatus(now)
+
+		require.NotNil(t, status)
+		require.Equal(t, PairingStatusExpired, *status)
+	})
+
+	t.Run("returns PAIRED for paired SystemAuth after token expiration", func(t *testing.T) {
+		paired := PairingStatusPaired
+		expiresAt := now.Add(-time.Minute)
+		sysAuth := SystemAuth{PairingStatus: &paired, TokenExpiresAt: &expiresAt}
+
+		status := sysAuth.EffectivePairingStatus(now)
+
+		require.NotNil(t, status)
+		require.Equal(t, PairingStatusPaired, *status)
+	})
+}
+
+func TestPairingStatusCanTransitionTo(t *testing.T) {
+	testCases := []struct {
+		From     PairingStatus
+		To       PairingStatus
+		Expected bool
+	}{
+		{From: PairingStatusIssued, To: PairingStatusConsumed, Expected: true},
+		{From: PairingStatusIssued, To: PairingStatusPaired, Expected: true},
+		{From: PairingStatusConsumed, To: PairingStatusPaired, Expected: true},
+		{From: PairingStatusPaired, To: PairingStatusPaired, Expected: true},
+		{From: PairingStatusPaired, To: PairingStatusConsumed, Expected: false},
+		{From: PairingStatusConsumed, To: PairingStatusConsumed, Expected: false},
+		{From: PairingStatusIssued, To: PairingStatusIssued, Expected: false},
+		{From: PairingStatusIssued, To: PairingStatusExpired, Expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.From)+" to "+string(testCase.To), func(t *testing.T) {
+			require.Equal(t, testCase.Expected, testCase.From.CanTransitionTo(testCase.To))
+		})
+	}
+}
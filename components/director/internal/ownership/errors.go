@@ -0,0 +1,23 @@
+package ownership
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/consumer"
+)
+
+func AccessDeniedError(apiConsumer consumer.Consumer, field string) error {
+	return fmt.Errorf("access denied: %s %s can call %s only on the objects it owns", apiConsumer.ConsumerType, apiConsumer.ConsumerID, field)
+}
+
+func ListNotAllowedError(apiConsumer consumer.Consumer, field string) error {
+	return fmt.Errorf("access denied: %s %s cannot call %s since it lists objects of all owners", apiConsumer.ConsumerType, apiConsumer.ConsumerID, field)
+}
+
+func UnknownFieldError(apiConsumer consumer.Consumer, field string) error {
+	return fmt.Errorf("access denied: %s %s cannot call %s since its ownership rule is not defined", apiConsumer.ConsumerType, apiConsumer.ConsumerID, field)
+}
+
+func UnknownConsumerTypeError(apiConsumer consumer.Consumer) error {
+	return fmt.Errorf("access denied: consumer %s has unknown type %q", apiConsumer.ConsumerID, apiConsumer.ConsumerType)
+}
@@ -0,0 +1,10 @@
+package ownership
+
+// IsCovered reports whether the query or mutation has an ownership rule or is deliberately exempted from it
+func IsCovered(field string) bool {
+	_, hasRule := rules[field]
+	_, isList := listQueries[field]
+	_, isUnrestricted := unrestricted[field]
+
+	return hasRule || isList || isUnrestricted
+}
@@ -0,0 +1,328 @@
+package ownership
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/pkg/errors"
+
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/pkg/consumer"
+)
+
+//go:generate mockery -name=ApplicationService -output=automock -outpkg=automock -case=underscore
+type ApplicationService interface {
+	Get(ctx context.Context, id string) (*model.Application, error)
+}
+
+//go:generate mockery -name=APIService -output=automock -outpkg=automock -case=underscore
+type APIService interface {
+	Get(ctx context.Context, id string) (*model.APIDefinition, error)
+}
+
+//go:generate mockery -name=EventAPIService -output=automock -outpkg=automock -case=underscore
+type EventAPIService interface {
+	Get(ctx context.Context, id string) (*model.EventAPIDefinition, error)
+}
+
+//go:generate mockery -name=DocumentService -output=automock -outpkg=automock -case=underscore
+type DocumentService interface {
+	Get(ctx context.Context, id string) (*model.Document, error)
+}
+
+//go:generate mockery -name=WebhookService -output=automock -outpkg=automock -case=underscore
+type WebhookService interface {
+	Get(ctx context.Context, id string) (*model.Webhook, error)
+}
+
+//go:generate mockery -name=SystemAuthService -output=automock -outpkg=automock -case=underscore
+type SystemAuthService interface {
+	GetGlobal(ctx context.Context, id string) (*model.SystemAuth, error)
+}
+
+type objectKind int
+
+const (
+	applicationObject objectKind = iota
+	apiObject
+	eventAPIObject
+	documentObject
+	webhookObject
+	runtimeObject
+	integrationSystemObject
+	systemAuthObject
+)
+
+type rule struct {
+	arg  string
+	kind objectKind
+}
+
+// rules map the queries and mutations which operate on a single object to the argument holding the ID of that object
+var rules = map[string]rule{
+	"application":            {arg: "id", kind: applicationObject},
+	"applicationsForRuntime": {arg: "runtimeID", kind: runtimeObject},
+	"runtime":                {arg: "id", kind: runtimeObject},
+	"integrationSystem":      {arg: "id", kind: integrationSystemObject},
+
+	"updateApplication":                  {arg: "id", kind: applicationObject},
+	"deleteApplication":                  {arg: "id", kind: applicationObject},
+	"addWebhook":                         {arg: "applicationID", kind: applicationObject},
+	"updateWebhook":                      {arg: "webhookID", kind: webhookObject},
+	"deleteWebhook":                      {arg: "webhookID", kind: webhookObject},
+	"addAPI":                             {arg: "applicationID", kind: applicationObject},
+	"updateAPI":                          {arg: "id", kind: apiObject},
+	"deleteAPI":                          {arg: "id", kind: apiObject},
+	"refetchAPISpec":                     {arg: "apiID", kind: apiObject},
+	"setAPIAuth":                         {arg: "apiID", kind: apiObject},
+	"deleteAPIAuth":                      {arg: "apiID", kind: apiObject},
+	"addEventAPI":                        {arg: "applicationID", kind: applicationObject},
+	"updateEventAPI":                     {arg: "id", kind: eventAPIObject},
+	"deleteEventAPI":                     {arg: "id", kind: eventAPIObject},
+	"refetchEventAPISpec":                {arg: "eventID", kind: eventAPIObject},
+	"addDocument":                        {arg: "applicationID", kind: applicationObject},
+	"deleteDocument":                     {arg: "id", kind: documentObject},
+	"setApplicationLabel":                {arg: "applicationID", kind: applicationObject},
+	"deleteApplicationLabel":             {arg: "applicationID", kind: applicationObject},
+	"generateOneTimeTokenForApplication": {arg: "id", kind: applicationObject},
+	"generateClientCredentialsForApplication": {arg: "id", kind: applicationObject},
+	"deleteSystemAuthForApplication":          {arg: "authID", kind: systemAuthObject},
+
+	"updateRuntime":                       {arg: "id", kind: runtimeObject},
+	"deleteRuntime":                       {arg: "id", kind: runtimeObject},
+	"setRuntimeLabel":                     {arg: "runtimeID", kind: runtimeObject},
+	"deleteRuntimeLabel":                  {arg: "runtimeID", kind: runtimeObject},
+	"generateOneTimeTokenForRuntime":      {arg: "id", kind: runtimeObject},
+	"generateClientCredentialsForRuntime": {arg: "id", kind: runtimeObject},
+	"deleteSystemAuthForRuntime":          {arg: "authID", kind: systemAuthObject},
+
+	"updateIntegrationSystem":                       {arg: "id", kind: integrationSystemObject},
+	"deleteIntegrationSystem":                       {arg: "id", kind: integrationSystemObject},
+	"generateClientCredentialsForIntegrationSystem": {arg: "id", kind: integrationSystemObject},
+	"deleteSystemAuthForIntegrationSystem":          {arg: "authID", kind: systemAuthObject},
+}
+
+// listQueries return objects of every owner, so only Integration Systems and users can call them
+var listQueries = map[string]struct{}{
+	"applications":       {},
+	"runtimes":           {},
+	"integrationSystems": {},
+}
+
+// unrestricted lists the queries and mutations which do not operate on objects of a single owner, so any consumer
+// with the required scopes can call them. Queries and mutations which are not listed in any of the maps are denied.
+var unrestricted = map[string]struct{}{
+	"__schema": {},
+	"__type":   {},
+
+	"labelDefinitions": {},
+	"labelDefinition":  {},
+	"healthChecks":     {},
+
+	"createApplication":       {},
+	"createRuntime":           {},
+	"createIntegrationSystem": {},
+	"createLabelDefinition":   {},
+	"updateLabelDefinition":   {},
+	"deleteLabelDefinition":   {},
+}
+
+type owner struct {
+	objectType model.SystemAuthReferenceObjectType
+	objectID   string
+}
+
+// Middleware verifies that Applications, Runtimes and Integration Systems call queries and mutations only on the objects they own.
+// Applications own themselves and their APIs, Event APIs, Documents, Webhooks, labels and system auths, Runtimes own themselves,
+// and Integration Systems own themselves and the Applications registered with their ID.
+type Middleware struct {
+	transact    persistence.Transactioner
+	appSvc      ApplicationService
+	apiSvc      APIService
+	eventAPISvc EventAPIService
+	docSvc      DocumentService
+	webhookSvc  WebhookService
+	sysAuthSvc  SystemAuthService
+}
+
+func NewMiddleware(transact persistence.Transactioner, appSvc ApplicationService, apiSvc APIService, eventAPISvc EventAPIService, docSvc DocumentService, webhookSvc WebhookService, sysAuthSvc SystemAuthService) *Middleware {
+	return &Middleware{
+		transact:    transact,
+		appSvc:      appSvc,
+		apiSvc:      apiSvc,
+		eventAPISvc: eventAPISvc,
+		docSvc:      docSvc,
+		webhookSvc:  webhookSvc,
+		sysAuthSvc:  sysAuthSvc,
+	}
+}
+
+func (m *Middleware) Handle(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	resCtx := graphql.GetResolverContext(ctx)
+	if resCtx == nil || (resCtx.Object != "Query" && resCtx.Object != "Mutation") {
+		return next(ctx)
+	}
+
+	apiConsumer, err := consumer.LoadFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	restricted, err := isRestricted(apiConsumer)
+	if err != nil {
+		return nil, err
+	}
+
+	if !restricted {
+		return next(ctx)
+	}
+
+	err = m.verify(ctx, apiConsumer, resCtx.Field.Name, resCtx.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return next(ctx)
+}
+
+// isRestricted reports whether the consumer can access only the objects it owns. Only the known consumer types are
+// let through, so that a consumer with a missing or unexpected type is never granted access to all objects
+func isRestricted(apiConsumer consumer.Consumer) (bool, error) {
+	switch apiConsumer.ConsumerType {
+	case consumer.Application, consumer.Runtime, consumer.IntegrationSystem:
+		return true, nil
+	case consumer.User:
+		return false, nil
+	}
+
+	return false, UnknownConsumerTypeError(apiConsumer)
+}
+
+func (m *Middleware) verify(ctx context.Context, apiConsumer consumer.Consumer, field string, args map[string]interface{}) error {
+	if _, isList := listQueries[field]; isList {
+		if apiConsumer.ConsumerType != consumer.IntegrationSystem {
+			return ListNotAllowedError(apiConsumer, field)
+		}
+		return nil
+	}
+
+	if _, isUnrestricted := unrestricted[field]; isUnrestricted {
+		return nil
+	}
+
+	fieldRule, found := rules[field]
+	if !found {
+		return UnknownFieldError(apiConsumer, field)
+	}
+
+	id, ok := args[fieldRule.arg].(string)
+	if !ok {
+		return fmt.Errorf("while reading the %s argument of %s", fieldRule.arg, field)
+	}
+
+	tx, err := m.transact.Begin()
+	if err != nil {
+		return err
+	}
+	defer m.transact.RollbackUnlessCommited(tx)
+	ctx = persistence.SaveToContext(ctx, tx)
+
+	objOwner, err := m.ownerOf(ctx, fieldRule.kind, id)
+	if err != nil {
+		return errors.Wrapf(err, "while determining the owner of the object requested in %s", field)
+	}
+
+	owns, err := m.owns(ctx, apiConsumer, objOwner)
+	if err != nil {
+		return errors.Wrapf(err, "while checking the ownership of the object requested in %s", field)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if !owns {
+		return AccessDeniedError(apiConsumer, field)
+	}
+
+	return nil
+}
+
+func (m *Middleware) ownerOf(ctx context.Context, kind objectKind, id string) (owner, error) {
+	switch kind {
+	case applicationObject:
+		return owner{objectType: model.ApplicationReference, objectID: id}, nil
+	case runtimeObject:
+		return owner{objectType: model.RuntimeReference, objectID: id}, nil
+	case integrationSystemObject:
+		return owner{objectType: model.IntegrationSystemReference, objectID: id}, nil
+	case apiObject:
+		api, err := m.apiSvc.Get(ctx, id)
+		if err != nil {
+			return owner{}, err
+		}
+		return owner{objectType: model.ApplicationReference, objectID: api.ApplicationID}, nil
+	case eventAPIObject:
+		eventAPI, err := m.eventAPISvc.Get(ctx, id)
+		if err != nil {
+			return owner{}, err
+		}
+		return owner{objectType: model.ApplicationReference, objectID: eventAPI.ApplicationID}, nil
+	case documentObject:
+		doc, err := m.docSvc.Get(ctx, id)
+		if err != nil {
+			return owner{}, err
+		}
+		return owner{objectType: model.ApplicationReference, objectID: doc.ApplicationID}, nil
+	case webhookObject:
+		webhook, err := m.webhookSvc.Get(ctx, id)
+		if err != nil {
+			return owner{}, err
+		}
+		return owner{objectType: model.ApplicationReference, objectID: webhook.ApplicationID}, nil
+	case systemAuthObject:
+		sysAuth, err := m.sysAuthSvc.GetGlobal(ctx, id)
+		if err != nil {
+			return owner{}, err
+		}
+
+		objType, err := sysAuth.GetReferenceObjectType()
+		if err != nil {
+			return owner{}, err
+		}
+
+		objID, err := sysAuth.GetReferenceObjectID()
+		if err != nil {
+			return owner{}, err
+		}
+
+		return owner{objectType: objType, objectID: objID}, nil
+	}
+
+	return owner{}, fmt.Errorf("unknown object kind %d", kind)
+}
+
+func (m *Middleware) owns(ctx context.Context, apiConsumer consumer.Consumer, objOwner owner) (bool, error) {
+	switch apiConsumer.ConsumerType {
+	case consumer.Application:
+		return objOwner.objectType == model.ApplicationReference && objOwner.objectID == apiConsumer.ConsumerID, nil
+	case consumer.Runtime:
+		return objOwner.objectType == model.RuntimeReference && objOwner.objectID == apiConsumer.ConsumerID, nil
+	case consumer.IntegrationSystem:
+		switch objOwner.objectType {
+		case model.IntegrationSystemReference:
+			return objOwner.objectID == apiConsumer.ConsumerID, nil
+		case model.ApplicationReference:
+			app, err := m.appSvc.Get(ctx, objOwner.objectID)
+			if err != nil {
+				return false, err
+			}
+			return app.IntegrationSystemID != nil && *app.IntegrationSystemID == apiConsumer.ConsumerID, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,287 @@
+package ownership_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+
+	"github.com/kyma-incubator/compass/components/director/internal/model"
+	"github.com/kyma-incubator/compass/components/director/internal/ownership"
+	"github.com/kyma-incubator/compass/components/director/internal/ownership/automock"
+	persistenceautomock "github.com/kyma-incubator/compass/components/director/internal/persistence/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence/txtest"
+	"github.com/kyma-incubator/compass/components/director/pkg/consumer"
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+)
+
+const (
+	appID     = "1ab4d2f6-8c4b-4d5e-9a71-2f6c1e8b9d03"
+	otherID   = "7f3e9c2a-5d1b-4e8f-a6c4-0b2d8e1f3a57"
+	runtimeID = "c4a1e7d9-3b2f-4a6e-8d5c-9e0f1b2a3c4d"
+	intSysID  = "e9d8c7b6-a5f4-4e3d-b2c1-0a9f8e7d6c5b"
+	apiID     = "5b6c7d8e-9f0a-4b1c-8d2e-3f4a5b6c7d8e"
+	authID    = "2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f"
+)
+
+func TestMiddleware_Handle(t *testing.T) {
+	testErr := errors.New("test error")
+	txGen := txtest.NewTransactionContextGenerator(testErr)
+
+	type services struct {
+		app     *automock.ApplicationService
+		api     *automock.APIService
+		sysAuth *automock.SystemAuthService
+	}
+
+	testCases := []struct {
+		Name          string
+		Consumer      *consumer.Consumer
+		Object        string
+		Field         string
+		Args          map[string]interface{}
+		TxFn          func() (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner)
+		ServicesFn    func(svcs services)
+		ExpectedErr   string
+		ExpectNextRun bool
+	}{
+		{
+			Name:          "Allows users to access any object",
+			Consumer:      &consumer.Consumer{ConsumerID: "admin", ConsumerType: consumer.User},
+			Object:        "Mutation",
+			Field:         "updateApplication",
+			Args:          map[string]interface{}{"id": otherID},
+			TxFn:          txGen.ThatDoesntStartTransaction,
+			ExpectNextRun: true,
+		},
+		{
+			Name:          "Does not check nested fields",
+			Consumer:      &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:        "Application",
+			Field:         "apis",
+			TxFn:          txGen.ThatDoesntStartTransaction,
+			ExpectNextRun: true,
+		},
+		{
+			Name:          "Allows Application to update itself",
+			Consumer:      &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:        "Mutation",
+			Field:         "updateApplication",
+			Args:          map[string]interface{}{"id": appID},
+			TxFn:          txGen.ThatSucceeds,
+			ExpectNextRun: true,
+		},
+		{
+			Name:     "Denies Application to update API of other Application",
+			Consumer: &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:   "Mutation",
+			Field:    "updateAPI",
+			Args:     map[string]interface{}{"id": apiID},
+			TxFn:     txGen.ThatSucceeds,
+			ServicesFn: func(svcs services) {
+				svcs.api.On("Get", txtest.CtxWithDBMatcher(), apiID).Return(&model.APIDefinition{ID: apiID, ApplicationID: otherID}, nil).Once()
+			},
+			ExpectedErr: "access denied",
+		},
+		{
+			Name:     "Allows Application to delete its own system auth",
+			Consumer: &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:   "Mutation",
+			Field:    "deleteSystemAuthForApplication",
+			Args:     map[string]interface{}{"authID": authID},
+			TxFn:     txGen.ThatSucceeds,
+			ServicesFn: func(svcs services) {
+				id := appID
+				svcs.sysAuth.On("GetGlobal", txtest.CtxWithDBMatcher(), authID).Return(&model.SystemAuth{ID: authID, AppID: &id}, nil).Once()
+			},
+			ExpectNextRun: true,
+		},
+		{
+			Name:        "Denies Application to list Applications",
+			Consumer:    &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:      "Query",
+			Field:       "applications",
+			TxFn:        txGen.ThatDoesntStartTransaction,
+			ExpectedErr: "access denied",
+		},
+		{
+			Name:          "Allows Runtime to query Applications for itself",
+			Consumer:      &consumer.Consumer{ConsumerID: runtimeID, ConsumerType: consumer.Runtime},
+			Object:        "Query",
+			Field:         "applicationsForRuntime",
+			Args:          map[string]interface{}{"runtimeID": runtimeID},
+			TxFn:          txGen.ThatSucceeds,
+			ExpectNextRun: true,
+		},
+		{
+			Name:        "Denies Runtime to query Applications for other Runtime",
+			Consumer:    &consumer.Consumer{ConsumerID: runtimeID, ConsumerType: consumer.Runtime},
+			Object:      "Query",
+			Field:       "applicationsForRuntime",
+			Args:        map[string]interface{}{"runtimeID": otherID},
+			TxFn:        txGen.ThatSucceeds,
+			ExpectedErr: "access denied",
+		},
+		{
+			Name:     "Allows Integration System to label its Application",
+			Consumer: &consumer.Consumer{ConsumerID: intSysID, ConsumerType: consumer.IntegrationSystem},
+			Object:   "Mutation",
+			Field:    "setApplicationLabel",
+			Args:     map[string]interface{}{"applicationID": appID, "key": "foo", "value": "bar"},
+			TxFn:     txGen.ThatSucceeds,
+			ServicesFn: func(svcs services) {
+				id := intSysID
+				svcs.app.On("Get", txtest.CtxWithDBMatcher(), appID).Return(&model.Application{ID: appID, IntegrationSystemID: &id}, nil).Once()
+			},
+			ExpectNextRun: true,
+		},
+		{
+			Name:     "Denies Integration System to label Application of other Integration System",
+			Consumer: &consumer.Consumer{ConsumerID: intSysID, ConsumerType: consumer.IntegrationSystem},
+			Object:   "Mutation",
+			Field:    "setApplicationLabel",
+			Args:     map[string]interface{}{"applicationID": appID, "key": "foo", "value": "bar"},
+			TxFn:     txGen.ThatSucceeds,
+			ServicesFn: func(svcs services) {
+				id := otherID
+				svcs.app.On("Get", txtest.CtxWithDBMatcher(), appID).Return(&model.Application{ID: appID, IntegrationSystemID: &id}, nil).Once()
+			},
+			ExpectedErr: "access denied",
+		},
+		{
+			Name:     "Returns error when getting API fails",
+			Consumer: &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:   "Mutation",
+			Field:    "deleteAPI",
+			Args:     map[string]interface{}{"id": apiID},
+			TxFn:     txGen.ThatDoesntExpectCommit,
+			ServicesFn: func(svcs services) {
+				svcs.api.On("Get", txtest.CtxWithDBMatcher(), apiID).Return(nil, testErr).Once()
+			},
+			ExpectedErr: testErr.Error(),
+		},
+		{
+			Name:          "Allows Runtime to create Application",
+			Consumer:      &consumer.Consumer{ConsumerID: runtimeID, ConsumerType: consumer.Runtime},
+			Object:        "Mutation",
+			Field:         "createApplication",
+			TxFn:          txGen.ThatDoesntStartTransaction,
+			ExpectNextRun: true,
+		},
+		{
+			Name:          "Allows Application to introspect the schema",
+			Consumer:      &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:        "Query",
+			Field:         "__schema",
+			TxFn:          txGen.ThatDoesntStartTransaction,
+			ExpectNextRun: true,
+		},
+		{
+			Name:        "Denies Application to call field without ownership rule",
+			Consumer:    &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:      "Mutation",
+			Field:       "unknownMutation",
+			Args:        map[string]interface{}{"id": appID},
+			TxFn:        txGen.ThatDoesntStartTransaction,
+			ExpectedErr: "access denied",
+		},
+		{
+			Name:        "Returns error when starting transaction fails",
+			Consumer:    &consumer.Consumer{ConsumerID: appID, ConsumerType: consumer.Application},
+			Object:      "Mutation",
+			Field:       "updateApplication",
+			Args:        map[string]interface{}{"id": appID},
+			TxFn:        txGen.ThatFailsOnBegin,
+			ExpectedErr: testErr.Error(),
+		},
+		{
+			Name:        "Returns error when consumer is missing",
+			Object:      "Mutation",
+			Field:       "updateApplication",
+			Args:        map[string]interface{}{"id": appID},
+			TxFn:        txGen.ThatDoesntStartTransaction,
+			ExpectedErr: consumer.NoConsumerError.Error(),
+		},
+		{
+			Name:        "Returns error when consumer type is missing",
+			Consumer:    &consumer.Consumer{ConsumerID: appID},
+			Object:      "Mutation",
+			Field:       "updateApplication",
+			Args:        map[string]interface{}{"id": otherID},
+			TxFn:        txGen.ThatDoesntStartTransaction,
+			ExpectedErr: "access denied: consumer " + appID + ` has unknown type ""`,
+		},
+		{
+			Name:        "Returns error when consumer type is unknown",
+			Consumer:    &consumer.Consumer{ConsumerID: appID, ConsumerType: "Unknown"},
+			Object:      "Query",
+			Field:       "applications",
+			TxFn:        txGen.ThatDoesntStartTransaction,
+			ExpectedErr: "access denied: consumer " + appID + ` has unknown type "Unknown"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			persistTx, transact := testCase.TxFn()
+			svcs := services{app: &automock.ApplicationService{}, api: &automock.APIService{}, sysAuth: &automock.SystemAuthService{}}
+			if testCase.ServicesFn != nil {
+				testCase.ServicesFn(svcs)
+			}
+
+			middleware := ownership.NewMiddleware(transact, svcs.app, svcs.api, &automock.EventAPIService{}, &automock.DocumentService{}, &automock.WebhookService{}, svcs.sysAuth)
+
+			ctx := context.Background()
+			if testCase.Consumer != nil {
+				ctx = consumer.SaveToContext(ctx, *testCase.Consumer)
+			}
+			ctx = gqlgen.WithResolverContext(ctx, &gqlgen.ResolverContext{
+				Object: testCase.Object,
+				Field:  gqlgen.CollectedField{Field: &ast.Field{Name: testCase.Field}},
+				Args:   testCase.Args,
+			})
+
+			nextRun := false
+			next := func(ctx context.Context) (interface{}, error) {
+				nextRun = true
+				return "result", nil
+			}
+
+			// when
+			result, err := middleware.Handle(ctx, next)
+
+			// then
+			if testCase.ExpectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.ExpectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "result", result)
+			}
+			assert.Equal(t, testCase.ExpectNextRun, nextRun)
+
+			persistTx.AssertExpectations(t)
+			transact.AssertExpectations(t)
+			svcs.app.AssertExpectations(t)
+			svcs.api.AssertExpectations(t)
+			svcs.sysAuth.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRules_CoverSchema(t *testing.T) {
+	schema := graphql.NewExecutableSchema(graphql.Config{}).Schema()
+
+	for _, definition := range []*ast.Definition{schema.Query, schema.Mutation} {
+		require.NotNil(t, definition)
+
+		for _, field := range definition.Fields {
+			assert.True(t, ownership.IsCovered(field.Name), "%s.%s has neither an ownership rule nor an exemption", definition.Name, field.Name)
+		}
+	}
+}
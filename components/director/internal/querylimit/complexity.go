@@ -0,0 +1,56 @@
+package querylimit
+
+import (
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+)
+
+// defaultPageSize is the value of the first argument when it isn't given, as declared in the schema
+const defaultPageSize = 100
+
+const maxInt = int(^uint(0) >> 1)
+
+// NewComplexityRoot returns the cost model of the schema. Every field costs 1 plus the cost of its children,
+// except the paginated fields, whose children are counted once for every item the page can contain.
+func NewComplexityRoot() graphql.ComplexityRoot {
+	root := graphql.ComplexityRoot{}
+
+	root.Application.Apis = func(childComplexity int, _ *string, first *int, _ *graphql.PageCursor) int {
+		return pageComplexity(childComplexity, first)
+	}
+	root.Application.EventAPIs = func(childComplexity int, _ *string, first *int, _ *graphql.PageCursor) int {
+		return pageComplexity(childComplexity, first)
+	}
+	root.Application.Documents = func(childComplexity int, first *int, _ *graphql.PageCursor) int {
+		return pageComplexity(childComplexity, first)
+	}
+	root.Query.Applications = func(childComplexity int, _ []*graphql.LabelFilter, first *int, _ *graphql.PageCursor) int {
+		return pageComplexity(childComplexity, first)
+	}
+	root.Query.ApplicationsForRuntime = func(childComplexity int, _ string, first *int, _ *graphql.PageCursor) int {
+		return pageComplexity(childComplexity, first)
+	}
+	root.Query.Runtimes = func(childComplexity int, _ []*graphql.LabelFilter, first *int, _ *graphql.PageCursor) int {
+		return pageComplexity(childComplexity, first)
+	}
+	root.Query.HealthChecks = func(childComplexity int, _ []graphql.HealthCheckType, _ *string, first *int, _ *graphql.PageCursor) int {
+		return pageComplexity(childComplexity, first)
+	}
+	root.Query.IntegrationSystems = func(childComplexity int, first *int, _ *graphql.PageCursor) int {
+		return pageComplexity(childComplexity, first)
+	}
+
+	return root
+}
+
+func pageComplexity(childComplexity int, first *int) int {
+	pageSize := defaultPageSize
+	if first != nil && *first > 0 {
+		pageSize = *first
+	}
+
+	if childComplexity > (maxInt-1)/pageSize {
+		return maxInt
+	}
+
+	return 1 + childComplexity*pageSize
+}
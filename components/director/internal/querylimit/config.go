@@ -0,0 +1,35 @@
+package querylimit
+
+import "github.com/kyma-incubator/compass/components/director/pkg/consumer"
+
+// Config holds the maximum depth and complexity of GraphQL operations for every consumer type.
+// A limit set to 0 is not checked.
+type Config struct {
+	UserMaxDepth                   int `envconfig:"default=10,APP_QUERY_LIMITS_USER_MAX_DEPTH"`
+	UserMaxComplexity              int `envconfig:"default=200000,APP_QUERY_LIMITS_USER_MAX_COMPLEXITY"`
+	ApplicationMaxDepth            int `envconfig:"default=10,APP_QUERY_LIMITS_APPLICATION_MAX_DEPTH"`
+	ApplicationMaxComplexity       int `envconfig:"default=20000,APP_QUERY_LIMITS_APPLICATION_MAX_COMPLEXITY"`
+	RuntimeMaxDepth                int `envconfig:"default=10,APP_QUERY_LIMITS_RUNTIME_MAX_DEPTH"`
+	RuntimeMaxComplexity           int `envconfig:"default=200000,APP_QUERY_LIMITS_RUNTIME_MAX_COMPLEXITY"`
+	IntegrationSystemMaxDepth      int `envconfig:"default=10,APP_QUERY_LIMITS_INTEGRATION_SYSTEM_MAX_DEPTH"`
+	IntegrationSystemMaxComplexity int `envconfig:"default=50000,APP_QUERY_LIMITS_INTEGRATION_SYSTEM_MAX_COMPLEXITY"`
+}
+
+type limits struct {
+	maxDepth      int
+	maxComplexity int
+}
+
+// limitsFor returns the limits of the consumer type. Consumers of unknown type get the limits of users.
+func (c Config) limitsFor(consumerType consumer.ConsumerType) limits {
+	switch consumerType {
+	case consumer.Application:
+		return limits{maxDepth: c.ApplicationMaxDepth, maxComplexity: c.ApplicationMaxComplexity}
+	case consumer.Runtime:
+		return limits{maxDepth: c.RuntimeMaxDepth, maxComplexity: c.RuntimeMaxComplexity}
+	case consumer.IntegrationSystem:
+		return limits{maxDepth: c.IntegrationSystemMaxDepth, maxComplexity: c.IntegrationSystemMaxComplexity}
+	default:
+		return limits{maxDepth: c.UserMaxDepth, maxComplexity: c.UserMaxComplexity}
+	}
+}
@@ -0,0 +1,86 @@
+package querylimit
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/kyma-incubator/compass/components/director/pkg/consumer"
+	"github.com/vektah/gqlparser/ast"
+)
+
+type executableSchema struct {
+	graphql.ExecutableSchema
+	cfg Config
+}
+
+// NewExecutableSchema returns the schema which rejects the operations exceeding the depth or complexity limit of the consumer
+func NewExecutableSchema(schema graphql.ExecutableSchema, cfg Config) graphql.ExecutableSchema {
+	return &executableSchema{ExecutableSchema: schema, cfg: cfg}
+}
+
+func (e *executableSchema) Query(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	if resp := e.checkLimits(ctx, op); resp != nil {
+		return resp
+	}
+
+	return e.ExecutableSchema.Query(ctx, op)
+}
+
+func (e *executableSchema) Mutation(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	if resp := e.checkLimits(ctx, op); resp != nil {
+		return resp
+	}
+
+	return e.ExecutableSchema.Mutation(ctx, op)
+}
+
+func (e *executableSchema) checkLimits(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	consumerType := consumer.User
+	if apiConsumer, err := consumer.LoadFromContext(ctx); err == nil && apiConsumer.ConsumerType != "" {
+		consumerType = apiConsumer.ConsumerType
+	}
+	l := e.cfg.limitsFor(consumerType)
+
+	if l.maxDepth > 0 {
+		if depth := selectionSetDepth(op.SelectionSet); depth > l.maxDepth {
+			return graphql.ErrorResponse(ctx, "operation has depth %d, which exceeds the limit of %d for %s", depth, l.maxDepth, consumerType)
+		}
+	}
+
+	if l.maxComplexity > 0 {
+		var variables map[string]interface{}
+		if reqCtx := graphql.GetRequestContext(ctx); reqCtx != nil {
+			variables = reqCtx.Variables
+		}
+		if cost := complexity.Calculate(e, op, variables); cost > l.maxComplexity {
+			return graphql.ErrorResponse(ctx, "operation has complexity %d, which exceeds the limit of %d for %s", cost, l.maxComplexity, consumerType)
+		}
+	}
+
+	return nil
+}
+
+// selectionSetDepth returns the number of nested fields in the deepest branch of the selection set, including fragments
+func selectionSetDepth(selectionSet ast.SelectionSet) int {
+	maxDepth := 0
+	for _, selection := range selectionSet {
+		var depth int
+		switch s := selection.(type) {
+		case *ast.Field:
+			depth = 1 + selectionSetDepth(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				depth = selectionSetDepth(s.Definition.SelectionSet)
+			}
+		case *ast.InlineFragment:
+			depth = selectionSetDepth(s.SelectionSet)
+		}
+
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+
+	return maxDepth
+}
@@ -0,0 +1,208 @@
+package querylimit_test
+
+import (
+	"context"
+	"testing"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vrischmann/envconfig"
+
+	"github.com/kyma-incubator/compass/components/director/internal/querylimit"
+	"github.com/kyma-incubator/compass/components/director/pkg/consumer"
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+)
+
+func TestExecutableSchema_Query(t *testing.T) {
+	cfg := querylimit.Config{
+		UserMaxDepth:             10,
+		UserMaxComplexity:        1000,
+		ApplicationMaxDepth:      3,
+		ApplicationMaxComplexity: 1000,
+		RuntimeMaxDepth:          10,
+		RuntimeMaxComplexity:     100,
+	}
+
+	testCases := []struct {
+		Name          string
+		Consumer      *consumer.Consumer
+		Query         string
+		Variables     map[string]interface{}
+		ExpectedError string
+	}{
+		{
+			Name:     "Executes query within limits",
+			Consumer: &consumer.Consumer{ConsumerType: consumer.User},
+			Query:    `{ applications(first: 100) { data { id name } } }`,
+		},
+		{
+			Name:          "Rejects query exceeding complexity limit of consumer type",
+			Consumer:      &consumer.Consumer{ConsumerType: consumer.Runtime},
+			Query:         `{ applications(first: 100) { data { id name } } }`,
+			ExpectedError: "operation has complexity 301, which exceeds the limit of 100 for Runtime",
+		},
+		{
+			Name:      "Multiplies complexity by first argument given in variables",
+			Consumer:  &consumer.Consumer{ConsumerType: consumer.Runtime},
+			Query:     `query($first: Int) { applications(first: $first) { data { id name } } }`,
+			Variables: map[string]interface{}{"first": 10},
+		},
+		{
+			Name:          "Uses default page size when first argument is not given",
+			Consumer:      &consumer.Consumer{ConsumerType: consumer.Runtime},
+			Query:         `{ runtimes { data { id } } }`,
+			ExpectedError: "operation has complexity 201, which exceeds the limit of 100 for Runtime",
+		},
+		{
+			Name:          "Multiplies complexity of nested pages",
+			Consumer:      &consumer.Consumer{ConsumerType: consumer.User},
+			Query:         `{ applications(first: 30) { data { apis(first: 30) { data { id } } } } }`,
+			ExpectedError: "operation has complexity 1861, which exceeds the limit of 1000 for Static User",
+		},
+		{
+			Name:          "Rejects query exceeding depth limit of consumer type",
+			Consumer:      &consumer.Consumer{ConsumerType: consumer.Application},
+			Query:         `{ application(id: "id") { apis { data { id } } } }`,
+			ExpectedError: "operation has depth 4, which exceeds the limit of 3 for Application",
+		},
+		{
+			Name:          "Counts depth of fragments",
+			Consumer:      &consumer.Consumer{ConsumerType: consumer.Application},
+			Query:         `{ application(id: "id") { ...App } } fragment App on Application { apis { data { id } } }`,
+			ExpectedError: "operation has depth 4, which exceeds the limit of 3 for Application",
+		},
+		{
+			Name:          "Uses limits of users when consumer is unknown",
+			Query:         `{ applications(first: 30) { data { apis(first: 30) { data { id } } } } }`,
+			ExpectedError: "operation has complexity 1861, which exceeds the limit of 1000 for Static User",
+		},
+		{
+			Name:     "Does not check limits set to zero",
+			Consumer: &consumer.Consumer{ConsumerType: consumer.IntegrationSystem},
+			Query:    `{ applications(first: 100) { data { apis(first: 100) { data { id } } } } }`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			inner := &fakeExecutableSchema{ExecutableSchema: graphql.NewExecutableSchema(graphql.Config{Complexity: querylimit.NewComplexityRoot()})}
+			schema := querylimit.NewExecutableSchema(inner, cfg)
+
+			doc, errs := gqlparser.LoadQuery(schema.Schema(), testCase.Query)
+			require.Nil(t, errs)
+
+			ctx := gqlgen.WithRequestContext(context.TODO(), gqlgen.NewRequestContext(doc, testCase.Query, testCase.Variables))
+			if testCase.Consumer != nil {
+				ctx = consumer.SaveToContext(ctx, *testCase.Consumer)
+			}
+
+			// when
+			resp := schema.Query(ctx, doc.Operations[0])
+
+			// then
+			if testCase.ExpectedError != "" {
+				require.Len(t, resp.Errors, 1)
+				assert.Equal(t, testCase.ExpectedError, resp.Errors[0].Message)
+				assert.False(t, inner.executed)
+			} else {
+				assert.Empty(t, resp.Errors)
+				assert.True(t, inner.executed)
+			}
+		})
+	}
+}
+
+type fakeExecutableSchema struct {
+	gqlgen.ExecutableSchema
+	executed bool
+}
+
+func (s *fakeExecutableSchema) Query(ctx context.Context, op *ast.OperationDefinition) *gqlgen.Response {
+	s.executed = true
+	return &gqlgen.Response{Data: []byte(`{}`)}
+}
+
+func TestExecutableSchema_DefaultLimits(t *testing.T) {
+	cfg := querylimit.Config{}
+	require.NoError(t, envconfig.Init(&cfg))
+
+	runtimeQuery := `{ applicationsForRuntime(runtimeID: "id") {
+		data { id name description labels healthCheckURL
+			apis { data { id name targetURL spec { data format type } } }
+			eventAPIs { data { id name spec { data format type } } } }
+		pageInfo { startCursor endCursor hasNextPage } totalCount } }`
+	applicationQuery := `{ application(id: "id") { id name labels
+		apis { data { id name targetURL spec { data format type } } }
+		eventAPIs { data { id name spec { data format type } } }
+		documents { data { id title format data } } } }`
+	nestedPagesQuery := `{ applications { data { apis { data { id } } eventAPIs { data { id } } documents { data { id } } } } }`
+
+	testCases := []struct {
+		Name          string
+		Consumer      consumer.Consumer
+		Query         string
+		ExpectedError string
+	}{
+		{
+			Name:     "Runtime fetches its Applications with APIs and Event APIs",
+			Consumer: consumer.Consumer{ConsumerType: consumer.Runtime},
+			Query:    runtimeQuery,
+		},
+		{
+			Name:     "User fetches Applications of Runtime with APIs and Event APIs",
+			Consumer: consumer.Consumer{ConsumerType: consumer.User},
+			Query:    runtimeQuery,
+		},
+		{
+			Name:     "Application fetches itself with APIs, Event APIs and Documents",
+			Consumer: consumer.Consumer{ConsumerType: consumer.Application},
+			Query:    applicationQuery,
+		},
+		{
+			Name:     "Integration System lists Applications",
+			Consumer: consumer.Consumer{ConsumerType: consumer.IntegrationSystem},
+			Query:    `{ applications { data { id name labels } pageInfo { hasNextPage endCursor } totalCount } }`,
+		},
+		{
+			Name:          "Rejects Application listing all Applications with nested pages",
+			Consumer:      consumer.Consumer{ConsumerType: consumer.Application},
+			Query:         nestedPagesQuery,
+			ExpectedError: "operation has complexity 60401, which exceeds the limit of 20000 for Application",
+		},
+		{
+			Name:          "Rejects User listing all Applications with nested pages",
+			Consumer:      consumer.Consumer{ConsumerType: consumer.User},
+			Query:         `{ applications(first: 200) { data { apis(first: 200) { data { id spec { data } } } eventAPIs(first: 200) { data { id } } } } }`,
+			ExpectedError: "operation has complexity 240601, which exceeds the limit of 200000 for Static User",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			inner := &fakeExecutableSchema{ExecutableSchema: graphql.NewExecutableSchema(graphql.Config{Complexity: querylimit.NewComplexityRoot()})}
+			schema := querylimit.NewExecutableSchema(inner, cfg)
+
+			doc, errs := gqlparser.LoadQuery(schema.Schema(), testCase.Query)
+			require.Nil(t, errs)
+
+			ctx := gqlgen.WithRequestContext(context.TODO(), gqlgen.NewRequestContext(doc, testCase.Query, nil))
+			ctx = consumer.SaveToContext(ctx, testCase.Consumer)
+
+			// when
+			resp := schema.Query(ctx, doc.Operations[0])
+
+			// then
+			if testCase.ExpectedError != "" {
+				require.Len(t, resp.Errors, 1)
+				assert.Equal(t, testCase.ExpectedError, resp.Errors[0].Message)
+			} else {
+				assert.Empty(t, resp.Errors)
+			}
+		})
+	}
+}
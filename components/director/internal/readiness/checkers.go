@@ -0,0 +1,64 @@
+package readiness
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/pkg/healthz"
+	"github.com/pkg/errors"
+)
+
+// NewDatabaseChecker returns the Checker which executes a trivial statement in the database
+func NewDatabaseChecker(transact persistence.Transactioner) healthz.Checker {
+	return healthz.CheckerFunc(func(ctx context.Context) error {
+		tx, err := transact.Begin()
+		if err != nil {
+			return errors.Wrap(err, "while opening transaction")
+		}
+		defer transact.RollbackUnlessCommited(tx)
+
+		if _, err := tx.Exec("SELECT 1"); err != nil {
+			return errors.Wrap(err, "while pinging database")
+		}
+
+		return tx.Commit()
+	})
+}
+
+//go:generate mockery -name=JWKSSynchronizer -output=automock -outpkg=automock -case=underscore
+type JWKSSynchronizer interface {
+	LastJWKSSynchronization() time.Time
+}
+
+// NewJWKSChecker returns the Checker which fails if JWKS has never been fetched, or if the last successful synchronization is older than maxAge.
+// maxAge set to 0 disables the age check.
+func NewJWKSChecker(synchronizer JWKSSynchronizer, maxAge time.Duration) healthz.Checker {
+	return healthz.CheckerFunc(func(ctx context.Context) error {
+		lastSync := synchronizer.LastJWKSSynchronization()
+		if lastSync.IsZero() {
+			return errors.New("JWKS has not been synchronized yet")
+		}
+		if maxAge > 0 && time.Since(lastSync) > maxAge {
+			return errors.Errorf("last successful JWKS synchronization was at %s", lastSync.Format(time.RFC3339))
+		}
+
+		return nil
+	})
+}
+
+//go:generate mockery -name=ScopesProvider -output=automock -outpkg=automock -case=underscore
+type ScopesProvider interface {
+	IsLoaded() bool
+}
+
+// NewScopesChecker returns the Checker which fails until the scopes configuration is loaded
+func NewScopesChecker(provider ScopesProvider) healthz.Checker {
+	return healthz.CheckerFunc(func(ctx context.Context) error {
+		if !provider.IsLoaded() {
+			return errors.New("scopes configuration has not been loaded")
+		}
+
+		return nil
+	})
+}
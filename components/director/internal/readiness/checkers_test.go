@@ -0,0 +1,152 @@
+package readiness_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	persistenceautomock "github.com/kyma-incubator/compass/components/director/internal/persistence/automock"
+	"github.com/kyma-incubator/compass/components/director/internal/persistence/txtest"
+	"github.com/kyma-incubator/compass/components/director/internal/readiness"
+	"github.com/kyma-incubator/compass/components/director/internal/readiness/automock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseChecker(t *testing.T) {
+	testErr := errors.New("test error")
+
+	testCases := []struct {
+		Name               string
+		TransactionerFn    func() (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner)
+		ExpectedErrMessage string
+	}{
+		{
+			Name: "Success",
+			TransactionerFn: func() (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner) {
+				persistTx, transact := txtest.NewTransactionContextGenerator(nil).ThatSucceeds()
+				persistTx.On("Exec", "SELECT 1").Return(nil, nil).Once()
+				return persistTx, transact
+			},
+		},
+		{
+			Name: "Returns error when transaction cannot be opened",
+			TransactionerFn: func() (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner) {
+				return txtest.NewTransactionContextGenerator(testErr).ThatFailsOnBegin()
+			},
+			ExpectedErrMessage: "while opening transaction: test error",
+		},
+		{
+			Name: "Returns error when statement fails",
+			TransactionerFn: func() (*persistenceautomock.PersistenceTx, *persistenceautomock.Transactioner) {
+				persistTx, transact := txtest.NewTransactionContextGenerator(nil).ThatDoesntExpectCommit()
+				persistTx.On("Exec", "SELECT 1").Return(nil, testErr).Once()
+				return persistTx, transact
+			},
+			ExpectedErrMessage: "while pinging database: test error",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			persistTx, transact := testCase.TransactionerFn()
+			checker := readiness.NewDatabaseChecker(transact)
+
+			// when
+			err := checker.Check(context.Background())
+
+			// then
+			if testCase.ExpectedErrMessage == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, testCase.ExpectedErrMessage)
+			}
+
+			persistTx.AssertExpectations(t)
+			transact.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJWKSChecker(t *testing.T) {
+	testCases := []struct {
+		Name               string
+		LastSync           time.Time
+		MaxAge             time.Duration
+		ExpectedErrMessage string
+	}{
+		{
+			Name:     "Success",
+			LastSync: time.Now().Add(-time.Minute),
+			MaxAge:   time.Hour,
+		},
+		{
+			Name:     "Success when age is not checked",
+			LastSync: time.Now().Add(-24 * time.Hour),
+		},
+		{
+			Name:               "Returns error when JWKS has never been synchronized",
+			MaxAge:             time.Hour,
+			ExpectedErrMessage: "JWKS has not been synchronized yet",
+		},
+		{
+			Name:               "Returns error when last synchronization is too old",
+			LastSync:           time.Date(2019, 10, 19, 12, 0, 0, 0, time.UTC),
+			MaxAge:             time.Hour,
+			ExpectedErrMessage: "last successful JWKS synchronization was at 2019-10-19T12:00:00Z",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			synchronizer := &automock.JWKSSynchronizer{}
+			synchronizer.On("LastJWKSSynchronization").Return(testCase.LastSync).Once()
+			checker := readiness.NewJWKSChecker(synchronizer, testCase.MaxAge)
+
+			// when
+			err := checker.Check(context.Background())
+
+			// then
+			if testCase.ExpectedErrMessage == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, testCase.ExpectedErrMessage)
+			}
+
+			synchronizer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScopesChecker(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// given
+		provider := &automock.ScopesProvider{}
+		provider.On("IsLoaded").Return(true).Once()
+		checker := readiness.NewScopesChecker(provider)
+
+		// when
+		err := checker.Check(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("Returns error when scopes are not loaded", func(t *testing.T) {
+		// given
+		provider := &automock.ScopesProvider{}
+		provider.On("IsLoaded").Return(false).Once()
+		checker := readiness.NewScopesChecker(provider)
+
+		// when
+		err := checker.Check(context.Background())
+
+		// then
+		assert.EqualError(t, err, "scopes configuration has not been loaded")
+		provider.AssertExpectations(t)
+	})
+}
@@ -10,6 +10,7 @@ type ConditionOp string
 
 const (
 	EqualOp     ConditionOp = "="
+	LessThanOp  ConditionOp = "<"
 	IsNotNullOp ConditionOp = "IS NOT NULL"
 )
 
@@ -28,6 +29,14 @@ func NewEqualCondition(field, val string) Condition {
 	}
 }
 
+func NewLessThanCondition(field, val string) Condition {
+	return Condition{
+		Field: field,
+		Val:   val,
+		Op:    LessThanOp,
+	}
+}
+
 func NewNotNullCondition(field string) Condition {
 	return Condition{
 		Field: field,
@@ -33,7 +33,7 @@ func (c *universalCreator) Create(ctx context.Context, dbEntity interface{}) err
 		return errors.New("item cannot be nil")
 	}
 
-	persist, err := persistence.FromCtx(ctx)
+	persist, err := persistenceFromCtx(ctx, c.tableName)
 	if err != nil {
 		return err
 	}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kyma-incubator/compass/components/director/internal/persistence"
 	"github.com/kyma-incubator/compass/components/director/pkg/str"
 
 	"github.com/pkg/errors"
@@ -51,7 +50,7 @@ func (g *universalDeleter) DeleteManyGlobal(ctx context.Context, conditions Cond
 }
 
 func (g *universalDeleter) unsafeDelete(ctx context.Context, tenant *string, conditions Conditions, requireSingleRemoval bool) error {
-	persist, err := persistence.FromCtx(ctx)
+	persist, err := persistenceFromCtx(ctx, g.tableName)
 	if err != nil {
 		return err
 	}
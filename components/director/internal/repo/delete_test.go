@@ -132,6 +132,19 @@ func TestDeleteGlobal(t *testing.T) {
 			require.NoError(t, err)
 		})
 
+		t.Run(fmt.Sprintf("[%s] success when less than condition", tn), func(t *testing.T) {
+			// GIVEN
+			expectedQuery := regexp.QuoteMeta("DELETE FROM users WHERE status = $1 AND expires_at < $2")
+			db, mock := testdb.MockDatabase(t)
+			ctx := persistence.SaveToContext(context.TODO(), db)
+			defer mock.AssertExpectations(t)
+			mock.ExpectExec(expectedQuery).WithArgs("ISSUED", "2019-11-14T12:00:00Z").WillReturnResult(sqlmock.NewResult(-1, 1))
+			// WHEN
+			err := testedMethod(ctx, repo.Conditions{repo.NewEqualCondition("status", "ISSUED"), repo.NewLessThanCondition("expires_at", "2019-11-14T12:00:00Z")})
+			// THEN
+			require.NoError(t, err)
+		})
+
 		t.Run(fmt.Sprintf("[%s] returns error on db operation", tn), func(t *testing.T) {
 			// GIVEN
 			expectedQuery := regexp.QuoteMeta("DELETE FROM users WHERE id_col = $1")
@@ -8,7 +8,6 @@ import (
 
 	"github.com/kyma-incubator/compass/components/director/pkg/str"
 
-	"github.com/kyma-incubator/compass/components/director/internal/persistence"
 	"github.com/pkg/errors"
 )
 
@@ -42,7 +41,7 @@ func (g *universalExistQuerier) ExistsGlobal(ctx context.Context, conditions Con
 }
 
 func (g *universalExistQuerier) unsafeExists(ctx context.Context, tenant *string, conditions Conditions) (bool, error) {
-	persist, err := persistence.FromCtx(ctx)
+	persist, err := persistenceFromCtx(ctx, g.tableName)
 	if err != nil {
 		return false, err
 	}
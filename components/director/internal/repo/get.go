@@ -8,7 +8,6 @@ import (
 
 	"github.com/kyma-incubator/compass/components/director/pkg/apperrors"
 
-	"github.com/kyma-incubator/compass/components/director/internal/persistence"
 	"github.com/kyma-incubator/compass/components/director/pkg/str"
 
 	"github.com/pkg/errors"
@@ -55,7 +54,7 @@ func (g *universalSingleGetter) unsafeGet(ctx context.Context, tenant *string, c
 	if dest == nil {
 		return errors.New("item cannot be nil")
 	}
-	persist, err := persistence.FromCtx(ctx)
+	persist, err := persistenceFromCtx(ctx, g.tableName)
 	if err != nil {
 		return err
 	}
@@ -7,8 +7,6 @@ import (
 	"github.com/kyma-incubator/compass/components/director/pkg/str"
 
 	"github.com/pkg/errors"
-
-	"github.com/kyma-incubator/compass/components/director/internal/persistence"
 )
 
 type Lister interface {
@@ -49,7 +47,7 @@ func (l *universalLister) ListGlobal(ctx context.Context, dest Collection, addit
 }
 
 func (l *universalLister) unsafeList(ctx context.Context, tenant *string, dest Collection, additionalConditions ...string) error {
-	persist, err := persistence.FromCtx(ctx)
+	persist, err := persistenceFromCtx(ctx, l.tableName)
 	if err != nil {
 		return err
 	}
@@ -55,7 +55,7 @@ func (g *universalPageableQuerier) ListGlobal(ctx context.Context, pageSize int,
 }
 
 func (g *universalPageableQuerier) unsafeList(ctx context.Context, tenant *string, pageSize int, cursor string, orderByColumn string, dest Collection, additionalConditions ...string) (*pagination.Page, int, error) {
-	persist, err := persistence.FromCtx(ctx)
+	persist, err := persistenceFromCtx(ctx, g.tableName)
 	if err != nil {
 		return nil, -1, err
 	}
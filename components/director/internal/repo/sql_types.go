@@ -1,6 +1,11 @@
 package repo
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
 
 func NewNullableString(text *string) sql.NullString {
 	nullString := sql.NullString{}
@@ -35,6 +40,15 @@ func NewValidNullableBool(boolean bool) sql.NullBool {
 	}
 }
 
+func NewNullableTime(t *time.Time) pq.NullTime {
+	var sqlTime pq.NullTime
+	if t != nil {
+		sqlTime = pq.NullTime{Valid: true, Time: *t}
+	}
+
+	return sqlTime
+}
+
 func StringPtrFromNullableString(sqlString sql.NullString) *string {
 	if sqlString.Valid {
 		return &sqlString.String
@@ -49,3 +63,10 @@ func BoolPtrFromNullableBool(sqlBool sql.NullBool) *bool {
 	}
 	return nil
 }
+
+func TimePtrFromNullableTime(sqlTime pq.NullTime) *time.Time {
+	if sqlTime.Valid {
+		return &sqlTime.Time
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -53,3 +54,22 @@ func TestNewNullableString(t *testing.T) {
 		assert.False(t, result.Valid)
 	})
 }
+
+func TestNewNullableTime(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		//GIVEN
+		input := time.Date(2019, 11, 14, 12, 0, 0, 0, time.UTC)
+		//WHEN
+		result := NewNullableTime(&input)
+		//THEN
+		assert.True(t, result.Valid)
+		assert.Equal(t, input, result.Time)
+	})
+
+	t.Run("return not valid when nil time", func(t *testing.T) {
+		//WHEN
+		result := NewNullableTime(nil)
+		//THEN
+		assert.False(t, result.Valid)
+	})
+}
@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/director/internal/persistence"
+	"github.com/kyma-incubator/compass/components/director/pkg/tracing"
+)
+
+// persistenceFromCtx extracts the persistence operation from the context and wraps it so that every SQL statement is traced
+func persistenceFromCtx(ctx context.Context, tableName string) (persistence.PersistenceOp, error) {
+	persist, err := persistence.FromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedPersistenceOp{PersistenceOp: persist, ctx: ctx, tableName: tableName}, nil
+}
+
+type tracedPersistenceOp struct {
+	persistence.PersistenceOp
+	ctx       context.Context
+	tableName string
+}
+
+func (t *tracedPersistenceOp) Get(dest interface{}, query string, args ...interface{}) error {
+	span := t.startSpan(query)
+	err := t.PersistenceOp.Get(dest, query, args...)
+	t.endSpan(span, err)
+
+	return err
+}
+
+func (t *tracedPersistenceOp) Select(dest interface{}, query string, args ...interface{}) error {
+	span := t.startSpan(query)
+	err := t.PersistenceOp.Select(dest, query, args...)
+	t.endSpan(span, err)
+
+	return err
+}
+
+func (t *tracedPersistenceOp) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	span := t.startSpan(query)
+	res, err := t.PersistenceOp.NamedExec(query, arg)
+	t.endSpan(span, err)
+
+	return res, err
+}
+
+func (t *tracedPersistenceOp) Exec(query string, args ...interface{}) (sql.Result, error) {
+	span := t.startSpan(query)
+	res, err := t.PersistenceOp.Exec(query, args...)
+	t.endSpan(span, err)
+
+	return res, err
+}
+
+func (t *tracedPersistenceOp) startSpan(query string) *tracing.Span {
+	operation := "QUERY"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+
+	_, span := tracing.StartSpan(t.ctx, fmt.Sprintf("%s %s", operation, t.tableName), tracing.SpanKindClient)
+	span.SetAttribute("db.system", "postgresql")
+	span.SetAttribute("db.sql.table", t.tableName)
+	span.SetAttribute("db.statement", query)
+
+	return span
+}
+
+func (t *tracedPersistenceOp) endSpan(span *tracing.Span, err error) {
+	if err != sql.ErrNoRows {
+		span.RecordError(err)
+	}
+	span.End()
+}
@@ -57,7 +57,7 @@ func (u *universalUpdater) unsafeUpdateSingle(ctx context.Context, dbEntity inte
 		return errors.New("item cannot be nil")
 	}
 
-	persist, err := persistence.FromCtx(ctx)
+	persist, err := persistenceFromCtx(ctx, u.tableName)
 	if err != nil {
 		return err
 	}
@@ -38,7 +38,7 @@ func (u *universalUpserter) Upsert(ctx context.Context, dbEntity interface{}) er
 		return errors.New("item cannot be nil")
 	}
 
-	persist, err := persistence.FromCtx(ctx)
+	persist, err := persistenceFromCtx(ctx, u.tableName)
 	if err != nil {
 		return err
 	}
@@ -3,6 +3,7 @@ package tenantmapping
 import (
 	"fmt"
 	"io/ioutil"
+	"sync"
 
 	"github.com/google/uuid"
 
@@ -23,32 +24,55 @@ type StaticUserRepository interface {
 }
 
 type staticUserRepository struct {
-	data map[string]StaticUser
+	srcPath string
+	mux     sync.RWMutex
+	data    map[string]StaticUser
 }
 
 func NewStaticUserRepository(srcPath string) (*staticUserRepository, error) {
-	staticUsersBytes, err := ioutil.ReadFile(srcPath)
+	repo := &staticUserRepository{srcPath: srcPath}
+	if err := repo.Load(); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// Load reads and validates the static users file. The previously loaded users are replaced only if the new file is valid.
+func (r *staticUserRepository) Load() error {
+	staticUsersBytes, err := ioutil.ReadFile(r.srcPath)
 	if err != nil {
-		return nil, errors.Wrap(err, "while reading static users file")
+		return errors.Wrap(err, "while reading static users file")
 	}
 
 	var staticUsers []StaticUser
 	if err := yaml.UnmarshalStrict(staticUsersBytes, &staticUsers, yaml.DisallowUnknownFields); err != nil {
-		return nil, errors.Wrap(err, "while unmarshalling static users YAML")
+		return errors.Wrap(err, "while unmarshalling static users YAML")
 	}
 
 	data := make(map[string]StaticUser)
 
 	for _, su := range staticUsers {
+		if su.Username == "" {
+			return errors.New("static user username cannot be empty")
+		}
+		if _, exists := data[su.Username]; exists {
+			return fmt.Errorf("static user with name %s is defined more than once", su.Username)
+		}
 		data[su.Username] = su
 	}
 
-	return &staticUserRepository{
-		data: data,
-	}, nil
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.data = data
+
+	return nil
 }
 
 func (r *staticUserRepository) Get(username string) (StaticUser, error) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
 	if staticUser, ok := r.data[username]; ok {
 		return staticUser, nil
 	}
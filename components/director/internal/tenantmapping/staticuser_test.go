@@ -83,6 +83,74 @@ func TestStaticUserRepository(t *testing.T) {
 		require.Equal(t, "while unmarshalling static users YAML: error unmarshaling JSON: while decoding JSON: json: unknown field \"scope\"", err.Error())
 	})
 
+	t.Run("NewStaticUserRepository should fail when username is defined more than once", func(t *testing.T) {
+		filePath := "static-users.tmp.json"
+		fileContent := validFileContent + `
+- username: "admin"
+  scopes:
+  - "application:read"
+  tenants: []
+`
+		err := ioutil.WriteFile(filePath, []byte(fileContent), 0644)
+		require.NoError(t, err)
+		defer func(t *testing.T) {
+			err := os.Remove(filePath)
+			require.NoError(t, err)
+		}(t)
+
+		_, err = NewStaticUserRepository(filePath)
+
+		require.EqualError(t, err, "static user with name admin is defined more than once")
+	})
+
+	t.Run("Load replaces static users with the ones from changed file", func(t *testing.T) {
+		filePath := "static-users.tmp.json"
+		err := ioutil.WriteFile(filePath, []byte(validFileContent), 0644)
+		require.NoError(t, err)
+		defer func(t *testing.T) {
+			err := os.Remove(filePath)
+			require.NoError(t, err)
+		}(t)
+		repo, err := NewStaticUserRepository(filePath)
+		require.NoError(t, err)
+		err = ioutil.WriteFile(filePath, []byte(`
+- username: "operator"
+  scopes:
+  - "runtime:read"
+  tenants: []
+`), 0644)
+		require.NoError(t, err)
+
+		err = repo.Load()
+
+		require.NoError(t, err)
+		_, err = repo.Get("operator")
+		require.NoError(t, err)
+		_, err = repo.Get("admin")
+		require.EqualError(t, err, "static user with name admin not found")
+	})
+
+	t.Run("Load keeps last valid static users when file is not valid", func(t *testing.T) {
+		filePath := "static-users.tmp.json"
+		err := ioutil.WriteFile(filePath, []byte(validFileContent), 0644)
+		require.NoError(t, err)
+		defer func(t *testing.T) {
+			err := os.Remove(filePath)
+			require.NoError(t, err)
+		}(t)
+		repo, err := NewStaticUserRepository(filePath)
+		require.NoError(t, err)
+		err = ioutil.WriteFile(filePath, []byte(unknownFieldsFileContent), 0644)
+		require.NoError(t, err)
+
+		err = repo.Load()
+
+		require.Error(t, err)
+		staticUser, err := repo.Get("developer")
+		require.NoError(t, err)
+		require.Equal(t, []string{"application:read"}, staticUser.Scopes)
+	})
+
 	t.Run("returns StaticUser instance when exists", func(t *testing.T) {
 		tenantIDs := []uuid.UUID{uuid.New()}
 		repo := staticUserRepository{
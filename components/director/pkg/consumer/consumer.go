@@ -0,0 +1,42 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+)
+
+// ConsumerType is the type of the object on behalf of which the request is made, as set by the tenant mapping
+type ConsumerType string
+
+const (
+	Runtime           ConsumerType = "Runtime"
+	Application       ConsumerType = "Application"
+	IntegrationSystem ConsumerType = "Integration System"
+	User              ConsumerType = "Static User"
+)
+
+type Consumer struct {
+	ConsumerID   string
+	ConsumerType ConsumerType
+}
+
+type key int
+
+const ConsumerContextKey key = iota
+
+var NoConsumerError = errors.New("cannot read consumer from context")
+
+func LoadFromContext(ctx context.Context) (Consumer, error) {
+	value := ctx.Value(ConsumerContextKey)
+
+	c, ok := value.(Consumer)
+	if !ok {
+		return Consumer{}, NoConsumerError
+	}
+
+	return c, nil
+}
+
+func SaveToContext(ctx context.Context, c Consumer) context.Context {
+	return context.WithValue(ctx, ConsumerContextKey, c)
+}
@@ -0,0 +1,30 @@
+package consumer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/consumer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumerContext(t *testing.T) {
+	t.Run("load returns consumer previously saved in context", func(t *testing.T) {
+		// GIVEN
+		givenConsumer := consumer.Consumer{ConsumerID: "foo", ConsumerType: consumer.Application}
+		ctx := consumer.SaveToContext(context.Background(), givenConsumer)
+		// WHEN
+		actual, err := consumer.LoadFromContext(ctx)
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, givenConsumer, actual)
+	})
+
+	t.Run("load returns error if consumer not found in ctx", func(t *testing.T) {
+		// WHEN
+		_, err := consumer.LoadFromContext(context.TODO())
+		// THEN
+		assert.Equal(t, consumer.NoConsumerError, err)
+	})
+}
@@ -307,6 +307,8 @@ type RuntimeInput struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
 	Labels      *Labels `json:"labels"`
+	// Optional. If not provided, the current status condition of the Runtime is kept.
+	StatusCondition *RuntimeStatusCondition `json:"statusCondition"`
 }
 
 type RuntimePage struct {
@@ -325,6 +327,8 @@ type RuntimeStatus struct {
 type SystemAuth struct {
 	ID   string `json:"id"`
 	Auth *Auth  `json:"auth"`
+	// Status of pairing with the Connector. Set only for System Auths created for a one-time token.
+	PairingStatus *PairingStatus `json:"pairingStatus"`
 }
 
 type TemplateValueInput struct {
@@ -771,6 +775,51 @@ func (e HealthCheckType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type PairingStatus string
+
+const (
+	PairingStatusIssued   PairingStatus = "ISSUED"
+	PairingStatusConsumed PairingStatus = "CONSUMED"
+	PairingStatusPaired   PairingStatus = "PAIRED"
+	PairingStatusExpired  PairingStatus = "EXPIRED"
+)
+
+var AllPairingStatus = []PairingStatus{
+	PairingStatusIssued,
+	PairingStatusConsumed,
+	PairingStatusPaired,
+	PairingStatusExpired,
+}
+
+func (e PairingStatus) IsValid() bool {
+	switch e {
+	case PairingStatusIssued, PairingStatusConsumed, PairingStatusPaired, PairingStatusExpired:
+		return true
+	}
+	return false
+}
+
+func (e PairingStatus) String() string {
+	return string(e)
+}
+
+func (e *PairingStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PairingStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PairingStatus", str)
+	}
+	return nil
+}
+
+func (e PairingStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type RuntimeStatusCondition string
 
 const (
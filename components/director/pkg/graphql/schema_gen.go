@@ -323,8 +323,9 @@ type ComplexityRoot struct {
 	}
 
 	SystemAuth struct {
-		Auth func(childComplexity int) int
-		ID   func(childComplexity int) int
+		Auth          func(childComplexity int) int
+		ID            func(childComplexity int) int
+		PairingStatus func(childComplexity int) int
 	}
 
 	Version struct {
@@ -1944,6 +1945,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SystemAuth.ID(childComplexity), true
 
+	case "SystemAuth.pairingStatus":
+		if e.complexity.SystemAuth.PairingStatus == nil {
+			break
+		}
+
+		return e.complexity.SystemAuth.PairingStatus(childComplexity), true
+
 	case "Version.deprecated":
 		if e.complexity.Version.Deprecated == nil {
 			break
@@ -2173,6 +2181,13 @@ enum HealthCheckType {
 	MANAGEMENT_PLANE_APPLICATION_HEALTHCHECK
 }
 
+enum PairingStatus {
+	ISSUED
+	CONSUMED
+	PAIRED
+	EXPIRED
+}
+
 enum RuntimeStatusCondition {
 	INITIAL
 	READY
@@ -2339,6 +2354,10 @@ input RuntimeInput {
 	name: String!
 	description: String
 	labels: Labels
+	"""
+	Optional. If not provided, the current status condition of the Runtime is kept.
+	"""
+	statusCondition: RuntimeStatusCondition
 }
 
 input TemplateValueInput {
@@ -2615,6 +2634,10 @@ type RuntimeStatus {
 type SystemAuth {
 	id: ID!
 	auth: Auth
+	"""
+	Status of pairing with the Connector. Set only for System Auths created for a one-time token.
+	"""
+	pairingStatus: PairingStatus
 }
 
 type Version {
@@ -9001,6 +9024,30 @@ func (ec *executionContext) _SystemAuth_auth(ctx context.Context, field graphql.
 	return ec.marshalOAuth2ᚖgithubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐAuth(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _SystemAuth_pairingStatus(ctx context.Context, field graphql.CollectedField, obj *SystemAuth) graphql.Marshaler {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
+	rctx := &graphql.ResolverContext{
+		Object:   "SystemAuth",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PairingStatus, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*PairingStatus)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOPairingStatus2ᚖgithubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐPairingStatus(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _Version_value(ctx context.Context, field graphql.CollectedField, obj *Version) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
@@ -10757,6 +10804,12 @@ func (ec *executionContext) unmarshalInputRuntimeInput(ctx context.Context, v in
 			if err != nil {
 				return it, err
 			}
+		case "statusCondition":
+			var err error
+			it.StatusCondition, err = ec.unmarshalORuntimeStatusCondition2ᚖgithubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐRuntimeStatusCondition(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -12657,6 +12710,8 @@ func (ec *executionContext) _SystemAuth(ctx context.Context, sel ast.SelectionSe
 			}
 		case "auth":
 			out.Values[i] = ec._SystemAuth_auth(ctx, field, obj)
+		case "pairingStatus":
+			out.Values[i] = ec._SystemAuth_pairingStatus(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -14891,6 +14946,30 @@ func (ec *executionContext) marshalOPageCursor2ᚖgithubᚗcomᚋkymaᚑincubato
 	return v
 }
 
+func (ec *executionContext) unmarshalOPairingStatus2githubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐPairingStatus(ctx context.Context, v interface{}) (PairingStatus, error) {
+	var res PairingStatus
+	return res, res.UnmarshalGQL(v)
+}
+
+func (ec *executionContext) marshalOPairingStatus2githubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐPairingStatus(ctx context.Context, sel ast.SelectionSet, v PairingStatus) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) unmarshalOPairingStatus2ᚖgithubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐPairingStatus(ctx context.Context, v interface{}) (*PairingStatus, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalOPairingStatus2githubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐPairingStatus(ctx, v)
+	return &res, err
+}
+
+func (ec *executionContext) marshalOPairingStatus2ᚖgithubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐPairingStatus(ctx context.Context, sel ast.SelectionSet, v *PairingStatus) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOPlaceholderDefinitionInput2ᚕᚖgithubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐPlaceholderDefinitionInput(ctx context.Context, v interface{}) ([]*PlaceholderDefinitionInput, error) {
 	var vSlice []interface{}
 	if v != nil {
@@ -14946,6 +15025,30 @@ func (ec *executionContext) marshalORuntime2ᚖgithubᚗcomᚋkymaᚑincubator
 	return ec._Runtime(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalORuntimeStatusCondition2githubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐRuntimeStatusCondition(ctx context.Context, v interface{}) (RuntimeStatusCondition, error) {
+	var res RuntimeStatusCondition
+	return res, res.UnmarshalGQL(v)
+}
+
+func (ec *executionContext) marshalORuntimeStatusCondition2githubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐRuntimeStatusCondition(ctx context.Context, sel ast.SelectionSet, v RuntimeStatusCondition) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) unmarshalORuntimeStatusCondition2ᚖgithubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐRuntimeStatusCondition(ctx context.Context, v interface{}) (*RuntimeStatusCondition, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalORuntimeStatusCondition2githubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐRuntimeStatusCondition(ctx, v)
+	return &res, err
+}
+
+func (ec *executionContext) marshalORuntimeStatusCondition2ᚖgithubᚗcomᚋkymaᚑincubatorᚋcompassᚋcomponentsᚋdirectorᚋpkgᚋgraphqlᚐRuntimeStatusCondition(ctx context.Context, sel ast.SelectionSet, v *RuntimeStatusCondition) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOString2string(ctx context.Context, v interface{}) (string, error) {
 	return graphql.UnmarshalString(v)
 }
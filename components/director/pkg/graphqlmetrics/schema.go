@@ -0,0 +1,71 @@
+package graphqlmetrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/ast"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+
+	unnamedOperation = "unnamed"
+)
+
+// Observer records the count and duration of the executed operations
+type Observer interface {
+	ObserveGraphQLOperation(operation, outcome string, duration time.Duration)
+}
+
+type executableSchema struct {
+	graphql.ExecutableSchema
+	observer Observer
+}
+
+// NewExecutableSchema returns the schema which measures the count and duration of the executed operations
+func NewExecutableSchema(schema graphql.ExecutableSchema, observer Observer) graphql.ExecutableSchema {
+	return &executableSchema{ExecutableSchema: schema, observer: observer}
+}
+
+func (e *executableSchema) Query(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	start := time.Now()
+	resp := e.ExecutableSchema.Query(ctx, op)
+	e.observe(op, resp, start)
+
+	return resp
+}
+
+func (e *executableSchema) Mutation(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	start := time.Now()
+	resp := e.ExecutableSchema.Mutation(ctx, op)
+	e.observe(op, resp, start)
+
+	return resp
+}
+
+func (e *executableSchema) observe(op *ast.OperationDefinition, resp *graphql.Response, start time.Time) {
+	outcome := OutcomeSuccess
+	if resp == nil || len(resp.Errors) > 0 {
+		outcome = OutcomeError
+	}
+
+	e.observer.ObserveGraphQLOperation(operationName(op), outcome, time.Since(start))
+}
+
+// operationName returns the name of the operation, or the name of its first root field if the operation is anonymous
+func operationName(op *ast.OperationDefinition) string {
+	if op.Name != "" {
+		return op.Name
+	}
+
+	for _, selection := range op.SelectionSet {
+		if field, ok := selection.(*ast.Field); ok {
+			return field.Name
+		}
+	}
+
+	return unnamedOperation
+}
@@ -0,0 +1,100 @@
+package graphqlmetrics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+	"github.com/kyma-incubator/compass/components/director/pkg/graphqlmetrics"
+)
+
+func TestExecutableSchema(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		Query             string
+		Errors            gqlerror.List
+		ExpectedOperation string
+		ExpectedOutcome   string
+	}{
+		{
+			Name:              "Counts successful operation by its name",
+			Query:             `query fetchApplications { applications { data { id } } }`,
+			ExpectedOperation: "fetchApplications",
+			ExpectedOutcome:   graphqlmetrics.OutcomeSuccess,
+		},
+		{
+			Name:              "Counts failed anonymous operation by its first field",
+			Query:             `{ runtimes { data { id } } applications { data { id } } }`,
+			Errors:            gqlerror.List{{Message: "test error"}},
+			ExpectedOperation: "runtimes",
+			ExpectedOutcome:   graphqlmetrics.OutcomeError,
+		},
+		{
+			Name:              "Counts mutation",
+			Query:             `mutation { deleteRuntime(id: "id") { id } }`,
+			ExpectedOperation: "deleteRuntime",
+			ExpectedOutcome:   graphqlmetrics.OutcomeSuccess,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			observer := &fakeObserver{}
+			inner := &fakeExecutableSchema{ExecutableSchema: graphql.NewExecutableSchema(graphql.Config{}), errors: testCase.Errors}
+			schema := graphqlmetrics.NewExecutableSchema(inner, observer)
+
+			doc, errs := gqlparser.LoadQuery(schema.Schema(), testCase.Query)
+			require.Nil(t, errs)
+			op := doc.Operations[0]
+
+			// when
+			var resp *gqlgen.Response
+			if op.Operation == ast.Mutation {
+				resp = schema.Mutation(context.TODO(), op)
+			} else {
+				resp = schema.Query(context.TODO(), op)
+			}
+
+			// then
+			assert.Equal(t, testCase.Errors, resp.Errors)
+			require.Len(t, observer.operations, 1)
+			assert.Equal(t, testCase.ExpectedOperation, observer.operations[0].name)
+			assert.Equal(t, testCase.ExpectedOutcome, observer.operations[0].outcome)
+		})
+	}
+}
+
+type fakeExecutableSchema struct {
+	gqlgen.ExecutableSchema
+	errors gqlerror.List
+}
+
+func (s *fakeExecutableSchema) Query(ctx context.Context, op *ast.OperationDefinition) *gqlgen.Response {
+	return &gqlgen.Response{Data: []byte(`{}`), Errors: s.errors}
+}
+
+func (s *fakeExecutableSchema) Mutation(ctx context.Context, op *ast.OperationDefinition) *gqlgen.Response {
+	return &gqlgen.Response{Data: []byte(`{}`), Errors: s.errors}
+}
+
+type observedOperation struct {
+	name    string
+	outcome string
+}
+
+type fakeObserver struct {
+	operations []observedOperation
+}
+
+func (o *fakeObserver) ObserveGraphQLOperation(operation, outcome string, _ time.Duration) {
+	o.operations = append(o.operations, observedOperation{name: operation, outcome: outcome})
+}
@@ -3,6 +3,7 @@ package scope
 import (
 	"fmt"
 	"io/ioutil"
+	"sync"
 
 	"github.com/kyma-incubator/compass/components/director/pkg/str"
 
@@ -19,9 +20,11 @@ func NewProvider(fileName string) *Provider {
 
 type Provider struct {
 	fileName     string
+	mux          sync.RWMutex
 	cachedConfig map[string]interface{}
 }
 
+// Load reads and validates the scopes configuration file. The previously loaded configuration is replaced only if the new one is valid.
 func (p *Provider) Load() error {
 	b, err := ioutil.ReadFile(p.fileName)
 	if err != nil {
@@ -31,18 +34,37 @@ func (p *Provider) Load() error {
 	if err := yaml.Unmarshal([]byte(b), &out); err != nil {
 		return errors.Wrap(err, "while unmarshalling YAML")
 	}
+	if err := validate(out); err != nil {
+		return errors.Wrap(err, "while validating scopes configuration")
+	}
+
+	p.mux.Lock()
+	defer p.mux.Unlock()
 	p.cachedConfig = out
 
 	return nil
 
 }
 
+// IsLoaded returns true if the scopes configuration has been loaded successfully at least once
+func (p *Provider) IsLoaded() bool {
+	return p.config() != nil
+}
+
+func (p *Provider) config() map[string]interface{} {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+
+	return p.cachedConfig
+}
+
 func (p *Provider) GetRequiredScopes(path string) ([]string, error) {
-	if p.cachedConfig == nil {
+	cachedConfig := p.config()
+	if cachedConfig == nil {
 		return nil, errors.New("required scopes configuration not loaded")
 	}
 	jPath := fmt.Sprintf("$.%s", path)
-	res, err := jsonpath.JsonPathLookup(p.cachedConfig, jPath)
+	res, err := jsonpath.JsonPathLookup(cachedConfig, jPath)
 	if err != nil {
 		return nil, errors.Wrapf(err, "while searching configuration using path %s", jPath)
 	}
@@ -72,11 +94,16 @@ func (p *Provider) GetRequiredScopes(path string) ([]string, error) {
 }
 
 func (p *Provider) GetAllScopes() ([]string, error) {
+	return allScopes(p.config())
+}
+
+// allScopes returns the unique scopes of all operations, failing if any operation is not defined as a string or a list of strings
+func allScopes(cachedConfig map[string]interface{}) ([]string, error) {
 	var scopes []string
-	for key, _ := range p.cachedConfig {
-		operations, ok := p.cachedConfig[key].(map[string]interface{})
+	for key, _ := range cachedConfig {
+		operations, ok := cachedConfig[key].(map[string]interface{})
 		if !ok {
-			return nil, fmt.Errorf("invalid type %T for %s; expected map[string]interface{}", p.cachedConfig[key], key)
+			return nil, fmt.Errorf("invalid type %T for %s; expected map[string]interface{}", cachedConfig[key], key)
 		}
 		for opKey, opScopes := range operations {
 			if opScopes == nil {
@@ -110,3 +137,14 @@ func (p *Provider) GetAllScopes() ([]string, error) {
 
 	return uniqueScopes, nil
 }
+
+// validate ensures that the configuration is not empty, groups the operations by their type and defines the scopes of every operation
+// as a string or a list of strings, so that none of the lookups fails after the configuration is swapped in
+func validate(config map[string]interface{}) error {
+	if len(config) == 0 {
+		return errors.New("configuration is empty")
+	}
+
+	_, err := allScopes(config)
+	return err
+}
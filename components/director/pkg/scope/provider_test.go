@@ -1,6 +1,9 @@
 package scope_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -28,6 +31,91 @@ func TestProvider_Load(t *testing.T) {
 		// THEN
 		require.EqualError(t, err, "while unmarshalling YAML: error converting YAML to JSON: yaml: found unexpected end of stream")
 	})
+
+	t.Run("returns error on empty configuration", func(t *testing.T) {
+		// GIVEN
+		sut := scope.NewProvider("testdata/empty.yaml")
+		// WHEN
+		err := sut.Load()
+		// THEN
+		require.EqualError(t, err, "while validating scopes configuration: configuration is empty")
+		assert.False(t, sut.IsLoaded())
+	})
+
+	t.Run("returns error when operations are not grouped by type", func(t *testing.T) {
+		// GIVEN
+		sut := scope.NewProvider("testdata/invalid4.yaml")
+		// WHEN
+		err := sut.Load()
+		// THEN
+		require.EqualError(t, err, "while validating scopes configuration: invalid type string for queries; expected map[string]interface{}")
+	})
+
+	t.Run("returns error when scopes list of operation contains other values than strings", func(t *testing.T) {
+		// GIVEN
+		sut := scope.NewProvider("testdata/invalid2.yaml")
+		// WHEN
+		err := sut.Load()
+		// THEN
+		require.EqualError(t, err, "while validating scopes configuration: invalid type float64 for updateApplication value; expected map[string]interface{}")
+		assert.False(t, sut.IsLoaded())
+	})
+
+	t.Run("returns error when scopes of operation are neither string nor list", func(t *testing.T) {
+		// GIVEN
+		sut := scope.NewProvider("testdata/invalid3.yaml")
+		// WHEN
+		err := sut.Load()
+		// THEN
+		require.EqualError(t, err, "while validating scopes configuration: invalid type float64 for application values; expected map[string]interface{} or string")
+		assert.False(t, sut.IsLoaded())
+	})
+
+	t.Run("keeps last valid configuration when reload fails", func(t *testing.T) {
+		// GIVEN
+		dir, err := ioutil.TempDir("", "scopes")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		fileName := filepath.Join(dir, "config.yaml")
+		copyFile(t, "testdata/valid.yaml", fileName)
+
+		sut := scope.NewProvider(fileName)
+		require.NoError(t, sut.Load())
+		copyFile(t, "testdata/invalid.yaml", fileName)
+		// WHEN
+		err = sut.Load()
+		// THEN
+		require.Error(t, err)
+		actual, err := sut.GetRequiredScopes("queries.runtime")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"runtime:get"}, actual)
+	})
+
+	t.Run("keeps last valid configuration when reloaded operation has invalid scopes", func(t *testing.T) {
+		// GIVEN
+		dir, err := ioutil.TempDir("", "scopes")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		fileName := filepath.Join(dir, "config.yaml")
+		copyFile(t, "testdata/valid.yaml", fileName)
+
+		sut := scope.NewProvider(fileName)
+		require.NoError(t, sut.Load())
+		copyFile(t, "testdata/invalid3.yaml", fileName)
+		// WHEN
+		err = sut.Load()
+		// THEN
+		require.Error(t, err)
+		actual, err := sut.GetRequiredScopes("queries.application")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"application:get"}, actual)
+	})
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	b, err := ioutil.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(dst, b, 0644))
 }
 
 func TestProvider_GetRequiredScopes(t *testing.T) {
@@ -85,14 +173,6 @@ func TestProvider_GetRequiredScopes(t *testing.T) {
 		require.EqualError(t, err, "unexpected scopes definition, should be string or list of strings, but was map[string]interface {}")
 
 	})
-
-	t.Run("returns error if path points to list with invalid types", func(t *testing.T) {
-		// WHEN
-		_, err := sut.GetRequiredScopes("mutations.updateApplication")
-		// THEN
-		require.EqualError(t, err, "unexpected scope value in a list, should be string but was float64")
-
-	})
 }
 
 func TestProvider_GetAllScopes(t *testing.T) {
@@ -109,28 +189,4 @@ func TestProvider_GetAllScopes(t *testing.T) {
 			"application:get", "runtime:get", "application:create", "global:create", "application:delete",
 		}, actual)
 	})
-
-	t.Run("returns error when incorrect scopes type in array", func(t *testing.T) {
-		// GIVEN
-		sut := scope.NewProvider("testdata/invalid2.yaml")
-		require.NoError(t, sut.Load())
-
-		// WHEN
-		_, err := sut.GetAllScopes()
-		// THEN
-		require.Error(t, err)
-		require.Equal(t, "invalid type float64 for updateApplication value; expected map[string]interface{}", err.Error())
-	})
-
-	t.Run("returns error when incorrect scope type", func(t *testing.T) {
-		// GIVEN
-		sut := scope.NewProvider("testdata/invalid3.yaml")
-		require.NoError(t, sut.Load())
-
-		// WHEN
-		_, err := sut.GetAllScopes()
-		// THEN
-		require.Error(t, err)
-		require.Equal(t, "invalid type float64 for application values; expected map[string]interface{} or string", err.Error())
-	})
 }
@@ -0,0 +1,12 @@
+package tracing
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type Config struct {
+	Exporter     string `envconfig:"default=none,APP_TRACING_EXPORTER"`
+	OTLPEndpoint string `envconfig:"default=http://localhost:4318/v1/traces,APP_TRACING_OTLP_ENDPOINT"`
+}
@@ -0,0 +1,264 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Exporter sends the ended spans to the tracing backend
+type Exporter interface {
+	Export(spans []SpanData) error
+}
+
+// NewStdoutExporter returns the Exporter which writes every span as a JSON line, to be used locally
+func NewStdoutExporter(serviceName string, w io.Writer) Exporter {
+	return &stdoutExporter{serviceName: serviceName, w: w}
+}
+
+type stdoutExporter struct {
+	serviceName string
+	mu          sync.Mutex
+	w           io.Writer
+}
+
+type stdoutSpan struct {
+	Service      string `json:"service"`
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId,omitempty"`
+	SpanData
+}
+
+func (e *stdoutExporter) Export(spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	encoder := json.NewEncoder(e.w)
+	for _, span := range spans {
+		out := stdoutSpan{Service: e.serviceName, TraceID: span.TraceID.String(), SpanID: span.SpanID.String(), SpanData: span}
+		if span.ParentSpanID.IsValid() {
+			out.ParentSpanID = span.ParentSpanID.String()
+		}
+		if err := encoder.Encode(out); err != nil {
+			return errors.Wrap(err, "while writing span")
+		}
+	}
+
+	return nil
+}
+
+// NewOTLPExporter returns the Exporter which sends the spans to the OTLP/HTTP endpoint in the JSON encoding
+func NewOTLPExporter(serviceName, endpoint string, client *http.Client) Exporter {
+	return &otlpExporter{serviceName: serviceName, endpoint: endpoint, client: client}
+}
+
+type otlpExporter struct {
+	serviceName string
+	endpoint    string
+	client      *http.Client
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string          `json:"key"`
+	Value otlpStringValue `json:"value"`
+}
+
+type otlpStringValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	otlpScopeName       = "github.com/kyma-incubator/compass"
+	otlpStatusCodeError = 2
+)
+
+func (e *otlpExporter) Export(spans []SpanData) error {
+	scopeSpans := otlpScopeSpans{Scope: otlpScope{Name: otlpScopeName}}
+	for _, span := range spans {
+		out := otlpSpan{
+			TraceID:           span.TraceID.String(),
+			SpanID:            span.SpanID.String(),
+			Name:              span.Name,
+			Kind:              span.Kind,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Attributes:        toOTLPAttributes(span.Attributes),
+		}
+		if span.ParentSpanID.IsValid() {
+			out.ParentSpanID = span.ParentSpanID.String()
+		}
+		if span.Error != "" {
+			out.Status = &otlpStatus{Code: otlpStatusCodeError, Message: span.Error}
+		}
+		scopeSpans.Spans = append(scopeSpans.Spans, out)
+	}
+
+	body, err := json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: toOTLPAttributes(map[string]string{"service.name": e.serviceName})},
+		ScopeSpans: []otlpScopeSpans{scopeSpans},
+	}}})
+	if err != nil {
+		return errors.Wrap(err, "while encoding spans")
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "while sending spans to %s", e.endpoint)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d when sending spans to %s", resp.StatusCode, e.endpoint)
+	}
+
+	return nil
+}
+
+func toOTLPAttributes(attributes map[string]string) []otlpAttribute {
+	var out []otlpAttribute
+	for key, value := range attributes {
+		out = append(out, otlpAttribute{Key: key, Value: otlpStringValue{StringValue: value}})
+	}
+
+	return out
+}
+
+const (
+	queueSize     = 2048
+	batchSize     = 512
+	flushInterval = 5 * time.Second
+)
+
+// batchProcessor exports the ended spans in batches in the background, dropping them when the queue is full
+// or the processor is shut down
+type batchProcessor struct {
+	exporter Exporter
+	queue    chan SpanData
+	done     chan struct{}
+	onError  func(err error)
+
+	// mu guards closing the queue, so spans ending during the shutdown are never sent on the closed queue
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newBatchProcessor(exporter Exporter, onError func(err error)) *batchProcessor {
+	p := &batchProcessor{
+		exporter: exporter,
+		queue:    make(chan SpanData, queueSize),
+		done:     make(chan struct{}),
+		onError:  onError,
+	}
+	go p.run()
+
+	return p
+}
+
+func (p *batchProcessor) enqueue(span SpanData) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return
+	}
+
+	select {
+	case p.queue <- span:
+	default:
+	}
+}
+
+func (p *batchProcessor) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]SpanData, 0, batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := p.exporter.Export(batch); err != nil {
+			p.onError(err)
+		}
+		batch = make([]SpanData, 0, batchSize)
+	}
+
+	for {
+		select {
+		case span, ok := <-p.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// shutdown exports the remaining spans. The spans ended afterwards are dropped.
+func (p *batchProcessor) shutdown() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	<-p.done
+}
@@ -0,0 +1,156 @@
+package tracing_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdoutExporter_Export(t *testing.T) {
+	// given
+	buf := &bytes.Buffer{}
+	exporter := tracing.NewStdoutExporter("director", buf)
+
+	// when
+	err := exporter.Export([]tracing.SpanData{fixSpanData()})
+
+	// then
+	require.NoError(t, err)
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "director", out["service"])
+	assert.Equal(t, "Query.applications", out["name"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", out["traceId"])
+	assert.Equal(t, "00f067aa0ba902b7", out["spanId"])
+	assert.Equal(t, "test error", out["error"])
+}
+
+func TestOTLPExporter_Export(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// given
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}))
+		defer server.Close()
+		exporter := tracing.NewOTLPExporter("director", server.URL, server.Client())
+
+		// when
+		err := exporter.Export([]tracing.SpanData{fixSpanData()})
+
+		// then
+		require.NoError(t, err)
+		resourceSpans := body["resourceSpans"].([]interface{})
+		require.Len(t, resourceSpans, 1)
+		scopeSpans := resourceSpans[0].(map[string]interface{})["scopeSpans"].([]interface{})
+		require.Len(t, scopeSpans, 1)
+		spans := scopeSpans[0].(map[string]interface{})["spans"].([]interface{})
+		require.Len(t, spans, 1)
+		span := spans[0].(map[string]interface{})
+		assert.Equal(t, "Query.applications", span["name"])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span["traceId"])
+		assert.Equal(t, "1571480000000000000", span["startTimeUnixNano"])
+		assert.Equal(t, float64(2), span["status"].(map[string]interface{})["code"])
+	})
+
+	t.Run("Returns error when collector responds with failure", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		exporter := tracing.NewOTLPExporter("director", server.URL, server.Client())
+
+		// when
+		err := exporter.Export([]tracing.SpanData{fixSpanData()})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status code 503")
+	})
+}
+
+func TestBatchProcessor_Shutdown(t *testing.T) {
+	t.Run("Exports spans ended before shutdown", func(t *testing.T) {
+		// given
+		exporter := &fakeExporter{}
+		processor := tracing.NewBatchProcessor(exporter, func(err error) {})
+
+		// when
+		processor.Enqueue(fixSpanData())
+		processor.Enqueue(fixSpanData())
+		processor.Shutdown()
+
+		// then
+		assert.Equal(t, 2, exporter.count())
+	})
+
+	t.Run("Drops spans ended during and after shutdown", func(t *testing.T) {
+		// given
+		exporter := &fakeExporter{}
+		processor := tracing.NewBatchProcessor(exporter, func(err error) {})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					processor.Enqueue(fixSpanData())
+				}
+			}()
+		}
+
+		// when
+		processor.Shutdown()
+		wg.Wait()
+		processor.Enqueue(fixSpanData())
+		processor.Shutdown()
+
+		// then
+		assert.True(t, exporter.count() <= 8*1000)
+	})
+}
+
+type fakeExporter struct {
+	mu    sync.Mutex
+	spans []tracing.SpanData
+}
+
+func (e *fakeExporter) Export(spans []tracing.SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *fakeExporter) count() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return len(e.spans)
+}
+
+func fixSpanData() tracing.SpanData {
+	span := tracing.SpanData{
+		Name:  "Query.applications",
+		Kind:  tracing.SpanKindInternal,
+		Start: time.Unix(1571480000, 0),
+		End:   time.Unix(1571480001, 0),
+		Error: "test error",
+	}
+	copy(span.TraceID[:], []byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36})
+	copy(span.SpanID[:], []byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7})
+
+	return span
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// NewMiddleware returns the middleware which starts a server span for every request, continuing the trace of the caller
+func NewMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := Extract(r.Context(), r.Header)
+			ctx, span := StartSpan(ctx, fmt.Sprintf("%s %s", r.Method, r.URL.Path), SpanKindServer)
+			defer span.End()
+			span.SetAttribute("http.method", r.Method)
+			span.SetAttribute("http.target", r.URL.Path)
+
+			rw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			span.SetAttribute("http.status_code", strconv.Itoa(rw.status))
+			if rw.status >= http.StatusInternalServerError {
+				span.RecordError(fmt.Errorf("status code %d", rw.status))
+			}
+		})
+	}
+}
+
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// NewTransport returns the RoundTripper which starts a client span for every request and propagates it to the called component
+func NewTransport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		ctx, span := StartSpan(req.Context(), fmt.Sprintf("%s %s", req.Method, req.URL.Host), SpanKindClient)
+		defer span.End()
+		span.SetAttribute("http.method", req.Method)
+		span.SetAttribute("http.url", req.URL.String())
+
+		req = req.WithContext(ctx)
+		req.Header = cloneHeader(req.Header)
+		Inject(ctx, req.Header)
+
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+
+		span.SetAttribute("http.status_code", strconv.Itoa(resp.StatusCode))
+		if resp.StatusCode >= http.StatusInternalServerError {
+			span.RecordError(fmt.Errorf("status code %d", resp.StatusCode))
+		}
+
+		return resp, nil
+	})
+}
+
+// cloneHeader copies the header, so that the request passed by the caller is not modified
+func cloneHeader(header http.Header) http.Header {
+	clone := make(http.Header, len(header))
+	for key, values := range header {
+		clone[key] = append([]string(nil), values...)
+	}
+
+	return clone
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const exportTimeout = 10 * time.Second
+
+// Configure sets the exporter of the spans of all tracing functions. The export errors are passed to onExportError.
+// It returns the function which exports the remaining spans.
+func Configure(cfg Config, serviceName string, onExportError func(err error)) (func(), error) {
+	var exporter Exporter
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		setTracer(&tracer{})
+		return func() {}, nil
+	case ExporterStdout:
+		exporter = NewStdoutExporter(serviceName, os.Stdout)
+	case ExporterOTLP:
+		exporter = NewOTLPExporter(serviceName, cfg.OTLPEndpoint, &http.Client{Timeout: exportTimeout})
+	default:
+		return nil, errors.Errorf("unknown tracing exporter %s", cfg.Exporter)
+	}
+
+	processor := newBatchProcessor(exporter, onExportError)
+	setTracer(&tracer{processor: processor})
+
+	return func() {
+		setTracer(&tracer{})
+		processor.shutdown()
+	}, nil
+}
@@ -1,21 +1,61 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
-
+	"time"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/director"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/externalapi"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/service"
+	"github.com/kyma-incubator/compass/components/gateway/internal/connector-adapter/connector"
+	connectorapi "github.com/kyma-incubator/compass/components/gateway/internal/connector-adapter/externalapi"
+	"github.com/kyma-incubator/compass/components/gateway/internal/graphqlclient"
+	"github.com/kyma-incubator/compass/components/gateway/internal/healthz"
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+	"github.com/kyma-incubator/compass/components/gateway/internal/metrics"
+	"github.com/kyma-incubator/compass/components/gateway/internal/ratelimit"
+	"github.com/kyma-incubator/compass/components/gateway/internal/readiness"
+	"github.com/kyma-incubator/compass/components/gateway/internal/stitching"
+	"github.com/kyma-incubator/compass/components/gateway/internal/tracing"
 	"github.com/kyma-incubator/compass/components/gateway/pkg/proxy"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/gorilla/mux"
 	"github.com/vrischmann/envconfig"
 )
 
+const readinessCheckTimeout = 5 * time.Second
+
 type config struct {
-	Address string `envconfig:"default=127.0.0.1:3001"`
+	Address        string `envconfig:"default=127.0.0.1:3001"`
+	MetricsAddress string `envconfig:"default=127.0.0.1:9090"`
 
 	DirectorOrigin  string `envconfig:"default=http://127.0.0.1:3000"`
 	ConnectorOrigin string `envconfig:"default=http://127.0.0.1:3000"`
+
+	GraphQLEndpoint           string        `envconfig:"default=/graphql"`
+	ConnectorTokenResolverURL string        `envconfig:"default=http://127.0.0.1:8080/v1/tokens/resolve"`
+	LegacyConnectorURL        string        `envconfig:"default=http://127.0.0.1:3001"`
+	LegacyMetadataURL         string        `envconfig:"default=http://127.0.0.1:3001"`
+	ClientTimeout             time.Duration `envconfig:"default=30s"`
+
+	ReadinessRequireUpstreams bool `envconfig:"default=false"`
+
+	StitchingEnabled            bool   `envconfig:"default=false"`
+	StitchingIntrospectionToken string `envconfig:"optional"`
+
+	RateLimitsSrc           string        `envconfig:"optional"`
+	RateLimitRedisAddress   string        `envconfig:"optional"`
+	RateLimitRedisPassword  string        `envconfig:"optional"`
+	RateLimitRedisTimeout   time.Duration `envconfig:"default=1s"`
+	RateLimitTrustedProxies []string      `envconfig:"optional"`
+	RateLimitIdentityPaths  []string      `envconfig:"optional"`
+
+	Tracing tracing.Config
 }
 
 func main() {
@@ -23,37 +63,75 @@ func main() {
 	err := envconfig.InitWithPrefix(&cfg, "APP")
 	exitOnError(err, "Error while loading app config")
 
+	shutdownTracing, err := tracing.Configure(cfg.Tracing, "gateway", func(err error) {
+		log.Printf("Failed to export spans: %s\n", err.Error())
+	})
+	exitOnError(err, "Error while configuring tracing")
+	defer shutdownTracing()
+
+	limiter, err := newLimiter(cfg)
+	exitOnError(err, "Error while initializing rate limiter")
+
 	router := mux.NewRouter()
+	router.Use(tracing.NewMiddleware(), limiter.Middleware)
+
+	metricsCollector := metrics.NewCollector()
+	prometheus.MustRegister(metricsCollector)
+	connectorTransport := tracing.NewTransport(metricsCollector.InstrumentRoundTripper("connector", http.DefaultTransport))
+	directorTransport := tracing.NewTransport(metricsCollector.InstrumentRoundTripper("director", http.DefaultTransport))
 
-	err = proxyRequestsForComponent(router, "/connector", cfg.ConnectorOrigin)
+	err = proxyRequestsForComponent(router, "/connector", cfg.ConnectorOrigin, connectorTransport)
 	exitOnError(err, "Error while initializing proxy for Connector")
 
-	err = proxyRequestsForComponent(router, "/director", cfg.DirectorOrigin)
+	err = proxyRequestsForComponent(router, "/director", cfg.DirectorOrigin, directorTransport)
 	exitOnError(err, "Error while initializing proxy for Director")
 
-	router.HandleFunc("/healthz", func(writer http.ResponseWriter, request *http.Request) {
-		writer.WriteHeader(200)
-		_, err := writer.Write([]byte("ok"))
-		if err != nil {
-			log.Println(errors.Wrapf(err, "while writing to response body").Error())
-		}
-	})
+	connectorClient := &http.Client{Timeout: cfg.ClientTimeout, Transport: connectorTransport}
+	directorClient := &http.Client{Timeout: cfg.ClientTimeout, Transport: directorTransport}
+
+	registerLegacyAPIHandlers(router, cfg, connectorClient, directorClient)
+
+	if cfg.StitchingEnabled {
+		err = registerStitchedGraphQLHandler(router, cfg, connectorClient, directorClient)
+		exitOnError(err, "Error while stitching Director and Connector schemas")
+	}
+
+	livenessHandler := healthz.NewLivenessHandler()
+	router.HandleFunc("/healthz", livenessHandler)
+	router.HandleFunc("/livez", livenessHandler)
+	upstreamClient := &http.Client{Timeout: readinessCheckTimeout}
+	// the unavailable upstreams make the Gateway unready only on demand, so that it still serves the other upstream and its own routes
+	upstreamsInformational := !cfg.ReadinessRequireUpstreams
+	router.HandleFunc("/readyz", healthz.NewReadinessHandler(readinessCheckTimeout,
+		healthz.Check{Name: "director", Checker: readiness.NewUpstreamChecker(upstreamClient, cfg.DirectorOrigin), Informational: upstreamsInformational},
+		healthz.Check{Name: "connector", Checker: readiness.NewUpstreamChecker(upstreamClient, cfg.ConnectorOrigin), Informational: upstreamsInformational},
+	))
 
 	http.Handle("/", router)
 
+	metricsRouter := mux.NewRouter()
+	metricsRouter.Handle("/metrics", promhttp.Handler())
+	go func() {
+		log.Printf("Exposing metrics on %s", cfg.MetricsAddress)
+		if err := http.ListenAndServe(cfg.MetricsAddress, metricsRouter); err != nil {
+			panic(err)
+		}
+	}()
+
 	log.Printf("Listening on %s", cfg.Address)
 	if err := http.ListenAndServe(cfg.Address, nil); err != nil {
 		panic(err)
 	}
 }
 
-func proxyRequestsForComponent(router *mux.Router, path string, targetOrigin string, middleware ...mux.MiddlewareFunc) error {
+func proxyRequestsForComponent(router *mux.Router, path string, targetOrigin string, transport http.RoundTripper, middleware ...mux.MiddlewareFunc) error {
 	log.Printf("Proxying requests on path `%s` to `%s`\n", path, targetOrigin)
 
 	componentProxy, err := proxy.New(targetOrigin, path)
 	if err != nil {
 		return errors.Wrapf(err, "while initializing proxy for component")
 	}
+	componentProxy.Transport = transport
 
 	connector := router.PathPrefix(path).Subrouter()
 	connector.PathPrefix("").HandlerFunc(componentProxy.ServeHTTP)
@@ -62,6 +140,69 @@ func proxyRequestsForComponent(router *mux.Router, path string, targetOrigin str
 	return nil
 }
 
+// newLimiter returns the rate limiter of all routes, which lets all requests through if no limits are configured
+func newLimiter(cfg config) (*ratelimit.Limiter, error) {
+	var limits []ratelimit.Limit
+	if cfg.RateLimitsSrc != "" {
+		var err error
+		limits, err = ratelimit.LoadLimits(cfg.RateLimitsSrc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	store := ratelimit.NewMemoryStore()
+	if cfg.RateLimitRedisAddress != "" {
+		log.Printf("Sharing rate limit counters in Redis at %s\n", cfg.RateLimitRedisAddress)
+		store = ratelimit.NewRedisStore(cfg.RateLimitRedisAddress, cfg.RateLimitRedisPassword, cfg.RateLimitRedisTimeout)
+	}
+
+	trustedProxies, err := ratelimit.ParseTrustedProxies(cfg.RateLimitTrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return ratelimit.NewLimiter(store, limits, trustedProxies, cfg.RateLimitIdentityPaths), nil
+}
+
+// registerLegacyAPIHandlers exposes the Kyma Application Registry and Connector REST APIs, which are translated to GraphQL calls
+func registerLegacyAPIHandlers(router *mux.Router, cfg config, connectorHTTPClient, directorHTTPClient *http.Client) {
+	connectorGQLClient := graphqlclient.New(cfg.ConnectorOrigin+cfg.GraphQLEndpoint, connectorHTTPClient)
+	connectorClient := connector.NewClient(connectorGQLClient, connectorHTTPClient, cfg.ConnectorTokenResolverURL)
+	connectorHandler := connectorapi.NewHandler(connectorClient, connectorapi.Config{
+		ConnectorURL: cfg.LegacyConnectorURL,
+		MetadataURL:  cfg.LegacyMetadataURL,
+	})
+	connectorHandler.RegisterRoutes(router)
+
+	directorGQLClient := graphqlclient.New(cfg.DirectorOrigin+cfg.GraphQLEndpoint, directorHTTPClient)
+	serviceHandler := externalapi.NewServiceHandler(service.NewService(director.NewClient(directorGQLClient)))
+	serviceHandler.RegisterRoutes(router)
+}
+
+// registerStitchedGraphQLHandler serves the merged schema of the Director and the Connector on the GraphQL endpoint
+func registerStitchedGraphQLHandler(router *mux.Router, cfg config, connectorHTTPClient, directorHTTPClient *http.Client) error {
+	backends := []stitching.Backend{
+		{Name: "Director", Client: graphqlclient.New(cfg.DirectorOrigin+cfg.GraphQLEndpoint, directorHTTPClient)},
+		{Name: "Connector", Client: graphqlclient.New(cfg.ConnectorOrigin+cfg.GraphQLEndpoint, connectorHTTPClient)},
+	}
+
+	header := http.Header{}
+	if cfg.StitchingIntrospectionToken != "" {
+		header.Set(httputils.HeaderAuthorization, "Bearer "+cfg.StitchingIntrospectionToken)
+	}
+
+	schema, err := stitching.Introspect(context.Background(), backends, header)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Serving stitched schema of Director and Connector on path `%s`\n", cfg.GraphQLEndpoint)
+	router.Handle(cfg.GraphQLEndpoint, stitching.NewHandler(schema, backends)).Methods(http.MethodPost)
+
+	return nil
+}
+
 func exitOnError(err error, context string) {
 	if err != nil {
 		wrappedError := errors.Wrap(err, context)
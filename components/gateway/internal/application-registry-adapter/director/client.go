@@ -0,0 +1,253 @@
+package director
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/graphqlclient"
+	"github.com/pkg/errors"
+)
+
+type Client interface {
+	// FindApplication returns the Application with the given ID or name, or nil if it doesn't exist
+	FindApplication(ctx context.Context, idOrName string) (*Application, error)
+	AddAPI(ctx context.Context, appID string, in APIDefinitionInput) (string, error)
+	DeleteAPI(ctx context.Context, id string) error
+	AddEventAPI(ctx context.Context, appID string, in EventAPIDefinitionInput) (string, error)
+	DeleteEventAPI(ctx context.Context, id string) error
+	AddDocument(ctx context.Context, appID string, in DocumentInput) (string, error)
+	DeleteDocument(ctx context.Context, id string) error
+	SetApplicationLabel(ctx context.Context, appID, key string, value interface{}) error
+	DeleteApplicationLabel(ctx context.Context, appID, key string) error
+}
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+const applicationFields = `id
+	name
+	labels
+	apis {
+		data {
+			id
+			name
+			description
+			targetURL
+			group
+			spec {
+				data
+				type
+				format
+				fetchRequest { url }
+			}
+			defaultAuth {
+				credential {
+					... on BasicCredentialData { username password }
+					... on OAuthCredentialData { clientId clientSecret url }
+				}
+				additionalHeaders
+				additionalQueryParams
+				requestAuth {
+					csrf { tokenEndpointURL }
+				}
+			}
+		}
+	}
+	eventAPIs {
+		data {
+			id
+			name
+			description
+			group
+			spec { data type format }
+		}
+	}
+	documents {
+		data { id title displayName description format kind data }
+	}`
+
+type client struct {
+	gqlClient graphqlclient.Client
+}
+
+func NewClient(gqlClient graphqlclient.Client) Client {
+	return &client{gqlClient: gqlClient}
+}
+
+func (c *client) FindApplication(ctx context.Context, idOrName string) (*Application, error) {
+	if uuidRegex.MatchString(idOrName) {
+		app, err := c.getApplication(ctx, idOrName)
+		if err != nil || app != nil {
+			return app, err
+		}
+	}
+
+	return c.findApplicationByName(ctx, idOrName)
+}
+
+func (c *client) getApplication(ctx context.Context, id string) (*Application, error) {
+	req := graphqlclient.NewRequest(fmt.Sprintf(`query ($id: ID!) {
+	result: application(id: $id) {
+	%s
+	}
+}`, applicationFields))
+	req.Var("id", id)
+
+	resp := struct {
+		Result *Application `json:"result"`
+	}{}
+	err := c.gqlClient.Run(ctx, req, &resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while getting application %s", id)
+	}
+
+	return resp.Result, nil
+}
+
+// findApplicationByName pages through Applications as the Director doesn't filter them by name
+func (c *client) findApplicationByName(ctx context.Context, name string) (*Application, error) {
+	cursor := ""
+	for {
+		req := graphqlclient.NewRequest(fmt.Sprintf(`query ($first: Int, $after: PageCursor) {
+	result: applications(first: $first, after: $after) {
+		data {
+		%s
+		}
+		pageInfo { endCursor hasNextPage }
+	}
+}`, applicationFields))
+		req.Var("first", applicationsPageSize)
+		req.Var("after", cursor)
+
+		resp := struct {
+			Result ApplicationPage `json:"result"`
+		}{}
+		err := c.gqlClient.Run(ctx, req, &resp)
+		if err != nil {
+			return nil, errors.Wrap(err, "while listing applications")
+		}
+
+		for _, app := range resp.Result.Data {
+			if app != nil && app.Name == name {
+				return app, nil
+			}
+		}
+
+		if !resp.Result.PageInfo.HasNextPage {
+			return nil, nil
+		}
+		cursor = resp.Result.PageInfo.EndCursor
+	}
+}
+
+func (c *client) AddAPI(ctx context.Context, appID string, in APIDefinitionInput) (string, error) {
+	req := graphqlclient.NewRequest(`mutation ($appID: ID!, $in: APIDefinitionInput!) {
+	result: addAPI(applicationID: $appID, in: $in) { id }
+}`)
+	req.Var("appID", appID)
+	req.Var("in", in)
+
+	id, err := c.runForID(ctx, req)
+	if err != nil {
+		return "", errors.Wrapf(err, "while adding API to application %s", appID)
+	}
+
+	return id, nil
+}
+
+func (c *client) DeleteAPI(ctx context.Context, id string) error {
+	req := graphqlclient.NewRequest(`mutation ($id: ID!) {
+	result: deleteAPI(id: $id) { id }
+}`)
+	req.Var("id", id)
+
+	_, err := c.runForID(ctx, req)
+	return errors.Wrapf(err, "while deleting API %s", id)
+}
+
+func (c *client) AddEventAPI(ctx context.Context, appID string, in EventAPIDefinitionInput) (string, error) {
+	req := graphqlclient.NewRequest(`mutation ($appID: ID!, $in: EventAPIDefinitionInput!) {
+	result: addEventAPI(applicationID: $appID, in: $in) { id }
+}`)
+	req.Var("appID", appID)
+	req.Var("in", in)
+
+	id, err := c.runForID(ctx, req)
+	if err != nil {
+		return "", errors.Wrapf(err, "while adding Event API to application %s", appID)
+	}
+
+	return id, nil
+}
+
+func (c *client) DeleteEventAPI(ctx context.Context, id string) error {
+	req := graphqlclient.NewRequest(`mutation ($id: ID!) {
+	result: deleteEventAPI(id: $id) { id }
+}`)
+	req.Var("id", id)
+
+	_, err := c.runForID(ctx, req)
+	return errors.Wrapf(err, "while deleting Event API %s", id)
+}
+
+func (c *client) AddDocument(ctx context.Context, appID string, in DocumentInput) (string, error) {
+	req := graphqlclient.NewRequest(`mutation ($appID: ID!, $in: DocumentInput!) {
+	result: addDocument(applicationID: $appID, in: $in) { id }
+}`)
+	req.Var("appID", appID)
+	req.Var("in", in)
+
+	id, err := c.runForID(ctx, req)
+	if err != nil {
+		return "", errors.Wrapf(err, "while adding document to application %s", appID)
+	}
+
+	return id, nil
+}
+
+func (c *client) DeleteDocument(ctx context.Context, id string) error {
+	req := graphqlclient.NewRequest(`mutation ($id: ID!) {
+	result: deleteDocument(id: $id) { id }
+}`)
+	req.Var("id", id)
+
+	_, err := c.runForID(ctx, req)
+	return errors.Wrapf(err, "while deleting document %s", id)
+}
+
+func (c *client) SetApplicationLabel(ctx context.Context, appID, key string, value interface{}) error {
+	req := graphqlclient.NewRequest(`mutation ($appID: ID!, $key: String!, $value: Any!) {
+	result: setApplicationLabel(applicationID: $appID, key: $key, value: $value) { key }
+}`)
+	req.Var("appID", appID)
+	req.Var("key", key)
+	req.Var("value", value)
+
+	err := c.gqlClient.Run(ctx, req, nil)
+	return errors.Wrapf(err, "while setting label %s on application %s", key, appID)
+}
+
+func (c *client) DeleteApplicationLabel(ctx context.Context, appID, key string) error {
+	req := graphqlclient.NewRequest(`mutation ($appID: ID!, $key: String!) {
+	result: deleteApplicationLabel(applicationID: $appID, key: $key) { key }
+}`)
+	req.Var("appID", appID)
+	req.Var("key", key)
+
+	err := c.gqlClient.Run(ctx, req, nil)
+	return errors.Wrapf(err, "while deleting label %s from application %s", key, appID)
+}
+
+func (c *client) runForID(ctx context.Context, req *graphqlclient.Request) (string, error) {
+	resp := struct {
+		Result struct {
+			ID string `json:"id"`
+		} `json:"result"`
+	}{}
+	err := c.gqlClient.Run(ctx, req, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Result.ID, nil
+}
@@ -0,0 +1,187 @@
+package director
+
+// Application contains the fields of a Director Application which the Application Registry adapter works on
+type Application struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Labels    map[string]interface{} `json:"labels"`
+	APIs      APIDefinitionPage      `json:"apis"`
+	EventAPIs EventAPIDefinitionPage `json:"eventAPIs"`
+	Documents DocumentPage           `json:"documents"`
+}
+
+type ApplicationPage struct {
+	Data     []*Application `json:"data"`
+	PageInfo PageInfo       `json:"pageInfo"`
+}
+
+type PageInfo struct {
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
+}
+
+type APIDefinitionPage struct {
+	Data []*APIDefinition `json:"data"`
+}
+
+type EventAPIDefinitionPage struct {
+	Data []*EventAPIDefinition `json:"data"`
+}
+
+type DocumentPage struct {
+	Data []*Document `json:"data"`
+}
+
+type APIDefinition struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description"`
+	TargetURL   string   `json:"targetURL"`
+	Group       *string  `json:"group"`
+	Spec        *APISpec `json:"spec"`
+	DefaultAuth *Auth    `json:"defaultAuth"`
+}
+
+type APISpec struct {
+	Data         *string       `json:"data"`
+	Type         string        `json:"type"`
+	Format       string        `json:"format"`
+	FetchRequest *FetchRequest `json:"fetchRequest"`
+}
+
+type FetchRequest struct {
+	URL string `json:"url"`
+}
+
+type Auth struct {
+	Credential            CredentialData         `json:"credential"`
+	AdditionalHeaders     map[string][]string    `json:"additionalHeaders"`
+	AdditionalQueryParams map[string][]string    `json:"additionalQueryParams"`
+	RequestAuth           *CredentialRequestAuth `json:"requestAuth"`
+}
+
+// CredentialData flattens the BasicCredentialData and OAuthCredentialData union
+type CredentialData struct {
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+	URL          string `json:"url"`
+}
+
+type CredentialRequestAuth struct {
+	Csrf *CSRFTokenCredentialRequestAuth `json:"csrf"`
+}
+
+type CSRFTokenCredentialRequestAuth struct {
+	TokenEndpointURL string `json:"tokenEndpointURL"`
+}
+
+type EventAPIDefinition struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description *string      `json:"description"`
+	Group       *string      `json:"group"`
+	Spec        EventAPISpec `json:"spec"`
+}
+
+type EventAPISpec struct {
+	Data   *string `json:"data"`
+	Type   string  `json:"type"`
+	Format string  `json:"format"`
+}
+
+type Document struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	DisplayName string  `json:"displayName"`
+	Description string  `json:"description"`
+	Format      string  `json:"format"`
+	Kind        *string `json:"kind"`
+	Data        *string `json:"data"`
+}
+
+type APIDefinitionInput struct {
+	Name        string        `json:"name"`
+	Description *string       `json:"description,omitempty"`
+	TargetURL   string        `json:"targetURL"`
+	Group       *string       `json:"group,omitempty"`
+	Spec        *APISpecInput `json:"spec,omitempty"`
+	DefaultAuth *AuthInput    `json:"defaultAuth,omitempty"`
+}
+
+type APISpecInput struct {
+	Data         *string            `json:"data,omitempty"`
+	Type         string             `json:"type"`
+	Format       string             `json:"format"`
+	FetchRequest *FetchRequestInput `json:"fetchRequest,omitempty"`
+}
+
+type FetchRequestInput struct {
+	URL string `json:"url"`
+}
+
+type AuthInput struct {
+	Credential            *CredentialDataInput        `json:"credential"`
+	AdditionalHeaders     map[string][]string         `json:"additionalHeaders,omitempty"`
+	AdditionalQueryParams map[string][]string         `json:"additionalQueryParams,omitempty"`
+	RequestAuth           *CredentialRequestAuthInput `json:"requestAuth,omitempty"`
+}
+
+type CredentialDataInput struct {
+	Basic *BasicCredentialDataInput `json:"basic,omitempty"`
+	Oauth *OAuthCredentialDataInput `json:"oauth,omitempty"`
+}
+
+type BasicCredentialDataInput struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type OAuthCredentialDataInput struct {
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+	URL          string `json:"url"`
+}
+
+type CredentialRequestAuthInput struct {
+	Csrf *CSRFTokenCredentialRequestAuthInput `json:"csrf,omitempty"`
+}
+
+type CSRFTokenCredentialRequestAuthInput struct {
+	TokenEndpointURL string               `json:"tokenEndpointURL"`
+	Credential       *CredentialDataInput `json:"credential"`
+}
+
+type EventAPIDefinitionInput struct {
+	Name        string            `json:"name"`
+	Description *string           `json:"description,omitempty"`
+	Group       *string           `json:"group,omitempty"`
+	Spec        EventAPISpecInput `json:"spec"`
+}
+
+type EventAPISpecInput struct {
+	Data          *string `json:"data,omitempty"`
+	EventSpecType string  `json:"eventSpecType"`
+	Format        string  `json:"format"`
+}
+
+type DocumentInput struct {
+	Title       string  `json:"title"`
+	DisplayName string  `json:"displayName"`
+	Description string  `json:"description"`
+	Format      string  `json:"format"`
+	Kind        *string `json:"kind,omitempty"`
+	Data        *string `json:"data,omitempty"`
+}
+
+const (
+	APISpecTypeOpenAPI       = "OPEN_API"
+	APISpecTypeOData         = "ODATA"
+	EventAPISpecTypeAsyncAPI = "ASYNC_API"
+	SpecFormatJSON           = "JSON"
+	SpecFormatYAML           = "YAML"
+	SpecFormatXML            = "XML"
+	DocumentFormatMarkdown   = "MARKDOWN"
+	applicationsPageSize     = 100
+)
@@ -0,0 +1,169 @@
+package externalapi
+
+import (
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/model"
+)
+
+func serviceDetailsToServiceDefinition(details ServiceDetails) model.ServiceDefinition {
+	serviceDef := model.ServiceDefinition{
+		Name:             details.Name,
+		Provider:         details.Provider,
+		Identifier:       details.Identifier,
+		Description:      details.Description,
+		ShortDescription: details.ShortDescription,
+		Documentation:    details.Documentation,
+	}
+
+	if details.Labels != nil {
+		labels := details.Labels
+		serviceDef.Labels = &labels
+	}
+
+	if details.Api != nil {
+		serviceDef.Api = &model.API{
+			TargetUrl:        details.Api.TargetUrl,
+			Spec:             details.Api.Spec,
+			SpecificationUrl: details.Api.SpecificationUrl,
+			ApiType:          details.Api.ApiType,
+			Credentials:      credentialsToModel(details.Api.Credentials),
+		}
+		if details.Api.RequestParameters != nil {
+			serviceDef.Api.RequestParameters = &model.RequestParameters{
+				Headers:         details.Api.RequestParameters.Headers,
+				QueryParameters: details.Api.RequestParameters.QueryParameters,
+			}
+		}
+	}
+
+	if details.Events != nil {
+		serviceDef.Events = &model.Events{Spec: details.Events.Spec}
+	}
+
+	return serviceDef
+}
+
+func credentialsToModel(credentials *CredentialsWithCSRF) *model.Credentials {
+	if credentials == nil {
+		return nil
+	}
+
+	result := &model.Credentials{}
+	if credentials.Oauth != nil {
+		result.Oauth = &model.Oauth{
+			URL:          credentials.Oauth.URL,
+			ClientID:     credentials.Oauth.ClientID,
+			ClientSecret: credentials.Oauth.ClientSecret,
+			CSRFInfo:     csrfInfoToModel(credentials.Oauth.CSRFInfo),
+		}
+	}
+	if credentials.Basic != nil {
+		result.Basic = &model.Basic{
+			Username: credentials.Basic.Username,
+			Password: credentials.Basic.Password,
+			CSRFInfo: csrfInfoToModel(credentials.Basic.CSRFInfo),
+		}
+	}
+	if credentials.CertificateGen != nil {
+		result.CertificateGen = &model.CertificateGen{
+			CommonName:  credentials.CertificateGen.CommonName,
+			Certificate: credentials.CertificateGen.Certificate,
+			CSRFInfo:    csrfInfoToModel(credentials.CertificateGen.CSRFInfo),
+		}
+	}
+
+	return result
+}
+
+func csrfInfoToModel(csrfInfo *CSRFInfo) *model.CSRFInfo {
+	if csrfInfo == nil {
+		return nil
+	}
+
+	return &model.CSRFInfo{TokenEndpointURL: csrfInfo.TokenEndpointURL}
+}
+
+func serviceDefinitionToServiceDetails(serviceDef model.ServiceDefinition) ServiceDetails {
+	details := ServiceDetails{
+		Provider:         serviceDef.Provider,
+		Name:             serviceDef.Name,
+		Description:      serviceDef.Description,
+		ShortDescription: serviceDef.ShortDescription,
+		Identifier:       serviceDef.Identifier,
+		Documentation:    serviceDef.Documentation,
+	}
+
+	if serviceDef.Labels != nil {
+		details.Labels = *serviceDef.Labels
+	}
+
+	if serviceDef.Api != nil {
+		details.Api = &API{
+			TargetUrl:        serviceDef.Api.TargetUrl,
+			Spec:             serviceDef.Api.Spec,
+			SpecificationUrl: serviceDef.Api.SpecificationUrl,
+			ApiType:          serviceDef.Api.ApiType,
+			Credentials:      credentialsFromModel(serviceDef.Api.Credentials),
+		}
+		if serviceDef.Api.RequestParameters != nil {
+			details.Api.RequestParameters = &RequestParameters{
+				Headers:         serviceDef.Api.RequestParameters.Headers,
+				QueryParameters: serviceDef.Api.RequestParameters.QueryParameters,
+			}
+		}
+	}
+
+	if serviceDef.Events != nil {
+		details.Events = &Events{Spec: serviceDef.Events.Spec}
+	}
+
+	return details
+}
+
+func credentialsFromModel(credentials *model.Credentials) *CredentialsWithCSRF {
+	if credentials == nil {
+		return nil
+	}
+
+	result := &CredentialsWithCSRF{}
+	if credentials.Oauth != nil {
+		result.Oauth = &OauthWithCSRF{
+			URL:          credentials.Oauth.URL,
+			ClientID:     credentials.Oauth.ClientID,
+			ClientSecret: credentials.Oauth.ClientSecret,
+			CSRFInfo:     csrfInfoFromModel(credentials.Oauth.CSRFInfo),
+		}
+	}
+	if credentials.Basic != nil {
+		result.Basic = &BasicAuthWithCSRF{
+			Username: credentials.Basic.Username,
+			Password: credentials.Basic.Password,
+			CSRFInfo: csrfInfoFromModel(credentials.Basic.CSRFInfo),
+		}
+	}
+
+	return result
+}
+
+func csrfInfoFromModel(csrfInfo *model.CSRFInfo) *CSRFInfo {
+	if csrfInfo == nil {
+		return nil
+	}
+
+	return &CSRFInfo{TokenEndpointURL: csrfInfo.TokenEndpointURL}
+}
+
+func serviceDefinitionToService(serviceDef model.ServiceDefinition) Service {
+	service := Service{
+		ID:          serviceDef.ID,
+		Provider:    serviceDef.Provider,
+		Name:        serviceDef.Name,
+		Description: serviceDef.Description,
+		Identifier:  serviceDef.Identifier,
+	}
+
+	if serviceDef.Labels != nil {
+		service.Labels = *serviceDef.Labels
+	}
+
+	return service
+}
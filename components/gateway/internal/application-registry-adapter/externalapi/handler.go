@@ -0,0 +1,148 @@
+package externalapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/service"
+	"github.com/kyma-incubator/compass/components/gateway/internal/graphqlclient"
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+	"github.com/pkg/errors"
+)
+
+const (
+	applicationPathVariable = "app"
+	serviceIDPathVariable   = "serviceId"
+)
+
+// ServiceHandler serves the services endpoints of the Kyma Application Registry REST API
+type ServiceHandler struct {
+	service service.Service
+}
+
+func NewServiceHandler(service service.Service) *ServiceHandler {
+	return &ServiceHandler{service: service}
+}
+
+// RegisterRoutes registers the handler on /{app}/v1/metadata/services of the given router
+func (h *ServiceHandler) RegisterRoutes(router *mux.Router) {
+	services := router.PathPrefix("/{" + applicationPathVariable + "}/v1/metadata/services").Subrouter()
+	services.HandleFunc("", h.Create).Methods(http.MethodPost)
+	services.HandleFunc("", h.GetAll).Methods(http.MethodGet)
+	services.HandleFunc("/{"+serviceIDPathVariable+"}", h.Get).Methods(http.MethodGet)
+	services.HandleFunc("/{"+serviceIDPathVariable+"}", h.Update).Methods(http.MethodPut)
+	services.HandleFunc("/{"+serviceIDPathVariable+"}", h.Delete).Methods(http.MethodDelete)
+}
+
+func (h *ServiceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	details, ok := decodeServiceDetails(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := h.service.Create(forwardingContext(r), mux.Vars(r)[applicationPathVariable], serviceDetailsToServiceDefinition(details))
+	if err != nil {
+		respondWithServiceError(w, errors.Wrap(err, "while creating service"))
+		return
+	}
+
+	httputils.RespondWithBody(w, http.StatusOK, CreateServiceResponse{ID: id})
+}
+
+func (h *ServiceHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	serviceDefs, err := h.service.GetAll(forwardingContext(r), mux.Vars(r)[applicationPathVariable])
+	if err != nil {
+		respondWithServiceError(w, errors.Wrap(err, "while listing services"))
+		return
+	}
+
+	services := make([]Service, 0, len(serviceDefs))
+	for _, serviceDef := range serviceDefs {
+		services = append(services, serviceDefinitionToService(serviceDef))
+	}
+
+	httputils.RespondWithBody(w, http.StatusOK, services)
+}
+
+func (h *ServiceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	serviceDef, err := h.service.Get(forwardingContext(r), vars[applicationPathVariable], vars[serviceIDPathVariable])
+	if err != nil {
+		respondWithServiceError(w, errors.Wrap(err, "while getting service"))
+		return
+	}
+
+	httputils.RespondWithBody(w, http.StatusOK, serviceDefinitionToServiceDetails(serviceDef))
+}
+
+func (h *ServiceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	details, ok := decodeServiceDetails(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	serviceDef, err := h.service.Update(forwardingContext(r), vars[applicationPathVariable], vars[serviceIDPathVariable], serviceDetailsToServiceDefinition(details))
+	if err != nil {
+		respondWithServiceError(w, errors.Wrap(err, "while updating service"))
+		return
+	}
+
+	httputils.RespondWithBody(w, http.StatusOK, serviceDefinitionToServiceDetails(serviceDef))
+}
+
+func (h *ServiceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := h.service.Delete(forwardingContext(r), vars[applicationPathVariable], vars[serviceIDPathVariable])
+	if err != nil {
+		respondWithServiceError(w, errors.Wrap(err, "while deleting service"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeServiceDetails(w http.ResponseWriter, r *http.Request) (ServiceDetails, bool) {
+	defer httputils.Close(r.Body)
+
+	details := ServiceDetails{}
+	err := json.NewDecoder(r.Body).Decode(&details)
+	if err != nil {
+		httputils.RespondWithError(w, http.StatusBadRequest, errors.Wrap(err, "while decoding request body"))
+		return details, false
+	}
+
+	err = validateServiceDetails(details)
+	if err != nil {
+		httputils.RespondWithError(w, http.StatusBadRequest, err)
+		return details, false
+	}
+
+	return details, true
+}
+
+// forwardingContext authenticates the Director calls with the credentials of the REST API caller
+func forwardingContext(r *http.Request) context.Context {
+	header := http.Header{}
+	if authorization := r.Header.Get(httputils.HeaderAuthorization); authorization != "" {
+		header.Set(httputils.HeaderAuthorization, authorization)
+	}
+
+	return graphqlclient.WithForwardedHeader(r.Context(), header)
+}
+
+func respondWithServiceError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case service.IsNotFound(err):
+		status = http.StatusNotFound
+	case service.IsWrongInput(err):
+		status = http.StatusBadRequest
+	}
+
+	httputils.RespondWithError(w, status, err)
+}
@@ -0,0 +1,162 @@
+package externalapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/model"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/service"
+	"github.com/kyma-incubator/compass/components/gateway/internal/graphqlclient"
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceHandler(t *testing.T) {
+	serviceDef := model.ServiceDefinition{
+		ID:          "service-id",
+		Name:        "orders",
+		Provider:    "SAP",
+		Description: "Orders API",
+		Api:         &model.API{TargetUrl: "https://orders.example.com"},
+	}
+
+	t.Run("should create service with credentials of the caller", func(t *testing.T) {
+		// given
+		svc := &fakeService{id: "service-id"}
+		body := `{"name": "orders", "provider": "SAP", "description": "Orders API", "api": {"targetUrl": "https://orders.example.com", "credentials": {"basic": {"username": "user", "password": "pass"}}}}`
+		req := httptest.NewRequest(http.MethodPost, "/orders-app/v1/metadata/services", strings.NewReader(body))
+		req.Header.Set(httputils.HeaderAuthorization, "Bearer token")
+
+		// when
+		rr := serve(svc, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp := CreateServiceResponse{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, "service-id", resp.ID)
+		assert.Equal(t, "orders-app", svc.application)
+		assert.Equal(t, "user", svc.serviceDef.Api.Credentials.Basic.Username)
+		assert.Equal(t, "Bearer token", svc.header.Get(httputils.HeaderAuthorization))
+	})
+
+	t.Run("should reject service without required fields", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodPost, "/orders-app/v1/metadata/services", strings.NewReader(`{"name": "orders"}`))
+
+		// when
+		rr := serve(&fakeService{}, req)
+
+		// then
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		resp := httputils.ErrorResponse{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, "missing required fields: provider, description", resp.Error)
+	})
+
+	t.Run("should list services", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/orders-app/v1/metadata/services", nil)
+
+		// when
+		rr := serve(&fakeService{serviceDefs: []model.ServiceDefinition{serviceDef}}, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		var services []Service
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&services))
+		assert.Equal(t, []Service{{ID: "service-id", Provider: "SAP", Name: "orders", Description: "Orders API"}}, services)
+	})
+
+	t.Run("should get service", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/orders-app/v1/metadata/services/service-id", nil)
+		svc := &fakeService{serviceDefs: []model.ServiceDefinition{serviceDef}}
+
+		// when
+		rr := serve(svc, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		details := ServiceDetails{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&details))
+		assert.Equal(t, "https://orders.example.com", details.Api.TargetUrl)
+		assert.Equal(t, "service-id", svc.id)
+	})
+
+	t.Run("should return not found when service doesn't exist", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodDelete, "/orders-app/v1/metadata/services/service-id", nil)
+
+		// when
+		rr := serve(&fakeService{err: service.NotFound("service service-id not found")}, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should delete service", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodDelete, "/orders-app/v1/metadata/services/service-id", nil)
+
+		// when
+		rr := serve(&fakeService{}, req)
+
+		// then
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
+
+func serve(svc service.Service, req *http.Request) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	NewServiceHandler(svc).RegisterRoutes(router)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+type fakeService struct {
+	application string
+	id          string
+	serviceDef  model.ServiceDefinition
+	serviceDefs []model.ServiceDefinition
+	header      http.Header
+	err         error
+}
+
+func (s *fakeService) Create(ctx context.Context, application string, serviceDef model.ServiceDefinition) (string, error) {
+	s.application, s.serviceDef, s.header = application, serviceDef, graphqlclient.ForwardedHeaderFromContext(ctx)
+	return s.id, s.err
+}
+
+func (s *fakeService) Get(ctx context.Context, application, id string) (model.ServiceDefinition, error) {
+	s.application, s.id = application, id
+	if s.err != nil || len(s.serviceDefs) == 0 {
+		return model.ServiceDefinition{}, s.err
+	}
+	return s.serviceDefs[0], nil
+}
+
+func (s *fakeService) GetAll(ctx context.Context, application string) ([]model.ServiceDefinition, error) {
+	s.application = application
+	return s.serviceDefs, s.err
+}
+
+func (s *fakeService) Update(ctx context.Context, application, id string, serviceDef model.ServiceDefinition) (model.ServiceDefinition, error) {
+	s.application, s.id, s.serviceDef = application, id, serviceDef
+	return serviceDef, s.err
+}
+
+func (s *fakeService) Delete(ctx context.Context, application, id string) error {
+	s.application, s.id = application, id
+	return s.err
+}
@@ -0,0 +1,77 @@
+package externalapi
+
+import "encoding/json"
+
+// Service is the summary of a service returned when listing services
+type Service struct {
+	ID          string            `json:"id"`
+	Provider    string            `json:"provider"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Identifier  string            `json:"identifier,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// ServiceDetails is the request and response body of a single service
+type ServiceDetails struct {
+	Provider         string            `json:"provider"`
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	ShortDescription string            `json:"shortDescription,omitempty"`
+	Identifier       string            `json:"identifier,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	Api              *API              `json:"api,omitempty"`
+	Events           *Events           `json:"events,omitempty"`
+	Documentation    json.RawMessage   `json:"documentation,omitempty"`
+}
+
+type CreateServiceResponse struct {
+	ID string `json:"id"`
+}
+
+type API struct {
+	TargetUrl         string               `json:"targetUrl"`
+	Credentials       *CredentialsWithCSRF `json:"credentials,omitempty"`
+	Spec              json.RawMessage      `json:"spec,omitempty"`
+	SpecificationUrl  string               `json:"specificationUrl,omitempty"`
+	ApiType           string               `json:"apiType,omitempty"`
+	RequestParameters *RequestParameters   `json:"requestParameters,omitempty"`
+}
+
+type RequestParameters struct {
+	Headers         *map[string][]string `json:"headers,omitempty"`
+	QueryParameters *map[string][]string `json:"queryParameters,omitempty"`
+}
+
+type CredentialsWithCSRF struct {
+	Oauth          *OauthWithCSRF          `json:"oauth,omitempty"`
+	Basic          *BasicAuthWithCSRF      `json:"basic,omitempty"`
+	CertificateGen *CertificateGenWithCSRF `json:"certificateGen,omitempty"`
+}
+
+type CSRFInfo struct {
+	TokenEndpointURL string `json:"tokenEndpointURL"`
+}
+
+type OauthWithCSRF struct {
+	URL          string    `json:"url"`
+	ClientID     string    `json:"clientId"`
+	ClientSecret string    `json:"clientSecret,omitempty"`
+	CSRFInfo     *CSRFInfo `json:"csrfInfo,omitempty"`
+}
+
+type BasicAuthWithCSRF struct {
+	Username string    `json:"username"`
+	Password string    `json:"password,omitempty"`
+	CSRFInfo *CSRFInfo `json:"csrfInfo,omitempty"`
+}
+
+type CertificateGenWithCSRF struct {
+	CommonName  string    `json:"commonName"`
+	Certificate string    `json:"certificate,omitempty"`
+	CSRFInfo    *CSRFInfo `json:"csrfInfo,omitempty"`
+}
+
+type Events struct {
+	Spec json.RawMessage `json:"spec,omitempty"`
+}
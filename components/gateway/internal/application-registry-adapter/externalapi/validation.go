@@ -0,0 +1,34 @@
+package externalapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func validateServiceDetails(details ServiceDetails) error {
+	var missing []string
+
+	if details.Name == "" {
+		missing = append(missing, "name")
+	}
+	if details.Provider == "" {
+		missing = append(missing, "provider")
+	}
+	if details.Description == "" {
+		missing = append(missing, "description")
+	}
+	if details.Api != nil && details.Api.TargetUrl == "" {
+		missing = append(missing, "api.targetUrl")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+
+	if details.Api == nil && details.Events == nil {
+		return errors.New("at least one of api or events must be provided")
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/director"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/model"
+)
+
+const (
+	// serviceLabelPrefix prefixes the Application label which stores the service fields that Compass doesn't model
+	serviceLabelPrefix = "legacy-service-"
+	// documentationKind marks the Documents which hold the documentation of a service
+	documentationKind = "LegacyServiceDocumentation"
+
+	apiTypeOData   = "OData"
+	apiTypeOpenAPI = "OpenAPI"
+)
+
+// serviceDetails is stored as the value of the service label
+type serviceDetails struct {
+	Name             string            `json:"name"`
+	Provider         string            `json:"provider"`
+	Identifier       string            `json:"identifier,omitempty"`
+	Description      string            `json:"description"`
+	ShortDescription string            `json:"shortDescription,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+}
+
+func serviceLabelKey(id string) string {
+	return serviceLabelPrefix + id
+}
+
+func toServiceDetails(serviceDef model.ServiceDefinition) serviceDetails {
+	details := serviceDetails{
+		Name:             serviceDef.Name,
+		Provider:         serviceDef.Provider,
+		Identifier:       serviceDef.Identifier,
+		Description:      serviceDef.Description,
+		ShortDescription: serviceDef.ShortDescription,
+	}
+	if serviceDef.Labels != nil {
+		details.Labels = *serviceDef.Labels
+	}
+
+	return details
+}
+
+func serviceDetailsFromLabel(value interface{}) (serviceDetails, error) {
+	details := serviceDetails{}
+
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return details, err
+	}
+
+	err = json.Unmarshal(marshalled, &details)
+	return details, err
+}
+
+func toAPIDefinitionInput(id string, serviceDef model.ServiceDefinition) (*director.APIDefinitionInput, error) {
+	api := serviceDef.Api
+	if api == nil {
+		return nil, nil
+	}
+
+	in := &director.APIDefinitionInput{
+		Name:        serviceDef.Name,
+		Description: &serviceDef.Description,
+		TargetURL:   api.TargetUrl,
+		Group:       &id,
+	}
+
+	apiType := director.APISpecTypeOpenAPI
+	if strings.EqualFold(api.ApiType, apiTypeOData) {
+		apiType = director.APISpecTypeOData
+	}
+
+	if len(api.Spec) > 0 {
+		data := string(api.Spec)
+		in.Spec = &director.APISpecInput{Data: &data, Type: apiType, Format: specFormat(api.Spec)}
+	} else if api.SpecificationUrl != "" {
+		format := director.SpecFormatJSON
+		if apiType == director.APISpecTypeOData {
+			format = director.SpecFormatXML
+		}
+		in.Spec = &director.APISpecInput{
+			Type:         apiType,
+			Format:       format,
+			FetchRequest: &director.FetchRequestInput{URL: api.SpecificationUrl},
+		}
+	}
+
+	auth, err := toAuthInput(api)
+	if err != nil {
+		return nil, err
+	}
+	in.DefaultAuth = auth
+
+	return in, nil
+}
+
+func toAuthInput(api *model.API) (*director.AuthInput, error) {
+	credentials := api.Credentials
+	hasRequestParameters := api.RequestParameters != nil &&
+		(api.RequestParameters.Headers != nil || api.RequestParameters.QueryParameters != nil)
+
+	if credentials == nil || (credentials.Oauth == nil && credentials.Basic == nil && credentials.CertificateGen == nil) {
+		if hasRequestParameters {
+			return nil, WrongInput("requestParameters can be provided only together with credentials")
+		}
+		return nil, nil
+	}
+
+	if credentials.CertificateGen != nil {
+		return nil, WrongInput("certificateGen credentials are not supported")
+	}
+
+	credential := &director.CredentialDataInput{}
+	var csrfInfo *model.CSRFInfo
+	if credentials.Oauth != nil {
+		credential.Oauth = &director.OAuthCredentialDataInput{
+			ClientID:     credentials.Oauth.ClientID,
+			ClientSecret: credentials.Oauth.ClientSecret,
+			URL:          credentials.Oauth.URL,
+		}
+		csrfInfo = credentials.Oauth.CSRFInfo
+	} else {
+		credential.Basic = &director.BasicCredentialDataInput{
+			Username: credentials.Basic.Username,
+			Password: credentials.Basic.Password,
+		}
+		csrfInfo = credentials.Basic.CSRFInfo
+	}
+
+	auth := &director.AuthInput{Credential: credential}
+
+	if hasRequestParameters {
+		if api.RequestParameters.Headers != nil {
+			auth.AdditionalHeaders = *api.RequestParameters.Headers
+		}
+		if api.RequestParameters.QueryParameters != nil {
+			auth.AdditionalQueryParams = *api.RequestParameters.QueryParameters
+		}
+	}
+
+	if csrfInfo != nil {
+		auth.RequestAuth = &director.CredentialRequestAuthInput{
+			Csrf: &director.CSRFTokenCredentialRequestAuthInput{
+				TokenEndpointURL: csrfInfo.TokenEndpointURL,
+				Credential:       credential,
+			},
+		}
+	}
+
+	return auth, nil
+}
+
+func toEventAPIDefinitionInput(id string, serviceDef model.ServiceDefinition) *director.EventAPIDefinitionInput {
+	if serviceDef.Events == nil {
+		return nil
+	}
+
+	in := &director.EventAPIDefinitionInput{
+		Name:        serviceDef.Name,
+		Description: &serviceDef.Description,
+		Group:       &id,
+		Spec: director.EventAPISpecInput{
+			EventSpecType: director.EventAPISpecTypeAsyncAPI,
+			Format:        specFormat(serviceDef.Events.Spec),
+		},
+	}
+	if len(serviceDef.Events.Spec) > 0 {
+		data := string(serviceDef.Events.Spec)
+		in.Spec.Data = &data
+	}
+
+	return in
+}
+
+func toDocumentInput(id string, serviceDef model.ServiceDefinition) *director.DocumentInput {
+	if len(serviceDef.Documentation) == 0 {
+		return nil
+	}
+
+	kind := documentationKind
+	data := string(serviceDef.Documentation)
+
+	return &director.DocumentInput{
+		Title:       id,
+		DisplayName: serviceDef.Name,
+		Description: serviceDef.Description,
+		Format:      director.DocumentFormatMarkdown,
+		Kind:        &kind,
+		Data:        &data,
+	}
+}
+
+func toServiceDefinition(id string, details serviceDetails, objects serviceObjects) model.ServiceDefinition {
+	serviceDef := model.ServiceDefinition{
+		ID:               id,
+		Name:             details.Name,
+		Provider:         details.Provider,
+		Identifier:       details.Identifier,
+		Description:      details.Description,
+		ShortDescription: details.ShortDescription,
+	}
+	if details.Labels != nil {
+		labels := details.Labels
+		serviceDef.Labels = &labels
+	}
+
+	if objects.api != nil {
+		serviceDef.Api = toModelAPI(objects.api)
+	}
+
+	if objects.eventAPI != nil {
+		serviceDef.Events = &model.Events{}
+		if objects.eventAPI.Spec.Data != nil {
+			serviceDef.Events.Spec = []byte(*objects.eventAPI.Spec.Data)
+		}
+	}
+
+	if objects.document != nil && objects.document.Data != nil {
+		serviceDef.Documentation = []byte(*objects.document.Data)
+	}
+
+	return serviceDef
+}
+
+// toModelAPI doesn't return the secrets of the API credentials
+func toModelAPI(apiDef *director.APIDefinition) *model.API {
+	api := &model.API{TargetUrl: apiDef.TargetURL}
+
+	if apiDef.Spec != nil {
+		api.ApiType = apiTypeOpenAPI
+		if apiDef.Spec.Type == director.APISpecTypeOData {
+			api.ApiType = apiTypeOData
+		}
+		if apiDef.Spec.Data != nil {
+			api.Spec = []byte(*apiDef.Spec.Data)
+		}
+		if apiDef.Spec.FetchRequest != nil {
+			api.SpecificationUrl = apiDef.Spec.FetchRequest.URL
+		}
+	}
+
+	auth := apiDef.DefaultAuth
+	if auth == nil {
+		return api
+	}
+
+	var csrfInfo *model.CSRFInfo
+	if auth.RequestAuth != nil && auth.RequestAuth.Csrf != nil {
+		csrfInfo = &model.CSRFInfo{TokenEndpointURL: auth.RequestAuth.Csrf.TokenEndpointURL}
+	}
+
+	api.Credentials = &model.Credentials{}
+	if auth.Credential.URL != "" || auth.Credential.ClientID != "" {
+		api.Credentials.Oauth = &model.Oauth{URL: auth.Credential.URL, ClientID: auth.Credential.ClientID, CSRFInfo: csrfInfo}
+	} else {
+		api.Credentials.Basic = &model.Basic{Username: auth.Credential.Username, CSRFInfo: csrfInfo}
+	}
+
+	if auth.AdditionalHeaders != nil || auth.AdditionalQueryParams != nil {
+		api.RequestParameters = &model.RequestParameters{}
+		if auth.AdditionalHeaders != nil {
+			headers := auth.AdditionalHeaders
+			api.RequestParameters.Headers = &headers
+		}
+		if auth.AdditionalQueryParams != nil {
+			queryParams := auth.AdditionalQueryParams
+			api.RequestParameters.QueryParameters = &queryParams
+		}
+	}
+
+	return api
+}
+
+func specFormat(spec []byte) string {
+	trimmed := bytes.TrimSpace(spec)
+	switch {
+	case json.Valid(trimmed):
+		return director.SpecFormatJSON
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return director.SpecFormatXML
+	}
+
+	return director.SpecFormatYAML
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/director"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serviceID = "3d6c3e7f-0b5a-4f39-9f55-2c1d2b0b4f11"
+
+func TestToAPIDefinitionInput(t *testing.T) {
+	t.Run("should convert API with OAuth credentials and CSRF", func(t *testing.T) {
+		// given
+		headers := map[string][]string{"X-Foo": {"bar"}}
+		serviceDef := model.ServiceDefinition{
+			Name:        "orders",
+			Description: "Orders API",
+			Api: &model.API{
+				TargetUrl: "https://orders.example.com",
+				Spec:      []byte(`{"swagger": "2.0"}`),
+				Credentials: &model.Credentials{
+					Oauth: &model.Oauth{URL: "https://auth.example.com", ClientID: "id", ClientSecret: "secret", CSRFInfo: &model.CSRFInfo{TokenEndpointURL: "https://csrf.example.com"}},
+				},
+				RequestParameters: &model.RequestParameters{Headers: &headers},
+			},
+		}
+
+		// when
+		in, err := toAPIDefinitionInput(serviceID, serviceDef)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, in)
+		assert.Equal(t, "orders", in.Name)
+		assert.Equal(t, serviceID, *in.Group)
+		assert.Equal(t, director.APISpecTypeOpenAPI, in.Spec.Type)
+		assert.Equal(t, director.SpecFormatJSON, in.Spec.Format)
+		assert.Equal(t, &director.OAuthCredentialDataInput{ClientID: "id", ClientSecret: "secret", URL: "https://auth.example.com"}, in.DefaultAuth.Credential.Oauth)
+		assert.Equal(t, headers, in.DefaultAuth.AdditionalHeaders)
+		assert.Equal(t, "https://csrf.example.com", in.DefaultAuth.RequestAuth.Csrf.TokenEndpointURL)
+	})
+
+	t.Run("should fetch OData specification from URL", func(t *testing.T) {
+		// given
+		serviceDef := model.ServiceDefinition{
+			Name: "orders",
+			Api:  &model.API{TargetUrl: "https://orders.example.com", SpecificationUrl: "https://orders.example.com/$metadata", ApiType: "ODATA"},
+		}
+
+		// when
+		in, err := toAPIDefinitionInput(serviceID, serviceDef)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, director.APISpecTypeOData, in.Spec.Type)
+		assert.Equal(t, director.SpecFormatXML, in.Spec.Format)
+		assert.Equal(t, "https://orders.example.com/$metadata", in.Spec.FetchRequest.URL)
+		assert.Nil(t, in.DefaultAuth)
+	})
+
+	t.Run("should reject certificateGen credentials", func(t *testing.T) {
+		// given
+		serviceDef := model.ServiceDefinition{
+			Api: &model.API{Credentials: &model.Credentials{CertificateGen: &model.CertificateGen{CommonName: "foo"}}},
+		}
+
+		// when
+		_, err := toAPIDefinitionInput(serviceID, serviceDef)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, IsWrongInput(err))
+	})
+
+	t.Run("should reject request parameters without credentials", func(t *testing.T) {
+		// given
+		queryParams := map[string][]string{"foo": {"bar"}}
+		serviceDef := model.ServiceDefinition{
+			Api: &model.API{RequestParameters: &model.RequestParameters{QueryParameters: &queryParams}},
+		}
+
+		// when
+		_, err := toAPIDefinitionInput(serviceID, serviceDef)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, IsWrongInput(err))
+	})
+}
+
+func TestSpecFormat(t *testing.T) {
+	assert.Equal(t, director.SpecFormatJSON, specFormat([]byte(` {"asyncapi": "1.0.0"}`)))
+	assert.Equal(t, director.SpecFormatXML, specFormat([]byte(`<edmx:Edmx Version="1.0"></edmx:Edmx>`)))
+	assert.Equal(t, director.SpecFormatYAML, specFormat([]byte("asyncapi: 1.0.0")))
+}
+
+func TestToServiceDefinition(t *testing.T) {
+	// given
+	data := `{"asyncapi": "1.0.0"}`
+	docData := "# Orders"
+	details := serviceDetails{Name: "orders", Provider: "SAP", Description: "Orders API", Labels: map[string]string{"connected-app": "orders"}}
+	objects := serviceObjects{
+		api: &director.APIDefinition{
+			TargetURL: "https://orders.example.com",
+			Spec:      &director.APISpec{Type: director.APISpecTypeOData, Format: director.SpecFormatXML, FetchRequest: &director.FetchRequest{URL: "https://orders.example.com/$metadata"}},
+			DefaultAuth: &director.Auth{
+				Credential: director.CredentialData{Username: "user", Password: "password"},
+			},
+		},
+		eventAPI: &director.EventAPIDefinition{Spec: director.EventAPISpec{Data: &data}},
+		document: &director.Document{Data: &docData},
+	}
+
+	// when
+	serviceDef := toServiceDefinition(serviceID, details, objects)
+
+	// then
+	assert.Equal(t, serviceID, serviceDef.ID)
+	assert.Equal(t, "SAP", serviceDef.Provider)
+	assert.Equal(t, map[string]string{"connected-app": "orders"}, *serviceDef.Labels)
+	assert.Equal(t, apiTypeOData, serviceDef.Api.ApiType)
+	assert.Equal(t, "https://orders.example.com/$metadata", serviceDef.Api.SpecificationUrl)
+	assert.Equal(t, &model.Basic{Username: "user"}, serviceDef.Api.Credentials.Basic)
+	assert.Equal(t, []byte(data), serviceDef.Events.Spec)
+	assert.Equal(t, []byte(docData), serviceDef.Documentation)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type notFoundError struct {
+	message string
+}
+
+func (e notFoundError) Error() string {
+	return e.message
+}
+
+type wrongInputError struct {
+	message string
+}
+
+func (e wrongInputError) Error() string {
+	return e.message
+}
+
+func NotFound(format string, args ...interface{}) error {
+	return notFoundError{message: fmt.Sprintf(format, args...)}
+}
+
+func WrongInput(format string, args ...interface{}) error {
+	return wrongInputError{message: fmt.Sprintf(format, args...)}
+}
+
+func IsNotFound(err error) bool {
+	_, ok := errors.Cause(err).(notFoundError)
+	return ok
+}
+
+func IsWrongInput(err error) bool {
+	_, ok := errors.Cause(err).(wrongInputError)
+	return ok
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/director"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/model"
+	"github.com/pkg/errors"
+)
+
+// Service manages the services of an Application the way the Kyma Application Registry does.
+// A service is stored in the Director as an API, an Event API and a Document grouped by the service ID,
+// and an Application label with the fields which Compass doesn't model.
+type Service interface {
+	Create(ctx context.Context, application string, serviceDef model.ServiceDefinition) (string, error)
+	Get(ctx context.Context, application, id string) (model.ServiceDefinition, error)
+	GetAll(ctx context.Context, application string) ([]model.ServiceDefinition, error)
+	Update(ctx context.Context, application, id string, serviceDef model.ServiceDefinition) (model.ServiceDefinition, error)
+	Delete(ctx context.Context, application, id string) error
+}
+
+type serviceObjects struct {
+	api      *director.APIDefinition
+	eventAPI *director.EventAPIDefinition
+	document *director.Document
+}
+
+type service struct {
+	directorClient director.Client
+}
+
+func NewService(directorClient director.Client) Service {
+	return &service{directorClient: directorClient}
+}
+
+func (s *service) Create(ctx context.Context, application string, serviceDef model.ServiceDefinition) (string, error) {
+	app, err := s.getApplication(ctx, application)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := newServiceID()
+	if err != nil {
+		return "", errors.Wrap(err, "while generating service ID")
+	}
+
+	err = s.createObjects(ctx, app.ID, id, serviceDef)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *service) Get(ctx context.Context, application, id string) (model.ServiceDefinition, error) {
+	app, err := s.getApplication(ctx, application)
+	if err != nil {
+		return model.ServiceDefinition{}, err
+	}
+
+	value, found := app.Labels[serviceLabelKey(id)]
+	if !found {
+		return model.ServiceDefinition{}, NotFound("service %s not found", id)
+	}
+
+	details, err := serviceDetailsFromLabel(value)
+	if err != nil {
+		return model.ServiceDefinition{}, errors.Wrapf(err, "while reading details of service %s", id)
+	}
+
+	return toServiceDefinition(id, details, findServiceObjects(app, id)), nil
+}
+
+func (s *service) GetAll(ctx context.Context, application string) ([]model.ServiceDefinition, error) {
+	app, err := s.getApplication(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceDefs := make([]model.ServiceDefinition, 0)
+	for key, value := range app.Labels {
+		if !strings.HasPrefix(key, serviceLabelPrefix) {
+			continue
+		}
+
+		id := strings.TrimPrefix(key, serviceLabelPrefix)
+		details, err := serviceDetailsFromLabel(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading details of service %s", id)
+		}
+
+		serviceDefs = append(serviceDefs, toServiceDefinition(id, details, findServiceObjects(app, id)))
+	}
+
+	return serviceDefs, nil
+}
+
+// Update replaces the API, Event API and Document of the service, as the Application Registry doesn't support partial updates
+func (s *service) Update(ctx context.Context, application, id string, serviceDef model.ServiceDefinition) (model.ServiceDefinition, error) {
+	app, err := s.getApplication(ctx, application)
+	if err != nil {
+		return model.ServiceDefinition{}, err
+	}
+
+	if _, found := app.Labels[serviceLabelKey(id)]; !found {
+		return model.ServiceDefinition{}, NotFound("service %s not found", id)
+	}
+
+	err = s.deleteObjects(ctx, findServiceObjects(app, id))
+	if err != nil {
+		return model.ServiceDefinition{}, err
+	}
+
+	err = s.createObjects(ctx, app.ID, id, serviceDef)
+	if err != nil {
+		return model.ServiceDefinition{}, err
+	}
+
+	serviceDef.ID = id
+	return serviceDef, nil
+}
+
+func (s *service) Delete(ctx context.Context, application, id string) error {
+	app, err := s.getApplication(ctx, application)
+	if err != nil {
+		return err
+	}
+
+	if _, found := app.Labels[serviceLabelKey(id)]; !found {
+		return NotFound("service %s not found", id)
+	}
+
+	err = s.deleteObjects(ctx, findServiceObjects(app, id))
+	if err != nil {
+		return err
+	}
+
+	return s.directorClient.DeleteApplicationLabel(ctx, app.ID, serviceLabelKey(id))
+}
+
+func (s *service) getApplication(ctx context.Context, application string) (*director.Application, error) {
+	app, err := s.directorClient.FindApplication(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+
+	if app == nil {
+		return nil, NotFound("application %s not found", application)
+	}
+
+	return app, nil
+}
+
+func (s *service) createObjects(ctx context.Context, appID, id string, serviceDef model.ServiceDefinition) error {
+	apiInput, err := toAPIDefinitionInput(id, serviceDef)
+	if err != nil {
+		return err
+	}
+
+	if apiInput != nil {
+		_, err = s.directorClient.AddAPI(ctx, appID, *apiInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	if eventAPIInput := toEventAPIDefinitionInput(id, serviceDef); eventAPIInput != nil {
+		_, err = s.directorClient.AddEventAPI(ctx, appID, *eventAPIInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	if documentInput := toDocumentInput(id, serviceDef); documentInput != nil {
+		_, err = s.directorClient.AddDocument(ctx, appID, *documentInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.directorClient.SetApplicationLabel(ctx, appID, serviceLabelKey(id), toServiceDetails(serviceDef))
+}
+
+func (s *service) deleteObjects(ctx context.Context, objects serviceObjects) error {
+	if objects.api != nil {
+		err := s.directorClient.DeleteAPI(ctx, objects.api.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if objects.eventAPI != nil {
+		err := s.directorClient.DeleteEventAPI(ctx, objects.eventAPI.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if objects.document != nil {
+		err := s.directorClient.DeleteDocument(ctx, objects.document.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findServiceObjects(app *director.Application, id string) serviceObjects {
+	objects := serviceObjects{}
+
+	for _, api := range app.APIs.Data {
+		if api != nil && api.Group != nil && *api.Group == id {
+			objects.api = api
+			break
+		}
+	}
+
+	for _, eventAPI := range app.EventAPIs.Data {
+		if eventAPI != nil && eventAPI.Group != nil && *eventAPI.Group == id {
+			objects.eventAPI = eventAPI
+			break
+		}
+	}
+
+	for _, document := range app.Documents.Data {
+		if document != nil && document.Title == id && document.Kind != nil && *document.Kind == documentationKind {
+			objects.document = document
+			break
+		}
+	}
+
+	return objects
+}
+
+// newServiceID generates a random UUID, as the service ID must be known before its objects are created
+func newServiceID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
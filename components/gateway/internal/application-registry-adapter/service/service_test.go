@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/director"
+	"github.com/kyma-incubator/compass/components/gateway/internal/application-registry-adapter/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appID = "5b0f6c2e-7c1d-4a5e-8f3b-9d2e1c0a4b6f"
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	serviceDef := model.ServiceDefinition{
+		Name:        "orders",
+		Provider:    "SAP",
+		Description: "Orders API",
+		Api:         &model.API{TargetUrl: "https://orders.example.com", Spec: []byte(`{"swagger": "2.0"}`)},
+		Events:      &model.Events{Spec: []byte(`{"asyncapi": "1.0.0"}`)},
+	}
+
+	t.Run("should create, get, update and delete service", func(t *testing.T) {
+		// given
+		directorClient := newFakeDirectorClient()
+		svc := NewService(directorClient)
+
+		// when
+		id, err := svc.Create(ctx, "orders-app", serviceDef)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, directorClient.app.APIs.Data, 1)
+		assert.Len(t, directorClient.app.EventAPIs.Data, 1)
+		assert.Empty(t, directorClient.app.Documents.Data)
+
+		// when
+		created, err := svc.Get(ctx, "orders-app", id)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, id, created.ID)
+		assert.Equal(t, "SAP", created.Provider)
+		assert.Equal(t, serviceDef.Api.Spec, created.Api.Spec)
+		assert.Equal(t, serviceDef.Events.Spec, created.Events.Spec)
+
+		// when
+		updatedDef := serviceDef
+		updatedDef.Events = nil
+		updatedDef.Documentation = []byte("# Orders")
+		_, err = svc.Update(ctx, appID, id, updatedDef)
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, directorClient.app.APIs.Data, 1)
+		assert.Empty(t, directorClient.app.EventAPIs.Data)
+		assert.Len(t, directorClient.app.Documents.Data, 1)
+
+		all, err := svc.GetAll(ctx, "orders-app")
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, []byte("# Orders"), all[0].Documentation)
+
+		// when
+		err = svc.Delete(ctx, "orders-app", id)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, directorClient.app.APIs.Data)
+		assert.Empty(t, directorClient.app.Documents.Data)
+		assert.Empty(t, directorClient.app.Labels)
+	})
+
+	t.Run("should return not found error when application doesn't exist", func(t *testing.T) {
+		// given
+		svc := NewService(newFakeDirectorClient())
+
+		// when
+		_, err := svc.GetAll(ctx, "unknown")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("should return not found error when service doesn't exist", func(t *testing.T) {
+		// given
+		svc := NewService(newFakeDirectorClient())
+
+		// when
+		err := svc.Delete(ctx, "orders-app", "unknown")
+
+		// then
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("should return error when Director fails", func(t *testing.T) {
+		// given
+		directorClient := newFakeDirectorClient()
+		directorClient.err = errors.New("test error")
+		svc := NewService(directorClient)
+
+		// when
+		_, err := svc.Create(ctx, "orders-app", serviceDef)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "test error")
+		assert.False(t, IsNotFound(err))
+	})
+}
+
+// fakeDirectorClient keeps a single Application in memory
+type fakeDirectorClient struct {
+	app    *director.Application
+	nextID int
+	err    error
+}
+
+func newFakeDirectorClient() *fakeDirectorClient {
+	return &fakeDirectorClient{
+		app: &director.Application{ID: appID, Name: "orders-app", Labels: map[string]interface{}{}},
+	}
+}
+
+func (c *fakeDirectorClient) newID() string {
+	c.nextID++
+	return fmt.Sprintf("id-%d", c.nextID)
+}
+
+func (c *fakeDirectorClient) FindApplication(ctx context.Context, idOrName string) (*director.Application, error) {
+	if idOrName == c.app.ID || idOrName == c.app.Name {
+		return c.app, nil
+	}
+
+	return nil, nil
+}
+
+func (c *fakeDirectorClient) AddAPI(ctx context.Context, appID string, in director.APIDefinitionInput) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+
+	api := &director.APIDefinition{ID: c.newID(), Name: in.Name, TargetURL: in.TargetURL, Group: in.Group}
+	if in.Spec != nil {
+		api.Spec = &director.APISpec{Data: in.Spec.Data, Type: in.Spec.Type, Format: in.Spec.Format}
+	}
+	c.app.APIs.Data = append(c.app.APIs.Data, api)
+
+	return api.ID, nil
+}
+
+func (c *fakeDirectorClient) DeleteAPI(ctx context.Context, id string) error {
+	var apis []*director.APIDefinition
+	for _, api := range c.app.APIs.Data {
+		if api.ID != id {
+			apis = append(apis, api)
+		}
+	}
+	c.app.APIs.Data = apis
+
+	return nil
+}
+
+func (c *fakeDirectorClient) AddEventAPI(ctx context.Context, appID string, in director.EventAPIDefinitionInput) (string, error) {
+	eventAPI := &director.EventAPIDefinition{ID: c.newID(), Name: in.Name, Group: in.Group, Spec: director.EventAPISpec{Data: in.Spec.Data}}
+	c.app.EventAPIs.Data = append(c.app.EventAPIs.Data, eventAPI)
+
+	return eventAPI.ID, nil
+}
+
+func (c *fakeDirectorClient) DeleteEventAPI(ctx context.Context, id string) error {
+	var eventAPIs []*director.EventAPIDefinition
+	for _, eventAPI := range c.app.EventAPIs.Data {
+		if eventAPI.ID != id {
+			eventAPIs = append(eventAPIs, eventAPI)
+		}
+	}
+	c.app.EventAPIs.Data = eventAPIs
+
+	return nil
+}
+
+func (c *fakeDirectorClient) AddDocument(ctx context.Context, appID string, in director.DocumentInput) (string, error) {
+	document := &director.Document{ID: c.newID(), Title: in.Title, Kind: in.Kind, Data: in.Data}
+	c.app.Documents.Data = append(c.app.Documents.Data, document)
+
+	return document.ID, nil
+}
+
+func (c *fakeDirectorClient) DeleteDocument(ctx context.Context, id string) error {
+	var documents []*director.Document
+	for _, document := range c.app.Documents.Data {
+		if document.ID != id {
+			documents = append(documents, document)
+		}
+	}
+	c.app.Documents.Data = documents
+
+	return nil
+}
+
+func (c *fakeDirectorClient) SetApplicationLabel(ctx context.Context, appID, key string, value interface{}) error {
+	c.app.Labels[key] = value
+	return nil
+}
+
+func (c *fakeDirectorClient) DeleteApplicationLabel(ctx context.Context, appID, key string) error {
+	delete(c.app.Labels, key)
+	return nil
+}
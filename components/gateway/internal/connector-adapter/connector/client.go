@@ -0,0 +1,145 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/graphqlclient"
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+	"github.com/pkg/errors"
+)
+
+const (
+	connectorTokenHeader    = "Connector-Token"
+	clientIDFromTokenHeader = "Client-Id-From-Token"
+)
+
+type Configuration struct {
+	Token                         *Token                         `json:"token"`
+	CertificateSigningRequestInfo *CertificateSigningRequestInfo `json:"certificateSigningRequestInfo"`
+	ManagementPlaneInfo           *ManagementPlaneInfo           `json:"managementPlaneInfo"`
+}
+
+type Token struct {
+	Token string `json:"token"`
+}
+
+type CertificateSigningRequestInfo struct {
+	Subject      string `json:"subject"`
+	KeyAlgorithm string `json:"keyAlgorithm"`
+}
+
+type ManagementPlaneInfo struct {
+	DirectorURL                    *string `json:"directorURL"`
+	CertificateSecuredConnectorURL *string `json:"certificateSecuredConnectorURL"`
+}
+
+type CertificationResult struct {
+	CertificateChain  string `json:"certificateChain"`
+	CaCertificate     string `json:"caCertificate"`
+	ClientCertificate string `json:"clientCertificate"`
+}
+
+// Client calls the Connector on behalf of the holder of a one-time token
+type Client interface {
+	// ResolveToken exchanges the one-time token for the ID of the client it was issued for
+	ResolveToken(ctx context.Context, token string) (string, error)
+	Configuration(ctx context.Context, clientID string) (Configuration, error)
+	SignCSR(ctx context.Context, clientID, csr string) (CertificationResult, error)
+}
+
+type client struct {
+	gqlClient        graphqlclient.Client
+	httpClient       *http.Client
+	tokenResolverURL string
+}
+
+func NewClient(gqlClient graphqlclient.Client, httpClient *http.Client, tokenResolverURL string) Client {
+	return &client{
+		gqlClient:        gqlClient,
+		httpClient:       httpClient,
+		tokenResolverURL: tokenResolverURL,
+	}
+}
+
+type authenticationSession struct {
+	Subject string                 `json:"subject"`
+	Extra   map[string]interface{} `json:"extra"`
+	Header  http.Header            `json:"header"`
+}
+
+// ResolveToken calls the Connector token resolver in the same way as the Oathkeeper hydrator,
+// as legacy clients pass the token in the query instead of the Connector-Token header
+func (c *client) ResolveToken(ctx context.Context, token string) (string, error) {
+	body, err := json.Marshal(authenticationSession{Extra: map[string]interface{}{}, Header: http.Header{}})
+	if err != nil {
+		return "", errors.Wrap(err, "while encoding authentication session")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.tokenResolverURL, bytes.NewReader(body))
+	if err != nil {
+		return "", errors.Wrap(err, "while creating token resolve request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set(connectorTokenHeader, token)
+	req.Header.Set(httputils.HeaderContentType, httputils.ContentTypeApplicationJSON)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "while resolving token")
+	}
+	defer httputils.Close(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("while resolving token: unexpected status %d", resp.StatusCode)
+	}
+
+	session := authenticationSession{}
+	err = json.NewDecoder(resp.Body).Decode(&session)
+	if err != nil {
+		return "", errors.Wrap(err, "while decoding authentication session")
+	}
+
+	return session.Header.Get(clientIDFromTokenHeader), nil
+}
+
+func (c *client) Configuration(ctx context.Context, clientID string) (Configuration, error) {
+	req := graphqlclient.NewRequest(`query {
+	result: configuration {
+		token { token }
+		certificateSigningRequestInfo { subject keyAlgorithm }
+		managementPlaneInfo { directorURL certificateSecuredConnectorURL }
+	}
+}`)
+	req.Header.Set(clientIDFromTokenHeader, clientID)
+
+	resp := struct {
+		Result Configuration `json:"result"`
+	}{}
+	err := c.gqlClient.Run(ctx, req, &resp)
+	if err != nil {
+		return Configuration{}, errors.Wrap(err, "while getting configuration")
+	}
+
+	return resp.Result, nil
+}
+
+func (c *client) SignCSR(ctx context.Context, clientID, csr string) (CertificationResult, error) {
+	req := graphqlclient.NewRequest(`mutation ($csr: String!) {
+	result: signCertificateSigningRequest(csr: $csr) { certificateChain caCertificate clientCertificate }
+}`)
+	req.Var("csr", csr)
+	req.Header.Set(clientIDFromTokenHeader, clientID)
+
+	resp := struct {
+		Result CertificationResult `json:"result"`
+	}{}
+	err := c.gqlClient.Run(ctx, req, &resp)
+	if err != nil {
+		return CertificationResult{}, errors.Wrap(err, "while signing certificate signing request")
+	}
+
+	return resp.Result, nil
+}
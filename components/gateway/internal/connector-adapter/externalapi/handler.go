@@ -0,0 +1,129 @@
+package externalapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/compass/components/gateway/internal/connector-adapter/connector"
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+)
+
+const (
+	tokenQueryParam      = "token"
+	signingRequestsPath  = "/v1/applications/signingRequests/info"
+	certificatesPath     = "/v1/applications/certificates"
+	metadataServicesPath = "/%s/v1/metadata/services"
+)
+
+// Config holds the external URLs returned to legacy clients
+type Config struct {
+	// ConnectorURL is the URL under which the legacy Connector REST API is exposed
+	ConnectorURL string
+	// MetadataURL is the URL under which the legacy Application Registry REST API is exposed for clients with certificates
+	MetadataURL string
+}
+
+// Handler serves the token secured endpoints of the legacy Connector REST API
+type Handler struct {
+	connectorClient connector.Client
+	config          Config
+}
+
+func NewHandler(connectorClient connector.Client, config Config) *Handler {
+	return &Handler{
+		connectorClient: connectorClient,
+		config:          config,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc(signingRequestsPath, h.GetInfo).Methods(http.MethodGet)
+	router.HandleFunc(certificatesPath, h.SignCSR).Methods(http.MethodPost)
+}
+
+// GetInfo consumes the token and returns the certificate subject with a new token for the certificate signing request
+func (h *Handler) GetInfo(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := h.resolveToken(w, r)
+	if !ok {
+		return
+	}
+
+	configuration, err := h.connectorClient.Configuration(r.Context(), clientID)
+	if err != nil {
+		httputils.RespondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if configuration.Token == nil || configuration.CertificateSigningRequestInfo == nil {
+		httputils.RespondWithError(w, http.StatusInternalServerError, errors.New("connector returned incomplete configuration"))
+		return
+	}
+
+	csrURL := fmt.Sprintf("%s%s?%s=%s", strings.TrimSuffix(h.config.ConnectorURL, "/"), certificatesPath, tokenQueryParam, url.QueryEscape(configuration.Token.Token))
+
+	httputils.RespondWithBody(w, http.StatusOK, InfoResponse{
+		CsrURL: csrURL,
+		API: InfoAPI{
+			MetadataURL: strings.TrimSuffix(h.config.MetadataURL, "/") + fmt.Sprintf(metadataServicesPath, clientID),
+		},
+		CertificateInfo: CertificateInfo{
+			Subject:      configuration.CertificateSigningRequestInfo.Subject,
+			KeyAlgorithm: configuration.CertificateSigningRequestInfo.KeyAlgorithm,
+		},
+	})
+}
+
+// SignCSR consumes the token and signs the certificate signing request of the client
+func (h *Handler) SignCSR(w http.ResponseWriter, r *http.Request) {
+	defer httputils.Close(r.Body)
+
+	certRequest := CertificateRequest{}
+	err := json.NewDecoder(r.Body).Decode(&certRequest)
+	if err != nil || certRequest.CSR == "" {
+		httputils.RespondWithError(w, http.StatusBadRequest, errors.New("request body must contain the csr field"))
+		return
+	}
+
+	clientID, ok := h.resolveToken(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.connectorClient.SignCSR(r.Context(), clientID, certRequest.CSR)
+	if err != nil {
+		httputils.RespondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httputils.RespondWithBody(w, http.StatusCreated, CertificateResponse{
+		CRTChain:  result.CertificateChain,
+		ClientCRT: result.ClientCertificate,
+		CaCRT:     result.CaCertificate,
+	})
+}
+
+func (h *Handler) resolveToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token := r.URL.Query().Get(tokenQueryParam)
+	if token == "" {
+		httputils.RespondWithError(w, http.StatusForbidden, errors.New("token not provided"))
+		return "", false
+	}
+
+	clientID, err := h.connectorClient.ResolveToken(r.Context(), token)
+	if err != nil {
+		httputils.RespondWithError(w, http.StatusInternalServerError, err)
+		return "", false
+	}
+
+	if clientID == "" {
+		httputils.RespondWithError(w, http.StatusForbidden, errors.New("invalid token"))
+		return "", false
+	}
+
+	return clientID, true
+}
@@ -0,0 +1,156 @@
+package externalapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/compass/components/gateway/internal/connector-adapter/connector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientID = "5b0f6c2e-7c1d-4a5e-8f3b-9d2e1c0a4b6f"
+	token    = "one-time-token"
+)
+
+var config = Config{
+	ConnectorURL: "https://compass-gateway.kyma.local",
+	MetadataURL:  "https://compass-gateway-mtls.kyma.local/",
+}
+
+func TestHandler_GetInfo(t *testing.T) {
+	t.Run("should return CSR info with new token", func(t *testing.T) {
+		// given
+		client := &fakeConnectorClient{
+			tokens: map[string]string{token: clientID},
+			configuration: connector.Configuration{
+				Token:                         &connector.Token{Token: "csr-token"},
+				CertificateSigningRequestInfo: &connector.CertificateSigningRequestInfo{Subject: "O=Org,CN=" + clientID, KeyAlgorithm: "rsa2048"},
+			},
+		}
+		req := httptest.NewRequest(http.MethodGet, "/v1/applications/signingRequests/info?token="+token, nil)
+
+		// when
+		rr := serve(client, req)
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		info := InfoResponse{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&info))
+		assert.Equal(t, "https://compass-gateway.kyma.local/v1/applications/certificates?token=csr-token", info.CsrURL)
+		assert.Equal(t, "https://compass-gateway-mtls.kyma.local/"+clientID+"/v1/metadata/services", info.API.MetadataURL)
+		assert.Equal(t, CertificateInfo{Subject: "O=Org,CN=" + clientID, KeyAlgorithm: "rsa2048"}, info.CertificateInfo)
+		assert.Equal(t, clientID, client.clientID)
+	})
+
+	t.Run("should return forbidden when token is invalid", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/v1/applications/signingRequests/info?token=invalid", nil)
+
+		// when
+		rr := serve(&fakeConnectorClient{}, req)
+
+		// then
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return forbidden when token is missing", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/v1/applications/signingRequests/info", nil)
+
+		// when
+		rr := serve(&fakeConnectorClient{}, req)
+
+		// then
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return internal error when Connector fails", func(t *testing.T) {
+		// given
+		client := &fakeConnectorClient{tokens: map[string]string{token: clientID}, err: errors.New("test error")}
+		req := httptest.NewRequest(http.MethodGet, "/v1/applications/signingRequests/info?token="+token, nil)
+
+		// when
+		rr := serve(client, req)
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestHandler_SignCSR(t *testing.T) {
+	t.Run("should return signed certificates", func(t *testing.T) {
+		// given
+		client := &fakeConnectorClient{
+			tokens: map[string]string{token: clientID},
+			result: connector.CertificationResult{CertificateChain: "chain", ClientCertificate: "client", CaCertificate: "ca"},
+		}
+		req := httptest.NewRequest(http.MethodPost, "/v1/applications/certificates?token="+token, strings.NewReader(`{"csr": "encoded-csr"}`))
+
+		// when
+		rr := serve(client, req)
+
+		// then
+		require.Equal(t, http.StatusCreated, rr.Code)
+		resp := CertificateResponse{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, CertificateResponse{CRTChain: "chain", ClientCRT: "client", CaCRT: "ca"}, resp)
+		assert.Equal(t, "encoded-csr", client.csr)
+	})
+
+	t.Run("should return bad request when CSR is missing", func(t *testing.T) {
+		// given
+		client := &fakeConnectorClient{tokens: map[string]string{token: clientID}}
+		req := httptest.NewRequest(http.MethodPost, "/v1/applications/certificates?token="+token, strings.NewReader(`{}`))
+
+		// when
+		rr := serve(client, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, client.tokens, token, "token should not be consumed")
+	})
+}
+
+func serve(client connector.Client, req *http.Request) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	NewHandler(client, config).RegisterRoutes(router)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// fakeConnectorClient consumes tokens like the Connector does
+type fakeConnectorClient struct {
+	tokens        map[string]string
+	configuration connector.Configuration
+	result        connector.CertificationResult
+	clientID      string
+	csr           string
+	err           error
+}
+
+func (c *fakeConnectorClient) ResolveToken(ctx context.Context, token string) (string, error) {
+	clientID := c.tokens[token]
+	delete(c.tokens, token)
+	return clientID, nil
+}
+
+func (c *fakeConnectorClient) Configuration(ctx context.Context, clientID string) (connector.Configuration, error) {
+	c.clientID = clientID
+	return c.configuration, c.err
+}
+
+func (c *fakeConnectorClient) SignCSR(ctx context.Context, clientID, csr string) (connector.CertificationResult, error) {
+	c.clientID, c.csr = clientID, csr
+	return c.result, c.err
+}
@@ -0,0 +1,31 @@
+package externalapi
+
+// InfoResponse is returned by the signingRequests/info endpoint of the legacy Connector REST API
+type InfoResponse struct {
+	CsrURL          string          `json:"csrUrl"`
+	API             InfoAPI         `json:"api"`
+	CertificateInfo CertificateInfo `json:"certificate"`
+}
+
+type InfoAPI struct {
+	MetadataURL     string `json:"metadataUrl,omitempty"`
+	EventsURL       string `json:"eventsUrl,omitempty"`
+	InfoURL         string `json:"infoUrl,omitempty"`
+	CertificatesURL string `json:"certificatesUrl,omitempty"`
+}
+
+type CertificateInfo struct {
+	Subject      string `json:"subject"`
+	Extensions   string `json:"extensions"`
+	KeyAlgorithm string `json:"key-algorithm"`
+}
+
+type CertificateRequest struct {
+	CSR string `json:"csr"`
+}
+
+type CertificateResponse struct {
+	CRTChain  string `json:"crt"`
+	ClientCRT string `json:"clientCrt"`
+	CaCRT     string `json:"caCrt"`
+}
@@ -0,0 +1,134 @@
+package graphqlclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+	"github.com/pkg/errors"
+)
+
+// Client runs GraphQL operations against a single endpoint
+type Client interface {
+	// Run decodes the data of the response into resp and returns the GraphQL errors as an error
+	Run(ctx context.Context, req *Request, resp interface{}) error
+	// Do returns the response as it was received, together with the GraphQL errors
+	Do(ctx context.Context, req *Request) (*Response, error)
+}
+
+// Request is a GraphQL operation with its variables and additional HTTP headers
+type Request struct {
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+	Header        http.Header
+}
+
+// Response is a GraphQL response which wasn't decoded yet
+type Response struct {
+	Data   json.RawMessage   `json:"data"`
+	Errors []json.RawMessage `json:"errors,omitempty"`
+}
+
+func NewRequest(query string) *Request {
+	return &Request{
+		Query:     query,
+		Variables: map[string]interface{}{},
+		Header:    http.Header{},
+	}
+}
+
+func (r *Request) Var(key string, value interface{}) {
+	r.Variables[key] = value
+}
+
+type requestBody struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type responseError struct {
+	Message string `json:"message"`
+}
+
+type client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func New(endpoint string, httpClient *http.Client) Client {
+	return &client{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+	}
+}
+
+func (c *client) Run(ctx context.Context, req *Request, resp interface{}) error {
+	respBody, err := c.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if len(respBody.Errors) > 0 {
+		messages := make([]string, 0, len(respBody.Errors))
+		for _, rawErr := range respBody.Errors {
+			respErr := responseError{}
+			if err := json.Unmarshal(rawErr, &respErr); err != nil {
+				respErr.Message = string(rawErr)
+			}
+			messages = append(messages, respErr.Message)
+		}
+		return fmt.Errorf("graphql: %s", strings.Join(messages, "; "))
+	}
+
+	if resp == nil || len(respBody.Data) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(respBody.Data, resp)
+	if err != nil {
+		return errors.Wrap(err, "while decoding GraphQL response data")
+	}
+
+	return nil
+}
+
+func (c *client) Do(ctx context.Context, req *Request) (*Response, error) {
+	body, err := json.Marshal(requestBody{Query: req.Query, OperationName: req.OperationName, Variables: req.Variables})
+	if err != nil {
+		return nil, errors.Wrap(err, "while encoding GraphQL request")
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating HTTP request")
+	}
+	httpReq = httpReq.WithContext(ctx)
+
+	for key, values := range ForwardedHeaderFromContext(ctx) {
+		httpReq.Header[key] = values
+	}
+	for key, values := range req.Header {
+		httpReq.Header[key] = values
+	}
+	httpReq.Header.Set(httputils.HeaderContentType, httputils.ContentTypeApplicationJSON)
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while calling %s", c.endpoint)
+	}
+	defer httputils.Close(httpResp.Body)
+
+	respBody := &Response{}
+	err = json.NewDecoder(httpResp.Body).Decode(respBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while decoding response with status %d", httpResp.StatusCode)
+	}
+
+	return respBody, nil
+}
@@ -0,0 +1,80 @@
+package graphqlclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Run(t *testing.T) {
+	t.Run("should send variables and headers and decode data", func(t *testing.T) {
+		// given
+		var receivedBody requestBody
+		var receivedHeader http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedHeader = r.Header
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&receivedBody))
+			_, err := w.Write([]byte(`{"data": {"result": {"id": "foo"}}}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		req := NewRequest("query ($id: ID!) { result: application(id: $id) { id } }")
+		req.Var("id", "foo")
+		req.Header.Set("Client-Id-From-Token", "client")
+		ctx := WithForwardedHeader(context.Background(), http.Header{"Authorization": []string{"Bearer token"}})
+
+		resp := struct {
+			Result struct {
+				ID string `json:"id"`
+			} `json:"result"`
+		}{}
+
+		// when
+		err := New(server.URL, http.DefaultClient).Run(ctx, req, &resp)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "foo", resp.Result.ID)
+		assert.Equal(t, req.Query, receivedBody.Query)
+		assert.Equal(t, map[string]interface{}{"id": "foo"}, receivedBody.Variables)
+		assert.Equal(t, "Bearer token", receivedHeader.Get("Authorization"))
+		assert.Equal(t, "client", receivedHeader.Get("Client-Id-From-Token"))
+	})
+
+	t.Run("should return GraphQL errors", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"errors": [{"message": "insufficient scopes provided"}, {"message": "other"}], "data": null}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		// when
+		err := New(server.URL, http.DefaultClient).Run(context.Background(), NewRequest("query { foo }"), nil)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, "graphql: insufficient scopes provided; other", err.Error())
+	})
+
+	t.Run("should return error when response is not JSON", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		// when
+		err := New(server.URL, http.DefaultClient).Run(context.Background(), NewRequest("query { foo }"), nil)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while decoding response with status 502")
+	})
+}
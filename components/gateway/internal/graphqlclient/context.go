@@ -0,0 +1,25 @@
+package graphqlclient
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey string
+
+const forwardedHeaderKey contextKey = "ForwardedHeader"
+
+// WithForwardedHeader returns a context in which every GraphQL request carries the given headers,
+// so the caller of the REST API is authenticated against the GraphQL API in the same way
+func WithForwardedHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, forwardedHeaderKey, header)
+}
+
+func ForwardedHeaderFromContext(ctx context.Context) http.Header {
+	header, ok := ctx.Value(forwardedHeaderKey).(http.Header)
+	if !ok {
+		return http.Header{}
+	}
+
+	return header
+}
@@ -0,0 +1,115 @@
+package healthz_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/healthz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLivenessHandler(t *testing.T) {
+	// given
+	rec := httptest.NewRecorder()
+
+	// when
+	healthz.NewLivenessHandler()(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, healthz.Response{Status: healthz.StatusOK}, decodeResponse(t, rec))
+}
+
+func TestReadinessHandler(t *testing.T) {
+	succeeding := healthz.CheckerFunc(func(ctx context.Context) error {
+		return nil
+	})
+	failing := healthz.CheckerFunc(func(ctx context.Context) error {
+		return errors.New("test error")
+	})
+	hanging := healthz.CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	testCases := []struct {
+		Name             string
+		Checks           []healthz.Check
+		ExpectedCode     int
+		ExpectedStatus   string
+		ExpectedStatuses []string
+		ExpectedErrors   []string
+	}{
+		{
+			Name:             "All checks succeed",
+			Checks:           []healthz.Check{{Name: "first", Checker: succeeding}, {Name: "second", Checker: succeeding}},
+			ExpectedCode:     http.StatusOK,
+			ExpectedStatus:   healthz.StatusOK,
+			ExpectedStatuses: []string{healthz.StatusOK, healthz.StatusOK},
+			ExpectedErrors:   []string{"", ""},
+		},
+		{
+			Name:             "One of checks fails",
+			Checks:           []healthz.Check{{Name: "first", Checker: succeeding}, {Name: "second", Checker: failing}},
+			ExpectedCode:     http.StatusServiceUnavailable,
+			ExpectedStatus:   healthz.StatusFailed,
+			ExpectedStatuses: []string{healthz.StatusOK, healthz.StatusFailed},
+			ExpectedErrors:   []string{"", "test error"},
+		},
+		{
+			Name:             "Informational check fails",
+			Checks:           []healthz.Check{{Name: "first", Checker: succeeding}, {Name: "second", Checker: failing, Informational: true}},
+			ExpectedCode:     http.StatusOK,
+			ExpectedStatus:   healthz.StatusOK,
+			ExpectedStatuses: []string{healthz.StatusOK, healthz.StatusFailed},
+			ExpectedErrors:   []string{"", "test error"},
+		},
+		{
+			Name:             "Check exceeds timeout",
+			Checks:           []healthz.Check{{Name: "first", Checker: hanging}},
+			ExpectedCode:     http.StatusServiceUnavailable,
+			ExpectedStatus:   healthz.StatusFailed,
+			ExpectedStatuses: []string{healthz.StatusFailed},
+			ExpectedErrors:   []string{context.DeadlineExceeded.Error()},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// given
+			handler := healthz.NewReadinessHandler(50*time.Millisecond, testCase.Checks...)
+			rec := httptest.NewRecorder()
+
+			// when
+			handler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			// then
+			assert.Equal(t, testCase.ExpectedCode, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			response := decodeResponse(t, rec)
+			assert.Equal(t, testCase.ExpectedStatus, response.Status)
+			require.Len(t, response.Checks, len(testCase.Checks))
+			for i, check := range response.Checks {
+				assert.Equal(t, testCase.Checks[i].Name, check.Name)
+				assert.Equal(t, testCase.ExpectedStatuses[i], check.Status)
+				assert.Equal(t, testCase.ExpectedErrors[i], check.Error)
+				assert.Equal(t, testCase.Checks[i].Informational, check.Informational)
+				assert.NotEmpty(t, check.Duration)
+			}
+		})
+	}
+}
+
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder) healthz.Response {
+	var response healthz.Response
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+
+	return response
+}
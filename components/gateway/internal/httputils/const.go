@@ -0,0 +1,7 @@
+package httputils
+
+const (
+	ContentTypeApplicationJSON = "application/json"
+	HeaderContentType          = "Content-Type"
+	HeaderAuthorization        = "Authorization"
+)
@@ -0,0 +1,7 @@
+package httputils
+
+// ErrorResponse is the error body returned by the legacy REST API
+type ErrorResponse struct {
+	Code  int    `json:"code"`
+	Error string `json:"error"`
+}
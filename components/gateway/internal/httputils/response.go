@@ -0,0 +1,21 @@
+package httputils
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+func RespondWithBody(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Add(HeaderContentType, ContentTypeApplicationJSON)
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(data)
+	if err != nil {
+		log.Printf("Failed to encode response: %s", err.Error())
+	}
+}
+
+func RespondWithError(w http.ResponseWriter, status int, err error) {
+	log.Println(err.Error())
+	RespondWithBody(w, status, ErrorResponse{Code: status, Error: err.Error()})
+}
@@ -0,0 +1,13 @@
+package httputils
+
+import (
+	"io"
+	"log"
+)
+
+func Close(closer io.Closer) {
+	err := closer.Close()
+	if err != nil {
+		log.Printf("Warning: failed to close: %s", err.Error())
+	}
+}
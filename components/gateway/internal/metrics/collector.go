@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Namespace = "compass"
+	Subsystem = "gateway"
+
+	// CodeError is recorded instead of the status code when the upstream component could not be reached
+	CodeError = "error"
+)
+
+// Collector holds the metrics of the Gateway
+type Collector struct {
+	upstreamDuration *prometheus.HistogramVec
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		upstreamDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "upstream_request_duration_seconds",
+			Help:      "Duration of requests to the upstream components by component, method and status code",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"component", "method", "code"}),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.upstreamDuration.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.upstreamDuration.Collect(ch)
+}
+
+// InstrumentRoundTripper returns the RoundTripper which measures the duration of the requests to the component
+func (c *Collector) InstrumentRoundTripper(component string, next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+
+		code := CodeError
+		if err == nil {
+			code = strconv.Itoa(resp.StatusCode)
+		}
+		c.upstreamDuration.WithLabelValues(component, req.Method, code).Observe(time.Since(start).Seconds())
+
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector_InstrumentRoundTripper(t *testing.T) {
+	t.Run("should observe duration of requests by component, method and status code", func(t *testing.T) {
+		// given
+		collector := NewCollector()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+		client := &http.Client{Transport: collector.InstrumentRoundTripper("director", http.DefaultTransport)}
+
+		// when
+		resp, err := client.Post(server.URL, "application/json", nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Equal(t, map[string]uint64{"director POST 202": 1}, sampleCounts(t, collector))
+	})
+
+	t.Run("should observe failed requests with error code", func(t *testing.T) {
+		// given
+		collector := NewCollector()
+		failing := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})
+		client := &http.Client{Transport: collector.InstrumentRoundTripper("connector", failing)}
+
+		// when
+		_, err := client.Get("http://connector.local/graphql")
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, map[string]uint64{"connector GET error": 1}, sampleCounts(t, collector))
+	})
+}
+
+// sampleCounts returns the number of observed requests by their space separated component, method and code
+func sampleCounts(t *testing.T, collector *Collector) map[string]uint64 {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	counts := map[string]uint64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			counts[labels["component"]+" "+labels["method"]+" "+labels["code"]] = metric.GetHistogram().GetSampleCount()
+		}
+	}
+
+	return counts
+}
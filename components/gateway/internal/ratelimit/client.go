@@ -0,0 +1,149 @@
+package ratelimit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+)
+
+const (
+	clientIDFromCertificateHeader = "Client-Id-From-Certificate"
+	clientIDFromTokenHeader       = "Client-Id-From-Token"
+	forwardedForHeader            = "X-Forwarded-For"
+)
+
+// Client is the caller whose requests are counted together
+type Client struct {
+	Tenant string
+	Type   string
+	ID     string
+}
+
+type claims struct {
+	Tenant     string `json:"tenant"`
+	ObjectID   string `json:"objectID"`
+	ObjectType string `json:"objectType"`
+}
+
+// clientFromRequest identifies the caller by the claims of the ID token issued by Oathkeeper.
+// Requests without the token are identified by the client ID headers set by Oathkeeper, or by the caller address.
+// The token is not verified, as it is verified by the component which handles the request, so the token and the headers
+// are read only on the identity paths, on which Oathkeeper replaces them. On other paths they are set by the caller itself.
+func clientFromRequest(r *http.Request, trustedProxies []*net.IPNet, identityPaths []string) Client {
+	if !isIdentityPath(r.URL.Path, identityPaths) {
+		return Client{ID: remoteAddress(r, trustedProxies)}
+	}
+
+	if c, ok := claimsFromAuthorization(r.Header.Get(httputils.HeaderAuthorization)); ok && c.ObjectID != "" {
+		return Client{Tenant: c.Tenant, Type: c.ObjectType, ID: c.ObjectID}
+	}
+
+	for _, header := range []string{clientIDFromCertificateHeader, clientIDFromTokenHeader} {
+		if clientID := r.Header.Get(header); clientID != "" {
+			return Client{ID: clientID}
+		}
+	}
+
+	return Client{ID: remoteAddress(r, trustedProxies)}
+}
+
+// isIdentityPath reports whether the request path is one of the identity paths or their subpaths.
+// The "*" segment of the identity path matches any single segment of the request path.
+func isIdentityPath(requestPath string, identityPaths []string) bool {
+	requestSegments := strings.Split(strings.Trim(requestPath, "/"), "/")
+
+	for _, identityPath := range identityPaths {
+		trimmed := strings.Trim(identityPath, "/")
+		if trimmed == "" {
+			return true
+		}
+
+		if segmentsMatch(strings.Split(trimmed, "/"), requestSegments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func segmentsMatch(identitySegments, requestSegments []string) bool {
+	if len(identitySegments) > len(requestSegments) {
+		return false
+	}
+
+	for i, segment := range identitySegments {
+		if segment != "*" && segment != requestSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func claimsFromAuthorization(authorization string) (claims, bool) {
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	parts := strings.Split(token, ".")
+	if token == authorization || len(parts) != 3 {
+		return claims{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims{}, false
+	}
+
+	c := claims{}
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return claims{}, false
+	}
+
+	return c, true
+}
+
+// remoteAddress returns the address of the caller. The X-Forwarded-For header is honored only in requests sent by a trusted proxy,
+// in which case the caller is the right-most address which does not belong to a trusted proxy, as the addresses on its left
+// are set by the caller itself.
+func remoteAddress(r *http.Request, trustedProxies []*net.IPNet) string {
+	address, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		address = r.RemoteAddr
+	}
+
+	if !isTrusted(address, trustedProxies) {
+		return address
+	}
+
+	hops := strings.Split(strings.Join(r.Header[forwardedForHeader], ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+
+		address = hop
+		if !isTrusted(hop, trustedProxies) {
+			break
+		}
+	}
+
+	return address
+}
+
+func isTrusted(address string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
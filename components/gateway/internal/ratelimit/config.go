@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// Limit is the number of requests which a single client of a tenant can send to a component in a time window.
+// A limit without a client type applies to the clients of every type for which there is no dedicated limit.
+type Limit struct {
+	Path       string   `json:"path"`
+	ClientType string   `json:"clientType"`
+	Requests   int64    `json:"requests"`
+	Window     Duration `json:"window"`
+}
+
+// Duration is a time.Duration which is written as a string, such as "1m"
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.Wrap(err, "while decoding duration")
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.Wrapf(err, "while parsing duration %s", s)
+	}
+
+	d.Duration = duration
+	return nil
+}
+
+// LoadLimits reads the limits from a YAML file
+func LoadLimits(srcPath string) ([]Limit, error) {
+	limitsBytes, err := ioutil.ReadFile(srcPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading rate limits file")
+	}
+
+	var limits []Limit
+	if err := yaml.UnmarshalStrict(limitsBytes, &limits, yaml.DisallowUnknownFields); err != nil {
+		return nil, errors.Wrap(err, "while unmarshalling rate limits YAML")
+	}
+
+	for _, limit := range limits {
+		if err := limit.validate(); err != nil {
+			return nil, errors.Wrapf(err, "while validating rate limit for path %s and client type %q", limit.Path, limit.ClientType)
+		}
+	}
+
+	return limits, nil
+}
+
+func (l Limit) validate() error {
+	if l.Path == "" {
+		return errors.New("path cannot be empty")
+	}
+	if l.Requests <= 0 {
+		return fmt.Errorf("requests must be positive, got %d", l.Requests)
+	}
+	if l.Window.Duration <= 0 {
+		return fmt.Errorf("window must be positive, got %s", l.Window.Duration)
+	}
+
+	return nil
+}
+
+// ParseTrustedProxies parses the addresses of the proxies whose X-Forwarded-For header is honored. Both IP addresses and CIDR ranges are accepted.
+func ParseTrustedProxies(values []string) ([]*net.IPNet, error) {
+	proxies := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy address %s", value)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, proxy, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing trusted proxy range %s", value)
+		}
+		proxies = append(proxies, proxy)
+	}
+
+	return proxies, nil
+}
@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLimits(t *testing.T) {
+	t.Run("should load limits", func(t *testing.T) {
+		// given
+		path := writeTempFile(t, `
+- path: /director
+  clientType: Runtime
+  requests: 100
+  window: 1m
+- path: /director
+  requests: 1000
+  window: 1m
+`)
+		defer os.Remove(path)
+
+		// when
+		limits, err := LoadLimits(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []Limit{
+			{Path: "/director", ClientType: "Runtime", Requests: 100, Window: Duration{time.Minute}},
+			{Path: "/director", Requests: 1000, Window: Duration{time.Minute}},
+		}, limits)
+	})
+
+	t.Run("should return error when limit is invalid", func(t *testing.T) {
+		// given
+		path := writeTempFile(t, `
+- path: /director
+  requests: 0
+  window: 1m
+`)
+		defer os.Remove(path)
+
+		// when
+		_, err := LoadLimits(path)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requests must be positive")
+	})
+
+	t.Run("should return error when field is unknown", func(t *testing.T) {
+		// given
+		path := writeTempFile(t, `
+- path: /director
+  requests: 10
+  window: 1m
+  burst: 5
+`)
+		defer os.Remove(path)
+
+		// when
+		_, err := LoadLimits(path)
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	t.Run("should parse addresses and ranges", func(t *testing.T) {
+		// when
+		proxies, err := ParseTrustedProxies([]string{"10.1.0.0/16", " 127.0.0.1", "::1", ""})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, proxies, 3)
+		assert.Equal(t, "10.1.0.0/16", proxies[0].String())
+		assert.Equal(t, "127.0.0.1/32", proxies[1].String())
+		assert.Equal(t, "::1/128", proxies[2].String())
+	})
+
+	t.Run("should return error when address is invalid", func(t *testing.T) {
+		// when
+		_, err := ParseTrustedProxies([]string{"10.1.0"})
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func writeTempFile(t *testing.T, content string) string {
+	file, err := ioutil.TempFile("", "rate-limits-*.yaml")
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+
+	return file.Name()
+}
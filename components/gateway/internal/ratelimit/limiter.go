@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+	"github.com/pkg/errors"
+)
+
+const (
+	headerRetryAfter         = "Retry-After"
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+)
+
+// Limiter rejects the requests of clients which exceeded their limits
+type Limiter struct {
+	store          Store
+	limits         []Limit
+	trustedProxies []*net.IPNet
+	identityPaths  []string
+}
+
+// NewLimiter returns the Limiter, which honors the X-Forwarded-For header only in requests sent by the trusted proxies,
+// and identifies the clients by the ID token and the client ID headers only on the identity paths
+func NewLimiter(store Store, limits []Limit, trustedProxies []*net.IPNet, identityPaths []string) *Limiter {
+	return &Limiter{store: store, limits: limits, trustedProxies: trustedProxies, identityPaths: identityPaths}
+}
+
+// Middleware limits the requests to every route of the router. The request is counted against the limit of the longest path
+// which prefixes the request path.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := clientFromRequest(r, l.trustedProxies, l.identityPaths)
+		limit, ok := l.limitFor(r.URL.Path, client.Type)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := strings.Join([]string{"ratelimit", limit.Path, client.Tenant, client.Type, client.ID}, ":")
+		count, resetIn, err := l.store.Increment(key, limit.Window.Duration)
+		if err != nil {
+			log.Println(errors.Wrapf(err, "while counting requests of client %s, letting the request through", client.ID).Error())
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		remaining := limit.Requests - count
+		if remaining < 0 {
+			remaining = 0
+		}
+		w.Header().Set(headerRateLimitLimit, strconv.FormatInt(limit.Requests, 10))
+		w.Header().Set(headerRateLimitRemaining, strconv.FormatInt(remaining, 10))
+
+		if count > limit.Requests {
+			retryAfter := int64(math.Ceil(resetIn.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			w.Header().Set(headerRetryAfter, strconv.FormatInt(retryAfter, 10))
+			httputils.RespondWithBody(w, http.StatusTooManyRequests, httputils.ErrorResponse{
+				Code:  http.StatusTooManyRequests,
+				Error: fmt.Sprintf("rate limit of %d requests per %s exceeded", limit.Requests, limit.Window.Duration),
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitFor returns the limit of the longest path matching the request path. Among the limits of that path,
+// the limit for the client type is preferred over the limit without a client type.
+func (l *Limiter) limitFor(requestPath, clientType string) (Limit, bool) {
+	var found *Limit
+	for i, limit := range l.limits {
+		if !pathMatches(limit.Path, requestPath) || (limit.ClientType != "" && limit.ClientType != clientType) {
+			continue
+		}
+		if found == nil || len(limit.Path) > len(found.Path) || (len(limit.Path) == len(found.Path) && found.ClientType == "" && limit.ClientType != "") {
+			found = &l.limits[i]
+		}
+	}
+
+	if found == nil {
+		return Limit{}, false
+	}
+
+	return *found, true
+}
+
+// pathMatches reports whether the limit path is the request path or one of its parent paths
+func pathMatches(limitPath, requestPath string) bool {
+	prefix := strings.TrimSuffix(limitPath, "/")
+	return requestPath == prefix || strings.HasPrefix(requestPath, prefix+"/")
+}
@@ -0,0 +1,260 @@
+package ratelimit
+
+import (
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tenant = "3e64ebae-38b5-46a0-b1ed-9ccee153a0ae"
+
+var allPaths = []string{"/"}
+
+func TestLimiter_Middleware(t *testing.T) {
+	limits := []Limit{
+		{Path: "/director", ClientType: "Runtime", Requests: 2, Window: Duration{time.Minute}},
+		{Path: "/director", Requests: 3, Window: Duration{time.Minute}},
+	}
+
+	t.Run("should reject requests over the limit of client type with Retry-After", func(t *testing.T) {
+		// given
+		handler := NewLimiter(NewMemoryStore(), limits, nil, allPaths).Middleware(okHandler())
+		token := fixToken(`{"tenant":"` + tenant + `","objectID":"runtime-1","objectType":"Runtime"}`)
+
+		// when
+		codes := sendRequests(handler, 3, token)
+		rr := sendRequest(handler, token)
+
+		// then
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "60", rr.Header().Get(headerRetryAfter))
+		assert.Equal(t, "2", rr.Header().Get(headerRateLimitLimit))
+		assert.Equal(t, "0", rr.Header().Get(headerRateLimitRemaining))
+		assert.Contains(t, rr.Body.String(), "rate limit of 2 requests per 1m0s exceeded")
+	})
+
+	t.Run("should count requests of every client separately", func(t *testing.T) {
+		// given
+		handler := NewLimiter(NewMemoryStore(), limits, nil, allPaths).Middleware(okHandler())
+
+		// when
+		first := sendRequests(handler, 3, fixToken(`{"tenant":"`+tenant+`","objectID":"runtime-1","objectType":"Runtime"}`))
+		second := sendRequests(handler, 2, fixToken(`{"tenant":"`+tenant+`","objectID":"runtime-2","objectType":"Runtime"}`))
+
+		// then
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, first)
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK}, second)
+	})
+
+	t.Run("should use limit without client type for other clients", func(t *testing.T) {
+		// given
+		handler := NewLimiter(NewMemoryStore(), limits, nil, allPaths).Middleware(okHandler())
+		token := fixToken(`{"tenant":"` + tenant + `","objectID":"app-1","objectType":"Application"}`)
+
+		// when
+		codes := sendRequests(handler, 4, token)
+
+		// then
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	})
+
+	t.Run("should not limit paths without limits", func(t *testing.T) {
+		// given
+		handler := NewLimiter(NewMemoryStore(), limits, nil, allPaths).Middleware(okHandler())
+
+		// when
+		codes := sendRequestsTo(handler, "/connector/graphql", 4, "")
+
+		// then
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK}, codes)
+	})
+
+	t.Run("should apply limit of the longest matching path", func(t *testing.T) {
+		// given
+		limits := []Limit{
+			{Path: "/", Requests: 1, Window: Duration{time.Minute}},
+			{Path: "/director", Requests: 3, Window: Duration{time.Minute}},
+		}
+		handler := NewLimiter(NewMemoryStore(), limits, nil, allPaths).Middleware(okHandler())
+
+		// when
+		director := sendRequestsTo(handler, "/director/graphql", 3, "")
+		legacy := sendRequestsTo(handler, "/app/v1/metadata/services", 2, "")
+		similar := sendRequestsTo(handler, "/directory", 1, "")
+
+		// then
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusOK}, director)
+		assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, legacy)
+		assert.Equal(t, []int{http.StatusTooManyRequests}, similar)
+	})
+
+	t.Run("should let requests through when store fails", func(t *testing.T) {
+		// given
+		handler := NewLimiter(&failingStore{}, limits, nil, allPaths).Middleware(okHandler())
+
+		// when
+		codes := sendRequests(handler, 4, "")
+
+		// then
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK}, codes)
+	})
+}
+
+func TestClientFromRequest(t *testing.T) {
+	t.Run("should read client from ID token claims", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/director/graphql", nil)
+		req.Header.Set("Authorization", fixToken(`{"tenant":"`+tenant+`","objectID":"runtime-1","objectType":"Runtime"}`))
+
+		// when
+		client := clientFromRequest(req, nil, allPaths)
+
+		// then
+		assert.Equal(t, Client{Tenant: tenant, Type: "Runtime", ID: "runtime-1"}, client)
+	})
+
+	t.Run("should read client ID from certificate header", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/connector/graphql", nil)
+		req.Header.Set(clientIDFromCertificateHeader, "app-1")
+
+		// when
+		client := clientFromRequest(req, nil, allPaths)
+
+		// then
+		assert.Equal(t, Client{ID: "app-1"}, client)
+	})
+
+	t.Run("should use caller address when request is anonymous", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/connector/graphql", nil)
+		req.RemoteAddr = "10.0.0.1:41234"
+		req.Header.Set("Authorization", "Bearer not-a-jwt")
+
+		// when
+		client := clientFromRequest(req, nil, allPaths)
+
+		// then
+		assert.Equal(t, Client{ID: "10.0.0.1"}, client)
+	})
+
+	t.Run("should ignore X-Forwarded-For header sent by untrusted caller", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/connector/graphql", nil)
+		req.RemoteAddr = "192.168.1.10:41234"
+		req.Header.Set(forwardedForHeader, "10.0.0.1")
+
+		// when
+		client := clientFromRequest(req, trustedProxies(t, "10.1.0.0/16"), allPaths)
+
+		// then
+		assert.Equal(t, Client{ID: "192.168.1.10"}, client)
+	})
+
+	t.Run("should use right-most untrusted address from X-Forwarded-For header of trusted proxy", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/connector/graphql", nil)
+		req.RemoteAddr = "10.1.0.5:41234"
+		req.Header.Add(forwardedForHeader, "1.2.3.4, 192.168.1.10")
+		req.Header.Add(forwardedForHeader, "10.1.0.7")
+
+		// when
+		client := clientFromRequest(req, trustedProxies(t, "10.1.0.0/16"), allPaths)
+
+		// then
+		assert.Equal(t, Client{ID: "192.168.1.10"}, client)
+	})
+
+	t.Run("should use caller address instead of ID token and client ID headers outside identity paths", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/v1/applications/certificates", nil)
+		req.RemoteAddr = "10.0.0.1:41234"
+		req.Header.Set("Authorization", fixToken(`{"tenant":"`+tenant+`","objectID":"runtime-1","objectType":"Runtime"}`))
+		req.Header.Set(clientIDFromTokenHeader, "app-1")
+
+		// when
+		client := clientFromRequest(req, nil, []string{"/director/graphql", "/connector/graphql"})
+
+		// then
+		assert.Equal(t, Client{ID: "10.0.0.1"}, client)
+	})
+
+	t.Run("should match any segment of identity path with wildcard", func(t *testing.T) {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/app/v1/metadata/services/svc-1", nil)
+		req.Header.Set(clientIDFromCertificateHeader, "app-1")
+
+		// when
+		client := clientFromRequest(req, nil, []string{"/*/v1/metadata/services"})
+
+		// then
+		assert.Equal(t, Client{ID: "app-1"}, client)
+	})
+}
+
+func trustedProxies(t *testing.T, values ...string) []*net.IPNet {
+	proxies, err := ParseTrustedProxies(values)
+	require.NoError(t, err)
+
+	return proxies
+}
+
+type failingStore struct{}
+
+func (s *failingStore) Increment(string, time.Duration) (int64, time.Duration, error) {
+	return 0, 0, errors.New("test error")
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func sendRequests(handler http.Handler, n int, authorization string) []int {
+	codes := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		codes = append(codes, sendRequest(handler, authorization).Code)
+	}
+
+	return codes
+}
+
+func sendRequestsTo(handler http.Handler, path string, n int, authorization string) []int {
+	codes := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		codes = append(codes, sendRequestTo(handler, path, authorization).Code)
+	}
+
+	return codes
+}
+
+func sendRequest(handler http.Handler, authorization string) *httptest.ResponseRecorder {
+	return sendRequestTo(handler, "/director/graphql", authorization)
+}
+
+func sendRequestTo(handler http.Handler, path string, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func fixToken(claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	return "Bearer " + header + "." + payload + ".signature"
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+// incrementScript increments the counter and starts its window atomically, so the counters are consistent across Gateway replicas
+const incrementScript = `local count = redis.call('INCR', KEYS[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}`
+
+const (
+	maxIdleRedisConnections = 16
+	redisIdleTimeout        = 5 * time.Minute
+)
+
+type redisStore struct {
+	pool   *redis.Pool
+	script *redis.Script
+}
+
+// NewRedisStore returns a Store which keeps the counters in Redis, so the limits hold across Gateway replicas
+func NewRedisStore(address, password string, timeout time.Duration) Store {
+	return &redisStore{
+		pool: &redis.Pool{
+			MaxIdle:     maxIdleRedisConnections,
+			IdleTimeout: redisIdleTimeout,
+			Dial: func() (redis.Conn, error) {
+				return redis.Dial("tcp", address,
+					redis.DialPassword(password),
+					redis.DialConnectTimeout(timeout),
+					redis.DialReadTimeout(timeout),
+					redis.DialWriteTimeout(timeout),
+				)
+			},
+		},
+		script: redis.NewScript(1, incrementScript),
+	}
+}
+
+func (s *redisStore) Increment(key string, window time.Duration) (int64, time.Duration, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	values, err := redis.Int64s(s.script.Do(conn, key, int64(window/time.Millisecond)))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "while incrementing counter in Redis")
+	}
+	if len(values) != 2 {
+		return 0, 0, fmt.Errorf("unexpected Redis reply %v", values)
+	}
+
+	return values[0], time.Duration(values[1]) * time.Millisecond, nil
+}
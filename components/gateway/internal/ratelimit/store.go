@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Store counts requests in fixed time windows. The window of a key starts with its first request.
+type Store interface {
+	// Increment counts a request and returns the number of requests in the current window and the time left until the window ends
+	Increment(key string, window time.Duration) (int64, time.Duration, error)
+}
+
+type memoryWindow struct {
+	count     int64
+	expiresAt time.Time
+}
+
+type memoryStore struct {
+	mutex     sync.Mutex
+	windows   map[string]memoryWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore returns a Store which keeps the counters of a single Gateway replica
+func NewMemoryStore() Store {
+	return &memoryStore{
+		windows: make(map[string]memoryWindow),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Increment(key string, window time.Duration) (int64, time.Duration, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.sweep(now, window)
+
+	current, ok := s.windows[key]
+	if !ok || !now.Before(current.expiresAt) {
+		current = memoryWindow{expiresAt: now.Add(window)}
+	}
+	current.count++
+	s.windows[key] = current
+
+	return current.count, current.expiresAt.Sub(now), nil
+}
+
+// sweep removes the expired windows, at most once per window
+func (s *memoryStore) sweep(now time.Time, window time.Duration) {
+	if now.Sub(s.lastSweep) < window {
+		return
+	}
+
+	for key, w := range s.windows {
+		if !now.Before(w.expiresAt) {
+			delete(s.windows, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Increment(t *testing.T) {
+	t.Run("should count requests in window and start new window when it ends", func(t *testing.T) {
+		// given
+		now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+		store := &memoryStore{windows: make(map[string]memoryWindow), now: func() time.Time { return now }}
+
+		// when
+		first, _, err := store.Increment("key", time.Minute)
+		require.NoError(t, err)
+		now = now.Add(20 * time.Second)
+		second, resetIn, err := store.Increment("key", time.Minute)
+		require.NoError(t, err)
+		now = now.Add(40 * time.Second)
+		third, _, err := store.Increment("key", time.Minute)
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, int64(1), first)
+		assert.Equal(t, int64(2), second)
+		assert.Equal(t, 40*time.Second, resetIn)
+		assert.Equal(t, int64(1), third)
+	})
+}
+
+func TestRedisStore_Increment(t *testing.T) {
+	t.Run("should load increment script and read counter", func(t *testing.T) {
+		// given
+		commands := make(chan []string, 3)
+		address := fakeRedis(t, commands, "+OK\r\n", "-NOSCRIPT No matching script\r\n", "*2\r\n:3\r\n:59000\r\n")
+		store := NewRedisStore(address, "secret", time.Second)
+
+		// when
+		count, resetIn, err := store.Increment("ratelimit:/director", time.Minute)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, 59*time.Second, resetIn)
+		assert.Equal(t, []string{"AUTH", "secret"}, <-commands)
+		evalSHA := <-commands
+		require.Len(t, evalSHA, 5)
+		assert.Equal(t, "EVALSHA", evalSHA[0])
+		eval := <-commands
+		require.Len(t, eval, 5)
+		assert.Equal(t, "EVAL", eval[0])
+		assert.Equal(t, incrementScript, eval[1])
+		assert.Equal(t, []string{"1", "ratelimit:/director", "60000"}, eval[2:])
+	})
+
+	t.Run("should return Redis error", func(t *testing.T) {
+		// given
+		commands := make(chan []string, 1)
+		address := fakeRedis(t, commands, "-ERR test error\r\n")
+		store := NewRedisStore(address, "", time.Second)
+
+		// when
+		_, _, err := store.Increment("key", time.Minute)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ERR test error")
+	})
+}
+
+// fakeRedis accepts a single connection, records the commands and answers them with the given replies
+func fakeRedis(t *testing.T, commands chan<- []string, replies ...string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		for _, reply := range replies {
+			command, err := readCommand(reader)
+			if err != nil {
+				return
+			}
+			commands <- command
+			if _, err := conn.Write([]byte(reply)); err != nil {
+				return
+			}
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// readCommand reads a command sent as an array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	var length int
+	if _, err := fmt.Fscanf(reader, "*%d\r\n", &length); err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, length)
+	for i := 0; i < length; i++ {
+		var argLength int
+		if _, err := fmt.Fscanf(reader, "$%d\r\n", &argLength); err != nil {
+			return nil, err
+		}
+		arg := make([]byte, argLength+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		args = append(args, string(arg[:argLength]))
+	}
+
+	return args, nil
+}
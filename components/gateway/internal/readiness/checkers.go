@@ -0,0 +1,31 @@
+package readiness
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/healthz"
+	"github.com/pkg/errors"
+)
+
+// NewUpstreamChecker returns the Checker which fails if the upstream component does not respond.
+// Any HTTP response means that the component is reachable, regardless of its status code.
+func NewUpstreamChecker(client *http.Client, url string) healthz.Checker {
+	return healthz.CheckerFunc(func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return errors.Wrapf(err, "while creating request to %s", url)
+		}
+
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return errors.Wrapf(err, "while calling %s", url)
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+		return nil
+	})
+}
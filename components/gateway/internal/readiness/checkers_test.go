@@ -0,0 +1,43 @@
+package readiness
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamChecker(t *testing.T) {
+	t.Run("should succeed when upstream responds", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		checker := NewUpstreamChecker(server.Client(), server.URL)
+
+		// when
+		err := checker.Check(context.Background())
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when upstream is unreachable", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := server.URL
+		server.Close()
+		checker := NewUpstreamChecker(http.DefaultClient, url)
+
+		// when
+		err := checker.Check(context.Background())
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while calling "+url)
+	})
+}
@@ -0,0 +1,449 @@
+package stitching
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+const defaultDeprecationReason = "No longer supported"
+
+// object is a value of the introspection type system, which is resolved by the Gateway instead of the backends
+type object interface {
+	typeName() string
+	// field returns nil, a scalar, an object or a list of objects
+	field(name string, args map[string]interface{}) interface{}
+}
+
+// executor resolves the introspection fields of an operation against the stitched schema
+type executor struct {
+	schema    *ast.Schema
+	fragments ast.FragmentDefinitionList
+	variables map[string]interface{}
+}
+
+func (e *executor) executeObject(selections ast.SelectionSet, obj object) *orderedMap {
+	result := newOrderedMap()
+	fields := make(map[string][]*ast.Field)
+	keys := e.collectFields(selections, obj.typeName(), nil, fields)
+
+	for _, key := range keys {
+		field := fields[key][0]
+		if field.Name == "__typename" {
+			result.set(key, obj.typeName())
+			continue
+		}
+
+		var subSelections ast.SelectionSet
+		for _, f := range fields[key] {
+			subSelections = append(subSelections, f.SelectionSet...)
+		}
+
+		value := obj.field(field.Name, field.ArgumentMap(e.variables))
+		result.set(key, e.completeValue(subSelections, value))
+	}
+
+	return result
+}
+
+func (e *executor) completeValue(selections ast.SelectionSet, value interface{}) interface{} {
+	switch v := value.(type) {
+	case object:
+		return e.executeObject(selections, v)
+	case []object:
+		list := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			list = append(list, e.executeObject(selections, item))
+		}
+		return list
+	default:
+		return v
+	}
+}
+
+// collectFields groups the fields of the selection set by their response keys, in the order in which the keys appear
+func (e *executor) collectFields(selections ast.SelectionSet, typeName string, keys []string, fields map[string][]*ast.Field) []string {
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if !e.included(s.Directives) {
+				continue
+			}
+			if _, exists := fields[s.Alias]; !exists {
+				keys = append(keys, s.Alias)
+			}
+			fields[s.Alias] = append(fields[s.Alias], s)
+		case *ast.FragmentSpread:
+			fragment := e.fragments.ForName(s.Name)
+			if !e.included(s.Directives) || fragment == nil || fragment.TypeCondition != typeName {
+				continue
+			}
+			keys = e.collectFields(fragment.SelectionSet, typeName, keys, fields)
+		case *ast.InlineFragment:
+			if !e.included(s.Directives) || (s.TypeCondition != "" && s.TypeCondition != typeName) {
+				continue
+			}
+			keys = e.collectFields(s.SelectionSet, typeName, keys, fields)
+		}
+	}
+
+	return keys
+}
+
+// included evaluates the @skip and @include directives
+func (e *executor) included(directives ast.DirectiveList) bool {
+	if skip := directives.ForName("skip"); skip != nil && e.directiveCondition(skip) {
+		return false
+	}
+	if include := directives.ForName("include"); include != nil && !e.directiveCondition(include) {
+		return false
+	}
+
+	return true
+}
+
+func (e *executor) directiveCondition(directive *ast.Directive) bool {
+	arg := directive.Arguments.ForName("if")
+	if arg == nil || arg.Value == nil {
+		return false
+	}
+
+	if arg.Value.Kind == ast.Variable {
+		condition, _ := e.variables[arg.Value.Raw].(bool)
+		return condition
+	}
+
+	return arg.Value.Raw == "true"
+}
+
+type rootObject struct {
+	schema *ast.Schema
+	name   string
+}
+
+func (o rootObject) typeName() string {
+	return o.name
+}
+
+func (o rootObject) field(name string, args map[string]interface{}) interface{} {
+	switch name {
+	case "__schema":
+		return schemaObject{schema: o.schema}
+	case "__type":
+		typeName, _ := args["name"].(string)
+		def, ok := o.schema.Types[typeName]
+		if !ok {
+			return nil
+		}
+		return namedTypeObject{schema: o.schema, def: def}
+	}
+
+	return nil
+}
+
+type schemaObject struct {
+	schema *ast.Schema
+}
+
+func (o schemaObject) typeName() string {
+	return "__Schema"
+}
+
+func (o schemaObject) field(name string, _ map[string]interface{}) interface{} {
+	switch name {
+	case "types":
+		names := make([]string, 0, len(o.schema.Types))
+		for typeName := range o.schema.Types {
+			names = append(names, typeName)
+		}
+		sort.Strings(names)
+
+		types := make([]object, 0, len(names))
+		for _, typeName := range names {
+			types = append(types, namedTypeObject{schema: o.schema, def: o.schema.Types[typeName]})
+		}
+		return types
+	case "queryType":
+		return definitionObject(o.schema, o.schema.Query)
+	case "mutationType":
+		return definitionObject(o.schema, o.schema.Mutation)
+	case "subscriptionType":
+		return definitionObject(o.schema, o.schema.Subscription)
+	case "directives":
+		names := make([]string, 0, len(o.schema.Directives))
+		for directiveName := range o.schema.Directives {
+			names = append(names, directiveName)
+		}
+		sort.Strings(names)
+
+		directives := make([]object, 0, len(names))
+		for _, directiveName := range names {
+			directives = append(directives, directiveObject{schema: o.schema, def: o.schema.Directives[directiveName]})
+		}
+		return directives
+	}
+
+	return nil
+}
+
+func definitionObject(schema *ast.Schema, def *ast.Definition) interface{} {
+	if def == nil {
+		return nil
+	}
+
+	return namedTypeObject{schema: schema, def: def}
+}
+
+func typeObject(schema *ast.Schema, typ *ast.Type) object {
+	if typ.NonNull {
+		nullable := *typ
+		nullable.NonNull = false
+		return wrappingTypeObject{schema: schema, kind: "NON_NULL", ofType: &nullable}
+	}
+	if typ.Elem != nil {
+		return wrappingTypeObject{schema: schema, kind: "LIST", ofType: typ.Elem}
+	}
+
+	return namedTypeObject{schema: schema, def: schema.Types[typ.NamedType]}
+}
+
+type wrappingTypeObject struct {
+	schema *ast.Schema
+	kind   string
+	ofType *ast.Type
+}
+
+func (o wrappingTypeObject) typeName() string {
+	return "__Type"
+}
+
+func (o wrappingTypeObject) field(name string, _ map[string]interface{}) interface{} {
+	switch name {
+	case "kind":
+		return o.kind
+	case "ofType":
+		return typeObject(o.schema, o.ofType)
+	}
+
+	return nil
+}
+
+type namedTypeObject struct {
+	schema *ast.Schema
+	def    *ast.Definition
+}
+
+func (o namedTypeObject) typeName() string {
+	return "__Type"
+}
+
+func (o namedTypeObject) field(name string, args map[string]interface{}) interface{} {
+	includeDeprecated, _ := args["includeDeprecated"].(bool)
+
+	switch name {
+	case "kind":
+		return string(o.def.Kind)
+	case "name":
+		return o.def.Name
+	case "description":
+		return optionalString(o.def.Description)
+	case "fields":
+		if o.def.Kind != ast.Object && o.def.Kind != ast.Interface {
+			return nil
+		}
+		fields := make([]object, 0, len(o.def.Fields))
+		for _, f := range o.def.Fields {
+			if strings.HasPrefix(f.Name, "__") || (!includeDeprecated && isDeprecated(f.Directives)) {
+				continue
+			}
+			fields = append(fields, fieldObject{schema: o.schema, def: f})
+		}
+		return fields
+	case "interfaces":
+		if o.def.Kind != ast.Object {
+			return nil
+		}
+		interfaces := make([]object, 0, len(o.def.Interfaces))
+		for _, i := range o.def.Interfaces {
+			interfaces = append(interfaces, namedTypeObject{schema: o.schema, def: o.schema.Types[i]})
+		}
+		return interfaces
+	case "possibleTypes":
+		if o.def.Kind != ast.Interface && o.def.Kind != ast.Union {
+			return nil
+		}
+		possibleTypes := make([]object, 0)
+		for _, def := range o.schema.GetPossibleTypes(o.def) {
+			possibleTypes = append(possibleTypes, namedTypeObject{schema: o.schema, def: def})
+		}
+		return possibleTypes
+	case "enumValues":
+		if o.def.Kind != ast.Enum {
+			return nil
+		}
+		values := make([]object, 0, len(o.def.EnumValues))
+		for _, v := range o.def.EnumValues {
+			if !includeDeprecated && isDeprecated(v.Directives) {
+				continue
+			}
+			values = append(values, enumValueObject{def: v})
+		}
+		return values
+	case "inputFields":
+		if o.def.Kind != ast.InputObject {
+			return nil
+		}
+		inputFields := make([]object, 0, len(o.def.Fields))
+		for _, f := range o.def.Fields {
+			inputFields = append(inputFields, inputValueObject{schema: o.schema, name: f.Name, description: f.Description, typ: f.Type, defaultValue: f.DefaultValue})
+		}
+		return inputFields
+	}
+
+	return nil
+}
+
+type fieldObject struct {
+	schema *ast.Schema
+	def    *ast.FieldDefinition
+}
+
+func (o fieldObject) typeName() string {
+	return "__Field"
+}
+
+func (o fieldObject) field(name string, _ map[string]interface{}) interface{} {
+	switch name {
+	case "name":
+		return o.def.Name
+	case "description":
+		return optionalString(o.def.Description)
+	case "args":
+		return argumentObjects(o.schema, o.def.Arguments)
+	case "type":
+		return typeObject(o.schema, o.def.Type)
+	case "isDeprecated":
+		return isDeprecated(o.def.Directives)
+	case "deprecationReason":
+		return deprecationReason(o.def.Directives)
+	}
+
+	return nil
+}
+
+type inputValueObject struct {
+	schema       *ast.Schema
+	name         string
+	description  string
+	typ          *ast.Type
+	defaultValue *ast.Value
+}
+
+func (o inputValueObject) typeName() string {
+	return "__InputValue"
+}
+
+func (o inputValueObject) field(name string, _ map[string]interface{}) interface{} {
+	switch name {
+	case "name":
+		return o.name
+	case "description":
+		return optionalString(o.description)
+	case "type":
+		return typeObject(o.schema, o.typ)
+	case "defaultValue":
+		if o.defaultValue == nil {
+			return nil
+		}
+		return printValue(o.defaultValue)
+	}
+
+	return nil
+}
+
+type enumValueObject struct {
+	def *ast.EnumValueDefinition
+}
+
+func (o enumValueObject) typeName() string {
+	return "__EnumValue"
+}
+
+func (o enumValueObject) field(name string, _ map[string]interface{}) interface{} {
+	switch name {
+	case "name":
+		return o.def.Name
+	case "description":
+		return optionalString(o.def.Description)
+	case "isDeprecated":
+		return isDeprecated(o.def.Directives)
+	case "deprecationReason":
+		return deprecationReason(o.def.Directives)
+	}
+
+	return nil
+}
+
+type directiveObject struct {
+	schema *ast.Schema
+	def    *ast.DirectiveDefinition
+}
+
+func (o directiveObject) typeName() string {
+	return "__Directive"
+}
+
+func (o directiveObject) field(name string, _ map[string]interface{}) interface{} {
+	switch name {
+	case "name":
+		return o.def.Name
+	case "description":
+		return optionalString(o.def.Description)
+	case "locations":
+		locations := make([]string, 0, len(o.def.Locations))
+		for _, l := range o.def.Locations {
+			locations = append(locations, string(l))
+		}
+		return locations
+	case "args":
+		return argumentObjects(o.schema, o.def.Arguments)
+	}
+
+	return nil
+}
+
+func argumentObjects(schema *ast.Schema, args ast.ArgumentDefinitionList) []object {
+	objects := make([]object, 0, len(args))
+	for _, arg := range args {
+		objects = append(objects, inputValueObject{schema: schema, name: arg.Name, description: arg.Description, typ: arg.Type, defaultValue: arg.DefaultValue})
+	}
+
+	return objects
+}
+
+func isDeprecated(directives ast.DirectiveList) bool {
+	return directives.ForName("deprecated") != nil
+}
+
+func deprecationReason(directives ast.DirectiveList) interface{} {
+	deprecated := directives.ForName("deprecated")
+	if deprecated == nil {
+		return nil
+	}
+
+	reason := deprecated.Arguments.ForName("reason")
+	if reason == nil || reason.Value == nil {
+		return defaultDeprecationReason
+	}
+
+	return reason.Value.Raw
+}
+
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
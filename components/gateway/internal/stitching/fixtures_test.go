@@ -0,0 +1,146 @@
+package stitching
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/graphqlclient"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend is a GraphQL server which answers the introspection query with its schema and every other query with the given data
+type fakeBackend struct {
+	server *httptest.Server
+	schema introspectionSchema
+	data   string
+	errors string
+
+	mutex    sync.Mutex
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	body   requestBody
+	header http.Header
+}
+
+func newFakeBackend(t *testing.T, schema introspectionSchema, data string) *fakeBackend {
+	backend := &fakeBackend{schema: schema, data: data}
+	backend.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := requestBody{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		if strings.Contains(body.Query, "IntrospectionQuery") {
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": introspectionResult{Schema: backend.schema},
+			}))
+			return
+		}
+
+		backend.mutex.Lock()
+		backend.requests = append(backend.requests, recordedRequest{body: body, header: r.Header})
+		backend.mutex.Unlock()
+
+		resp := `{"data":` + backend.data
+		if backend.errors != "" {
+			resp += `,"errors":` + backend.errors
+		}
+		_, err := w.Write([]byte(resp + "}"))
+		require.NoError(t, err)
+	}))
+
+	return backend
+}
+
+func (b *fakeBackend) backend(name string) Backend {
+	return Backend{Name: name, Client: graphqlclient.New(b.server.URL, b.server.Client())}
+}
+
+type requestBody struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func fixDirectorSchema() introspectionSchema {
+	return introspectionSchema{
+		QueryType:    &namedTypeRef{Name: "Query"},
+		MutationType: &namedTypeRef{Name: "Mutation"},
+		Types: []introspectionType{
+			fixObjectType("Query",
+				fixField("applications", fixNonNull(fixList(fixNonNull(fixNamed("OBJECT", "Application"))))),
+				fixField("application", fixNamed("OBJECT", "Application"), fixArg("id", fixNonNull(fixNamed("SCALAR", "ID")))),
+			),
+			fixObjectType("Mutation",
+				fixField("registerApplication", fixNonNull(fixNamed("OBJECT", "Application")), fixArg("in", fixNonNull(fixNamed("INPUT_OBJECT", "ApplicationInput")))),
+			),
+			fixObjectType("Application",
+				fixField("id", fixNonNull(fixNamed("SCALAR", "ID"))),
+				fixField("name", fixNonNull(fixNamed("SCALAR", "String"))),
+			),
+			{
+				Kind:        "INPUT_OBJECT",
+				Name:        "ApplicationInput",
+				InputFields: []introspectionInputValue{fixArg("name", fixNonNull(fixNamed("SCALAR", "String")))},
+			},
+			fixScalarType("ID"),
+			fixScalarType("String"),
+			fixObjectType("__Schema"),
+		},
+	}
+}
+
+func fixConnectorSchema() introspectionSchema {
+	return introspectionSchema{
+		QueryType:    &namedTypeRef{Name: "Query"},
+		MutationType: &namedTypeRef{Name: "Mutation"},
+		Types: []introspectionType{
+			fixObjectType("Query",
+				fixField("configuration", fixNonNull(fixNamed("OBJECT", "Configuration"))),
+			),
+			fixObjectType("Mutation",
+				fixField("generateApplicationToken", fixNonNull(fixNamed("OBJECT", "Token")), fixArg("appID", fixNonNull(fixNamed("SCALAR", "ID")))),
+			),
+			fixObjectType("Configuration",
+				fixField("token", fixNamed("OBJECT", "Token")),
+			),
+			fixObjectType("Token",
+				fixField("token", fixNonNull(fixNamed("SCALAR", "String"))),
+			),
+			fixScalarType("ID"),
+			fixScalarType("String"),
+		},
+	}
+}
+
+func fixObjectType(name string, fields ...introspectionField) introspectionType {
+	return introspectionType{Kind: "OBJECT", Name: name, Fields: fields}
+}
+
+func fixScalarType(name string) introspectionType {
+	return introspectionType{Kind: "SCALAR", Name: name}
+}
+
+func fixField(name string, typ typeRef, args ...introspectionInputValue) introspectionField {
+	return introspectionField{Name: name, Type: typ, Args: args}
+}
+
+func fixArg(name string, typ typeRef) introspectionInputValue {
+	return introspectionInputValue{Name: name, Type: typ}
+}
+
+func fixNamed(kind, name string) typeRef {
+	return typeRef{Kind: kind, Name: &name}
+}
+
+func fixNonNull(ofType typeRef) typeRef {
+	return typeRef{Kind: "NON_NULL", OfType: &ofType}
+}
+
+func fixList(ofType typeRef) typeRef {
+	return typeRef{Kind: "LIST", OfType: &ofType}
+}
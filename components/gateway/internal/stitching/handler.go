@@ -0,0 +1,182 @@
+package stitching
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/graphqlclient"
+	"github.com/kyma-incubator/compass/components/gateway/internal/httputils"
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// skippedHeaders are not forwarded to the backends, as they describe the connection with the caller or the incoming body
+var skippedHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+	"Content-Length",
+	"Content-Type",
+	"Accept-Encoding",
+}
+
+// Request is the body of a GraphQL request
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the body of a GraphQL response
+type Response struct {
+	Data   interface{}   `json:"data"`
+	Errors []interface{} `json:"errors,omitempty"`
+}
+
+type responseError struct {
+	Message string `json:"message"`
+}
+
+// Handler serves the stitched schema. It sends the root fields to the backends which own them and merges their responses.
+type Handler struct {
+	schema  *Schema
+	clients map[string]graphqlclient.Client
+}
+
+func NewHandler(schema *Schema, backends []Backend) *Handler {
+	clients := make(map[string]graphqlclient.Client, len(backends))
+	for _, backend := range backends {
+		clients[backend.Name] = backend.Client
+	}
+
+	return &Handler{schema: schema, clients: clients}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := Request{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		respondWithErrors(w, http.StatusBadRequest, errors.Wrap(err, "while decoding GraphQL request"))
+		return
+	}
+
+	doc, gqlErrs := gqlparser.LoadQuery(h.schema.schema, req.Query)
+	if gqlErrs != nil {
+		errs := make([]interface{}, 0, len(gqlErrs))
+		for _, gqlErr := range gqlErrs {
+			errs = append(errs, gqlErr)
+		}
+		httputils.RespondWithBody(w, http.StatusUnprocessableEntity, Response{Errors: errs})
+		return
+	}
+
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		respondWithErrors(w, http.StatusUnprocessableEntity, fmt.Errorf("operation %q not found", req.OperationName))
+		return
+	}
+	if op.Operation == ast.Subscription {
+		respondWithErrors(w, http.StatusUnprocessableEntity, errors.New("subscriptions are not supported"))
+		return
+	}
+
+	operations, local, err := h.schema.plan(doc, op, req.Variables)
+	if err != nil {
+		respondWithErrors(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	ctx := graphqlclient.WithForwardedHeader(r.Context(), forwardedHeader(r.Header))
+
+	data := make(map[string]json.RawMessage)
+	dataIsNull := false
+	var errs []interface{}
+	for _, operation := range operations {
+		gqlReq := graphqlclient.NewRequest(operation.query)
+		gqlReq.OperationName = op.Name
+		gqlReq.Variables = operation.variables
+
+		resp, err := h.clients[operation.backend].Do(ctx, gqlReq)
+		if err != nil {
+			log.Println(errors.Wrapf(err, "while calling %s", operation.backend).Error())
+			errs = append(errs, responseError{Message: fmt.Sprintf("%s is unavailable", operation.backend)})
+			dataIsNull = true
+			continue
+		}
+
+		for _, respErr := range resp.Errors {
+			errs = append(errs, respErr)
+		}
+
+		backendData := make(map[string]json.RawMessage)
+		if len(resp.Data) == 0 || json.Unmarshal(resp.Data, &backendData) != nil || backendData == nil {
+			dataIsNull = true
+			continue
+		}
+		for key, value := range backendData {
+			data[key] = value
+		}
+	}
+
+	if dataIsNull {
+		httputils.RespondWithBody(w, http.StatusOK, Response{Data: nil, Errors: errs})
+		return
+	}
+
+	exec := &executor{schema: h.schema.schema, fragments: doc.Fragments, variables: req.Variables}
+	result := exec.executeObject(local, rootObject{schema: h.schema.schema, name: rootTypeName(op.Operation)})
+
+	httputils.RespondWithBody(w, http.StatusOK, Response{Data: mergeData(exec, op, data, result), Errors: errs})
+}
+
+// mergeData orders the data of the backends and the Gateway as the root selections of the operation
+func mergeData(exec *executor, op *ast.OperationDefinition, backendData map[string]json.RawMessage, localData *orderedMap) *orderedMap {
+	merged := newOrderedMap()
+	fields := make(map[string][]*ast.Field)
+	for _, key := range exec.collectFields(op.SelectionSet, rootTypeName(op.Operation), nil, fields) {
+		if value, ok := localData.values[key]; ok {
+			merged.set(key, value)
+		} else if value, ok := backendData[key]; ok {
+			merged.set(key, value)
+		}
+	}
+
+	var remainingKeys []string
+	for key := range backendData {
+		if _, ok := merged.values[key]; !ok {
+			remainingKeys = append(remainingKeys, key)
+		}
+	}
+	sort.Strings(remainingKeys)
+	for _, key := range remainingKeys {
+		merged.set(key, backendData[key])
+	}
+
+	return merged
+}
+
+func forwardedHeader(header http.Header) http.Header {
+	forwarded := make(http.Header, len(header))
+	for key, values := range header {
+		forwarded[key] = values
+	}
+	for _, key := range skippedHeaders {
+		forwarded.Del(key)
+	}
+
+	return forwarded
+}
+
+func respondWithErrors(w http.ResponseWriter, status int, err error) {
+	log.Println(err.Error())
+	httputils.RespondWithBody(w, status, Response{Errors: []interface{}{responseError{Message: err.Error()}}})
+}
@@ -0,0 +1,183 @@
+package stitching
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Run("should send root fields to their backends and merge responses", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `{"apps":[{"id":"1"}]}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, fixConnectorSchema(), `{"configuration":{"token":{"token":"abc"}}}`)
+		defer connector.server.Close()
+		handler := newHandler(t, director, connector)
+		query := `query Fetch { configuration { token { token } } apps: applications { ...App } }
+fragment App on Application { id }`
+
+		// when
+		rr := serveRequest(handler, `{"query":`+quote(query)+`,"operationName":"Fetch"}`, "Bearer token")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data":{"configuration":{"token":{"token":"abc"}},"apps":[{"id":"1"}]}}`, rr.Body.String())
+		assert.True(t, strings.HasPrefix(rr.Body.String(), `{"data":{"configuration"`))
+
+		require.Len(t, director.requests, 1)
+		assert.Contains(t, director.requests[0].body.Query, "apps: applications {\n\t\t...App\n\t}")
+		assert.Contains(t, director.requests[0].body.Query, "fragment App on Application")
+		assert.NotContains(t, director.requests[0].body.Query, "configuration")
+		assert.Equal(t, "Fetch", director.requests[0].body.OperationName)
+		assert.Equal(t, "Bearer token", director.requests[0].header.Get("Authorization"))
+
+		require.Len(t, connector.requests, 1)
+		assert.NotContains(t, connector.requests[0].body.Query, "applications")
+		assert.NotContains(t, connector.requests[0].body.Query, "fragment")
+		assert.Equal(t, "Bearer token", connector.requests[0].header.Get("Authorization"))
+	})
+
+	t.Run("should pass only used variables", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `{"application":{"name":"foo"}}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, fixConnectorSchema(), `{"configuration":{"token":null}}`)
+		defer connector.server.Close()
+		handler := newHandler(t, director, connector)
+		query := `query($id: ID!, $withName: Boolean = true) { application(id: $id) { name @include(if: $withName) } configuration { token { token } } }`
+
+		// when
+		rr := serveRequest(handler, `{"query":`+quote(query)+`,"variables":{"id":"1"}}`, "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data":{"application":{"name":"foo"},"configuration":{"token":null}}}`, rr.Body.String())
+		require.Len(t, director.requests, 1)
+		assert.Contains(t, director.requests[0].body.Query, "query($id: ID!, $withName: Boolean = true)")
+		assert.Equal(t, map[string]interface{}{"id": "1"}, director.requests[0].body.Variables)
+		require.Len(t, connector.requests, 1)
+		assert.True(t, strings.HasPrefix(connector.requests[0].body.Query, "query {"))
+		assert.Empty(t, connector.requests[0].body.Variables)
+	})
+
+	t.Run("should return backend errors", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `null`)
+		director.errors = `[{"message":"insufficient scopes","path":["applications"]}]`
+		defer director.server.Close()
+		connector := newFakeBackend(t, fixConnectorSchema(), `{"configuration":{"token":null}}`)
+		defer connector.server.Close()
+		handler := newHandler(t, director, connector)
+
+		// when
+		rr := serveRequest(handler, `{"query":"{ applications { id } configuration { token { token } } }"}`, "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data":null,"errors":[{"message":"insufficient scopes","path":["applications"]}]}`, rr.Body.String())
+	})
+
+	t.Run("should resolve introspection in the Gateway", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `{}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, fixConnectorSchema(), `{}`)
+		defer connector.server.Close()
+		handler := newHandler(t, director, connector)
+		query := `{ __typename __schema { queryType { name } mutationType { fields { name } } } app: __type(name: "Application") { kind fields { name type { kind ofType { name } } } } }`
+
+		// when
+		rr := serveRequest(handler, `{"query":`+quote(query)+`}`, "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data":{
+			"__typename":"Query",
+			"__schema":{"queryType":{"name":"Query"},"mutationType":{"fields":[{"name":"registerApplication"},{"name":"generateApplicationToken"}]}},
+			"app":{"kind":"OBJECT","fields":[{"name":"id","type":{"kind":"NON_NULL","ofType":{"name":"ID"}}},{"name":"name","type":{"kind":"NON_NULL","ofType":{"name":"String"}}}]}
+		}}`, rr.Body.String())
+		assert.Empty(t, director.requests)
+		assert.Empty(t, connector.requests)
+	})
+
+	t.Run("should split mutations of different backends in requested order", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `{"registerApplication":{"id":"1"}}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, fixConnectorSchema(), `{"generateApplicationToken":{"token":"abc"}}`)
+		defer connector.server.Close()
+		handler := newHandler(t, director, connector)
+		query := `mutation { registerApplication(in: {name: "foo"}) { id } generateApplicationToken(appID: "1") { token } }`
+
+		// when
+		rr := serveRequest(handler, `{"query":`+quote(query)+`}`, "")
+
+		// then
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data":{"registerApplication":{"id":"1"},"generateApplicationToken":{"token":"abc"}}}`, rr.Body.String())
+		require.Len(t, director.requests, 1)
+		assert.Contains(t, director.requests[0].body.Query, `registerApplication(in: {name: "foo"})`)
+		require.Len(t, connector.requests, 1)
+		assert.Contains(t, connector.requests[0].body.Query, `generateApplicationToken(appID: "1")`)
+	})
+
+	t.Run("should return validation errors", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `{}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, fixConnectorSchema(), `{}`)
+		defer connector.server.Close()
+		handler := newHandler(t, director, connector)
+
+		// when
+		rr := serveRequest(handler, `{"query":"{ runtimes { id } }"}`, "")
+
+		// then
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), `Cannot query field \"runtimes\" on type \"Query\".`)
+		assert.Empty(t, director.requests)
+	})
+
+	t.Run("should reject fragments on root type selecting fields of many backends", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `{}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, fixConnectorSchema(), `{}`)
+		defer connector.server.Close()
+		handler := newHandler(t, director, connector)
+		query := `{ ...Root } fragment Root on Query { applications { id } configuration { token { token } } }`
+
+		// when
+		rr := serveRequest(handler, `{"query":`+quote(query)+`}`, "")
+
+		// then
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Contains(t, rr.Body.String(), "fragment Root on Query selects fields of both Director and Connector")
+		assert.Empty(t, director.requests)
+	})
+}
+
+func newHandler(t *testing.T, director, connector *fakeBackend) *Handler {
+	backends := []Backend{director.backend("Director"), connector.backend("Connector")}
+	schema, err := Introspect(context.Background(), backends, http.Header{})
+	require.NoError(t, err)
+
+	return NewHandler(schema, backends)
+}
+
+func serveRequest(handler *Handler, body, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
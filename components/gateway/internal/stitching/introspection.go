@@ -0,0 +1,287 @@
+package stitching
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const introspectionQuery = `query IntrospectionQuery {
+	__schema {
+		queryType { name }
+		mutationType { name }
+		subscriptionType { name }
+		types { ...FullType }
+	}
+}
+
+fragment FullType on __Type {
+	kind
+	name
+	description
+	fields(includeDeprecated: true) {
+		name
+		description
+		args { ...InputValue }
+		type { ...TypeRef }
+		isDeprecated
+		deprecationReason
+	}
+	inputFields { ...InputValue }
+	interfaces { ...TypeRef }
+	enumValues(includeDeprecated: true) {
+		name
+		description
+		isDeprecated
+		deprecationReason
+	}
+	possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+	name
+	description
+	type { ...TypeRef }
+	defaultValue
+}
+
+fragment TypeRef on __Type {
+	kind
+	name
+	ofType {
+		kind
+		name
+		ofType {
+			kind
+			name
+			ofType {
+				kind
+				name
+				ofType {
+					kind
+					name
+				}
+			}
+		}
+	}
+}`
+
+const (
+	queryTypeName    = "Query"
+	mutationTypeName = "Mutation"
+)
+
+var builtInScalars = map[string]struct{}{
+	"Int":     {},
+	"Float":   {},
+	"String":  {},
+	"Boolean": {},
+	"ID":      {},
+}
+
+type introspectionResult struct {
+	Schema introspectionSchema `json:"__schema"`
+}
+
+type introspectionSchema struct {
+	QueryType        *namedTypeRef       `json:"queryType"`
+	MutationType     *namedTypeRef       `json:"mutationType"`
+	SubscriptionType *namedTypeRef       `json:"subscriptionType"`
+	Types            []introspectionType `json:"types"`
+}
+
+type namedTypeRef struct {
+	Name string `json:"name"`
+}
+
+type introspectionType struct {
+	Kind          string                    `json:"kind"`
+	Name          string                    `json:"name"`
+	Description   *string                   `json:"description"`
+	Fields        []introspectionField      `json:"fields"`
+	InputFields   []introspectionInputValue `json:"inputFields"`
+	Interfaces    []typeRef                 `json:"interfaces"`
+	EnumValues    []introspectionEnumValue  `json:"enumValues"`
+	PossibleTypes []typeRef                 `json:"possibleTypes"`
+}
+
+type introspectionField struct {
+	Name              string                    `json:"name"`
+	Description       *string                   `json:"description"`
+	Args              []introspectionInputValue `json:"args"`
+	Type              typeRef                   `json:"type"`
+	IsDeprecated      bool                      `json:"isDeprecated"`
+	DeprecationReason *string                   `json:"deprecationReason"`
+}
+
+type introspectionInputValue struct {
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+	Type         typeRef `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+type introspectionEnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   *string  `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+func (t typeRef) String() string {
+	switch t.Kind {
+	case "NON_NULL":
+		if t.OfType == nil {
+			return ""
+		}
+		return t.OfType.String() + "!"
+	case "LIST":
+		if t.OfType == nil {
+			return ""
+		}
+		return "[" + t.OfType.String() + "]"
+	}
+
+	if t.Name == nil {
+		return ""
+	}
+	return *t.Name
+}
+
+// rootTypeNames returns the names of the root operation types mapped to the names used in the stitched schema
+func (s introspectionSchema) rootTypeNames() map[string]string {
+	names := make(map[string]string)
+	if s.QueryType != nil {
+		names[s.QueryType.Name] = queryTypeName
+	}
+	if s.MutationType != nil {
+		names[s.MutationType.Name] = mutationTypeName
+	}
+
+	return names
+}
+
+// isBuiltIn tells if the type is defined by the GraphQL specification, so it must not be redefined in the stitched schema
+func (t introspectionType) isBuiltIn() bool {
+	if strings.HasPrefix(t.Name, "__") {
+		return true
+	}
+
+	_, ok := builtInScalars[t.Name]
+	return ok
+}
+
+// sdl prints the type in the GraphQL Schema Definition Language
+func (t introspectionType) sdl(name string) (string, error) {
+	b := &strings.Builder{}
+	writeDescription(b, "", t.Description)
+
+	switch t.Kind {
+	case "SCALAR":
+		fmt.Fprintf(b, "scalar %s\n", name)
+	case "OBJECT", "INTERFACE":
+		keyword := "type"
+		if t.Kind == "INTERFACE" {
+			keyword = "interface"
+		}
+		fmt.Fprintf(b, "%s %s", keyword, name)
+		if len(t.Interfaces) > 0 {
+			interfaces := make([]string, 0, len(t.Interfaces))
+			for _, i := range t.Interfaces {
+				interfaces = append(interfaces, i.String())
+			}
+			fmt.Fprintf(b, " implements %s", strings.Join(interfaces, " & "))
+		}
+		b.WriteString(" {\n")
+		writeFields(b, t.Fields)
+		b.WriteString("}\n")
+	case "UNION":
+		members := make([]string, 0, len(t.PossibleTypes))
+		for _, p := range t.PossibleTypes {
+			members = append(members, p.String())
+		}
+		fmt.Fprintf(b, "union %s = %s\n", name, strings.Join(members, " | "))
+	case "ENUM":
+		fmt.Fprintf(b, "enum %s {\n", name)
+		for _, v := range t.EnumValues {
+			writeDescription(b, "\t", v.Description)
+			fmt.Fprintf(b, "\t%s%s\n", v.Name, deprecationSDL(v.IsDeprecated, v.DeprecationReason))
+		}
+		b.WriteString("}\n")
+	case "INPUT_OBJECT":
+		fmt.Fprintf(b, "input %s {\n", name)
+		for _, f := range t.InputFields {
+			writeDescription(b, "\t", f.Description)
+			fmt.Fprintf(b, "\t%s\n", inputValueSDL(f))
+		}
+		b.WriteString("}\n")
+	default:
+		return "", fmt.Errorf("unknown kind %s of type %s", t.Kind, t.Name)
+	}
+
+	return b.String(), nil
+}
+
+func writeFields(b *strings.Builder, fields []introspectionField) {
+	for _, f := range fields {
+		writeDescription(b, "\t", f.Description)
+		fmt.Fprintf(b, "\t%s%s: %s%s\n", f.Name, argumentsSDL(f.Args), f.Type.String(), deprecationSDL(f.IsDeprecated, f.DeprecationReason))
+	}
+}
+
+func argumentsSDL(args []introspectionInputValue) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	printed := make([]string, 0, len(args))
+	for _, arg := range args {
+		printed = append(printed, inputValueSDL(arg))
+	}
+
+	return "(" + strings.Join(printed, ", ") + ")"
+}
+
+func inputValueSDL(v introspectionInputValue) string {
+	sdl := v.Name + ": " + v.Type.String()
+	if v.DefaultValue != nil {
+		sdl += " = " + *v.DefaultValue
+	}
+
+	return sdl
+}
+
+func deprecationSDL(isDeprecated bool, reason *string) string {
+	if !isDeprecated {
+		return ""
+	}
+	if reason == nil {
+		return " @deprecated"
+	}
+
+	return fmt.Sprintf(" @deprecated(reason: %s)", quote(*reason))
+}
+
+func writeDescription(b *strings.Builder, indent string, description *string) {
+	if description == nil || *description == "" {
+		return
+	}
+
+	fmt.Fprintf(b, "%s%s\n", indent, quote(*description))
+}
+
+// quote prints the string as a GraphQL string literal, which escapes characters in the same way as JSON
+func quote(s string) string {
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		return `""`
+	}
+
+	return string(quoted)
+}
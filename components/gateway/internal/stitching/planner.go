@@ -0,0 +1,190 @@
+package stitching
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// backendOperation is the part of the incoming operation which is sent to a single backend
+type backendOperation struct {
+	backend   string
+	query     string
+	variables map[string]interface{}
+}
+
+// plan splits the root selections of the operation between the backends which own them, keeping the introspection fields for the Gateway.
+// Queries are sent once per backend. Mutations are split whenever the owner changes, so their root fields still run in the requested order.
+func (s *Schema) plan(doc *ast.QueryDocument, op *ast.OperationDefinition, variables map[string]interface{}) ([]backendOperation, ast.SelectionSet, error) {
+	type group struct {
+		backend    string
+		selections ast.SelectionSet
+	}
+	var groups []*group
+	var local ast.SelectionSet
+
+	for _, selection := range op.SelectionSet {
+		owner, err := s.selectionOwner(doc, op.Operation, selection)
+		if err != nil {
+			return nil, nil, err
+		}
+		if owner == "" {
+			local = append(local, selection)
+			continue
+		}
+
+		var target *group
+		if op.Operation == ast.Mutation {
+			if len(groups) > 0 && groups[len(groups)-1].backend == owner {
+				target = groups[len(groups)-1]
+			}
+		} else {
+			for _, g := range groups {
+				if g.backend == owner {
+					target = g
+				}
+			}
+		}
+		if target == nil {
+			target = &group{backend: owner}
+			groups = append(groups, target)
+		}
+		target.selections = append(target.selections, selection)
+	}
+
+	operations := make([]backendOperation, 0, len(groups))
+	for _, g := range groups {
+		usage := newUsage(doc)
+		usage.collect(g.selections)
+		usage.collectDirectives(op.Directives)
+
+		var variableDefinitions ast.VariableDefinitionList
+		backendVariables := make(map[string]interface{})
+		for _, definition := range op.VariableDefinitions {
+			if _, used := usage.variables[definition.Variable]; !used {
+				continue
+			}
+			variableDefinitions = append(variableDefinitions, definition)
+			if value, ok := variables[definition.Variable]; ok {
+				backendVariables[definition.Variable] = value
+			}
+		}
+
+		operations = append(operations, backendOperation{
+			backend:   g.backend,
+			query:     printOperation(op, g.selections, variableDefinitions, usage.fragments),
+			variables: backendVariables,
+		})
+	}
+
+	return operations, local, nil
+}
+
+// selectionOwner returns the backend which resolves the root selection, or an empty string if it is resolved by the Gateway
+func (s *Schema) selectionOwner(doc *ast.QueryDocument, operation ast.Operation, selection ast.Selection) (string, error) {
+	switch sel := selection.(type) {
+	case *ast.Field:
+		if strings.HasPrefix(sel.Name, "__") {
+			return "", nil
+		}
+		owner, ok := s.owner(operation, sel.Name)
+		if !ok {
+			return "", fmt.Errorf("unknown field %s.%s", rootTypeName(operation), sel.Name)
+		}
+		return owner, nil
+	case *ast.FragmentSpread:
+		fragment := doc.Fragments.ForName(sel.Name)
+		if fragment == nil {
+			return "", fmt.Errorf("unknown fragment %s", sel.Name)
+		}
+		return s.fragmentOwner(doc, operation, "fragment "+sel.Name, fragment.SelectionSet)
+	case *ast.InlineFragment:
+		return s.fragmentOwner(doc, operation, "inline fragment", sel.SelectionSet)
+	}
+
+	return "", fmt.Errorf("unknown selection %T", selection)
+}
+
+// fragmentOwner returns the only backend which resolves the root fields of the fragment
+func (s *Schema) fragmentOwner(doc *ast.QueryDocument, operation ast.Operation, name string, selections ast.SelectionSet) (string, error) {
+	owner := ""
+	for _, selection := range selections {
+		if field, ok := selection.(*ast.Field); ok && field.Name == "__typename" {
+			continue
+		}
+
+		selectionOwner, err := s.selectionOwner(doc, operation, selection)
+		if err != nil {
+			return "", err
+		}
+		if selectionOwner == "" {
+			return "", fmt.Errorf("%s on %s cannot contain introspection fields", name, rootTypeName(operation))
+		}
+		if owner != "" && owner != selectionOwner {
+			return "", fmt.Errorf("%s on %s selects fields of both %s and %s", name, rootTypeName(operation), owner, selectionOwner)
+		}
+		owner = selectionOwner
+	}
+
+	return owner, nil
+}
+
+// usage collects the variables and fragments used by a part of the operation
+type usage struct {
+	doc       *ast.QueryDocument
+	variables map[string]struct{}
+	fragments ast.FragmentDefinitionList
+}
+
+func newUsage(doc *ast.QueryDocument) *usage {
+	return &usage{doc: doc, variables: make(map[string]struct{})}
+}
+
+func (u *usage) collect(selections ast.SelectionSet) {
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			for _, arg := range s.Arguments {
+				u.collectValue(arg.Value)
+			}
+			u.collectDirectives(s.Directives)
+			u.collect(s.SelectionSet)
+		case *ast.FragmentSpread:
+			u.collectDirectives(s.Directives)
+			if u.fragments.ForName(s.Name) != nil {
+				continue
+			}
+			fragment := u.doc.Fragments.ForName(s.Name)
+			if fragment == nil {
+				continue
+			}
+			u.fragments = append(u.fragments, fragment)
+			u.collectDirectives(fragment.Directives)
+			u.collect(fragment.SelectionSet)
+		case *ast.InlineFragment:
+			u.collectDirectives(s.Directives)
+			u.collect(s.SelectionSet)
+		}
+	}
+}
+
+func (u *usage) collectDirectives(directives ast.DirectiveList) {
+	for _, d := range directives {
+		for _, arg := range d.Arguments {
+			u.collectValue(arg.Value)
+		}
+	}
+}
+
+func (u *usage) collectValue(value *ast.Value) {
+	if value == nil {
+		return
+	}
+	if value.Kind == ast.Variable {
+		u.variables[value.Raw] = struct{}{}
+	}
+	for _, child := range value.Children {
+		u.collectValue(child.Value)
+	}
+}
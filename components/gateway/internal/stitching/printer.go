@@ -0,0 +1,158 @@
+package stitching
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// printOperation prints the operation with the given root selections, followed by the fragments it uses
+func printOperation(op *ast.OperationDefinition, selections ast.SelectionSet, variables ast.VariableDefinitionList, fragments ast.FragmentDefinitionList) string {
+	b := &strings.Builder{}
+	b.WriteString(string(op.Operation))
+	if op.Name != "" {
+		b.WriteString(" " + op.Name)
+	}
+	if len(variables) > 0 {
+		definitions := make([]string, 0, len(variables))
+		for _, v := range variables {
+			definition := "$" + v.Variable + ": " + v.Type.String()
+			if v.DefaultValue != nil {
+				definition += " = " + printValue(v.DefaultValue)
+			}
+			definitions = append(definitions, definition)
+		}
+		b.WriteString("(" + strings.Join(definitions, ", ") + ")")
+	}
+	printDirectives(b, op.Directives)
+	printSelectionSet(b, selections, "")
+
+	for _, fragment := range fragments {
+		b.WriteString("\n\nfragment " + fragment.Name + " on " + fragment.TypeCondition)
+		printDirectives(b, fragment.Directives)
+		printSelectionSet(b, fragment.SelectionSet, "")
+	}
+
+	return b.String()
+}
+
+func printSelectionSet(b *strings.Builder, selections ast.SelectionSet, indent string) {
+	if len(selections) == 0 {
+		return
+	}
+
+	b.WriteString(" {\n")
+	for _, selection := range selections {
+		b.WriteString(indent + "\t")
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.Alias != "" && s.Alias != s.Name {
+				b.WriteString(s.Alias + ": ")
+			}
+			b.WriteString(s.Name)
+			if len(s.Arguments) > 0 {
+				args := make([]string, 0, len(s.Arguments))
+				for _, arg := range s.Arguments {
+					args = append(args, arg.Name+": "+printValue(arg.Value))
+				}
+				b.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			printDirectives(b, s.Directives)
+			printSelectionSet(b, s.SelectionSet, indent+"\t")
+		case *ast.FragmentSpread:
+			b.WriteString("..." + s.Name)
+			printDirectives(b, s.Directives)
+		case *ast.InlineFragment:
+			b.WriteString("...")
+			if s.TypeCondition != "" {
+				b.WriteString(" on " + s.TypeCondition)
+			}
+			printDirectives(b, s.Directives)
+			printSelectionSet(b, s.SelectionSet, indent+"\t")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(indent + "}")
+}
+
+func printDirectives(b *strings.Builder, directives ast.DirectiveList) {
+	for _, d := range directives {
+		b.WriteString(" @" + d.Name)
+		if len(d.Arguments) > 0 {
+			args := make([]string, 0, len(d.Arguments))
+			for _, arg := range d.Arguments {
+				args = append(args, arg.Name+": "+printValue(arg.Value))
+			}
+			b.WriteString("(" + strings.Join(args, ", ") + ")")
+		}
+	}
+}
+
+// printValue prints the value as a GraphQL literal
+func printValue(v *ast.Value) string {
+	switch v.Kind {
+	case ast.Variable:
+		return "$" + v.Raw
+	case ast.StringValue, ast.BlockValue:
+		return quote(v.Raw)
+	case ast.ListValue:
+		items := make([]string, 0, len(v.Children))
+		for _, child := range v.Children {
+			items = append(items, printValue(child.Value))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case ast.ObjectValue:
+		fields := make([]string, 0, len(v.Children))
+		for _, child := range v.Children {
+			fields = append(fields, child.Name+": "+printValue(child.Value))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		return v.Raw
+	}
+}
+
+// orderedMap is a JSON object which keeps the order of its keys, as GraphQL responses follow the order of the selections
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	b := &bytes.Buffer{}
+	b.WriteString("{")
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteString(",")
+		}
+
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(encodedKey)
+		b.WriteString(":")
+		b.Write(encodedValue)
+	}
+	b.WriteString("}")
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,132 @@
+package stitching
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/gateway/internal/graphqlclient"
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Backend is a GraphQL server whose schema is a part of the stitched schema
+type Backend struct {
+	Name   string
+	Client graphqlclient.Client
+}
+
+// Schema is the stitched schema of all backends, together with the backend which owns each root field
+type Schema struct {
+	schema *ast.Schema
+	// owners maps the root type name and the root field name to the name of the backend
+	owners map[string]map[string]string
+}
+
+type backendSchema struct {
+	backend string
+	schema  introspectionSchema
+}
+
+// Introspect fetches the schemas of the backends and stitches them. It fails if two backends define a type or a root field with the same name.
+func Introspect(ctx context.Context, backends []Backend, header http.Header) (*Schema, error) {
+	schemas := make([]backendSchema, 0, len(backends))
+	for _, backend := range backends {
+		req := graphqlclient.NewRequest(introspectionQuery)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+
+		result := introspectionResult{}
+		err := backend.Client.Run(ctx, req, &result)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while introspecting %s", backend.Name)
+		}
+
+		schemas = append(schemas, backendSchema{backend: backend.Name, schema: result.Schema})
+	}
+
+	return stitch(schemas)
+}
+
+func stitch(schemas []backendSchema) (*Schema, error) {
+	sdl := &strings.Builder{}
+	typeOwners := make(map[string]string)
+	owners := map[string]map[string]string{
+		queryTypeName:    {},
+		mutationTypeName: {},
+	}
+	rootFields := map[string][]introspectionField{}
+
+	for _, s := range schemas {
+		rootTypeNames := s.schema.rootTypeNames()
+
+		for _, t := range s.schema.Types {
+			if t.isBuiltIn() {
+				continue
+			}
+			if s.schema.SubscriptionType != nil && t.Name == s.schema.SubscriptionType.Name {
+				continue
+			}
+
+			if rootTypeName, ok := rootTypeNames[t.Name]; ok {
+				for _, f := range t.Fields {
+					if owner, exists := owners[rootTypeName][f.Name]; exists {
+						return nil, fmt.Errorf("field %s.%s is defined by both %s and %s", rootTypeName, f.Name, owner, s.backend)
+					}
+					owners[rootTypeName][f.Name] = s.backend
+					rootFields[rootTypeName] = append(rootFields[rootTypeName], f)
+				}
+				continue
+			}
+
+			if t.Name == queryTypeName || t.Name == mutationTypeName {
+				return nil, fmt.Errorf("type %s defined by %s conflicts with the root type of the stitched schema", t.Name, s.backend)
+			}
+			if owner, exists := typeOwners[t.Name]; exists {
+				return nil, fmt.Errorf("type %s is defined by both %s and %s", t.Name, owner, s.backend)
+			}
+			typeOwners[t.Name] = s.backend
+
+			typeSDL, err := t.sdl(t.Name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "while printing schema of %s", s.backend)
+			}
+			sdl.WriteString(typeSDL)
+			sdl.WriteString("\n")
+		}
+	}
+
+	for _, rootTypeName := range []string{queryTypeName, mutationTypeName} {
+		if len(rootFields[rootTypeName]) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(sdl, "type %s {\n", rootTypeName)
+		writeFields(sdl, rootFields[rootTypeName])
+		sdl.WriteString("}\n")
+	}
+
+	schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: "stitched.graphql", Input: sdl.String()})
+	if gqlErr != nil {
+		return nil, errors.Wrap(gqlErr, "while loading stitched schema")
+	}
+
+	return &Schema{schema: schema, owners: owners}, nil
+}
+
+// owner returns the name of the backend which resolves the root field
+func (s *Schema) owner(operation ast.Operation, fieldName string) (string, bool) {
+	owner, ok := s.owners[rootTypeName(operation)][fieldName]
+	return owner, ok
+}
+
+func rootTypeName(operation ast.Operation) string {
+	if operation == ast.Mutation {
+		return mutationTypeName
+	}
+
+	return queryTypeName
+}
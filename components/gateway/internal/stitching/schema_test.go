@@ -0,0 +1,112 @@
+package stitching
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestIntrospect(t *testing.T) {
+	t.Run("should stitch schemas and assign root fields to backends", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `{}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, fixConnectorSchema(), `{}`)
+		defer connector.server.Close()
+
+		// when
+		schema, err := Introspect(context.Background(), []Backend{director.backend("Director"), connector.backend("Connector")}, http.Header{})
+
+		// then
+		require.NoError(t, err)
+		assert.NotNil(t, schema.schema.Types["Application"])
+		assert.NotNil(t, schema.schema.Types["Token"])
+		assertOwner(t, schema, ast.Query, "applications", "Director")
+		assertOwner(t, schema, ast.Query, "configuration", "Connector")
+		assertOwner(t, schema, ast.Mutation, "registerApplication", "Director")
+		assertOwner(t, schema, ast.Mutation, "generateApplicationToken", "Connector")
+	})
+
+	t.Run("should rename root types", func(t *testing.T) {
+		// given
+		connectorSchema := fixConnectorSchema()
+		connectorSchema.QueryType = &namedTypeRef{Name: "ConnectorQuery"}
+		connectorSchema.Types[0].Name = "ConnectorQuery"
+		connector := newFakeBackend(t, connectorSchema, `{}`)
+		defer connector.server.Close()
+
+		// when
+		schema, err := Introspect(context.Background(), []Backend{connector.backend("Connector")}, http.Header{})
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, schema.schema.Types["ConnectorQuery"])
+		assertOwner(t, schema, ast.Query, "configuration", "Connector")
+	})
+
+	t.Run("should fail when backends define the same type", func(t *testing.T) {
+		// given
+		connectorSchema := fixConnectorSchema()
+		connectorSchema.Types = append(connectorSchema.Types, fixObjectType("Application", fixField("id", fixNamed("SCALAR", "ID"))))
+		director := newFakeBackend(t, fixDirectorSchema(), `{}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, connectorSchema, `{}`)
+		defer connector.server.Close()
+
+		// when
+		_, err := Introspect(context.Background(), []Backend{director.backend("Director"), connector.backend("Connector")}, http.Header{})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "type Application is defined by both Director and Connector")
+	})
+
+	t.Run("should fail when backends define the same root field", func(t *testing.T) {
+		// given
+		connectorSchema := fixConnectorSchema()
+		connectorSchema.Types[0].Fields = append(connectorSchema.Types[0].Fields, fixField("applications", fixNamed("SCALAR", "String")))
+		director := newFakeBackend(t, fixDirectorSchema(), `{}`)
+		defer director.server.Close()
+		connector := newFakeBackend(t, connectorSchema, `{}`)
+		defer connector.server.Close()
+
+		// when
+		_, err := Introspect(context.Background(), []Backend{director.backend("Director"), connector.backend("Connector")}, http.Header{})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field Query.applications is defined by both Director and Connector")
+	})
+
+	t.Run("should send introspection query with given headers", func(t *testing.T) {
+		// given
+		director := newFakeBackend(t, fixDirectorSchema(), `{}`)
+		defer director.server.Close()
+		var authorization string
+		director.server.Config.Handler = withHeaderRecorder(director.server.Config.Handler, "Authorization", &authorization)
+
+		// when
+		_, err := Introspect(context.Background(), []Backend{director.backend("Director")}, http.Header{"Authorization": []string{"Bearer token"}})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token", authorization)
+	})
+}
+
+func withHeaderRecorder(next http.Handler, key string, value *string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*value = r.Header.Get(key)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func assertOwner(t *testing.T, schema *Schema, operation ast.Operation, field, expected string) {
+	owner, ok := schema.owner(operation, field)
+	require.True(t, ok)
+	assert.Equal(t, expected, owner)
+}
@@ -1,13 +1,23 @@
 package main
 
 import (
-	"github.com/kyma-incubator/compass/components/provisioner/internal/api"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/hydroform"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/hydroform/client"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hyperscaler"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/installation"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence"
-	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/database"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dbsession"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/provisioning"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/runtimeagent"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/tracing"
 	"github.com/pkg/errors"
 
 	"path/filepath"
@@ -20,24 +30,46 @@ import (
 	"k8s.io/client-go/util/homedir"
 )
 
-func newPersistenceService(connectionString, schemaPath string) (persistence.Service, error) {
-	connection, err := database.InitializeDatabase(connectionString, schemaPath)
-	if err != nil {
-		return nil, err
-	}
+const installerDownloadTimeout = 2 * time.Minute
 
-	dbSessionFactory := dbsession.NewFactory(connection)
+func newPersistenceService(connection *dbr.Connection, cipher dbsession.ConfigurationCipher) persistence.Service {
+	dbSessionFactory := dbsession.NewFactory(connection, cipher)
 	uuidGenerator := persistence.NewUUIDGenerator()
 
-	return persistence.NewService(dbSessionFactory, uuidGenerator), nil
+	return persistence.NewService(dbSessionFactory, uuidGenerator)
 }
 
-func newProvisioningService(persistenceService persistence.Service, secrets v1.SecretInterface) provisioning.Service {
+func newHydroformService(secrets v1.SecretInterface) hydroform.Service {
 	hydroformClient := client.NewHydroformClient()
-	hydroformService := hydroform.NewHydroformService(secrets, hydroformClient)
+
+	return hydroform.NewHydroformService(secrets, hydroformClient)
+}
+
+func newDirectorClient(url string, timeout time.Duration, oauthConfig director.OAuthConfig) director.Client {
+	httpClient := &http.Client{Timeout: timeout, Transport: tracing.NewTransport(http.DefaultTransport)}
+	tokenProvider := director.NewClientCredentialsProvider(httpClient, oauthConfig)
+
+	return director.NewDirectorClient(httpClient, url, tokenProvider)
+}
+
+func newInstallationService(config installation.Config) installation.Service {
+	return installation.NewInstallationService(&http.Client{Timeout: installerDownloadTimeout}, config)
+}
+
+func newProvisioningService(persistenceService persistence.Service, hydroformService hydroform.Service, installationService installation.Service,
+	accountPool hyperscaler.AccountPool, directorClient director.Client, runtimeAgent runtimeagent.Service, lease model.OperationLease) provisioning.Service {
 	uuidGenerator := persistence.NewUUIDGenerator()
 
-	return provisioning.NewProvisioningService(persistenceService, uuidGenerator, hydroformService)
+	return provisioning.NewProvisioningService(persistenceService, uuidGenerator, hydroformService, installationService, accountPool, directorClient, runtimeAgent, lease)
+}
+
+func newLeaseOwner() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to get hostname")
+	}
+
+	return fmt.Sprintf("%s-%s", hostname, persistence.NewUUIDGenerator().New()), nil
 }
 
 func newSecretsInterface(namespace string) (v1.SecretInterface, error) {
@@ -61,16 +93,9 @@ func newSecretsInterface(namespace string) (v1.SecretInterface, error) {
 	return coreClientset.CoreV1().Secrets(namespace), nil
 }
 
-func newResolver(connectionString string, schemaFilePath string, namespace string) (*api.Resolver, error) {
-	persistenceService, err := newPersistenceService(connectionString, schemaFilePath)
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to initialize persistence")
+func runPeriodically(period time.Duration, function func()) {
+	for {
+		function()
+		time.Sleep(period)
 	}
-
-	secretInterface, err := newSecretsInterface(namespace)
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to create secrets interface")
-	}
-
-	return api.NewResolver(newProvisioningService(persistenceService, secretInterface)), nil
 }
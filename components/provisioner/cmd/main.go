@@ -3,24 +3,52 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/99designs/gqlgen/handler"
 	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/api"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/authenticator"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/graphqlmetrics"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/graphqltracing"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/healthz"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hyperscaler"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/installation"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/metrics"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/database"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dbsession"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/provisioning"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/readiness"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/runtimeagent"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/scope"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/tracing"
 	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/vrischmann/envconfig"
 )
 
 const connStringFormat string = "host=%s port=%s user=%s password=%s dbname=%s sslmode=%s"
 
+const readinessCheckTimeout = 5 * time.Second
+
 type config struct {
 	Address               string `envconfig:"default=127.0.0.1:3050"`
+	MetricsAddress        string `envconfig:"default=127.0.0.1:9090"`
 	APIEndpoint           string `envconfig:"default=/graphql"`
 	PlaygroundAPIEndpoint string `envconfig:"default=/graphql"`
 	CredentialsNamespace  string `envconfig:"default=compass-system"`
 
+	ScopesConfigurationFile string
+	JWKSEndpoint            string        `envconfig:"default=file://hack/default-jwks.json"`
+	JWKSSyncPeriod          time.Duration `envconfig:"default=5m"`
+	AllowJWTSigningNone     bool          `envconfig:"default=true"`
+
 	Database struct {
 		User     string `envconfig:"default=postgres"`
 		Password string `envconfig:"default=password"`
@@ -29,17 +57,58 @@ type config struct {
 		Name     string `envconfig:"default=provisioner"`
 		SSLMode  string `envconfig:"default=disable"`
 
-		SchemaFilePath string `envconfig:"default=assets/database/provisioner.sql"`
+		// Key used to encrypt values of the secret Kyma configuration entries
+		EncryptionKey string
+	}
+
+	Director struct {
+		URL           string        `envconfig:"default=https://compass-gateway-auth-oauth.kyma.local/director/graphql"`
+		OAuthTokenURL string        `envconfig:"default=https://oauth2.kyma.local/oauth2/token"`
+		ClientID      string        `envconfig:"optional"`
+		ClientSecret  string        `envconfig:"optional"`
+		Timeout       time.Duration `envconfig:"default=30s"`
+	}
+
+	Installation struct {
+		InstallerURLFormat string        `envconfig:"default=https://github.com/kyma-project/kyma/releases/download/%s/kyma-installer-cluster.yaml"`
+		Timeout            time.Duration `envconfig:"default=1h"`
+		PollInterval       time.Duration `envconfig:"default=30s"`
+	}
+
+	RuntimeAgent struct {
+		ConnectionTimeout time.Duration `envconfig:"default=10m"`
+		PollInterval      time.Duration `envconfig:"default=10s"`
 	}
+
+	Operations struct {
+		LeaseOwner      string        `envconfig:"optional"`
+		LeaseDuration   time.Duration `envconfig:"default=2m"`
+		Timeout         time.Duration `envconfig:"default=2h"`
+		ReconcilePeriod time.Duration `envconfig:"default=1m"`
+	}
+
+	Tracing tracing.Config
 }
 
 func (c *config) String() string {
-	return fmt.Sprintf("Address: %s, APIEndpoint: %s, CredentialsNamespace: %s "+
+	return fmt.Sprintf("Address: %s, MetricsAddress: %s, APIEndpoint: %s, CredentialsNamespace: %s "+
+		"ScopesConfigurationFile: %s, JWKSEndpoint: %s, JWKSSyncPeriod: %s, AllowJWTSigningNone: %t, "+
 		"DatabaseUser: %s, DatabaseHost: %s, DatabasePort: %s, "+
-		"DatabaseName: %s, DatabaseSSLMode: %s, DatabaseSchemaFilePath: %s",
-		c.Address, c.APIEndpoint, c.CredentialsNamespace,
+		"DatabaseName: %s, DatabaseSSLMode: %s, "+
+		"DirectorURL: %s, DirectorOAuthTokenURL: %s, DirectorTimeout: %s, "+
+		"InstallationInstallerURLFormat: %s, InstallationTimeout: %s, InstallationPollInterval: %s, "+
+		"RuntimeAgentConnectionTimeout: %s, RuntimeAgentPollInterval: %s, "+
+		"OperationsLeaseOwner: %s, OperationsLeaseDuration: %s, OperationsTimeout: %s, OperationsReconcilePeriod: %s, "+
+		"TracingExporter: %s, TracingOTLPEndpoint: %s",
+		c.Address, c.MetricsAddress, c.APIEndpoint, c.CredentialsNamespace,
+		c.ScopesConfigurationFile, c.JWKSEndpoint, c.JWKSSyncPeriod, c.AllowJWTSigningNone,
 		c.Database.User, c.Database.Host, c.Database.Port,
-		c.Database.Name, c.Database.SSLMode, c.Database.SchemaFilePath)
+		c.Database.Name, c.Database.SSLMode,
+		c.Director.URL, c.Director.OAuthTokenURL, c.Director.Timeout,
+		c.Installation.InstallerURLFormat, c.Installation.Timeout, c.Installation.PollInterval,
+		c.RuntimeAgent.ConnectionTimeout, c.RuntimeAgent.PollInterval,
+		c.Operations.LeaseOwner, c.Operations.LeaseDuration, c.Operations.Timeout, c.Operations.ReconcilePeriod,
+		c.Tracing.Exporter, c.Tracing.OTLPEndpoint)
 }
 
 func main() {
@@ -53,22 +122,101 @@ func main() {
 	connString := fmt.Sprintf(connStringFormat, cfg.Database.Host, cfg.Database.Port, cfg.Database.User,
 		cfg.Database.Password, cfg.Database.Name, cfg.Database.SSLMode)
 
-	resolver, err := newResolver(connString, cfg.Database.SchemaFilePath, cfg.CredentialsNamespace)
-	exitOnError(err, "Failed to initialize GraphQL resolver ")
+	if cfg.Operations.LeaseOwner == "" {
+		cfg.Operations.LeaseOwner, err = newLeaseOwner()
+		exitOnError(err, "Failed to generate operations lease owner")
+	}
+
+	shutdownTracing, err := tracing.Configure(cfg.Tracing, "provisioner", func(err error) {
+		log.Warnf("Failed to export spans: %s", err.Error())
+	})
+	exitOnError(err, "Failed to configure tracing")
+	defer shutdownTracing()
+
+	metricsCollector := metrics.NewCollector()
+	prometheus.MustRegister(metricsCollector)
+
+	connection, err := database.Connect(connString)
+	exitOnError(err, "Failed to initialize persistence")
+	configurationCipher, err := dbsession.NewConfigurationCipher(cfg.Database.EncryptionKey)
+	exitOnError(err, "Failed to create configuration cipher")
+	persistenceService := metrics.NewPersistenceService(newPersistenceService(connection, configurationCipher), metricsCollector)
+
+	secretInterface, err := newSecretsInterface(cfg.CredentialsNamespace)
+	exitOnError(err, "Failed to create secrets interface")
+
+	hydroformService := newHydroformService(secretInterface)
+	accountPool := hyperscaler.NewAccountPool(secretInterface)
+	lease := model.OperationLease{Owner: cfg.Operations.LeaseOwner, Duration: cfg.Operations.LeaseDuration}
+
+	directorClient := newDirectorClient(cfg.Director.URL, cfg.Director.Timeout, director.OAuthConfig{
+		TokenURL:     cfg.Director.OAuthTokenURL,
+		ClientID:     cfg.Director.ClientID,
+		ClientSecret: cfg.Director.ClientSecret,
+	})
+	runtimeAgent := runtimeagent.NewRuntimeAgentService(runtimeagent.Config{
+		ConnectionTimeout: cfg.RuntimeAgent.ConnectionTimeout,
+		PollInterval:      cfg.RuntimeAgent.PollInterval,
+	})
+	installationService := newInstallationService(installation.Config{
+		InstallerURLFormat: cfg.Installation.InstallerURLFormat,
+		Timeout:            cfg.Installation.Timeout,
+		PollInterval:       cfg.Installation.PollInterval,
+	})
+
+	resolver := api.NewResolver(newProvisioningService(persistenceService, hydroformService, installationService, accountPool, directorClient, runtimeAgent, lease))
+
+	log.Infof("Starting operations reconciler with %s lease owner", cfg.Operations.LeaseOwner)
+	operationsReconciler := provisioning.NewOperationsReconciler(persistenceService, hydroformService, installationService, accountPool,
+		directorClient, runtimeAgent, lease, cfg.Operations.Timeout)
+	go runPeriodically(cfg.Operations.ReconcilePeriod, operationsReconciler.Reconcile)
+
+	scopesProvider := scope.NewProvider(cfg.ScopesConfigurationFile)
+	err = scopesProvider.Load()
+	exitOnError(err, "Failed to load scopes configuration")
 
 	gqlCfg := gqlschema.Config{
 		Resolvers: resolver,
+		Directives: gqlschema.DirectiveRoot{
+			HasScopes: scope.NewDirective(scopesProvider).VerifyScopes,
+		},
 	}
-	executableSchema := gqlschema.NewExecutableSchema(gqlCfg)
+	executableSchema := graphqlmetrics.NewExecutableSchema(gqlschema.NewExecutableSchema(gqlCfg), metricsCollector)
+
+	authMiddleware := authenticator.New(cfg.JWKSEndpoint, cfg.AllowJWTSigningNone)
+	go runPeriodically(cfg.JWKSSyncPeriod, func() {
+		err := authMiddleware.SynchronizeJWKS()
+		if err != nil {
+			log.Errorf("Failed to synchronize JWKS: %s", err.Error())
+		}
+	})
 
 	log.Printf("Registering endpoint on %s...", cfg.APIEndpoint)
 
 	router := mux.NewRouter()
+	router.Use(tracing.NewMiddleware())
 	router.HandleFunc("/", handler.Playground("Dataloader", cfg.PlaygroundAPIEndpoint))
-	router.HandleFunc(cfg.APIEndpoint, handler.GraphQL(executableSchema))
+	router.HandleFunc("/livez", healthz.NewLivenessHandler())
+	router.HandleFunc("/readyz", healthz.NewReadinessHandler(readinessCheckTimeout,
+		healthz.Check{Name: "database", Checker: readiness.NewDatabaseChecker(connection)},
+		healthz.Check{Name: "secrets", Checker: readiness.NewSecretsChecker(secretInterface)},
+	))
+
+	apiRouter := router.PathPrefix(cfg.APIEndpoint).Subrouter()
+	apiRouter.Use(authMiddleware.Handler())
+	apiRouter.HandleFunc("", handler.GraphQL(executableSchema, handler.ResolverMiddleware(graphqltracing.ResolverMiddleware)))
 
 	http.Handle("/", router)
 
+	metricsRouter := mux.NewRouter()
+	metricsRouter.Handle("/metrics", promhttp.Handler())
+	go func() {
+		log.Printf("Metrics listening on %s...", cfg.MetricsAddress)
+		if err := http.ListenAndServe(cfg.MetricsAddress, metricsRouter); err != nil {
+			panic(err)
+		}
+	}()
+
 	log.Printf("API listening on %s...", cfg.Address)
 	if err := http.ListenAndServe(cfg.Address, router); err != nil {
 		panic(err)
@@ -3,13 +3,17 @@ package api
 import (
 	"context"
 	"errors"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/kyma-incubator/compass/components/provisioner/internal/provisioning"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/tenant"
 	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
 )
 
+const defaultPageSize = 100
+
 type Resolver struct {
 	provisioning provisioning.Service
 }
@@ -31,36 +35,45 @@ func NewResolver(provisioningService provisioning.Service) *Resolver {
 	}
 }
 
-func (r *Resolver) ProvisionRuntime(ctx context.Context, id string, config gqlschema.ProvisionRuntimeInput) (string, error) {
-	err := validateInput(config)
-	if err != nil {
-		log.Errorf("Failed to provision runtime %s: %s", id, err)
-		return "", err
+func (r *Resolver) ProvisionRuntime(ctx context.Context, id *string, config gqlschema.ProvisionRuntimeInput) (*gqlschema.OperationStatus, error) {
+	runtimeID := ""
+	if id != nil {
+		runtimeID = *id
 	}
 
-	log.Infof("Requested provisioning of %s runtime.", id)
-
-	operationID, _, err := r.provisioning.ProvisionRuntime(id, config)
+	tenantID, err := tenant.LoadFromContext(ctx)
 	if err != nil {
-		log.Errorf("Failed to provision runtime %s: %s", id, err)
+		return nil, err
 	}
-	log.Infof("Provisioning stared for %s runtime. Operation id %s", id, operationID)
 
-	return operationID, err
-}
+	validationErrors := validateProvisionRuntimeInput(config)
+	if len(validationErrors) > 0 {
+		err = validationFailure(validationErrors)
+		log.Errorf("Failed to provision runtime %s: %s", runtimeID, err)
+		return nil, err
+	}
 
-func validateInput(config gqlschema.ProvisionRuntimeInput) error {
-	if len(config.KymaConfig.Modules) == 0 {
-		return errors.New("cannot provision runtime since Kyma modules list is empty")
+	log.Infof("Requested provisioning of %s runtime.", runtimeID)
+
+	status, _, err := r.provisioning.ProvisionRuntime(tenantID, runtimeID, config)
+	if err != nil {
+		log.Errorf("Failed to provision runtime %s: %s", runtimeID, err)
+		return nil, err
 	}
+	log.Infof("Provisioning stared for %s runtime. Operation id %s", *status.RuntimeID, *status.ID)
 
-	return nil
+	return status, nil
 }
 
 func (r *Resolver) DeprovisionRuntime(ctx context.Context, id string) (string, error) {
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	log.Infof("Requested deprovisioning of %s runtime.", id)
 
-	operationID, _, err := r.provisioning.DeprovisionRuntime(id)
+	operationID, _, err := r.provisioning.DeprovisionRuntime(tenantID, id)
 	if err != nil {
 		log.Errorf("Failed to provision runtime %s: %s", id, err)
 	}
@@ -70,15 +83,48 @@ func (r *Resolver) DeprovisionRuntime(ctx context.Context, id string) (string, e
 }
 
 func (r *Resolver) UpgradeRuntime(ctx context.Context, id string, config gqlschema.UpgradeRuntimeInput) (string, error) {
-	return "", nil
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	log.Infof("Requested upgrade of %s runtime.", id)
+
+	operationID, _, err := r.provisioning.UpgradeRuntime(tenantID, id, config)
+	if err != nil {
+		log.Errorf("Failed to upgrade runtime %s: %s", id, err)
+		return "", err
+	}
+	log.Infof("Upgrade started for %s runtime. Operation id %s", id, operationID)
+
+	return operationID, nil
 }
 
 func (r *Resolver) ReconnectRuntimeAgent(ctx context.Context, id string) (string, error) {
-	return "", nil
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	log.Infof("Requested reconnecting Runtime Agent for %s runtime.", id)
+
+	operationID, _, err := r.provisioning.ReconnectRuntimeAgent(tenantID, id)
+	if err != nil {
+		log.Errorf("Failed to reconnect Runtime Agent for runtime %s: %s", id, err)
+		return "", err
+	}
+	log.Infof("Reconnecting Runtime Agent started for %s runtime. Operation id %s", id, operationID)
+
+	return operationID, nil
 }
 
 func (r *Resolver) RuntimeStatus(ctx context.Context, runtimeID string) (*gqlschema.RuntimeStatus, error) {
-	status, err := r.provisioning.RuntimeStatus(runtimeID)
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := r.provisioning.RuntimeStatus(tenantID, runtimeID)
 	if err != nil {
 		log.Errorf("Failed to get status for runtime %s: %s", runtimeID, err)
 	}
@@ -87,7 +133,12 @@ func (r *Resolver) RuntimeStatus(ctx context.Context, runtimeID string) (*gqlsch
 }
 
 func (r *Resolver) RuntimeOperationStatus(ctx context.Context, operationID string) (*gqlschema.OperationStatus, error) {
-	status, err := r.provisioning.RuntimeOperationStatus(operationID)
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := r.provisioning.RuntimeOperationStatus(tenantID, operationID)
 	if err != nil {
 		log.Errorf("Failed to get runtime operation status: %s Operation ID: %s", err, operationID)
 	}
@@ -95,8 +146,114 @@ func (r *Resolver) RuntimeOperationStatus(ctx context.Context, operationID strin
 	return status, err
 }
 
+func (r *Resolver) ValidateProvisionRuntimeInput(ctx context.Context, config gqlschema.ProvisionRuntimeInput) ([]*gqlschema.ValidationError, error) {
+	return validateProvisionRuntimeInput(config), nil
+}
+
+func (r *Resolver) HyperscalerAccountPools(ctx context.Context, hyperscaler *gqlschema.HyperscalerType, accountName *string) ([]*gqlschema.HyperscalerAccountPoolStatus, error) {
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := r.provisioning.HyperscalerAccountPools(tenantID, hyperscaler, accountName)
+	if err != nil {
+		log.Errorf("Failed to get Hyperscaler Account Pools status: %s", err)
+	}
+
+	return statuses, err
+}
+
+func (r *Resolver) Runtimes(ctx context.Context, filter *gqlschema.RuntimesFilter, first *int, after *string) (*gqlschema.RuntimePage, error) {
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize, err := validatePageSize(first)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter != nil && filter.State != nil {
+		err := validateOperationState(*filter.State)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := r.provisioning.Runtimes(tenantID, filter, pageSize, stringValue(after))
+	if err != nil {
+		log.Errorf("Failed to list runtimes: %s", err)
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (r *Resolver) Operations(ctx context.Context, runtimeID *string, operationType *gqlschema.OperationType, state *gqlschema.OperationState, since *time.Time, first *int, after *string) (*gqlschema.OperationPage, error) {
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize, err := validatePageSize(first)
+	if err != nil {
+		return nil, err
+	}
+
+	if state != nil {
+		err := validateOperationState(*state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := r.provisioning.Operations(tenantID, runtimeID, operationType, state, since, pageSize, stringValue(after))
+	if err != nil {
+		log.Errorf("Failed to list operations: %s", err)
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func validatePageSize(first *int) (int, error) {
+	if first == nil {
+		return defaultPageSize, nil
+	}
+
+	if *first < 1 {
+		return 0, errors.New("page size cannot be smaller than 1")
+	}
+
+	return *first, nil
+}
+
+// Operations are stored once they are started, so none of them is ever in the Pending state
+func validateOperationState(state gqlschema.OperationState) error {
+	if state == gqlschema.OperationStatePending {
+		return errors.New("filtering by the Pending state is not supported")
+	}
+
+	return nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
 func (r *Resolver) CleanupRuntimeData(ctx context.Context, id string) (string, error) {
-	res, err := r.provisioning.CleanupRuntimeData(id)
+	tenantID, err := tenant.LoadFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := r.provisioning.CleanupRuntimeData(tenantID, id)
 	if err != nil {
 		log.Errorf("Failed to cleanup data for runtime %s: %s", id, err)
 	}
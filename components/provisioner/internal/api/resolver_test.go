@@ -3,16 +3,21 @@ package api
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/provisioner/internal/provisioning/mocks"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/tenant"
 	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+const tenantID = "ee1ba4c1-c1a9-4bb3-8c59-8fa0ae8d6c5e"
+
 func TestResolver_ProvisionRuntime(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.SaveToContext(context.Background(), tenantID)
 	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
 
 	clusterConfig := &gqlschema.ClusterConfigInput{
@@ -21,13 +26,16 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 			ProjectName:       "Project",
 			NumberOfNodes:     3,
 			BootDiskSize:      "256",
-			MachineType:       "machine",
+			MachineType:       "n1-standard-4",
 			Region:            "region",
 			Zone:              new(string),
 			KubernetesVersion: "version",
 		},
 	}
 
+	secretName := "secretName"
+	credentials := &gqlschema.CredentialsInput{SecretName: &secretName}
+
 	t.Run("Should start provisioning and return operation ID", func(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
@@ -40,16 +48,42 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, KymaConfig: kymaConfig}
+		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig}
 
-		provisioningService.On("ProvisionRuntime", runtimeID, config).Return(expectedID, nil, nil)
+		provisioningService.On("ProvisionRuntime", tenantID, runtimeID, config).Return(&gqlschema.OperationStatus{ID: &expectedID, RuntimeID: &runtimeID}, nil, nil)
 
 		//when
-		operationID, err := provisioner.ProvisionRuntime(ctx, runtimeID, config)
+		status, err := provisioner.ProvisionRuntime(ctx, &runtimeID, config)
 
 		//then
 		require.NoError(t, err)
-		assert.Equal(t, expectedID, operationID)
+		assert.Equal(t, expectedID, *status.ID)
+	})
+
+	t.Run("Should register runtime in Director when ID is not provided", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		kymaConfig := &gqlschema.KymaConfigInput{
+			Version: "1.5",
+			Modules: gqlschema.AllKymaModule,
+		}
+
+		expectedID := "ec781980-0533-4098-aab7-96b535569732"
+
+		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig}
+
+		provisioningService.On("ProvisionRuntime", tenantID, "", config).Return(&gqlschema.OperationStatus{ID: &expectedID, RuntimeID: &runtimeID}, nil, nil)
+
+		//when
+		status, err := provisioner.ProvisionRuntime(ctx, nil, config)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, expectedID, *status.ID)
+		assert.Equal(t, runtimeID, *status.RuntimeID)
+		provisioningService.AssertExpectations(t)
 	})
 
 	t.Run("Should return error when Kyma config validation fails", func(t *testing.T) {
@@ -61,16 +95,87 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 			Version: "1.5",
 		}
 
-		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, KymaConfig: kymaConfig}
+		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig}
 
 		//when
-		operationID, err := provisioner.ProvisionRuntime(ctx, runtimeID, config)
+		status, err := provisioner.ProvisionRuntime(ctx, &runtimeID, config)
 
 		//then
 		require.Error(t, err)
-		assert.Empty(t, operationID)
+		assert.Nil(t, status)
 	})
 
+	t.Run("Should return error when component configuration key is empty", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		kymaConfig := &gqlschema.KymaConfigInput{
+			Version: "1.5",
+			Modules: gqlschema.AllKymaModule,
+			Components: []*gqlschema.ComponentConfigurationInput{
+				{
+					Component:     "assetstore",
+					Configuration: []*gqlschema.ConfigurationInput{{Key: "", Value: "value"}},
+				},
+			},
+		}
+
+		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig}
+
+		//when
+		status, err := provisioner.ProvisionRuntime(ctx, &runtimeID, config)
+
+		//then
+		require.Error(t, err)
+		assert.Nil(t, status)
+		provisioningService.AssertExpectations(t)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		credentials *gqlschema.CredentialsInput
+	}{
+		{
+			description: "neither secret name nor hyperscaler account is provided",
+			credentials: &gqlschema.CredentialsInput{},
+		},
+		{
+			description: "both secret name and hyperscaler account are provided",
+			credentials: &gqlschema.CredentialsInput{
+				SecretName:         &secretName,
+				HyperscalerAccount: &gqlschema.HyperscalerAccountInput{Hyperscaler: gqlschema.HyperscalerTypeGcp, AccountName: "account"},
+			},
+		},
+		{
+			description: "hyperscaler account name is empty",
+			credentials: &gqlschema.CredentialsInput{
+				HyperscalerAccount: &gqlschema.HyperscalerAccountInput{Hyperscaler: gqlschema.HyperscalerTypeGcp},
+			},
+		},
+	} {
+		t.Run("Should return error when "+testCase.description, func(t *testing.T) {
+			//given
+			provisioningService := &mocks.Service{}
+			provisioner := NewResolver(provisioningService)
+
+			kymaConfig := &gqlschema.KymaConfigInput{
+				Version: "1.5",
+				Modules: gqlschema.AllKymaModule,
+			}
+
+			config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: testCase.credentials, KymaConfig: kymaConfig}
+
+			//when
+			status, err := provisioner.ProvisionRuntime(ctx, &runtimeID, config)
+
+			//then
+			require.Error(t, err)
+			assert.Nil(t, status)
+			provisioningService.AssertExpectations(t)
+		})
+	}
+
 	t.Run("Should return error when provisioning fails", func(t *testing.T) {
 		//given
 		provisioningService := &mocks.Service{}
@@ -81,21 +186,77 @@ func TestResolver_ProvisionRuntime(t *testing.T) {
 			Modules: gqlschema.AllKymaModule,
 		}
 
-		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, KymaConfig: kymaConfig}
+		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig}
 
-		provisioningService.On("ProvisionRuntime", runtimeID, config).Return("", nil, errors.New("Provisioning failed"))
+		provisioningService.On("ProvisionRuntime", tenantID, runtimeID, config).Return(nil, nil, errors.New("Provisioning failed"))
 
 		//when
-		operationID, err := provisioner.ProvisionRuntime(ctx, runtimeID, config)
+		status, err := provisioner.ProvisionRuntime(ctx, &runtimeID, config)
 
 		//then
 		require.Error(t, err)
-		assert.Empty(t, operationID)
+		assert.Nil(t, status)
+	})
+	t.Run("Should return error when tenant is missing", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		kymaConfig := &gqlschema.KymaConfigInput{
+			Version: "1.5",
+			Modules: gqlschema.AllKymaModule,
+		}
+
+		config := gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig}
+
+		//when
+		status, err := provisioner.ProvisionRuntime(context.Background(), &runtimeID, config)
+
+		//then
+		require.Error(t, err)
+		assert.Nil(t, status)
+		provisioningService.AssertNotCalled(t, "ProvisionRuntime", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestResolver_ValidateProvisionRuntimeInput(t *testing.T) {
+	ctx := tenant.SaveToContext(context.Background(), tenantID)
+
+	t.Run("Should return field errors without calling provisioning service", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		secretName := "secretName"
+		config := gqlschema.ProvisionRuntimeInput{
+			ClusterConfig: &gqlschema.ClusterConfigInput{
+				GcpConfig: &gqlschema.GCPConfigInput{
+					Name:              "Something",
+					ProjectName:       "Project",
+					NumberOfNodes:     3,
+					BootDiskSize:      "256GB",
+					MachineType:       "n1-standard-4",
+					Region:            "europe-west4",
+					KubernetesVersion: "1.15",
+				},
+			},
+			Credentials: &gqlschema.CredentialsInput{SecretName: &secretName},
+			KymaConfig:  &gqlschema.KymaConfigInput{Version: "1.5", Modules: gqlschema.AllKymaModule},
+		}
+
+		//when
+		validationErrors, err := provisioner.ValidateProvisionRuntimeInput(ctx, config)
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, validationErrors, 1)
+		assert.Equal(t, "clusterConfig.gcpConfig.bootDiskSize", validationErrors[0].Field)
+		provisioningService.AssertExpectations(t)
 	})
 }
 
 func TestResolver_DeprovisionRuntime(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.SaveToContext(context.Background(), tenantID)
 	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
 
 	t.Run("Should start deprovisioning and return operation ID", func(t *testing.T) {
@@ -105,7 +266,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 
 		expectedID := "ec781980-0533-4098-aab7-96b535569732"
 
-		provisioningService.On("DeprovisionRuntime", runtimeID).Return(expectedID, nil, nil)
+		provisioningService.On("DeprovisionRuntime", tenantID, runtimeID).Return(expectedID, nil, nil)
 
 		//when
 		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID)
@@ -120,7 +281,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 		provisioningService := &mocks.Service{}
 		provisioner := NewResolver(provisioningService)
 
-		provisioningService.On("DeprovisionRuntime", runtimeID).Return("", nil, errors.New("Deprovisioning fails because reasons"))
+		provisioningService.On("DeprovisionRuntime", tenantID, runtimeID).Return("", nil, errors.New("Deprovisioning fails because reasons"))
 
 		//when
 		operationID, err := provisioner.DeprovisionRuntime(ctx, runtimeID)
@@ -132,7 +293,7 @@ func TestResolver_DeprovisionRuntime(t *testing.T) {
 }
 
 func TestResolver_RuntimeStatus(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.SaveToContext(context.Background(), tenantID)
 	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
 
 	t.Run("Should return operation status", func(t *testing.T) {
@@ -155,7 +316,7 @@ func TestResolver_RuntimeStatus(t *testing.T) {
 			RuntimeConnectionStatus: &gqlschema.RuntimeConnectionStatus{},
 		}
 
-		provisioningService.On("RuntimeStatus", runtimeID).Return(status, nil)
+		provisioningService.On("RuntimeStatus", tenantID, runtimeID).Return(status, nil)
 
 		//when
 		runtimeStatus, err := provisioner.RuntimeStatus(ctx, runtimeID)
@@ -170,7 +331,7 @@ func TestResolver_RuntimeStatus(t *testing.T) {
 		provisioningService := &mocks.Service{}
 		provisioner := NewResolver(provisioningService)
 
-		provisioningService.On("RuntimeStatus", runtimeID).Return(nil, errors.New("Runtime status fails"))
+		provisioningService.On("RuntimeStatus", tenantID, runtimeID).Return(nil, errors.New("Runtime status fails"))
 
 		//when
 		status, err := provisioner.RuntimeStatus(ctx, runtimeID)
@@ -182,7 +343,7 @@ func TestResolver_RuntimeStatus(t *testing.T) {
 }
 
 func TestResolver_RuntimeOperationStatus(t *testing.T) {
-	ctx := context.Background()
+	ctx := tenant.SaveToContext(context.Background(), tenantID)
 	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
 
 	t.Run("Should return operation status", func(t *testing.T) {
@@ -201,7 +362,7 @@ func TestResolver_RuntimeOperationStatus(t *testing.T) {
 			Message:   &message,
 		}
 
-		provisioningService.On("RuntimeOperationStatus", operationID).Return(operationStatus, nil)
+		provisioningService.On("RuntimeOperationStatus", tenantID, operationID).Return(operationStatus, nil)
 
 		//when
 		status, err := provisioner.RuntimeOperationStatus(ctx, operationID)
@@ -218,7 +379,7 @@ func TestResolver_RuntimeOperationStatus(t *testing.T) {
 
 		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
 
-		provisioningService.On("RuntimeOperationStatus", operationID).Return(nil, errors.New("Some error"))
+		provisioningService.On("RuntimeOperationStatus", tenantID, operationID).Return(nil, errors.New("Some error"))
 
 		//when
 		status, err := provisioner.RuntimeOperationStatus(ctx, operationID)
@@ -228,3 +389,166 @@ func TestResolver_RuntimeOperationStatus(t *testing.T) {
 		require.Empty(t, status)
 	})
 }
+
+func TestResolver_Runtimes(t *testing.T) {
+	ctx := tenant.SaveToContext(context.Background(), tenantID)
+
+	t.Run("Should return page of runtimes", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		provider := "gcp"
+		filter := &gqlschema.RuntimesFilter{Provider: &provider}
+		first := 10
+		after := "cursor"
+
+		expectedPage := &gqlschema.RuntimePage{
+			Data:       []*gqlschema.Runtime{{ID: "1100bb59-9c40-4ebb-b846-7477c4dc5bbd", Provider: provider}},
+			PageInfo:   &gqlschema.PageInfo{StartCursor: after},
+			TotalCount: 1,
+		}
+
+		provisioningService.On("Runtimes", tenantID, filter, first, after).Return(expectedPage, nil)
+
+		//when
+		page, err := provisioner.Runtimes(ctx, filter, &first, &after)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, expectedPage, page)
+		provisioningService.AssertExpectations(t)
+	})
+
+	t.Run("Should use default page size when it is not provided", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		provisioningService.On("Runtimes", tenantID, (*gqlschema.RuntimesFilter)(nil), defaultPageSize, "").Return(&gqlschema.RuntimePage{}, nil)
+
+		//when
+		_, err := provisioner.Runtimes(ctx, nil, nil, nil)
+
+		//then
+		require.NoError(t, err)
+		provisioningService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when page size is smaller than 1", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+		first := 0
+
+		//when
+		page, err := provisioner.Runtimes(ctx, nil, &first, nil)
+
+		//then
+		require.Error(t, err)
+		assert.Nil(t, page)
+		provisioningService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when filtering by Pending state", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+		state := gqlschema.OperationStatePending
+
+		//when
+		page, err := provisioner.Runtimes(ctx, &gqlschema.RuntimesFilter{State: &state}, nil, nil)
+
+		//then
+		require.Error(t, err)
+		assert.Nil(t, page)
+		provisioningService.AssertExpectations(t)
+	})
+}
+
+func TestResolver_Operations(t *testing.T) {
+	ctx := tenant.SaveToContext(context.Background(), tenantID)
+	runtimeID := "1100bb59-9c40-4ebb-b846-7477c4dc5bbd"
+
+	t.Run("Should return page of operations", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		operationID := "acc5040c-3bb6-47b8-8651-07f6950bd0a7"
+		operationType := gqlschema.OperationTypeProvision
+		state := gqlschema.OperationStateFailed
+		since := time.Date(2019, 11, 4, 12, 0, 0, 0, time.UTC)
+
+		expectedPage := &gqlschema.OperationPage{
+			Data:       []*gqlschema.OperationStatus{{ID: &operationID, Operation: operationType, State: state, RuntimeID: &runtimeID}},
+			PageInfo:   &gqlschema.PageInfo{},
+			TotalCount: 1,
+		}
+
+		provisioningService.On("Operations", tenantID, &runtimeID, &operationType, &state, &since, defaultPageSize, "").Return(expectedPage, nil)
+
+		//when
+		page, err := provisioner.Operations(ctx, &runtimeID, &operationType, &state, &since, nil, nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, expectedPage, page)
+		provisioningService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when listing operations fails", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		provisioningService.On("Operations", tenantID, &runtimeID, (*gqlschema.OperationType)(nil), (*gqlschema.OperationState)(nil), (*time.Time)(nil), defaultPageSize, "").
+			Return(nil, errors.New("some error"))
+
+		//when
+		page, err := provisioner.Operations(ctx, &runtimeID, nil, nil, nil, nil, nil)
+
+		//then
+		require.Error(t, err)
+		assert.Nil(t, page)
+		provisioningService.AssertExpectations(t)
+	})
+}
+
+func TestResolver_HyperscalerAccountPools(t *testing.T) {
+	t.Run("Should return pools of the tenant", func(t *testing.T) {
+		//given
+		ctx := tenant.SaveToContext(context.Background(), tenantID)
+		hyperscaler := gqlschema.HyperscalerTypeGcp
+
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		expectedStatuses := []*gqlschema.HyperscalerAccountPoolStatus{
+			{Hyperscaler: gqlschema.HyperscalerTypeGcp, AccountName: "account", Total: 2, Assigned: 1, Free: 1},
+		}
+
+		provisioningService.On("HyperscalerAccountPools", tenantID, &hyperscaler, (*string)(nil)).Return(expectedStatuses, nil)
+
+		//when
+		statuses, err := provisioner.HyperscalerAccountPools(ctx, &hyperscaler, nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, expectedStatuses, statuses)
+		provisioningService.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when tenant is missing", func(t *testing.T) {
+		//given
+		provisioningService := &mocks.Service{}
+		provisioner := NewResolver(provisioningService)
+
+		//when
+		_, err := provisioner.HyperscalerAccountPools(context.Background(), nil, nil)
+
+		//then
+		require.Error(t, err)
+		provisioningService.AssertNotCalled(t, "HyperscalerAccountPools", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
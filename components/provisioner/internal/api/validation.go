@@ -0,0 +1,236 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
+)
+
+var machineTypePatterns = map[string]*regexp.Regexp{
+	"gcp":   regexp.MustCompile(`^[a-z][a-z0-9]*-[a-z0-9-]+$`),
+	"aws":   regexp.MustCompile(`^[a-z][a-z0-9-]*\.[a-z0-9]+$`),
+	"azure": regexp.MustCompile(`^Standard_[A-Za-z0-9_]+$`),
+}
+
+type validator struct {
+	errors []*gqlschema.ValidationError
+}
+
+func (v *validator) addError(field, format string, args ...interface{}) {
+	v.errors = append(v.errors, &gqlschema.ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) notEmpty(field, value string) {
+	if value == "" {
+		v.addError(field, "must not be empty")
+	}
+}
+
+func (v *validator) positive(field string, value int) {
+	if value < 1 {
+		v.addError(field, "must be greater than 0")
+	}
+}
+
+func (v *validator) diskSize(field, value string) {
+	size, err := strconv.Atoi(value)
+	if err != nil {
+		v.addError(field, "must be a number of gigabytes, got %q", value)
+		return
+	}
+	v.positive(field, size)
+}
+
+func (v *validator) machineType(field, provider, value string) {
+	pattern, found := machineTypePatterns[provider]
+	if !found {
+		return
+	}
+
+	if !pattern.MatchString(value) {
+		v.addError(field, "%q is not a valid %s machine type", value, provider)
+	}
+}
+
+func (v *validator) cidr(field, value string) {
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		v.addError(field, "%q is not a valid CIDR", value)
+	}
+}
+
+func (v *validator) autoScaler(path string, min, max int) {
+	if min < 0 {
+		v.addError(path+".autoScalerMin", "must not be negative")
+	}
+
+	if min > max {
+		v.addError(path+".autoScalerMax", "must not be smaller than autoScalerMin")
+	}
+}
+
+// validateProvisionRuntimeInput performs all checks which do not require access to the cluster or the secrets
+// and returns an error for every invalid field
+func validateProvisionRuntimeInput(config gqlschema.ProvisionRuntimeInput) []*gqlschema.ValidationError {
+	v := &validator{errors: []*gqlschema.ValidationError{}}
+
+	v.validateClusterConfig(config.ClusterConfig)
+	v.validateCredentials(config.Credentials)
+	v.validateKymaConfig(config.KymaConfig)
+
+	return v.errors
+}
+
+func (v *validator) validateClusterConfig(config *gqlschema.ClusterConfigInput) {
+	if config == nil {
+		v.addError("clusterConfig", "must be provided")
+		return
+	}
+
+	providedConfigs := 0
+	if config.GardenerConfig != nil {
+		providedConfigs++
+		v.validateGardenerConfig("clusterConfig.gardenerConfig", config.GardenerConfig)
+	}
+	if config.GcpConfig != nil {
+		providedConfigs++
+		v.validateGCPConfig("clusterConfig.gcpConfig", config.GcpConfig)
+	}
+	if config.AwsConfig != nil {
+		providedConfigs++
+		v.validateAWSConfig("clusterConfig.awsConfig", config.AwsConfig)
+	}
+	if config.AzureConfig != nil {
+		providedConfigs++
+		v.validateAzureConfig("clusterConfig.azureConfig", config.AzureConfig)
+	}
+
+	if providedConfigs != 1 {
+		v.addError("clusterConfig", "exactly one of gardenerConfig, gcpConfig, awsConfig or azureConfig must be provided")
+	}
+}
+
+func (v *validator) validateGardenerConfig(path string, config *gqlschema.GardenerConfigInput) {
+	v.notEmpty(path+".name", config.Name)
+	v.notEmpty(path+".projectName", config.ProjectName)
+	v.notEmpty(path+".kubernetesVersion", config.KubernetesVersion)
+	v.positive(path+".nodeCount", config.NodeCount)
+	v.diskSize(path+".volumeSize", config.VolumeSize)
+	v.notEmpty(path+".region", config.Region)
+	v.notEmpty(path+".targetSecret", config.TargetSecret)
+	v.notEmpty(path+".diskType", config.DiskType)
+	v.notEmpty(path+".zone", config.Zone)
+	v.cidr(path+".cidr", config.Cidr)
+	v.autoScaler(path, config.AutoScalerMin, config.AutoScalerMax)
+
+	if config.MaxSurge < 0 {
+		v.addError(path+".maxSurge", "must not be negative")
+	}
+	if config.MaxUnavailable < 0 {
+		v.addError(path+".maxUnavailable", "must not be negative")
+	}
+
+	targetProvider := strings.ToLower(config.TargetProvider)
+	if _, found := machineTypePatterns[targetProvider]; !found {
+		v.addError(path+".targetProvider", "%q is not supported, use one of gcp, aws or azure", config.TargetProvider)
+		return
+	}
+	v.machineType(path+".machineType", targetProvider, config.MachineType)
+}
+
+func (v *validator) validateGCPConfig(path string, config *gqlschema.GCPConfigInput) {
+	v.notEmpty(path+".name", config.Name)
+	v.notEmpty(path+".projectName", config.ProjectName)
+	v.notEmpty(path+".kubernetesVersion", config.KubernetesVersion)
+	v.positive(path+".numberOfNodes", config.NumberOfNodes)
+	v.diskSize(path+".bootDiskSize", config.BootDiskSize)
+	v.machineType(path+".machineType", "gcp", config.MachineType)
+	v.notEmpty(path+".region", config.Region)
+}
+
+func (v *validator) validateAWSConfig(path string, config *gqlschema.AWSConfigInput) {
+	v.notEmpty(path+".name", config.Name)
+	v.notEmpty(path+".kubernetesVersion", config.KubernetesVersion)
+	v.positive(path+".nodeCount", config.NodeCount)
+	v.diskSize(path+".volumeSize", config.VolumeSize)
+	v.machineType(path+".machineType", "aws", config.MachineType)
+	v.notEmpty(path+".region", config.Region)
+	v.notEmpty(path+".zone", config.Zone)
+	v.cidr(path+".vpcCidr", config.VpcCidr)
+	v.cidr(path+".publicCidr", config.PublicCidr)
+	v.cidr(path+".internalCidr", config.InternalCidr)
+	v.autoScaler(path, config.AutoScalerMin, config.AutoScalerMax)
+}
+
+func (v *validator) validateAzureConfig(path string, config *gqlschema.AzureConfigInput) {
+	v.notEmpty(path+".name", config.Name)
+	v.notEmpty(path+".kubernetesVersion", config.KubernetesVersion)
+	v.positive(path+".nodeCount", config.NodeCount)
+	v.diskSize(path+".volumeSize", config.VolumeSize)
+	v.machineType(path+".machineType", "azure", config.MachineType)
+	v.notEmpty(path+".region", config.Region)
+	v.cidr(path+".vnetCidr", config.VnetCidr)
+	v.autoScaler(path, config.AutoScalerMin, config.AutoScalerMax)
+}
+
+func (v *validator) validateCredentials(credentials *gqlschema.CredentialsInput) {
+	if credentials == nil {
+		v.addError("credentials", "must be provided")
+		return
+	}
+
+	hasSecretName := credentials.SecretName != nil && *credentials.SecretName != ""
+
+	if hasSecretName == (credentials.HyperscalerAccount != nil) {
+		v.addError("credentials", "exactly one of secretName or hyperscalerAccount must be provided")
+	}
+
+	if credentials.HyperscalerAccount != nil {
+		v.notEmpty("credentials.hyperscalerAccount.accountName", credentials.HyperscalerAccount.AccountName)
+	}
+}
+
+func (v *validator) validateKymaConfig(config *gqlschema.KymaConfigInput) {
+	if config == nil {
+		v.addError("kymaConfig", "must be provided")
+		return
+	}
+
+	v.notEmpty("kymaConfig.version", config.Version)
+
+	if len(config.Modules) == 0 {
+		v.addError("kymaConfig.modules", "must not be empty")
+	}
+
+	for i, component := range config.Components {
+		if component == nil {
+			continue
+		}
+
+		path := fmt.Sprintf("kymaConfig.components[%d]", i)
+		v.notEmpty(path+".component", string(component.Component))
+		v.validateConfiguration(path+".configuration", component.Configuration)
+	}
+
+	v.validateConfiguration("kymaConfig.configuration", config.Configuration)
+}
+
+func (v *validator) validateConfiguration(path string, configuration []*gqlschema.ConfigurationInput) {
+	for i, entry := range configuration {
+		if entry != nil && entry.Key == "" {
+			v.addError(fmt.Sprintf("%s[%d].key", path, i), "must not be empty")
+		}
+	}
+}
+
+func validationFailure(validationErrors []*gqlschema.ValidationError) error {
+	messages := make([]string, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		messages = append(messages, fmt.Sprintf("%s %s", validationError.Field, validationError.Message))
+	}
+
+	return fmt.Errorf("cannot provision runtime since the input is invalid: %s", strings.Join(messages, "; "))
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProvisionRuntimeInput(t *testing.T) {
+	secretName := "secretName"
+
+	gcpConfig := func() *gqlschema.GCPConfigInput {
+		return &gqlschema.GCPConfigInput{
+			Name:              "Something",
+			ProjectName:       "Project",
+			KubernetesVersion: "1.15",
+			NumberOfNodes:     3,
+			BootDiskSize:      "30",
+			MachineType:       "n1-standard-4",
+			Region:            "europe-west4",
+		}
+	}
+
+	gardenerConfig := func() *gqlschema.GardenerConfigInput {
+		return &gqlschema.GardenerConfigInput{
+			Name:              "Something",
+			ProjectName:       "Project",
+			KubernetesVersion: "1.15",
+			NodeCount:         3,
+			VolumeSize:        "35",
+			MachineType:       "Standard_D8_v3",
+			Region:            "westeurope",
+			TargetProvider:    "Azure",
+			TargetSecret:      "secret",
+			DiskType:          "Standard_LRS",
+			Zone:              "1",
+			Cidr:              "10.250.0.0/19",
+			AutoScalerMin:     2,
+			AutoScalerMax:     4,
+			MaxSurge:          4,
+			MaxUnavailable:    1,
+		}
+	}
+
+	awsConfig := func() *gqlschema.AWSConfigInput {
+		return &gqlschema.AWSConfigInput{
+			Name:              "Something",
+			KubernetesVersion: "1.15",
+			NodeCount:         3,
+			VolumeSize:        "50",
+			MachineType:       "m5.xlarge",
+			Region:            "eu-central-1",
+			Zone:              "eu-central-1a",
+			VpcCidr:           "10.250.0.0/16",
+			PublicCidr:        "10.250.96.0/22",
+			InternalCidr:      "10.250.112.0/22",
+			AutoScalerMin:     2,
+			AutoScalerMax:     4,
+		}
+	}
+
+	input := func(clusterConfig *gqlschema.ClusterConfigInput) gqlschema.ProvisionRuntimeInput {
+		return gqlschema.ProvisionRuntimeInput{
+			ClusterConfig: clusterConfig,
+			Credentials:   &gqlschema.CredentialsInput{SecretName: &secretName},
+			KymaConfig:    &gqlschema.KymaConfigInput{Version: "1.8", Modules: gqlschema.AllKymaModule},
+		}
+	}
+
+	for _, testCase := range []struct {
+		description    string
+		input          func() gqlschema.ProvisionRuntimeInput
+		expectedFields []string
+	}{
+		{
+			description: "valid GCP config",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				return input(&gqlschema.ClusterConfigInput{GcpConfig: gcpConfig()})
+			},
+		},
+		{
+			description: "valid Gardener config",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				return input(&gqlschema.ClusterConfigInput{GardenerConfig: gardenerConfig()})
+			},
+		},
+		{
+			description: "valid AWS config",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				return input(&gqlschema.ClusterConfigInput{AwsConfig: awsConfig()})
+			},
+		},
+		{
+			description: "missing cluster config",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				return input(&gqlschema.ClusterConfigInput{})
+			},
+			expectedFields: []string{"clusterConfig"},
+		},
+		{
+			description: "two cluster configs",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				return input(&gqlschema.ClusterConfigInput{GcpConfig: gcpConfig(), AwsConfig: awsConfig()})
+			},
+			expectedFields: []string{"clusterConfig"},
+		},
+		{
+			description: "non-numeric boot disk size and unknown machine type",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				config := gcpConfig()
+				config.BootDiskSize = "30GB"
+				config.MachineType = "m5.xlarge"
+				return input(&gqlschema.ClusterConfigInput{GcpConfig: config})
+			},
+			expectedFields: []string{"clusterConfig.gcpConfig.bootDiskSize", "clusterConfig.gcpConfig.machineType"},
+		},
+		{
+			description: "unsupported Gardener target provider",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				config := gardenerConfig()
+				config.TargetProvider = "openstack"
+				return input(&gqlschema.ClusterConfigInput{GardenerConfig: config})
+			},
+			expectedFields: []string{"clusterConfig.gardenerConfig.targetProvider"},
+		},
+		{
+			description: "invalid Gardener CIDR and autoscaler limits",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				config := gardenerConfig()
+				config.Cidr = "10.250.0.0"
+				config.AutoScalerMin = 5
+				return input(&gqlschema.ClusterConfigInput{GardenerConfig: config})
+			},
+			expectedFields: []string{"clusterConfig.gardenerConfig.cidr", "clusterConfig.gardenerConfig.autoScalerMax"},
+		},
+		{
+			description: "zero AWS nodes",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				config := awsConfig()
+				config.NodeCount = 0
+				return input(&gqlschema.ClusterConfigInput{AwsConfig: config})
+			},
+			expectedFields: []string{"clusterConfig.awsConfig.nodeCount"},
+		},
+		{
+			description: "both secret name and hyperscaler account",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				config := input(&gqlschema.ClusterConfigInput{GcpConfig: gcpConfig()})
+				config.Credentials.HyperscalerAccount = &gqlschema.HyperscalerAccountInput{Hyperscaler: gqlschema.HyperscalerTypeGcp, AccountName: "account"}
+				return config
+			},
+			expectedFields: []string{"credentials"},
+		},
+		{
+			description: "empty Kyma modules and configuration key",
+			input: func() gqlschema.ProvisionRuntimeInput {
+				config := input(&gqlschema.ClusterConfigInput{GcpConfig: gcpConfig()})
+				config.KymaConfig.Modules = nil
+				config.KymaConfig.Components = []*gqlschema.ComponentConfigurationInput{
+					{Component: "assetstore", Configuration: []*gqlschema.ConfigurationInput{{Key: "", Value: "value"}}},
+				}
+				return config
+			},
+			expectedFields: []string{"kymaConfig.modules", "kymaConfig.components[0].configuration[0].key"},
+		},
+	} {
+		t.Run("Should validate "+testCase.description, func(t *testing.T) {
+			//when
+			validationErrors := validateProvisionRuntimeInput(testCase.input())
+
+			//then
+			fields := make([]string, 0, len(validationErrors))
+			for _, validationError := range validationErrors {
+				fields = append(fields, validationError.Field)
+			}
+
+			if len(testCase.expectedFields) == 0 {
+				assert.Empty(t, fields)
+			} else {
+				assert.Equal(t, testCase.expectedFields, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package authenticator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/scope"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/tenant"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tnt             = "2a1502ba-aded-11e9-a2a3-2a2ae2dbcce4"
+	scopes          = "runtime:read runtime:write"
+	publicJWKSURL   = "file://testdata/jwks-public.json"
+	privateJWKSURL  = "file://testdata/jwks-private.json"
+	privateJWKS2URL = "file://testdata/jwks-private2.json"
+	invalidJWKSURL  = "file://testdata/invalid.json"
+)
+
+func TestAuthenticator_SynchronizeJWKS(t *testing.T) {
+	t.Run("Should fetch JWKS", func(t *testing.T) {
+		//given
+		auth := New(publicJWKSURL, false)
+
+		//when
+		err := auth.SynchronizeJWKS()
+
+		//then
+		require.NoError(t, err)
+		assert.NotNil(t, auth.Jwks)
+	})
+
+	t.Run("Should return error when JWKS are invalid", func(t *testing.T) {
+		//given
+		auth := New(invalidJWKSURL, false)
+
+		//when
+		err := auth.SynchronizeJWKS()
+
+		//then
+		require.Error(t, err)
+	})
+}
+
+func TestAuthenticator_Handler(t *testing.T) {
+	privateJWKS, err := FetchJWK(privateJWKSURL)
+	require.NoError(t, err)
+
+	privateJWKS2, err := FetchJWK(privateJWKS2URL)
+	require.NoError(t, err)
+
+	t.Run("Should save tenant and scopes from signed token in context", func(t *testing.T) {
+		//given
+		handler := createMiddleware(t, false)(testHandler(t))
+		token := createSignedToken(t, jwtTokenClaims{Tenant: tnt, Scopes: scopes}, privateJWKS.Keys[0])
+
+		//when
+		rr := serve(t, handler, token)
+
+		//then
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "OK", rr.Body.String())
+	})
+
+	t.Run("Should accept token without signature when it is allowed", func(t *testing.T) {
+		//given
+		handler := createMiddleware(t, true)(testHandler(t))
+		token := createNotSignedToken(t, jwtTokenClaims{Tenant: tnt, Scopes: scopes})
+
+		//when
+		rr := serve(t, handler, token)
+
+		//then
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Should reject token without signature when it is not allowed", func(t *testing.T) {
+		//given
+		handler := createMiddleware(t, false)(testHandler(t))
+		token := createNotSignedToken(t, jwtTokenClaims{Tenant: tnt, Scopes: scopes})
+
+		//when
+		rr := serve(t, handler, token)
+
+		//then
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "while parsing token: unexpected signing method: none\n", rr.Body.String())
+	})
+
+	t.Run("Should reject token signed with different key", func(t *testing.T) {
+		//given
+		handler := createMiddleware(t, false)(testHandler(t))
+		token := createSignedToken(t, jwtTokenClaims{Tenant: tnt, Scopes: scopes}, privateJWKS2.Keys[0])
+
+		//when
+		rr := serve(t, handler, token)
+
+		//then
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "while parsing token: crypto/rsa: verification error\n", rr.Body.String())
+	})
+
+	t.Run("Should reject token without tenant", func(t *testing.T) {
+		//given
+		handler := createMiddleware(t, false)(testHandler(t))
+		token := createSignedToken(t, jwtTokenClaims{Scopes: scopes}, privateJWKS.Keys[0])
+
+		//when
+		rr := serve(t, handler, token)
+
+		//then
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "while parsing token: Tenant cannot be empty\n", rr.Body.String())
+	})
+
+	t.Run("Should reject expired token", func(t *testing.T) {
+		//given
+		handler := createMiddleware(t, false)(testHandler(t))
+		claims := jwtTokenClaims{Tenant: tnt, Scopes: scopes, StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(-time.Minute).Unix()}}
+		token := createSignedToken(t, claims, privateJWKS.Keys[0])
+
+		//when
+		rr := serve(t, handler, token)
+
+		//then
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("Should return bad request when token is missing", func(t *testing.T) {
+		//given
+		handler := createMiddleware(t, false)(testHandler(t))
+
+		//when
+		rr := serve(t, handler, "")
+
+		//then
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+type jwtTokenClaims struct {
+	Scopes string `json:"scopes"`
+	Tenant string `json:"tenant"`
+	jwt.StandardClaims
+}
+
+func createNotSignedToken(t *testing.T, claims jwtTokenClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+
+	signedToken, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	return signedToken
+}
+
+func createSignedToken(t *testing.T, claims jwtTokenClaims, key jwk.Key) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+
+	materializedKey, err := key.Materialize()
+	require.NoError(t, err)
+
+	signedToken, err := token.SignedString(materializedKey)
+	require.NoError(t, err)
+
+	return signedToken
+}
+
+func createMiddleware(t *testing.T, allowJWTSigningNone bool) func(next http.Handler) http.Handler {
+	auth := New(publicJWKSURL, allowJWTSigningNone)
+
+	err := auth.SynchronizeJWKS()
+	require.NoError(t, err)
+
+	return auth.Handler()
+}
+
+func serve(t *testing.T, handler http.Handler, token string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodPost, "/graphql", nil)
+	require.NoError(t, err)
+
+	if token != "" {
+		req.Header.Add(AuthorizationHeaderKey, fmt.Sprintf("Bearer %s", token))
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func testHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantFromContext, err := tenant.LoadFromContext(r.Context())
+		require.NoError(t, err)
+		assert.Equal(t, tnt, tenantFromContext)
+
+		scopesFromContext, err := scope.LoadFromContext(r.Context())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, strings.Split(scopes, " "), scopesFromContext)
+
+		_, err = w.Write([]byte("OK"))
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,236 @@
+package director
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	TenantHeader = "Tenant"
+
+	generateRuntimeTokenMutation = `mutation ($id: ID!) {
+	result: generateOneTimeTokenForRuntime(id: $id) {
+		token
+		connectorURL
+	}
+}`
+
+	createRuntimeMutation = `mutation ($in: RuntimeInput!) {
+	result: createRuntime(in: $in) {
+		id
+	}
+}`
+
+	runtimeQuery = `query ($id: ID!) {
+	result: runtime(id: $id) {
+		id
+		name
+		description
+		labels
+	}
+}`
+
+	updateRuntimeMutation = `mutation ($id: ID!, $in: RuntimeInput!) {
+	result: updateRuntime(id: $id, in: $in) {
+		id
+	}
+}`
+
+	deleteRuntimeMutation = `mutation ($id: ID!) {
+	result: deleteRuntime(id: $id) {
+		id
+	}
+}`
+)
+
+type OneTimeToken struct {
+	Token        string `json:"token"`
+	ConnectorURL string `json:"connectorURL"`
+}
+
+type RuntimeStatusCondition string
+
+const (
+	RuntimeStatusConditionInitial RuntimeStatusCondition = "INITIAL"
+	RuntimeStatusConditionReady   RuntimeStatusCondition = "READY"
+	RuntimeStatusConditionFailed  RuntimeStatusCondition = "FAILED"
+)
+
+type RuntimeInput struct {
+	Name            string                  `json:"name"`
+	Description     *string                 `json:"description,omitempty"`
+	Labels          map[string]interface{}  `json:"labels,omitempty"`
+	StatusCondition *RuntimeStatusCondition `json:"statusCondition,omitempty"`
+}
+
+type runtime struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description *string                `json:"description"`
+	Labels      map[string]interface{} `json:"labels"`
+}
+
+//go:generate mockery -name=Client
+type Client interface {
+	CreateRuntime(tenant string, input RuntimeInput) (string, error)
+	SetRuntimeStatusCondition(tenant, runtimeID string, condition RuntimeStatusCondition) error
+	DeleteRuntime(tenant, runtimeID string) error
+	GetConnectionToken(tenant, runtimeID string) (OneTimeToken, error)
+}
+
+type client struct {
+	httpClient    *http.Client
+	url           string
+	tokenProvider TokenProvider
+}
+
+func NewDirectorClient(httpClient *http.Client, url string, tokenProvider TokenProvider) Client {
+	return &client{
+		httpClient:    httpClient,
+		url:           url,
+		tokenProvider: tokenProvider,
+	}
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type graphQLResponse struct {
+	Data *struct {
+		Result json.RawMessage `json:"result"`
+	} `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+func (c *client) CreateRuntime(tenant string, input RuntimeInput) (string, error) {
+	var created runtime
+	err := c.execute(tenant, createRuntimeMutation, map[string]interface{}{"in": input}, &created)
+	if err != nil {
+		return "", errors.WithMessagef(err, "Failed to create %s runtime in Director", input.Name)
+	}
+
+	if created.ID == "" {
+		return "", errors.Errorf("Director returned empty ID of %s runtime", input.Name)
+	}
+
+	return created.ID, nil
+}
+
+// SetRuntimeStatusCondition updates the runtime with its current name, description and labels, as the Director replaces all of them on update
+func (c *client) SetRuntimeStatusCondition(tenant, runtimeID string, condition RuntimeStatusCondition) error {
+	var current *runtime
+	err := c.execute(tenant, runtimeQuery, map[string]interface{}{"id": runtimeID}, &current)
+	if err != nil {
+		return errors.WithMessagef(err, "Failed to get runtime %s from Director", runtimeID)
+	}
+
+	if current == nil {
+		return errors.Errorf("Runtime %s not found in Director", runtimeID)
+	}
+
+	input := RuntimeInput{
+		Name:            current.Name,
+		Description:     current.Description,
+		Labels:          current.Labels,
+		StatusCondition: &condition,
+	}
+
+	err = c.execute(tenant, updateRuntimeMutation, map[string]interface{}{"id": runtimeID, "in": input}, nil)
+	if err != nil {
+		return errors.WithMessagef(err, "Failed to update status of runtime %s in Director", runtimeID)
+	}
+
+	return nil
+}
+
+func (c *client) DeleteRuntime(tenant, runtimeID string) error {
+	err := c.execute(tenant, deleteRuntimeMutation, map[string]interface{}{"id": runtimeID}, nil)
+	if err != nil {
+		return errors.WithMessagef(err, "Failed to delete runtime %s from Director", runtimeID)
+	}
+
+	return nil
+}
+
+func (c *client) GetConnectionToken(tenant, runtimeID string) (OneTimeToken, error) {
+	var token OneTimeToken
+	err := c.execute(tenant, generateRuntimeTokenMutation, map[string]interface{}{"id": runtimeID}, &token)
+	if err != nil {
+		return OneTimeToken{}, errors.WithMessagef(err, "Failed to generate one-time token for runtime %s", runtimeID)
+	}
+
+	if token.Token == "" {
+		return OneTimeToken{}, errors.Errorf("Director returned empty one-time token for runtime %s", runtimeID)
+	}
+
+	return token, nil
+}
+
+// execute sends the GraphQL request to the Director on behalf of the tenant and decodes the result field of the response into the given value
+func (c *client) execute(tenant, query string, variables map[string]interface{}, result interface{}) error {
+	accessToken, err := c.tokenProvider.GetAccessToken()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get access token for Director")
+	}
+
+	body, err := json.Marshal(graphQLRequest{
+		Query:     query,
+		Variables: variables,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to marshal Director request")
+	}
+
+	request, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "Failed to create Director request")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	request.Header.Set(TenantHeader, tenant)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "Failed to call Director")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("Director responded with unexpected status %d", response.StatusCode)
+	}
+
+	var graphQLResp graphQLResponse
+	err = json.NewDecoder(response.Body).Decode(&graphQLResp)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decode Director response")
+	}
+
+	if len(graphQLResp.Errors) > 0 {
+		return errors.New(graphQLResp.Errors[0].Message)
+	}
+
+	if graphQLResp.Data == nil {
+		return errors.New("Director returned empty response")
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(graphQLResp.Data.Result, result)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decode Director response")
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package director_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	runtimeID   = "runtimeID"
+	tenant      = "tenant"
+	accessToken = "access-token"
+)
+
+func TestClient_GetConnectionToken(t *testing.T) {
+	t.Run("Should return one-time token for runtime", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, fmt.Sprintf("Bearer %s", accessToken), r.Header.Get("Authorization"))
+			assert.Equal(t, tenant, r.Header.Get(director.TenantHeader))
+
+			var request struct {
+				Variables map[string]interface{} `json:"variables"`
+			}
+			err := json.NewDecoder(r.Body).Decode(&request)
+			require.NoError(t, err)
+			assert.Equal(t, runtimeID, request.Variables["id"])
+
+			_, err = w.Write([]byte(`{"data":{"result":{"token":"token","connectorURL":"https://connector.kyma.local/graphql"}}}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return(accessToken, nil)
+
+		client := director.NewDirectorClient(http.DefaultClient, server.URL, tokenProvider)
+
+		//when
+		token, err := client.GetConnectionToken(tenant, runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, director.OneTimeToken{Token: "token", ConnectorURL: "https://connector.kyma.local/graphql"}, token)
+		tokenProvider.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when Director responded with GraphQL error", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"data":null,"errors":[{"message":"runtime not found"}]}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return(accessToken, nil)
+
+		client := director.NewDirectorClient(http.DefaultClient, server.URL, tokenProvider)
+
+		//when
+		_, err := client.GetConnectionToken(tenant, runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "runtime not found")
+	})
+
+	t.Run("Should return error when Director responded with unexpected status", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return(accessToken, nil)
+
+		client := director.NewDirectorClient(http.DefaultClient, server.URL, tokenProvider)
+
+		//when
+		_, err := client.GetConnectionToken(tenant, runtimeID)
+
+		//then
+		require.Error(t, err)
+	})
+
+	t.Run("Should return error when failed to get access token", func(t *testing.T) {
+		//given
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return("", errors.New("error"))
+
+		client := director.NewDirectorClient(http.DefaultClient, "http://director", tokenProvider)
+
+		//when
+		_, err := client.GetConnectionToken(tenant, runtimeID)
+
+		//then
+		require.Error(t, err)
+		tokenProvider.AssertExpectations(t)
+	})
+}
+
+func TestClient_CreateRuntime(t *testing.T) {
+	t.Run("Should create runtime and return its ID", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				Variables struct {
+					In director.RuntimeInput `json:"in"`
+				} `json:"variables"`
+			}
+			err := json.NewDecoder(r.Body).Decode(&request)
+			require.NoError(t, err)
+			assert.Equal(t, "runtime", request.Variables.In.Name)
+			assert.Equal(t, "gcp", request.Variables.In.Labels["provider"])
+
+			_, err = w.Write([]byte(`{"data":{"result":{"id":"runtimeID"}}}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return(accessToken, nil)
+
+		client := director.NewDirectorClient(http.DefaultClient, server.URL, tokenProvider)
+
+		//when
+		id, err := client.CreateRuntime(tenant, director.RuntimeInput{Name: "runtime", Labels: map[string]interface{}{"provider": "gcp"}})
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, runtimeID, id)
+	})
+
+	t.Run("Should return error when Director responded with GraphQL error", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"data":null,"errors":[{"message":"invalid name"}]}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return(accessToken, nil)
+
+		client := director.NewDirectorClient(http.DefaultClient, server.URL, tokenProvider)
+
+		//when
+		_, err := client.CreateRuntime(tenant, director.RuntimeInput{Name: "Runtime"})
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid name")
+	})
+}
+
+func TestClient_SetRuntimeStatusCondition(t *testing.T) {
+	t.Run("Should update runtime keeping its name, description and labels", func(t *testing.T) {
+		//given
+		var updated director.RuntimeInput
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				Query     string `json:"query"`
+				Variables struct {
+					ID string                `json:"id"`
+					In director.RuntimeInput `json:"in"`
+				} `json:"variables"`
+			}
+			err := json.NewDecoder(r.Body).Decode(&request)
+			require.NoError(t, err)
+			assert.Equal(t, runtimeID, request.Variables.ID)
+
+			if strings.Contains(request.Query, "updateRuntime") {
+				updated = request.Variables.In
+				_, err = w.Write([]byte(`{"data":{"result":{"id":"runtimeID"}}}`))
+			} else {
+				_, err = w.Write([]byte(`{"data":{"result":{"id":"runtimeID","name":"runtime","description":"desc","labels":{"scenarios":["DEFAULT"]}}}}`))
+			}
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return(accessToken, nil)
+
+		client := director.NewDirectorClient(http.DefaultClient, server.URL, tokenProvider)
+
+		//when
+		err := client.SetRuntimeStatusCondition(tenant, runtimeID, director.RuntimeStatusConditionReady)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "runtime", updated.Name)
+		require.NotNil(t, updated.Description)
+		assert.Equal(t, "desc", *updated.Description)
+		assert.Equal(t, []interface{}{"DEFAULT"}, updated.Labels["scenarios"])
+		require.NotNil(t, updated.StatusCondition)
+		assert.Equal(t, director.RuntimeStatusConditionReady, *updated.StatusCondition)
+	})
+
+	t.Run("Should return error when runtime does not exist", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{"data":{"result":null}}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return(accessToken, nil)
+
+		client := director.NewDirectorClient(http.DefaultClient, server.URL, tokenProvider)
+
+		//when
+		err := client.SetRuntimeStatusCondition(tenant, runtimeID, director.RuntimeStatusConditionFailed)
+
+		//then
+		require.Error(t, err)
+	})
+}
+
+func TestClient_DeleteRuntime(t *testing.T) {
+	t.Run("Should delete runtime", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				Query     string                 `json:"query"`
+				Variables map[string]interface{} `json:"variables"`
+			}
+			err := json.NewDecoder(r.Body).Decode(&request)
+			require.NoError(t, err)
+			assert.Contains(t, request.Query, "deleteRuntime")
+			assert.Equal(t, runtimeID, request.Variables["id"])
+
+			_, err = w.Write([]byte(`{"data":{"result":{"id":"runtimeID"}}}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		tokenProvider := &mocks.TokenProvider{}
+		tokenProvider.On("GetAccessToken").Return(accessToken, nil)
+
+		client := director.NewDirectorClient(http.DefaultClient, server.URL, tokenProvider)
+
+		//when
+		err := client.DeleteRuntime(tenant, runtimeID)
+
+		//then
+		require.NoError(t, err)
+		tokenProvider.AssertExpectations(t)
+	})
+}
+
+func TestClientCredentialsProvider_GetAccessToken(t *testing.T) {
+	t.Run("Should fetch access token once and cache it", func(t *testing.T) {
+		//given
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			clientID, clientSecret, ok := r.BasicAuth()
+			require.True(t, ok)
+			assert.Equal(t, "client", clientID)
+			assert.Equal(t, "secret", clientSecret)
+
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "runtime:write", r.PostForm.Get("scope"))
+
+			_, err := w.Write([]byte(fmt.Sprintf(`{"access_token":"%s","expires_in":3600}`, accessToken)))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		provider := director.NewClientCredentialsProvider(http.DefaultClient, director.OAuthConfig{
+			TokenURL:     server.URL,
+			ClientID:     "client",
+			ClientSecret: "secret",
+			Scopes:       []string{"runtime:write"},
+		})
+
+		//when
+		first, err := provider.GetAccessToken()
+		require.NoError(t, err)
+		second, err := provider.GetAccessToken()
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, accessToken, first)
+		assert.Equal(t, accessToken, second)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("Should return error when token endpoint responded with unexpected status", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		provider := director.NewClientCredentialsProvider(http.DefaultClient, director.OAuthConfig{TokenURL: server.URL})
+
+		//when
+		_, err := provider.GetAccessToken()
+
+		//then
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,96 @@
+package director
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// expirationMargin makes the token refreshed before it expires, so that it does not expire during the Director call
+const expirationMargin = 30 * time.Second
+
+//go:generate mockery -name=TokenProvider
+type TokenProvider interface {
+	GetAccessToken() (string, error)
+}
+
+type OAuthConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// clientCredentialsProvider fetches access tokens using OAuth 2.0 Client Credentials grant and caches them until they expire
+type clientCredentialsProvider struct {
+	httpClient *http.Client
+	config     OAuthConfig
+
+	mutex     sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func NewClientCredentialsProvider(httpClient *http.Client, config OAuthConfig) TokenProvider {
+	return &clientCredentialsProvider{
+		httpClient: httpClient,
+		config:     config,
+	}
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func (p *clientCredentialsProvider) GetAccessToken() (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.token != "" && time.Now().Before(p.expiresAt) {
+		return p.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(p.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.config.Scopes, " "))
+	}
+
+	request, err := http.NewRequest(http.MethodPost, p.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to create token request")
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(p.config.ClientID, p.config.ClientSecret)
+
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to fetch access token")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("Token endpoint responded with unexpected status %d", response.StatusCode)
+	}
+
+	var token tokenResponse
+	err = json.NewDecoder(response.Body).Decode(&token)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to decode access token")
+	}
+
+	if token.AccessToken == "" {
+		return "", errors.New("Token endpoint returned empty access token")
+	}
+
+	p.token = token.AccessToken
+	p.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - expirationMargin)
+
+	return p.token, nil
+}
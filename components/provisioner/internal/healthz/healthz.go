@@ -0,0 +1,114 @@
+package healthz
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+// Checker verifies whether a dependency of the component is available
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is an adapter allowing to use an ordinary function as a Checker
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Check is a named Checker of a single dependency. The failure of an informational Check is reported, but does not make the component unready.
+type Check struct {
+	Name          string
+	Checker       Checker
+	Informational bool
+}
+
+type Response struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks,omitempty"`
+}
+
+type CheckResult struct {
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+	Duration      string `json:"duration"`
+	Informational bool   `json:"informational,omitempty"`
+}
+
+// NewLivenessHandler returns the handler which reports that the process is able to serve requests, without checking its dependencies
+func NewLivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, http.StatusOK, Response{Status: StatusOK})
+	}
+}
+
+// NewReadinessHandler returns the handler which runs all checks concurrently and reports them in detail.
+// It responds with 503 Service Unavailable if any check which is not informational fails or does not finish within the timeout.
+func NewReadinessHandler(timeout time.Duration, checks ...Check) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		results := make([]CheckResult, len(checks))
+		wg := &sync.WaitGroup{}
+		for i, check := range checks {
+			wg.Add(1)
+			go func(i int, check Check) {
+				defer wg.Done()
+				results[i] = runCheck(ctx, check)
+			}(i, check)
+		}
+		wg.Wait()
+
+		response := Response{Status: StatusOK, Checks: results}
+		status := http.StatusOK
+		for _, result := range results {
+			if result.Status != StatusOK && !result.Informational {
+				response.Status = StatusFailed
+				status = http.StatusServiceUnavailable
+			}
+		}
+
+		writeResponse(w, status, response)
+	}
+}
+
+func runCheck(ctx context.Context, check Check) CheckResult {
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- check.Checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Name: check.Name, Status: StatusOK, Duration: time.Since(start).String(), Informational: check.Informational}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func writeResponse(w http.ResponseWriter, status int, response Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// the probe has gone away if the response cannot be written, so there is nobody to report the error to
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -19,6 +19,16 @@ func prepareConfig(input model.RuntimeConfig, credentialsFile string) (*types.Cl
 		return buildConfigForGCP(gcpConfig, credentialsFile)
 	}
 
+	awsConfig, ok := input.AWSConfig()
+	if ok {
+		return buildConfigForAWS(awsConfig, credentialsFile)
+	}
+
+	azureConfig, ok := input.AzureConfig()
+	if ok {
+		return buildConfigForAzure(azureConfig, credentialsFile)
+	}
+
 	return nil, nil, errors.New("configuration does not match any provider profiles")
 }
 
@@ -80,3 +90,63 @@ func buildConfigForGardener(config model.GardenerConfig, credentialsFile string)
 	}
 	return cluster, provider, nil
 }
+
+func buildConfigForAWS(config model.AWSConfig, credentialsFile string) (*types.Cluster, *types.Provider, error) {
+	diskSize, err := strconv.Atoi(config.VolumeSize)
+
+	if err != nil {
+		return &types.Cluster{}, &types.Provider{}, err
+	}
+
+	cluster := &types.Cluster{
+		KubernetesVersion: config.KubernetesVersion,
+		Name:              config.Name,
+		DiskSizeGB:        diskSize,
+		NodeCount:         config.NodeCount,
+		Location:          config.Region,
+		MachineType:       config.MachineType,
+	}
+
+	provider := &types.Provider{
+		Type:                types.AWS,
+		CredentialsFilePath: credentialsFile,
+		CustomConfigurations: map[string]interface{}{
+			"zone":           config.Zone,
+			"vpc_cidr":       config.VpcCidr,
+			"public_cidr":    config.PublicCidr,
+			"internal_cidr":  config.InternalCidr,
+			"autoscaler_min": config.AutoScalerMin,
+			"autoscaler_max": config.AutoScalerMax,
+		},
+	}
+	return cluster, provider, nil
+}
+
+func buildConfigForAzure(config model.AzureConfig, credentialsFile string) (*types.Cluster, *types.Provider, error) {
+	diskSize, err := strconv.Atoi(config.VolumeSize)
+
+	if err != nil {
+		return &types.Cluster{}, &types.Provider{}, err
+	}
+
+	cluster := &types.Cluster{
+		KubernetesVersion: config.KubernetesVersion,
+		Name:              config.Name,
+		DiskSizeGB:        diskSize,
+		NodeCount:         config.NodeCount,
+		Location:          config.Region,
+		MachineType:       config.MachineType,
+	}
+
+	provider := &types.Provider{
+		Type:                types.Azure,
+		CredentialsFilePath: credentialsFile,
+		CustomConfigurations: map[string]interface{}{
+			"zone":           config.Zone,
+			"vnet_cidr":      config.VnetCidr,
+			"autoscaler_min": config.AutoScalerMin,
+			"autoscaler_max": config.AutoScalerMax,
+		},
+	}
+	return cluster, provider, nil
+}
@@ -104,6 +104,103 @@ func TestConfiguration(t *testing.T) {
 		//when
 		cluster, provider, err := prepareConfig(config, credentials)
 
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, expectedCluster, cluster)
+		assert.Equal(t, expectedProvider, provider)
+	})
+	t.Run("Should return correct aws configuration", func(t *testing.T) {
+		//given
+		config := model.RuntimeConfig{ClusterConfig: model.AWSConfig{
+			ID:                "id",
+			Name:              "Something",
+			KubernetesVersion: "version",
+			NodeCount:         3,
+			VolumeSize:        "50",
+			MachineType:       "m5.xlarge",
+			Region:            "eu-central-1",
+			Zone:              "eu-central-1a",
+			VpcCidr:           "10.250.0.0/16",
+			PublicCidr:        "10.250.96.0/22",
+			InternalCidr:      "10.250.112.0/22",
+			AutoScalerMin:     1,
+			AutoScalerMax:     5,
+			ClusterID:         "runtimeID",
+		}}
+
+		credentials := "credentials.yaml"
+
+		expectedProvider := &types.Provider{
+			Type:                types.AWS,
+			CredentialsFilePath: credentials,
+			CustomConfigurations: map[string]interface{}{
+				"autoscaler_max": 5,
+				"autoscaler_min": 1,
+				"internal_cidr":  "10.250.112.0/22",
+				"public_cidr":    "10.250.96.0/22",
+				"vpc_cidr":       "10.250.0.0/16",
+				"zone":           "eu-central-1a"},
+		}
+
+		expectedCluster := &types.Cluster{
+			Name:              "Something",
+			NodeCount:         3,
+			DiskSizeGB:        50,
+			MachineType:       "m5.xlarge",
+			Location:          "eu-central-1",
+			KubernetesVersion: "version",
+		}
+
+		//when
+		cluster, provider, err := prepareConfig(config, credentials)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, expectedCluster, cluster)
+		assert.Equal(t, expectedProvider, provider)
+	})
+
+	t.Run("Should return correct azure configuration", func(t *testing.T) {
+		//given
+		config := model.RuntimeConfig{ClusterConfig: model.AzureConfig{
+			ID:                "id",
+			Name:              "Something",
+			KubernetesVersion: "version",
+			NodeCount:         3,
+			VolumeSize:        "50",
+			MachineType:       "Standard_D8_v3",
+			Region:            "westeurope",
+			Zone:              "1",
+			VnetCidr:          "10.250.0.0/19",
+			AutoScalerMin:     1,
+			AutoScalerMax:     5,
+			ClusterID:         "runtimeID",
+		}}
+
+		credentials := "credentials.yaml"
+
+		expectedProvider := &types.Provider{
+			Type:                types.Azure,
+			CredentialsFilePath: credentials,
+			CustomConfigurations: map[string]interface{}{
+				"autoscaler_max": 5,
+				"autoscaler_min": 1,
+				"vnet_cidr":      "10.250.0.0/19",
+				"zone":           "1"},
+		}
+
+		expectedCluster := &types.Cluster{
+			Name:              "Something",
+			NodeCount:         3,
+			DiskSizeGB:        50,
+			MachineType:       "Standard_D8_v3",
+			Location:          "westeurope",
+			KubernetesVersion: "version",
+		}
+
+		//when
+		cluster, provider, err := prepareConfig(config, credentials)
+
 		//then
 		require.NoError(t, err)
 		assert.Equal(t, expectedCluster, cluster)
@@ -4,9 +4,8 @@ package mocks
 
 import (
 	hydroform "github.com/kyma-incubator/compass/components/provisioner/internal/hydroform"
-	mock "github.com/stretchr/testify/mock"
-
 	model "github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -14,13 +13,48 @@ type Service struct {
 	mock.Mock
 }
 
-// DeprovisionCluster provides a mock function with given fields: runtimeConfig, secretName, terraformState
-func (_m *Service) DeprovisionCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string) error {
+// CheckClusterStatus provides a mock function with given fields: runtimeConfig, secretName, terraformState
+func (_m *Service) CheckClusterStatus(runtimeConfig model.RuntimeConfig, secretName string, terraformState string) (hydroform.ClusterInfo, error) {
 	ret := _m.Called(runtimeConfig, secretName, terraformState)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(model.RuntimeConfig, string, string) error); ok {
+	var r0 hydroform.ClusterInfo
+	if rf, ok := ret.Get(0).(func(model.RuntimeConfig, string, string) hydroform.ClusterInfo); ok {
 		r0 = rf(runtimeConfig, secretName, terraformState)
+	} else {
+		r0 = ret.Get(0).(hydroform.ClusterInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(model.RuntimeConfig, string, string) error); ok {
+		r1 = rf(runtimeConfig, secretName, terraformState)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckCredentials provides a mock function with given fields: secretName
+func (_m *Service) CheckCredentials(secretName string) error {
+	ret := _m.Called(secretName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(secretName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeprovisionCluster provides a mock function with given fields: runtimeConfig, secretName, terraformState, reportStep
+func (_m *Service) DeprovisionCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string, reportStep hydroform.StepReporter) error {
+	ret := _m.Called(runtimeConfig, secretName, terraformState, reportStep)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.RuntimeConfig, string, string, hydroform.StepReporter) error); ok {
+		r0 = rf(runtimeConfig, secretName, terraformState, reportStep)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -28,20 +62,41 @@ func (_m *Service) DeprovisionCluster(runtimeConfig model.RuntimeConfig, secretN
 	return r0
 }
 
-// ProvisionCluster provides a mock function with given fields: runtimeConfig, secretName
-func (_m *Service) ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName string) (hydroform.ClusterInfo, error) {
-	ret := _m.Called(runtimeConfig, secretName)
+// ProvisionCluster provides a mock function with given fields: runtimeConfig, secretName, reportStep
+func (_m *Service) ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName string, reportStep hydroform.StepReporter) (hydroform.ClusterInfo, error) {
+	ret := _m.Called(runtimeConfig, secretName, reportStep)
+
+	var r0 hydroform.ClusterInfo
+	if rf, ok := ret.Get(0).(func(model.RuntimeConfig, string, hydroform.StepReporter) hydroform.ClusterInfo); ok {
+		r0 = rf(runtimeConfig, secretName, reportStep)
+	} else {
+		r0 = ret.Get(0).(hydroform.ClusterInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(model.RuntimeConfig, string, hydroform.StepReporter) error); ok {
+		r1 = rf(runtimeConfig, secretName, reportStep)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpgradeCluster provides a mock function with given fields: runtimeConfig, secretName, terraformState, reportStep
+func (_m *Service) UpgradeCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string, reportStep hydroform.StepReporter) (hydroform.ClusterInfo, error) {
+	ret := _m.Called(runtimeConfig, secretName, terraformState, reportStep)
 
 	var r0 hydroform.ClusterInfo
-	if rf, ok := ret.Get(0).(func(model.RuntimeConfig, string) hydroform.ClusterInfo); ok {
-		r0 = rf(runtimeConfig, secretName)
+	if rf, ok := ret.Get(0).(func(model.RuntimeConfig, string, string, hydroform.StepReporter) hydroform.ClusterInfo); ok {
+		r0 = rf(runtimeConfig, secretName, terraformState, reportStep)
 	} else {
 		r0 = ret.Get(0).(hydroform.ClusterInfo)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.RuntimeConfig, string) error); ok {
-		r1 = rf(runtimeConfig, secretName)
+	if rf, ok := ret.Get(1).(func(model.RuntimeConfig, string, string, hydroform.StepReporter) error); ok {
+		r1 = rf(runtimeConfig, secretName, terraformState, reportStep)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -20,10 +20,16 @@ const credentialsKey = "credentials"
 
 //go:generate mockery -name=Service
 type Service interface {
-	ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName string) (ClusterInfo, error)
-	DeprovisionCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string) error
+	ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName string, reportStep StepReporter) (ClusterInfo, error)
+	DeprovisionCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string, reportStep StepReporter) error
+	UpgradeCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string, reportStep StepReporter) (ClusterInfo, error)
+	CheckClusterStatus(runtimeConfig model.RuntimeConfig, secretName string, terraformState string) (ClusterInfo, error)
+	CheckCredentials(secretName string) error
 }
 
+// StepReporter is called when the cluster operation moves on to the next step
+type StepReporter func(step model.OperationStepName, message string)
+
 type service struct {
 	secrets v1.SecretInterface
 	client  client.Client
@@ -42,7 +48,9 @@ type ClusterInfo struct {
 	State         string
 }
 
-func (s service) ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName string) (ClusterInfo, error) {
+func (s service) ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName string, reportStep StepReporter) (ClusterInfo, error) {
+	reportStep(model.StepPrepareClusterConfig, "Preparing cluster configuration")
+
 	credentialsFileName, err := s.saveCredentialsToFile(secretName)
 	if err != nil {
 		return ClusterInfo{}, err
@@ -56,6 +64,7 @@ func (s service) ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName
 	}
 
 	log.Infof("Starting cluster provisioning")
+	reportStep(model.StepProvisionCluster, "Provisioning cluster")
 
 	cluster, err = s.client.Provision(cluster, provider)
 	if err != nil {
@@ -68,6 +77,7 @@ func (s service) ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName
 	}
 
 	log.Info("Retrieving kubeconfig")
+	reportStep(model.StepRetrieveKubeconfig, "Retrieving kubeconfig")
 
 	kubeconfig, err := s.client.Credentials(cluster, provider)
 	if err != nil {
@@ -91,7 +101,9 @@ func (s service) ProvisionCluster(runtimeConfig model.RuntimeConfig, secretName
 	}, nil
 }
 
-func (s service) DeprovisionCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string) error {
+func (s service) DeprovisionCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string, reportStep StepReporter) error {
+	reportStep(model.StepPrepareClusterConfig, "Preparing cluster configuration")
+
 	credentialsFileName, err := s.saveCredentialsToFile(secretName)
 	if err != nil {
 		return err
@@ -114,19 +126,135 @@ func (s service) DeprovisionCluster(runtimeConfig model.RuntimeConfig, secretNam
 	cluster.ClusterInfo = &types.ClusterInfo{InternalState: state}
 
 	log.Infof("Starting cluster deprovisioning")
+	reportStep(model.StepDeprovisionCluster, "Deprovisioning cluster")
 	return s.client.Deprovision(cluster, provider)
 }
 
-func (s service) saveCredentialsToFile(secretName string) (string, error) {
+func (s service) UpgradeCluster(runtimeConfig model.RuntimeConfig, secretName string, terraformState string, reportStep StepReporter) (ClusterInfo, error) {
+	reportStep(model.StepPrepareClusterConfig, "Preparing cluster configuration")
+
+	credentialsFileName, err := s.saveCredentialsToFile(secretName)
+	if err != nil {
+		return ClusterInfo{}, err
+	}
+	defer removeFile(credentialsFileName)
+
+	log.Info("Preparing config for runtime upgrade")
+	cluster, provider, err := prepareConfig(runtimeConfig, credentialsFileName)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Config preparation failed")
+	}
+
+	state, err := jsonToState(terraformState)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Config preparation failed")
+	}
+
+	cluster.ClusterInfo = &types.ClusterInfo{InternalState: state}
+
+	// Provisioning with the existing Terraform state applies only the difference to the running cluster
+	log.Infof("Starting cluster upgrade")
+	reportStep(model.StepUpgradeCluster, "Upgrading cluster")
+
+	cluster, err = s.client.Provision(cluster, provider)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Cluster upgrade failed")
+	}
+
+	status, err := s.client.Status(cluster, provider)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Failed to get cluster status")
+	}
+
+	log.Info("Retrieving kubeconfig")
+	reportStep(model.StepRetrieveKubeconfig, "Retrieving kubeconfig")
+
+	kubeconfig, err := s.client.Credentials(cluster, provider)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Failed to get kubeconfig")
+	}
+
+	log.Info("Retrieving cluster state")
+
+	internalState, err := stateToJson(cluster.ClusterInfo.InternalState)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Failed to retrieve cluster state")
+	}
+
+	return ClusterInfo{
+		ClusterStatus: status.Phase,
+		State:         internalState,
+		KubeConfig:    string(kubeconfig),
+	}, nil
+}
+
+func (s service) CheckClusterStatus(runtimeConfig model.RuntimeConfig, secretName string, terraformState string) (ClusterInfo, error) {
+	credentialsFileName, err := s.saveCredentialsToFile(secretName)
+	if err != nil {
+		return ClusterInfo{}, err
+	}
+	defer removeFile(credentialsFileName)
+
+	log.Info("Preparing config for checking cluster status")
+	cluster, provider, err := prepareConfig(runtimeConfig, credentialsFileName)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Config preparation failed")
+	}
+
+	state, err := jsonToState(terraformState)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Config preparation failed")
+	}
+
+	cluster.ClusterInfo = &types.ClusterInfo{InternalState: state}
+
+	status, err := s.client.Status(cluster, provider)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Failed to get cluster status")
+	}
+
+	if status.Phase != types.Provisioned {
+		return ClusterInfo{ClusterStatus: status.Phase, State: terraformState}, nil
+	}
+
+	log.Info("Retrieving kubeconfig")
+
+	kubeconfig, err := s.client.Credentials(cluster, provider)
+	if err != nil {
+		return ClusterInfo{}, errors.Wrap(err, "Failed to get kubeconfig")
+	}
+
+	return ClusterInfo{
+		ClusterStatus: status.Phase,
+		State:         terraformState,
+		KubeConfig:    string(kubeconfig),
+	}, nil
+}
+
+// CheckCredentials verifies that the secret exists and contains the credentials required by Hydroform
+func (s service) CheckCredentials(secretName string) error {
+	_, err := s.readCredentials(secretName)
+	return err
+}
+
+func (s service) readCredentials(secretName string) ([]byte, error) {
 	secret, err := s.secrets.Get(secretName, meta.GetOptions{})
 	if err != nil {
-		return "", errors.WithMessagef(err, "Failed to get credentials from %s secret", secretName)
+		return nil, errors.WithMessagef(err, "Failed to get credentials from %s secret", secretName)
 	}
 
 	bytes, ok := secret.Data[credentialsKey]
+	if !ok || len(bytes) == 0 {
+		return nil, errors.Errorf("Credentials not found within the %s secret", secretName)
+	}
+
+	return bytes, nil
+}
 
-	if !ok {
-		return "", errors.New("Credentials not found within the secret")
+func (s service) saveCredentialsToFile(secretName string) (string, error) {
+	bytes, err := s.readCredentials(secretName)
+	if err != nil {
+		return "", err
 	}
 
 	tempFile, err := ioutil.TempFile("", secretName)
@@ -1,6 +1,7 @@
 package hydroform
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/hashicorp/terraform/terraform"
@@ -49,14 +50,17 @@ func TestService_ProvisionCluster(t *testing.T) {
 		hydroformClient.On("Status", mock.Anything, mock.Anything).Return(&types.ClusterStatus{Phase: types.Provisioned}, nil)
 		hydroformClient.On("Credentials", mock.Anything, mock.Anything).Return([]byte("kubeconfig"), nil)
 
+		steps := &stepRecorder{}
+
 		//when
-		info, err := hydroformService.ProvisionCluster(config, secretName)
+		info, err := hydroformService.ProvisionCluster(config, secretName, steps.report)
 
 		//then
 		require.NoError(t, err)
 		require.Equal(t, "kubeconfig", info.KubeConfig)
 		require.Equal(t, types.Provisioned, info.ClusterStatus)
 		require.Equal(t, terraformState, info.State)
+		require.Equal(t, []model.OperationStepName{model.StepPrepareClusterConfig, model.StepProvisionCluster, model.StepRetrieveKubeconfig}, steps.steps)
 	})
 }
 
@@ -73,11 +77,170 @@ func TestService_DeprovisionCluster(t *testing.T) {
 
 	hydroformClient.On("Deprovision", mock.Anything, mock.Anything).Return(nil)
 
+	steps := &stepRecorder{}
+
 	//when
-	err := hydroformService.DeprovisionCluster(config, secretName, terraformState)
+	err := hydroformService.DeprovisionCluster(config, secretName, terraformState, steps.report)
 
 	//then
 	require.NoError(t, err)
+	require.Equal(t, []model.OperationStepName{model.StepPrepareClusterConfig, model.StepDeprovisionCluster}, steps.steps)
+}
+
+func TestService_UpgradeCluster(t *testing.T) {
+	t.Run("Should apply configuration to existing cluster", func(t *testing.T) {
+		//given
+		hydroformClient := &mocks.Client{}
+		coreV1 := fake.NewSimpleClientset()
+		secrets := coreV1.CoreV1().Secrets(namespace)
+
+		createFakeCredentialsSecret(t, secrets)
+		defer deleteSecret(t, secrets)
+
+		hydroformService := NewHydroformService(secrets, hydroformClient)
+
+		hasState := func(cluster *types.Cluster) bool {
+			return cluster.ClusterInfo != nil && cluster.ClusterInfo.InternalState != nil
+		}
+
+		hydroformClient.On("Provision", mock.MatchedBy(hasState), mock.Anything).Return(&types.Cluster{ClusterInfo: &types.ClusterInfo{InternalState: &types.InternalState{TerraformState: &terraform.State{}}}}, nil)
+		hydroformClient.On("Status", mock.Anything, mock.Anything).Return(&types.ClusterStatus{Phase: types.Provisioned}, nil)
+		hydroformClient.On("Credentials", mock.Anything, mock.Anything).Return([]byte("kubeconfig"), nil)
+
+		steps := &stepRecorder{}
+
+		//when
+		info, err := hydroformService.UpgradeCluster(config, secretName, terraformState, steps.report)
+
+		//then
+		require.NoError(t, err)
+		require.Equal(t, "kubeconfig", info.KubeConfig)
+		require.Equal(t, types.Provisioned, info.ClusterStatus)
+		require.Equal(t, terraformState, info.State)
+		require.Equal(t, []model.OperationStepName{model.StepPrepareClusterConfig, model.StepUpgradeCluster, model.StepRetrieveKubeconfig}, steps.steps)
+		hydroformClient.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when applying configuration failed", func(t *testing.T) {
+		//given
+		hydroformClient := &mocks.Client{}
+		coreV1 := fake.NewSimpleClientset()
+		secrets := coreV1.CoreV1().Secrets(namespace)
+
+		createFakeCredentialsSecret(t, secrets)
+		defer deleteSecret(t, secrets)
+
+		hydroformService := NewHydroformService(secrets, hydroformClient)
+
+		hydroformClient.On("Provision", mock.Anything, mock.Anything).Return(nil, errors.New("error"))
+
+		//when
+		_, err := hydroformService.UpgradeCluster(config, secretName, terraformState, func(model.OperationStepName, string) {})
+
+		//then
+		require.Error(t, err)
+		hydroformClient.AssertExpectations(t)
+	})
+}
+
+func TestService_CheckClusterStatus(t *testing.T) {
+	t.Run("Should return cluster info for provisioned cluster", func(t *testing.T) {
+		//given
+		hydroformClient := &mocks.Client{}
+		coreV1 := fake.NewSimpleClientset()
+		secrets := coreV1.CoreV1().Secrets(namespace)
+
+		createFakeCredentialsSecret(t, secrets)
+		defer deleteSecret(t, secrets)
+
+		hydroformService := NewHydroformService(secrets, hydroformClient)
+
+		hydroformClient.On("Status", mock.Anything, mock.Anything).Return(&types.ClusterStatus{Phase: types.Provisioned}, nil)
+		hydroformClient.On("Credentials", mock.Anything, mock.Anything).Return([]byte("kubeconfig"), nil)
+
+		//when
+		info, err := hydroformService.CheckClusterStatus(config, secretName, terraformState)
+
+		//then
+		require.NoError(t, err)
+		require.Equal(t, "kubeconfig", info.KubeConfig)
+		require.Equal(t, types.Provisioned, info.ClusterStatus)
+		require.Equal(t, terraformState, info.State)
+		hydroformClient.AssertExpectations(t)
+	})
+
+	t.Run("Should not fetch kubeconfig when cluster is not provisioned", func(t *testing.T) {
+		//given
+		hydroformClient := &mocks.Client{}
+		coreV1 := fake.NewSimpleClientset()
+		secrets := coreV1.CoreV1().Secrets(namespace)
+
+		createFakeCredentialsSecret(t, secrets)
+		defer deleteSecret(t, secrets)
+
+		hydroformService := NewHydroformService(secrets, hydroformClient)
+
+		hydroformClient.On("Status", mock.Anything, mock.Anything).Return(&types.ClusterStatus{Phase: types.Errored}, nil)
+
+		//when
+		info, err := hydroformService.CheckClusterStatus(config, secretName, terraformState)
+
+		//then
+		require.NoError(t, err)
+		require.Equal(t, types.Errored, info.ClusterStatus)
+		require.Empty(t, info.KubeConfig)
+		hydroformClient.AssertExpectations(t)
+	})
+}
+
+func TestService_CheckCredentials(t *testing.T) {
+	t.Run("Should accept secret with credentials", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset().CoreV1().Secrets(namespace)
+
+		createFakeCredentialsSecret(t, secrets)
+		defer deleteSecret(t, secrets)
+
+		hydroformService := NewHydroformService(secrets, &mocks.Client{})
+
+		//when
+		err := hydroformService.CheckCredentials(secretName)
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("Should return error when secret does not exist", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset().CoreV1().Secrets(namespace)
+		hydroformService := NewHydroformService(secrets, &mocks.Client{})
+
+		//when
+		err := hydroformService.CheckCredentials(secretName)
+
+		//then
+		require.Error(t, err)
+	})
+
+	t.Run("Should return error when secret has no credentials key", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset().CoreV1().Secrets(namespace)
+
+		_, err := secrets.Create(&v1.Secret{
+			ObjectMeta: meta.ObjectMeta{Name: secretName, Namespace: namespace},
+			Data:       map[string][]byte{"other": []byte("value")},
+		})
+		require.NoError(t, err)
+		defer deleteSecret(t, secrets)
+
+		hydroformService := NewHydroformService(secrets, &mocks.Client{})
+
+		//when
+		err = hydroformService.CheckCredentials(secretName)
+
+		//then
+		require.Error(t, err)
+	})
 }
 
 func createFakeCredentialsSecret(t *testing.T, secrets core.SecretInterface) {
@@ -104,3 +267,11 @@ func deleteSecret(t *testing.T, secrets core.SecretInterface) {
 	err := secrets.Delete(secretName, &meta.DeleteOptions{})
 	require.NoError(t, err)
 }
+
+type stepRecorder struct {
+	steps []model.OperationStepName
+}
+
+func (r *stepRecorder) report(step model.OperationStepName, _ string) {
+	r.steps = append(r.steps, step)
+}
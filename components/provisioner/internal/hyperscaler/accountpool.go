@@ -0,0 +1,215 @@
+package hyperscaler
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Secrets with credentials are added to the pool of a tenant by labelling them with the tenant, the hyperscaler type and the account name.
+// A credential is assigned to a runtime by labelling its Secret with the Runtime ID.
+const (
+	TenantLabel          = "tenant"
+	HyperscalerTypeLabel = "hyperscaler-type"
+	AccountNameLabel     = "account-name"
+	RuntimeIDLabel       = "runtime-id"
+)
+
+type Type string
+
+const (
+	GCP      Type = "gcp"
+	AWS      Type = "aws"
+	Azure    Type = "azure"
+	Gardener Type = "gardener"
+)
+
+type PoolStatus struct {
+	HyperscalerType Type
+	AccountName     string
+	Total           int
+	Assigned        int
+}
+
+func (s PoolStatus) Free() int {
+	return s.Total - s.Assigned
+}
+
+//go:generate mockery -name=AccountPool
+type AccountPool interface {
+	AssignCredentials(tenant string, hyperscalerType Type, accountName, runtimeID string) (string, bool, error)
+	ReleaseCredentials(runtimeID string) error
+	PoolStatus(tenant string, hyperscalerType *Type, accountName *string) ([]PoolStatus, error)
+}
+
+type accountPool struct {
+	secrets v1.SecretInterface
+}
+
+func NewAccountPool(secrets v1.SecretInterface) AccountPool {
+	return &accountPool{
+		secrets: secrets,
+	}
+}
+
+// AssignCredentials returns the name of the Secret with credentials assigned to the runtime from the pool of the tenant
+// and whether the Secret was claimed by this call. Credentials already assigned to the runtime from the same pool are reused.
+func (p *accountPool) AssignCredentials(tenant string, hyperscalerType Type, accountName, runtimeID string) (string, bool, error) {
+	pool := []requirement{
+		equals(TenantLabel, tenant),
+		equals(HyperscalerTypeLabel, string(hyperscalerType)),
+		equals(AccountNameLabel, accountName),
+	}
+
+	assigned, err := p.listSecrets(append(pool, equals(RuntimeIDLabel, runtimeID))...)
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(assigned) > 0 {
+		return assigned[0].Name, false, nil
+	}
+
+	free, err := p.listSecrets(append(pool, doesNotExist(RuntimeIDLabel))...)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, secret := range free {
+		secret.Labels[RuntimeIDLabel] = runtimeID
+
+		_, err := p.secrets.Update(&secret)
+		if err == nil {
+			log.Infof("Assigned credentials from %s secret to runtime %s", secret.Name, runtimeID)
+			return secret.Name, true, nil
+		}
+
+		if !k8serrors.IsConflict(err) {
+			return "", false, errors.Wrapf(err, "Failed to assign credentials from %s secret", secret.Name)
+		}
+		// The Secret was modified concurrently, most likely assigned to another runtime, so the next one is tried
+	}
+
+	return "", false, errors.Errorf("No free %s credentials left in the pool of %s account", hyperscalerType, accountName)
+}
+
+// ReleaseCredentials returns credentials assigned to the runtime to the pool. It does nothing if no credentials are assigned.
+func (p *accountPool) ReleaseCredentials(runtimeID string) error {
+	assigned, err := p.listSecrets(exists(HyperscalerTypeLabel), equals(RuntimeIDLabel, runtimeID))
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range assigned {
+		delete(secret.Labels, RuntimeIDLabel)
+
+		_, err := p.secrets.Update(&secret)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to release credentials from %s secret", secret.Name)
+		}
+
+		log.Infof("Released credentials from %s secret assigned to runtime %s", secret.Name, runtimeID)
+	}
+
+	return nil
+}
+
+// PoolStatus returns the number of all and assigned credentials in each pool of the tenant, optionally filtered by the hyperscaler type and the account name
+func (p *accountPool) PoolStatus(tenant string, hyperscalerType *Type, accountName *string) ([]PoolStatus, error) {
+	pools := []requirement{equals(TenantLabel, tenant), exists(HyperscalerTypeLabel), exists(AccountNameLabel)}
+	if hyperscalerType != nil {
+		pools = append(pools, equals(HyperscalerTypeLabel, string(*hyperscalerType)))
+	}
+	if accountName != nil {
+		pools = append(pools, equals(AccountNameLabel, *accountName))
+	}
+
+	secrets, err := p.listSecrets(pools...)
+	if err != nil {
+		return nil, err
+	}
+
+	statusByPool := make(map[PoolStatus]*PoolStatus)
+	for _, secret := range secrets {
+		key := PoolStatus{HyperscalerType: Type(secret.Labels[HyperscalerTypeLabel]), AccountName: secret.Labels[AccountNameLabel]}
+
+		pool, ok := statusByPool[key]
+		if !ok {
+			pool = &PoolStatus{HyperscalerType: key.HyperscalerType, AccountName: key.AccountName}
+			statusByPool[key] = pool
+		}
+
+		pool.Total++
+		if _, assigned := secret.Labels[RuntimeIDLabel]; assigned {
+			pool.Assigned++
+		}
+	}
+
+	statuses := make([]PoolStatus, 0, len(statusByPool))
+	for _, pool := range statusByPool {
+		statuses = append(statuses, *pool)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].HyperscalerType != statuses[j].HyperscalerType {
+			return statuses[i].HyperscalerType < statuses[j].HyperscalerType
+		}
+		return statuses[i].AccountName < statuses[j].AccountName
+	})
+
+	return statuses, nil
+}
+
+func (p *accountPool) listSecrets(requirements ...requirement) ([]core.Secret, error) {
+	selector, err := labelSelector(requirements)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := p.secrets.List(meta.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list credentials secrets")
+	}
+
+	return secrets.Items, nil
+}
+
+type requirement struct {
+	key      string
+	operator selection.Operator
+	values   []string
+}
+
+func equals(key, value string) requirement {
+	return requirement{key: key, operator: selection.Equals, values: []string{value}}
+}
+
+func exists(key string) requirement {
+	return requirement{key: key, operator: selection.Exists}
+}
+
+func doesNotExist(key string) requirement {
+	return requirement{key: key, operator: selection.DoesNotExist}
+}
+
+// labelSelector rejects values which are not valid label values, as they could change the meaning of the selector
+func labelSelector(requirements []requirement) (string, error) {
+	selector := labels.NewSelector()
+
+	for _, r := range requirements {
+		parsed, err := labels.NewRequirement(r.key, r.operator, r.values)
+		if err != nil {
+			return "", errors.Wrapf(err, "Invalid value of %s label", r.key)
+		}
+		selector = selector.Add(*parsed)
+	}
+
+	return selector.String(), nil
+}
@@ -0,0 +1,234 @@
+package hyperscaler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	namespace = "compass-system"
+	tenant    = "tenant"
+	runtimeID = "runtimeID"
+)
+
+func TestAccountPool_AssignCredentials(t *testing.T) {
+	t.Run("Should assign free credentials from the pool", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset(
+			credentialsSecret("gcp-assigned", GCP, "account", "other"),
+			credentialsSecret("gcp-free", GCP, "account", ""),
+			credentialsSecret("gcp-other-account", GCP, "other", ""),
+			credentialsSecret("azure-free", Azure, "account", ""),
+			tenantCredentialsSecret("gcp-other-tenant", "other-tenant", GCP, "account", ""),
+		).CoreV1().Secrets(namespace)
+
+		pool := NewAccountPool(secrets)
+
+		//when
+		secretName, claimed, err := pool.AssignCredentials(tenant, GCP, "account", runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "gcp-free", secretName)
+		assert.True(t, claimed)
+
+		secret, err := secrets.Get("gcp-free", meta.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, runtimeID, secret.Labels[RuntimeIDLabel])
+	})
+
+	t.Run("Should return credentials already assigned to the runtime", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset(
+			credentialsSecret("gcp-free", GCP, "account", ""),
+			credentialsSecret("gcp-assigned", GCP, "account", runtimeID),
+		).CoreV1().Secrets(namespace)
+
+		pool := NewAccountPool(secrets)
+
+		//when
+		secretName, claimed, err := pool.AssignCredentials(tenant, GCP, "account", runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "gcp-assigned", secretName)
+		assert.False(t, claimed)
+	})
+
+	t.Run("Should return error when there are no free credentials in the pool", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset(
+			credentialsSecret("gcp-assigned", GCP, "account", "other"),
+			credentialsSecret("aws-free", AWS, "account", ""),
+		).CoreV1().Secrets(namespace)
+
+		pool := NewAccountPool(secrets)
+
+		//when
+		_, _, err := pool.AssignCredentials(tenant, GCP, "account", runtimeID)
+
+		//then
+		require.Error(t, err)
+	})
+
+	t.Run("Should not assign credentials from the pool of another tenant", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset(
+			tenantCredentialsSecret("gcp-other-tenant", "other-tenant", GCP, "account", ""),
+		).CoreV1().Secrets(namespace)
+
+		pool := NewAccountPool(secrets)
+
+		//when
+		_, _, err := pool.AssignCredentials(tenant, GCP, "account", runtimeID)
+
+		//then
+		require.Error(t, err)
+
+		secret, err := secrets.Get("gcp-other-tenant", meta.GetOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, secret.Labels, RuntimeIDLabel)
+	})
+
+	t.Run("Should return error when account name is not a valid label value", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset(credentialsSecret("gcp-free", GCP, "account", "")).CoreV1().Secrets(namespace)
+
+		pool := NewAccountPool(secrets)
+
+		//when
+		_, _, err := pool.AssignCredentials(tenant, GCP, "account,hyperscaler-type=gcp", runtimeID)
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid value of account-name label")
+
+		secret, err := secrets.Get("gcp-free", meta.GetOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, secret.Labels, RuntimeIDLabel)
+	})
+}
+
+func TestAccountPool_ReleaseCredentials(t *testing.T) {
+	t.Run("Should return credentials assigned to the runtime to the pool", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset(
+			credentialsSecret("gcp-assigned", GCP, "account", runtimeID),
+			credentialsSecret("gcp-other", GCP, "account", "other"),
+		).CoreV1().Secrets(namespace)
+
+		pool := NewAccountPool(secrets)
+
+		//when
+		err := pool.ReleaseCredentials(runtimeID)
+
+		//then
+		require.NoError(t, err)
+
+		secret, err := secrets.Get("gcp-assigned", meta.GetOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, secret.Labels, RuntimeIDLabel)
+
+		secret, err = secrets.Get("gcp-other", meta.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "other", secret.Labels[RuntimeIDLabel])
+	})
+
+	t.Run("Should do nothing when no credentials are assigned to the runtime", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset(credentialsSecret("gcp-free", GCP, "account", "")).CoreV1().Secrets(namespace)
+
+		pool := NewAccountPool(secrets)
+
+		//when
+		err := pool.ReleaseCredentials(runtimeID)
+
+		//then
+		require.NoError(t, err)
+	})
+}
+
+func TestAccountPool_PoolStatus(t *testing.T) {
+	secrets := fake.NewSimpleClientset(
+		credentialsSecret("gcp-1", GCP, "account", runtimeID),
+		credentialsSecret("gcp-2", GCP, "account", ""),
+		credentialsSecret("gcp-3", GCP, "other", ""),
+		credentialsSecret("azure-1", Azure, "account", "other"),
+		tenantCredentialsSecret("gcp-other-tenant", "other-tenant", GCP, "account", ""),
+		&core.Secret{ObjectMeta: meta.ObjectMeta{Name: "not-in-pool", Namespace: namespace}},
+	).CoreV1().Secrets(namespace)
+
+	pool := NewAccountPool(secrets)
+
+	t.Run("Should return status of all pools of the tenant", func(t *testing.T) {
+		//when
+		statuses, err := pool.PoolStatus(tenant, nil, nil)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []PoolStatus{
+			{HyperscalerType: Azure, AccountName: "account", Total: 1, Assigned: 1},
+			{HyperscalerType: GCP, AccountName: "account", Total: 2, Assigned: 1},
+			{HyperscalerType: GCP, AccountName: "other", Total: 1, Assigned: 0},
+		}, statuses)
+	})
+
+	t.Run("Should return error when account name is not a valid label value", func(t *testing.T) {
+		//given
+		accountName := "account,!runtime-id"
+
+		//when
+		_, err := pool.PoolStatus(tenant, nil, &accountName)
+
+		//then
+		require.Error(t, err)
+	})
+
+	t.Run("Should return status of pools filtered by hyperscaler type and account name", func(t *testing.T) {
+		//given
+		hyperscalerType := GCP
+		accountName := "account"
+
+		//when
+		statuses, err := pool.PoolStatus(tenant, &hyperscalerType, &accountName)
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, 2, statuses[0].Total)
+		assert.Equal(t, 1, statuses[0].Free())
+	})
+
+	t.Run("Should return error when tenant is not a valid label value", func(t *testing.T) {
+		//when
+		_, err := pool.PoolStatus("tenant,!runtime-id", nil, nil)
+
+		//then
+		require.Error(t, err)
+	})
+}
+
+func credentialsSecret(name string, hyperscalerType Type, accountName, runtimeID string) *core.Secret {
+	return tenantCredentialsSecret(name, tenant, hyperscalerType, accountName, runtimeID)
+}
+
+func tenantCredentialsSecret(name, tenant string, hyperscalerType Type, accountName, runtimeID string) *core.Secret {
+	labels := map[string]string{
+		TenantLabel:          tenant,
+		HyperscalerTypeLabel: string(hyperscalerType),
+		AccountNameLabel:     accountName,
+	}
+	if runtimeID != "" {
+		labels[RuntimeIDLabel] = runtimeID
+	}
+
+	return &core.Secret{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Data:       map[string][]byte{"credentials": []byte("credentials")},
+	}
+}
@@ -0,0 +1,134 @@
+package installation
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+const defaultNamespace = "default"
+
+// resourceApplier creates or updates arbitrary resources on the Runtime.
+// Resources are mapped to their API paths using discovery, so that Custom Resources can be applied right after their definitions.
+type resourceApplier struct {
+	restClient    rest.Interface
+	discovery     discovery.DiscoveryInterface
+	retryInterval time.Duration
+	retryCount    int
+}
+
+func (a *resourceApplier) apply(object *unstructured.Unstructured) error {
+	collectionPath, err := a.collectionPath(object)
+	if err != nil {
+		return err
+	}
+
+	body, err := object.MarshalJSON()
+	if err != nil {
+		return errors.Wrapf(err, "Failed to encode %s %s", object.GetKind(), object.GetName())
+	}
+
+	err = a.restClient.Post().AbsPath(collectionPath).Body(body).Do().Error()
+	if err == nil {
+		return nil
+	}
+
+	if !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "Failed to create %s %s", object.GetKind(), object.GetName())
+	}
+
+	return a.update(object, path.Join(collectionPath, object.GetName()))
+}
+
+func (a *resourceApplier) update(object *unstructured.Unstructured, resourcePath string) error {
+	response, err := a.restClient.Get().AbsPath(resourcePath).DoRaw()
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get %s %s", object.GetKind(), object.GetName())
+	}
+
+	current := &unstructured.Unstructured{}
+	err = current.UnmarshalJSON(response)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to decode %s %s", object.GetKind(), object.GetName())
+	}
+
+	object.SetResourceVersion(current.GetResourceVersion())
+
+	body, err := object.MarshalJSON()
+	if err != nil {
+		return errors.Wrapf(err, "Failed to encode %s %s", object.GetKind(), object.GetName())
+	}
+
+	err = a.restClient.Put().AbsPath(resourcePath).Body(body).Do().Error()
+	if err != nil {
+		return errors.Wrapf(err, "Failed to update %s %s", object.GetKind(), object.GetName())
+	}
+
+	return nil
+}
+
+func (a *resourceApplier) collectionPath(object *unstructured.Unstructured) (string, error) {
+	gvk := object.GroupVersionKind()
+
+	resource, err := a.findResource(gvk)
+	if err != nil {
+		return "", err
+	}
+
+	segments := []string{"/apis", gvk.Group, gvk.Version}
+	if gvk.Group == "" {
+		segments = []string{"/api", gvk.Version}
+	}
+
+	if resource.Namespaced {
+		namespace := object.GetNamespace()
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		segments = append(segments, "namespaces", namespace)
+	}
+
+	return path.Join(append(segments, resource.Name)...), nil
+}
+
+// findResource retries the lookup as resources defined by freshly created Custom Resource Definitions are not served immediately
+func (a *resourceApplier) findResource(gvk schema.GroupVersionKind) (meta.APIResource, error) {
+	var err error
+	for i := 0; i < a.retryCount; i++ {
+		var resource meta.APIResource
+		resource, err = a.lookupResource(gvk)
+		if err == nil {
+			return resource, nil
+		}
+		time.Sleep(a.retryInterval)
+	}
+
+	return meta.APIResource{}, err
+}
+
+func (a *resourceApplier) lookupResource(gvk schema.GroupVersionKind) (meta.APIResource, error) {
+	resources, err := a.discovery.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		return meta.APIResource{}, errors.Wrapf(err, "Failed to discover resources of %s", gvk.GroupVersion())
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Kind == gvk.Kind && !isSubresource(resource) {
+			return resource, nil
+		}
+	}
+
+	return meta.APIResource{}, errors.New(fmt.Sprintf("Resource of kind %s is not served in %s", gvk.Kind, gvk.GroupVersion()))
+}
+
+func isSubresource(resource meta.APIResource) bool {
+	return path.Base(resource.Name) != resource.Name
+}
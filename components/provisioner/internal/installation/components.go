@@ -0,0 +1,74 @@
+package installation
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type component struct {
+	name      string
+	namespace string
+}
+
+const kymaSystemNamespace = "kyma-system"
+
+var moduleComponents = map[model.KymaModule]component{
+	"Backup":             {name: "backup", namespace: kymaSystemNamespace},
+	"BackupInit":         {name: "backup-init", namespace: kymaSystemNamespace},
+	"Jaeger":             {name: "jaeger", namespace: kymaSystemNamespace},
+	"Logging":            {name: "logging", namespace: kymaSystemNamespace},
+	"Monitoring":         {name: "monitoring", namespace: kymaSystemNamespace},
+	"PrometheusOperator": {name: "prometheus-operator", namespace: kymaSystemNamespace},
+	"Kiali":              {name: "kiali", namespace: kymaSystemNamespace},
+	"KnativeBuild":       {name: "knative-build", namespace: "knative-build"},
+}
+
+// prepareInstallation adds components of the requested modules to the ones installed by default
+// and labels the Installation so that the installer picks it up.
+func prepareInstallation(object *unstructured.Unstructured, modules []model.KymaConfigModule) error {
+	components, _, err := unstructured.NestedSlice(object.Object, "spec", "components")
+	if err != nil {
+		return errors.Wrap(err, "Failed to read components of Kyma installation")
+	}
+
+	installed := make(map[string]bool)
+	for _, entry := range components {
+		if entryMap, ok := entry.(map[string]interface{}); ok {
+			installed[fmt.Sprint(entryMap["name"])] = true
+		}
+	}
+
+	for _, module := range modules {
+		moduleComponent, found := moduleComponents[module.Module]
+		if !found {
+			return errors.New(fmt.Sprintf("Unknown Kyma module %s", module.Module))
+		}
+
+		if installed[moduleComponent.name] {
+			continue
+		}
+
+		components = append(components, map[string]interface{}{
+			"name":      moduleComponent.name,
+			"namespace": moduleComponent.namespace,
+		})
+		installed[moduleComponent.name] = true
+	}
+
+	err = unstructured.SetNestedSlice(object.Object, components, "spec", "components")
+	if err != nil {
+		return errors.Wrap(err, "Failed to set components of Kyma installation")
+	}
+
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[installationActionKey] = installActionValue
+	object.SetLabels(labels)
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package installation
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	installerNamespace = "kyma-installer"
+
+	overridesLabelKey   = "installer"
+	overridesLabelValue = "overrides"
+	componentLabelKey   = "component"
+
+	// Overrides created by the Provisioner are labeled, so that the ones removed from the configuration can be deleted
+	managedByLabelKey   = "app.kubernetes.io/managed-by"
+	managedByLabelValue = "compass-provisioner"
+
+	globalOverridesName          = "global-overrides"
+	componentOverridesNameFormat = "%s-overrides"
+)
+
+// appliedOverrides holds the names of the ConfigMaps and Secrets saved from the current configuration
+type appliedOverrides struct {
+	configMaps map[string]bool
+	secrets    map[string]bool
+}
+
+// applyOverrides saves the configuration as Installer overrides.
+// Secret entries are saved in Secrets, all other entries in ConfigMaps.
+// Overrides saved from a previous configuration which are no longer part of it are deleted.
+func applyOverrides(client kubernetes.Interface, kymaConfig model.KymaConfig) error {
+	applied := appliedOverrides{
+		configMaps: map[string]bool{},
+		secrets:    map[string]bool{},
+	}
+
+	labels := map[string]string{
+		overridesLabelKey: overridesLabelValue,
+		managedByLabelKey: managedByLabelValue,
+	}
+
+	err := applyConfiguration(client, globalOverridesName, labels, kymaConfig.GlobalConfiguration, applied)
+	if err != nil {
+		return err
+	}
+
+	for _, component := range kymaConfig.Components {
+		labels := map[string]string{
+			overridesLabelKey: overridesLabelValue,
+			managedByLabelKey: managedByLabelValue,
+			componentLabelKey: string(component.Component),
+		}
+
+		err = applyConfiguration(client, fmt.Sprintf(componentOverridesNameFormat, component.Component), labels, component.Configuration, applied)
+		if err != nil {
+			return err
+		}
+	}
+
+	return deleteStaleOverrides(client, applied)
+}
+
+func applyConfiguration(client kubernetes.Interface, name string, labels map[string]string, configuration model.Configuration, applied appliedOverrides) error {
+	data := make(map[string]string)
+	secretData := make(map[string][]byte)
+
+	for _, entry := range configuration.ConfigEntries {
+		if entry.Secret {
+			secretData[entry.Key] = []byte(entry.Value)
+		} else {
+			data[entry.Key] = entry.Value
+		}
+	}
+
+	objectMeta := meta.ObjectMeta{
+		Name:      name,
+		Namespace: installerNamespace,
+		Labels:    labels,
+	}
+
+	if len(data) > 0 {
+		err := applyConfigMap(client, &core.ConfigMap{ObjectMeta: objectMeta, Data: data})
+		if err != nil {
+			return err
+		}
+		applied.configMaps[name] = true
+	}
+
+	if len(secretData) > 0 {
+		err := applySecret(client, &core.Secret{ObjectMeta: objectMeta, Data: secretData})
+		if err != nil {
+			return err
+		}
+		applied.secrets[name] = true
+	}
+
+	return nil
+}
+
+// deleteStaleOverrides deletes the overrides managed by the Provisioner which were not saved from the current configuration,
+// including the ones left after all entries of a ConfigMap or Secret were switched between secret and non-secret
+func deleteStaleOverrides(client kubernetes.Interface, applied appliedOverrides) error {
+	listOptions := meta.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", managedByLabelKey, managedByLabelValue)}
+
+	configMaps := client.CoreV1().ConfigMaps(installerNamespace)
+
+	configMapList, err := configMaps.List(listOptions)
+	if err != nil {
+		return errors.Wrap(err, "Failed to list overrides")
+	}
+
+	for _, configMap := range configMapList.Items {
+		if applied.configMaps[configMap.Name] {
+			continue
+		}
+
+		err = configMaps.Delete(configMap.Name, &meta.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete %s overrides", configMap.Name)
+		}
+	}
+
+	secrets := client.CoreV1().Secrets(installerNamespace)
+
+	secretList, err := secrets.List(listOptions)
+	if err != nil {
+		return errors.Wrap(err, "Failed to list secret overrides")
+	}
+
+	for _, secret := range secretList.Items {
+		if applied.secrets[secret.Name] {
+			continue
+		}
+
+		err = secrets.Delete(secret.Name, &meta.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete %s secret overrides", secret.Name)
+		}
+	}
+
+	return nil
+}
+
+func applyConfigMap(client kubernetes.Interface, configMap *core.ConfigMap) error {
+	configMaps := client.CoreV1().ConfigMaps(installerNamespace)
+
+	_, err := configMaps.Create(configMap)
+	if err == nil {
+		return nil
+	}
+
+	if !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "Failed to create %s overrides", configMap.Name)
+	}
+
+	_, err = configMaps.Update(configMap)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to update %s overrides", configMap.Name)
+	}
+
+	return nil
+}
+
+func applySecret(client kubernetes.Interface, secret *core.Secret) error {
+	secrets := client.CoreV1().Secrets(installerNamespace)
+
+	_, err := secrets.Create(secret)
+	if err == nil {
+		return nil
+	}
+
+	if !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "Failed to create %s secret overrides", secret.Name)
+	}
+
+	_, err = secrets.Update(secret)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to update %s secret overrides", secret.Name)
+	}
+
+	return nil
+}
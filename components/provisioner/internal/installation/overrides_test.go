@@ -0,0 +1,102 @@
+package installation
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	managedLabels := map[string]string{overridesLabelKey: overridesLabelValue, managedByLabelKey: managedByLabelValue}
+
+	t.Run("Should delete overrides no longer in the configuration", func(t *testing.T) {
+		//given
+		client := fake.NewSimpleClientset(
+			configMap("global-overrides", managedLabels, map[string]string{"global.domainName": "old.local"}),
+			configMap("assetstore-overrides", managedLabels, map[string]string{"minio.persistence.enabled": "false"}),
+			secret("assetstore-overrides", managedLabels, map[string][]byte{"minio.secretKey": []byte("secret-key")}),
+			configMap("user-overrides", map[string]string{overridesLabelKey: overridesLabelValue}, map[string]string{"key": "value"}),
+		)
+
+		kymaConfig := model.KymaConfig{
+			GlobalConfiguration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+				{Key: "global.domainName", Value: "kyma.local"},
+			}},
+		}
+
+		//when
+		err := applyOverrides(client, kymaConfig)
+
+		//then
+		require.NoError(t, err)
+
+		globalOverrides, err := client.CoreV1().ConfigMaps(installerNamespace).Get("global-overrides", meta.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"global.domainName": "kyma.local"}, globalOverrides.Data)
+
+		_, err = client.CoreV1().ConfigMaps(installerNamespace).Get("assetstore-overrides", meta.GetOptions{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		_, err = client.CoreV1().Secrets(installerNamespace).Get("assetstore-overrides", meta.GetOptions{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		_, err = client.CoreV1().ConfigMaps(installerNamespace).Get("user-overrides", meta.GetOptions{})
+		assert.NoError(t, err, "overrides not created by the Provisioner must be kept")
+	})
+
+	t.Run("Should move entries switched between secret and non-secret", func(t *testing.T) {
+		//given
+		labels := map[string]string{overridesLabelKey: overridesLabelValue, managedByLabelKey: managedByLabelValue, componentLabelKey: "assetstore"}
+
+		client := fake.NewSimpleClientset(
+			configMap("assetstore-overrides", labels, map[string]string{"minio.accessKey": "access-key"}),
+			secret("assetstore-overrides", labels, map[string][]byte{"minio.secretKey": []byte("secret-key")}),
+		)
+
+		kymaConfig := model.KymaConfig{
+			Components: []model.KymaComponentConfig{
+				{
+					Component: model.KymaComponent("assetstore"),
+					Configuration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+						{Key: "minio.accessKey", Value: "access-key", Secret: true},
+						{Key: "minio.secretKey", Value: "secret-key", Secret: true},
+					}},
+				},
+			},
+		}
+
+		//when
+		err := applyOverrides(client, kymaConfig)
+
+		//then
+		require.NoError(t, err)
+
+		_, err = client.CoreV1().ConfigMaps(installerNamespace).Get("assetstore-overrides", meta.GetOptions{})
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		secretOverrides, err := client.CoreV1().Secrets(installerNamespace).Get("assetstore-overrides", meta.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"minio.accessKey": []byte("access-key"), "minio.secretKey": []byte("secret-key")}, secretOverrides.Data)
+	})
+}
+
+func configMap(name string, labels map[string]string, data map[string]string) runtime.Object {
+	return &core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: installerNamespace, Labels: labels},
+		Data:       data,
+	}
+}
+
+func secret(name string, labels map[string]string, data map[string][]byte) runtime.Object {
+	return &core.Secret{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: installerNamespace, Labels: labels},
+		Data:       data,
+	}
+}
@@ -0,0 +1,216 @@
+package installation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/k8s"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	installationKind      = "Installation"
+	installationPath      = "/apis/installer.kyma-project.io/v1alpha1/namespaces/default/installations/kyma-installation"
+	installationActionKey = "action"
+	installActionValue    = "install"
+
+	installedState = "Installed"
+
+	applyRetryInterval = 2 * time.Second
+	applyRetryCount    = 15
+)
+
+type Config struct {
+	// InstallerURLFormat is the URL of the Kyma installer manifest, with the Kyma version as a placeholder
+	InstallerURLFormat string
+	Timeout            time.Duration
+	PollInterval       time.Duration
+}
+
+//go:generate mockery -name=Service
+type Service interface {
+	InstallKyma(kubeconfig string, kymaConfig model.KymaConfig) error
+	WaitForInstallation(ctx context.Context, kubeconfig string, reportProgress func(description string)) error
+}
+
+type service struct {
+	httpClient *http.Client
+	config     Config
+}
+
+func NewInstallationService(httpClient *http.Client, config Config) Service {
+	return &service{
+		httpClient: httpClient,
+		config:     config,
+	}
+}
+
+type installation struct {
+	Metadata struct {
+		Labels map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Status struct {
+		State       string `json:"state"`
+		Description string `json:"description"`
+	} `json:"status"`
+}
+
+// InstallKyma applies the installer of the requested Kyma version, saves the requested configuration as Installer overrides
+// and triggers the installation of the requested modules.
+// Applying the installer again updates existing resources, so a failed installation can be retried on the same cluster.
+func (s *service) InstallKyma(kubeconfig string, kymaConfig model.KymaConfig) error {
+	manifest, err := s.downloadInstaller(kymaConfig.Version)
+	if err != nil {
+		return err
+	}
+
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return err
+	}
+
+	client, err := k8s.NewClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	applier := &resourceApplier{
+		restClient:    client.CoreV1().RESTClient(),
+		discovery:     client.Discovery(),
+		retryInterval: applyRetryInterval,
+		retryCount:    applyRetryCount,
+	}
+
+	// Installations are applied last, as labeling them starts the installer, which requires overrides to be in place
+	var installations []*unstructured.Unstructured
+
+	for _, object := range objects {
+		if object.GetKind() == installationKind {
+			installations = append(installations, object)
+			continue
+		}
+
+		err = applier.apply(object)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = applyOverrides(client, kymaConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range installations {
+		err = prepareInstallation(object, kymaConfig.Modules)
+		if err != nil {
+			return err
+		}
+
+		err = applier.apply(object)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WaitForInstallation polls the Installation resource until Kyma is installed and reports every change of the installation description.
+// The installer removes the action label when it picks up the Installation, so until then the state of the previous installation is ignored.
+// Waiting stops when the context is cancelled.
+func (s *service) WaitForInstallation(ctx context.Context, kubeconfig string, reportProgress func(description string)) error {
+	client, err := k8s.NewClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	restClient := client.CoreV1().RESTClient()
+	deadline := time.Now().Add(s.config.Timeout)
+	lastDescription := ""
+
+	for {
+		response, err := restClient.Get().AbsPath(installationPath).DoRaw()
+		if err != nil {
+			return errors.Wrap(err, "Failed to get Kyma installation status")
+		}
+
+		var status installation
+		err = json.Unmarshal(response, &status)
+		if err != nil {
+			return errors.Wrap(err, "Failed to decode Kyma installation status")
+		}
+
+		_, pending := status.Metadata.Labels[installationActionKey]
+		if status.Status.State == installedState && !pending {
+			return nil
+		}
+
+		if status.Status.Description != "" && status.Status.Description != lastDescription {
+			lastDescription = status.Status.Description
+			reportProgress(lastDescription)
+		}
+
+		if time.Now().After(deadline) {
+			return errors.New(fmt.Sprintf("Kyma installation timed out after %s in %s state: %s", s.config.Timeout, status.Status.State, lastDescription))
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "Stopped waiting for Kyma installation")
+		case <-time.After(s.config.PollInterval):
+		}
+	}
+}
+
+func (s *service) downloadInstaller(version string) ([]byte, error) {
+	url := fmt.Sprintf(s.config.InstallerURLFormat, version)
+
+	response, err := s.httpClient.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to download Kyma installer from %s", url)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("Failed to download Kyma installer from %s: unexpected status %d", url, response.StatusCode))
+	}
+
+	manifest, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read Kyma installer from %s", url)
+	}
+
+	return manifest, nil
+}
+
+func decodeManifest(manifest []byte) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), len(manifest))
+
+	var objects []*unstructured.Unstructured
+	for {
+		content := map[string]interface{}{}
+		err := decoder.Decode(&content)
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to decode Kyma installer")
+		}
+
+		if len(content) == 0 {
+			continue
+		}
+
+		objects = append(objects, &unstructured.Unstructured{Object: content})
+	}
+}
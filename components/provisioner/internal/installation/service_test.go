@@ -0,0 +1,337 @@
+package installation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %s
+  name: runtime
+contexts:
+- context:
+    cluster: runtime
+    user: admin
+  name: runtime
+current-context: runtime
+users:
+- name: admin
+  user:
+    token: token
+`
+
+const installerManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: kyma-installer
+---
+apiVersion: installer.kyma-project.io/v1alpha1
+kind: Installation
+metadata:
+  name: kyma-installation
+  namespace: default
+spec:
+  components:
+  - name: core
+    namespace: kyma-system
+  - name: monitoring
+    namespace: kyma-system
+`
+
+const (
+	namespacesPath    = "/api/v1/namespaces"
+	installationsPath = "/apis/installer.kyma-project.io/v1alpha1/namespaces/default/installations"
+	configMapsPath    = "/api/v1/namespaces/kyma-installer/configmaps"
+	secretsPath       = "/api/v1/namespaces/kyma-installer/secrets"
+)
+
+var emptyList = map[string]interface{}{"kind": "List", "apiVersion": "v1", "items": []interface{}{}}
+
+var kymaConfig = model.KymaConfig{
+	Version: "1.7.0",
+	Modules: []model.KymaConfigModule{
+		{Module: model.KymaModule("Monitoring")},
+		{Module: model.KymaModule("Logging")},
+	},
+}
+
+func TestService_InstallKyma(t *testing.T) {
+	t.Run("Should apply installer and trigger installation of requested modules", func(t *testing.T) {
+		//given
+		var createdNamespace, updatedInstallation unstructured.Unstructured
+
+		installerServer := newInstallerServer(t)
+		defer installerServer.Close()
+
+		kubeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/api/v1":
+				respond(t, w, http.StatusOK, resourceList("v1", meta.APIResource{Name: "namespaces", Kind: "Namespace"}))
+			case r.Method == http.MethodGet && r.URL.Path == "/apis/installer.kyma-project.io/v1alpha1":
+				respond(t, w, http.StatusOK, resourceList("installer.kyma-project.io/v1alpha1",
+					meta.APIResource{Name: "installations", Kind: "Installation", Namespaced: true},
+					meta.APIResource{Name: "installations/status", Kind: "Installation", Namespaced: true}))
+			case r.Method == http.MethodGet && (r.URL.Path == configMapsPath || r.URL.Path == secretsPath):
+				respond(t, w, http.StatusOK, emptyList)
+			case r.Method == http.MethodPost && r.URL.Path == namespacesPath:
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&createdNamespace.Object))
+				respond(t, w, http.StatusCreated, createdNamespace.Object)
+			case r.Method == http.MethodPost && r.URL.Path == installationsPath:
+				respond(t, w, http.StatusConflict, map[string]interface{}{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "AlreadyExists", "code": 409})
+			case r.Method == http.MethodGet && r.URL.Path == installationsPath+"/kyma-installation":
+				respond(t, w, http.StatusOK, map[string]interface{}{
+					"apiVersion": "installer.kyma-project.io/v1alpha1",
+					"kind":       "Installation",
+					"metadata":   map[string]interface{}{"name": "kyma-installation", "resourceVersion": "5"},
+				})
+			case r.Method == http.MethodPut && r.URL.Path == installationsPath+"/kyma-installation":
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&updatedInstallation.Object))
+				respond(t, w, http.StatusOK, updatedInstallation.Object)
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer kubeServer.Close()
+
+		service := NewInstallationService(http.DefaultClient, Config{InstallerURLFormat: installerServer.URL + "/%s/kyma-installer-cluster.yaml"})
+
+		//when
+		err := service.InstallKyma(fmt.Sprintf(kubeconfigTemplate, kubeServer.URL), kymaConfig)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "kyma-installer", createdNamespace.GetName())
+		assert.Equal(t, "5", updatedInstallation.GetResourceVersion())
+		assert.Equal(t, installActionValue, updatedInstallation.GetLabels()[installationActionKey])
+
+		components, _, err := unstructured.NestedSlice(updatedInstallation.Object, "spec", "components")
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "core", "namespace": "kyma-system"},
+			map[string]interface{}{"name": "monitoring", "namespace": "kyma-system"},
+			map[string]interface{}{"name": "logging", "namespace": "kyma-system"},
+		}, components)
+	})
+
+	t.Run("Should save configuration as overrides before triggering installation", func(t *testing.T) {
+		//given
+		var requests []string
+		created := map[string]unstructured.Unstructured{}
+
+		installerServer := newInstallerServer(t)
+		defer installerServer.Close()
+
+		kubeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/api/v1":
+				respond(t, w, http.StatusOK, resourceList("v1", meta.APIResource{Name: "namespaces", Kind: "Namespace"}))
+			case r.Method == http.MethodGet && r.URL.Path == "/apis/installer.kyma-project.io/v1alpha1":
+				respond(t, w, http.StatusOK, resourceList("installer.kyma-project.io/v1alpha1",
+					meta.APIResource{Name: "installations", Kind: "Installation", Namespaced: true}))
+			case r.Method == http.MethodGet && (r.URL.Path == configMapsPath || r.URL.Path == secretsPath):
+				respond(t, w, http.StatusOK, emptyList)
+			case r.Method == http.MethodPost:
+				var object unstructured.Unstructured
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&object.Object))
+				requests = append(requests, r.URL.Path)
+				created[r.URL.Path+"/"+object.GetName()] = object
+				respond(t, w, http.StatusCreated, object.Object)
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer kubeServer.Close()
+
+		configuredKymaConfig := kymaConfig
+		configuredKymaConfig.Components = []model.KymaComponentConfig{
+			{
+				Component: model.KymaComponent("assetstore"),
+				Configuration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+					{Key: "minio.persistence.enabled", Value: "false"},
+					{Key: "minio.secretKey", Value: "secret-key", Secret: true},
+				}},
+			},
+		}
+		configuredKymaConfig.GlobalConfiguration = model.Configuration{ConfigEntries: []model.ConfigEntry{
+			{Key: "global.domainName", Value: "kyma.local"},
+		}}
+
+		service := NewInstallationService(http.DefaultClient, Config{InstallerURLFormat: installerServer.URL + "/%s/kyma-installer-cluster.yaml"})
+
+		//when
+		err := service.InstallKyma(fmt.Sprintf(kubeconfigTemplate, kubeServer.URL), configuredKymaConfig)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			namespacesPath,
+			configMapsPath,
+			configMapsPath,
+			secretsPath,
+			installationsPath,
+		}, requests)
+
+		globalOverrides := created[configMapsPath+"/global-overrides"]
+		assert.Equal(t, map[string]string{overridesLabelKey: overridesLabelValue, managedByLabelKey: managedByLabelValue}, globalOverrides.GetLabels())
+		assert.Equal(t, map[string]interface{}{"global.domainName": "kyma.local"}, globalOverrides.Object["data"])
+
+		componentOverrides := created[configMapsPath+"/assetstore-overrides"]
+		assert.Equal(t, map[string]string{overridesLabelKey: overridesLabelValue, managedByLabelKey: managedByLabelValue, componentLabelKey: "assetstore"}, componentOverrides.GetLabels())
+		assert.Equal(t, map[string]interface{}{"minio.persistence.enabled": "false"}, componentOverrides.Object["data"])
+
+		secretOverrides := created[secretsPath+"/assetstore-overrides"]
+		assert.Equal(t, map[string]interface{}{"minio.secretKey": "c2VjcmV0LWtleQ=="}, secretOverrides.Object["data"])
+	})
+
+	t.Run("Should return error when installer for requested version does not exist", func(t *testing.T) {
+		//given
+		installerServer := newInstallerServer(t)
+		defer installerServer.Close()
+
+		service := NewInstallationService(http.DefaultClient, Config{InstallerURLFormat: installerServer.URL + "/%s/kyma-installer-cluster.yaml"})
+
+		//when
+		err := service.InstallKyma(fmt.Sprintf(kubeconfigTemplate, "http://localhost"), model.KymaConfig{Version: "0.0.1"})
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status 404")
+	})
+}
+
+func TestService_WaitForInstallation(t *testing.T) {
+	t.Run("Should report progress until Kyma is installed", func(t *testing.T) {
+		//given
+		states := []string{"InProgress", "InProgress", "Installed"}
+		requests := 0
+
+		kubeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, installationPath, r.URL.Path)
+
+			state := states[requests]
+			description := fmt.Sprintf("Install component %d", requests)
+			requests++
+
+			respond(t, w, http.StatusOK, map[string]interface{}{"status": map[string]interface{}{"state": state, "description": description}})
+		}))
+		defer kubeServer.Close()
+
+		service := NewInstallationService(http.DefaultClient, Config{Timeout: time.Minute, PollInterval: time.Millisecond})
+
+		var reported []string
+
+		//when
+		err := service.WaitForInstallation(context.Background(), fmt.Sprintf(kubeconfigTemplate, kubeServer.URL), func(description string) {
+			reported = append(reported, description)
+		})
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Install component 0", "Install component 1"}, reported)
+	})
+
+	t.Run("Should wait until installer picks up the Installation when previous installation is finished", func(t *testing.T) {
+		//given
+		requests := 0
+
+		kubeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				respond(t, w, http.StatusOK, map[string]interface{}{
+					"metadata": map[string]interface{}{"labels": map[string]interface{}{installationActionKey: installActionValue}},
+					"status":   map[string]interface{}{"state": "Installed", "description": "Kyma installed"},
+				})
+				return
+			}
+
+			respond(t, w, http.StatusOK, map[string]interface{}{"status": map[string]interface{}{"state": "Installed", "description": "Kyma installed"}})
+		}))
+		defer kubeServer.Close()
+
+		service := NewInstallationService(http.DefaultClient, Config{Timeout: time.Minute, PollInterval: time.Millisecond})
+
+		//when
+		err := service.WaitForInstallation(context.Background(), fmt.Sprintf(kubeconfigTemplate, kubeServer.URL), func(string) {})
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("Should return error when installation timed out", func(t *testing.T) {
+		//given
+		kubeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respond(t, w, http.StatusOK, map[string]interface{}{"status": map[string]interface{}{"state": "Error", "description": "Install component core"}})
+		}))
+		defer kubeServer.Close()
+
+		service := NewInstallationService(http.DefaultClient, Config{Timeout: 0, PollInterval: time.Millisecond})
+
+		//when
+		err := service.WaitForInstallation(context.Background(), fmt.Sprintf(kubeconfigTemplate, kubeServer.URL), func(string) {})
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "in Error state: Install component core")
+	})
+
+	t.Run("Should stop waiting when context is cancelled", func(t *testing.T) {
+		//given
+		kubeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respond(t, w, http.StatusOK, map[string]interface{}{"status": map[string]interface{}{"state": "InProgress"}})
+		}))
+		defer kubeServer.Close()
+
+		service := NewInstallationService(http.DefaultClient, Config{Timeout: time.Minute, PollInterval: time.Minute})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		//when
+		err := service.WaitForInstallation(ctx, fmt.Sprintf(kubeconfigTemplate, kubeServer.URL), func(string) {})
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Stopped waiting for Kyma installation")
+	})
+}
+
+func newInstallerServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1.7.0/kyma-installer-cluster.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, err := w.Write([]byte(installerManifest))
+		require.NoError(t, err)
+	}))
+}
+
+func resourceList(groupVersion string, resources ...meta.APIResource) meta.APIResourceList {
+	return meta.APIResourceList{
+		TypeMeta:     meta.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: groupVersion,
+		APIResources: resources,
+	}
+}
+
+func respond(t *testing.T, w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(t, json.NewEncoder(w).Encode(body))
+}
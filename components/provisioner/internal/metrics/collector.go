@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Namespace = "compass"
+	Subsystem = "provisioner"
+)
+
+// operationDurationBuckets cover operations lasting from a minute, like deprovisioning, to several hours, like provisioning with a Kyma installation
+var operationDurationBuckets = []float64{60, 120, 300, 600, 900, 1200, 1800, 2700, 3600, 5400, 7200, 10800, 14400}
+
+// Collector holds the metrics of the Provisioner
+type Collector struct {
+	graphQLOperations        *prometheus.CounterVec
+	graphQLOperationDuration *prometheus.HistogramVec
+	operationDuration        *prometheus.HistogramVec
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		graphQLOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "graphql_operations_total",
+			Help:      "Number of executed GraphQL operations by operation name and outcome",
+		}, []string{"operation", "outcome"}),
+		graphQLOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "graphql_operation_duration_seconds",
+			Help:      "Duration of GraphQL operations by operation name and outcome",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "outcome"}),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of finished Runtime operations by operation type and state",
+			Buckets:   operationDurationBuckets,
+		}, []string{"type", "state"}),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.graphQLOperations.Describe(ch)
+	c.graphQLOperationDuration.Describe(ch)
+	c.operationDuration.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.graphQLOperations.Collect(ch)
+	c.graphQLOperationDuration.Collect(ch)
+	c.operationDuration.Collect(ch)
+}
+
+func (c *Collector) ObserveGraphQLOperation(operation, outcome string, duration time.Duration) {
+	c.graphQLOperations.WithLabelValues(operation, outcome).Inc()
+	c.graphQLOperationDuration.WithLabelValues(operation, outcome).Observe(duration.Seconds())
+}
+
+func (c *Collector) ObserveOperation(operationType model.OperationType, state model.OperationState, duration time.Duration) {
+	c.operationDuration.WithLabelValues(string(operationType), string(state)).Observe(duration.Seconds())
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence"
+	log "github.com/sirupsen/logrus"
+)
+
+type persistenceService struct {
+	persistence.Service
+	collector *Collector
+}
+
+// NewPersistenceService returns the persistence Service which measures the duration of the finished operations
+func NewPersistenceService(service persistence.Service, collector *Collector) persistence.Service {
+	return &persistenceService{Service: service, collector: collector}
+}
+
+func (s *persistenceService) SetAsFailed(operationID, leaseOwner, message string) error {
+	err := s.Service.SetAsFailed(operationID, leaseOwner, message)
+	if err != nil {
+		return err
+	}
+	s.observe(operationID, model.Failed)
+
+	return nil
+}
+
+func (s *persistenceService) SetAsSucceeded(operationID, leaseOwner string) error {
+	err := s.Service.SetAsSucceeded(operationID, leaseOwner)
+	if err != nil {
+		return err
+	}
+	s.observe(operationID, model.Succeeded)
+
+	return nil
+}
+
+func (s *persistenceService) Abort(operationID, message string) (bool, error) {
+	aborted, err := s.Service.Abort(operationID, message)
+	if err != nil || !aborted {
+		return aborted, err
+	}
+	s.observe(operationID, model.Failed)
+
+	return true, nil
+}
+
+func (s *persistenceService) observe(operationID string, state model.OperationState) {
+	operation, err := s.Service.GetUnscoped(operationID)
+	if err != nil {
+		log.Warnf("Failed to get %s operation to measure its duration: %s", operationID, err.Error())
+		return
+	}
+
+	s.collector.ObserveOperation(operation.Type, state, time.Since(operation.StartTimestamp))
+}
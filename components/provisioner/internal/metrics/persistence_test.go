@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	persistenceMocks "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	operationID    = "223949ed-e6b6-4ab2-ab3e-8e19cd456dd4"
+	leaseOwner     = "provisioner-0"
+	durationMetric = "compass_provisioner_operation_duration_seconds"
+)
+
+func TestPersistenceService(t *testing.T) {
+	operation := model.Operation{ID: operationID, Type: model.Provision, StartTimestamp: time.Now().Add(-90 * time.Second)}
+
+	t.Run("Should measure duration of succeeded operation", func(t *testing.T) {
+		//given
+		collector := NewCollector()
+		service := &persistenceMocks.Service{}
+		service.On("SetAsSucceeded", operationID, leaseOwner).Return(nil)
+		service.On("GetUnscoped", operationID).Return(operation, nil)
+
+		//when
+		err := NewPersistenceService(service, collector).SetAsSucceeded(operationID, leaseOwner)
+
+		//then
+		require.NoError(t, err)
+		service.AssertExpectations(t)
+		assertOperationCount(t, collector, model.Provision, model.Succeeded)
+	})
+
+	t.Run("Should measure duration of failed operation", func(t *testing.T) {
+		//given
+		collector := NewCollector()
+		service := &persistenceMocks.Service{}
+		service.On("SetAsFailed", operationID, leaseOwner, "failed").Return(nil)
+		service.On("GetUnscoped", operationID).Return(operation, nil)
+
+		//when
+		err := NewPersistenceService(service, collector).SetAsFailed(operationID, leaseOwner, "failed")
+
+		//then
+		require.NoError(t, err)
+		service.AssertExpectations(t)
+		assertOperationCount(t, collector, model.Provision, model.Failed)
+	})
+
+	t.Run("Should measure duration of aborted operation", func(t *testing.T) {
+		//given
+		collector := NewCollector()
+		service := &persistenceMocks.Service{}
+		service.On("Abort", operationID, "timed out").Return(true, nil)
+		service.On("GetUnscoped", operationID).Return(operation, nil)
+
+		//when
+		aborted, err := NewPersistenceService(service, collector).Abort(operationID, "timed out")
+
+		//then
+		require.NoError(t, err)
+		assert.True(t, aborted)
+		service.AssertExpectations(t)
+		assertOperationCount(t, collector, model.Provision, model.Failed)
+	})
+
+	t.Run("Should not measure operation when its state is not updated", func(t *testing.T) {
+		//given
+		collector := NewCollector()
+		service := &persistenceMocks.Service{}
+		service.On("SetAsSucceeded", operationID, leaseOwner).Return(errors.New("error"))
+
+		//when
+		err := NewPersistenceService(service, collector).SetAsSucceeded(operationID, leaseOwner)
+
+		//then
+		require.Error(t, err)
+		service.AssertExpectations(t)
+		service.AssertNotCalled(t, "GetUnscoped", operationID)
+	})
+}
+
+// assertOperationCount checks that the duration of a single operation of the type and state, lasting about 90 seconds, was observed
+func assertOperationCount(t *testing.T, collector *Collector, operationType model.OperationType, state model.OperationState) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != durationMetric {
+			continue
+		}
+		require.Len(t, family.GetMetric(), 1)
+		metric := family.GetMetric()[0]
+		labels := map[string]string{}
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		assert.Equal(t, map[string]string{"type": string(operationType), "state": string(state)}, labels)
+		assert.Equal(t, uint64(1), metric.GetHistogram().GetSampleCount())
+		assert.InDelta(t, 90, metric.GetHistogram().GetSampleSum(), 5)
+		return
+	}
+
+	t.Fatalf("metric %s not found", durationMetric)
+}
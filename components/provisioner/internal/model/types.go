@@ -6,11 +6,15 @@ import (
 
 type KymaModule string
 
+type KymaComponent string
+
 type KymaConfig struct {
-	ID        string
-	Version   string
-	Modules   []KymaConfigModule
-	ClusterID string
+	ID                  string
+	Version             string
+	Modules             []KymaConfigModule
+	Components          []KymaComponentConfig
+	GlobalConfiguration Configuration
+	ClusterID           string
 }
 
 type KymaConfigModule struct {
@@ -19,9 +23,28 @@ type KymaConfigModule struct {
 	KymaConfigID string
 }
 
+type KymaComponentConfig struct {
+	ID            string
+	Component     KymaComponent
+	Configuration Configuration
+	KymaConfigID  string
+}
+
+// Configuration holds Installer overrides
+type Configuration struct {
+	ConfigEntries []ConfigEntry `json:"configEntries"`
+}
+
+type ConfigEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret"`
+}
+
 type OperationState string
 
 const (
+	Pending    OperationState = "PENDING"
 	InProgress OperationState = "IN_PROGRESS"
 	Succeeded  OperationState = "SUCCEEDED"
 	Failed     OperationState = "FAILED"
@@ -42,6 +65,7 @@ type Cluster struct {
 	TerraformState        string
 	CredentialsSecretName string
 	CreationTimestamp     time.Time
+	Tenant                string
 }
 
 type Operation struct {
@@ -52,6 +76,70 @@ type Operation struct {
 	State          OperationState
 	Message        string
 	ClusterID      string
+	LeaseOwner     *string
+	LeaseExpiresAt *time.Time
+	Steps          []OperationStep
+}
+
+// OperationLease identifies the Provisioner instance executing operations and defines how long its claim is valid without renewal
+type OperationLease struct {
+	Owner    string
+	Duration time.Duration
+}
+
+// Progress returns the percentage of succeeded steps of the operation, or nil if the operation has no steps.
+// Succeeded operations are complete even if some steps were skipped, for example when a resumed operation continued from a later step.
+func (o Operation) Progress() *int {
+	if len(o.Steps) == 0 {
+		return nil
+	}
+
+	if o.State == Succeeded {
+		progress := 100
+		return &progress
+	}
+
+	succeeded := 0
+	for _, step := range o.Steps {
+		if step.State == Succeeded {
+			succeeded++
+		}
+	}
+
+	progress := succeeded * 100 / len(o.Steps)
+
+	return &progress
+}
+
+type OperationStepName string
+
+const (
+	StepPrepareClusterConfig   OperationStepName = "PREPARE_CLUSTER_CONFIG"
+	StepProvisionCluster       OperationStepName = "PROVISION_CLUSTER"
+	StepUpgradeCluster         OperationStepName = "UPGRADE_CLUSTER"
+	StepDeprovisionCluster     OperationStepName = "DEPROVISION_CLUSTER"
+	StepRetrieveKubeconfig     OperationStepName = "RETRIEVE_KUBECONFIG"
+	StepSaveClusterData        OperationStepName = "SAVE_CLUSTER_DATA"
+	StepStartInstallation      OperationStepName = "START_KYMA_INSTALLATION"
+	StepInstallKyma            OperationStepName = "INSTALL_KYMA"
+	StepStartKymaUpgrade       OperationStepName = "START_KYMA_UPGRADE"
+	StepUpgradeKyma            OperationStepName = "UPGRADE_KYMA"
+	StepSaveKymaConfig         OperationStepName = "SAVE_KYMA_CONFIG"
+	StepGenerateToken          OperationStepName = "GENERATE_ONE_TIME_TOKEN"
+	StepConfigureAgent         OperationStepName = "CONFIGURE_RUNTIME_AGENT"
+	StepWaitForAgentConnection OperationStepName = "WAIT_FOR_RUNTIME_AGENT_CONNECTION"
+)
+
+// OperationStep is one of the ordered steps of an operation. Steps are Pending until the operation reaches them.
+type OperationStep struct {
+	ID             string
+	OperationID    string
+	Sequence       int
+	Name           OperationStepName
+	State          OperationState
+	Message        string
+	StartTimestamp *time.Time
+	EndTimestamp   *time.Time
 }
 
 type GardenerConfig struct {
@@ -88,6 +176,38 @@ type GCPConfig struct {
 	Zone              string
 }
 
+type AWSConfig struct {
+	ID                string
+	ClusterID         string
+	Name              string
+	KubernetesVersion string
+	NodeCount         int
+	VolumeSize        string
+	MachineType       string
+	Region            string
+	Zone              string
+	VpcCidr           string
+	PublicCidr        string
+	InternalCidr      string
+	AutoScalerMin     int
+	AutoScalerMax     int
+}
+
+type AzureConfig struct {
+	ID                string
+	ClusterID         string
+	Name              string
+	KubernetesVersion string
+	NodeCount         int
+	VolumeSize        string
+	MachineType       string
+	Region            string
+	Zone              string
+	VnetCidr          string
+	AutoScalerMin     int
+	AutoScalerMax     int
+}
+
 type RuntimeAgentConnectionStatus int
 
 type ClusterConfig struct {
@@ -122,6 +242,30 @@ type RuntimeStatus struct {
 	RuntimeConfiguration    RuntimeConfig
 }
 
+// RuntimeSummary describes a Runtime on the list of Runtimes managed by the Provisioner
+type RuntimeSummary struct {
+	ID                string
+	Name              string
+	Provider          string
+	Region            string
+	CreationTimestamp time.Time
+	LastOperation     Operation
+}
+
+// RuntimeFilter narrows the list of Runtimes. Runtimes are matched by the state of their last operation.
+type RuntimeFilter struct {
+	State    *OperationState
+	Provider *string
+	Region   *string
+}
+
+type OperationFilter struct {
+	RuntimeID *string
+	Type      *OperationType
+	State     *OperationState
+	Since     *time.Time
+}
+
 func (rc RuntimeConfig) GCPConfig() (GCPConfig, bool) {
 	gcpConfig, ok := rc.ClusterConfig.(GCPConfig)
 
@@ -133,3 +277,15 @@ func (rc RuntimeConfig) GardenerConfig() (GardenerConfig, bool) {
 
 	return gardenerConfig, ok
 }
+
+func (rc RuntimeConfig) AWSConfig() (AWSConfig, bool) {
+	awsConfig, ok := rc.ClusterConfig.(AWSConfig)
+
+	return awsConfig, ok
+}
+
+func (rc RuntimeConfig) AzureConfig() (AzureConfig, bool) {
+	azureConfig, ok := rc.ClusterConfig.(AzureConfig)
+
+	return azureConfig, ok
+}
@@ -0,0 +1,45 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const cursorPrefix = "offset:"
+
+// DecodeOffsetCursor returns the offset encoded in the cursor. An empty cursor points to the first page.
+func DecodeOffsetCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	decodedValue, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.Wrap(err, "cursor is not correct")
+	}
+
+	if !strings.HasPrefix(string(decodedValue), cursorPrefix) {
+		return 0, errors.New("cursor is not correct")
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decodedValue), cursorPrefix))
+	if err != nil {
+		return 0, errors.Wrap(err, "cursor is not correct")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("cursor is not correct")
+	}
+
+	return offset, nil
+}
+
+// EncodeNextOffsetCursor returns the cursor pointing to the page following the page of given size starting at the offset
+func EncodeNextOffsetCursor(offset, pageSize int) string {
+	cursor := cursorPrefix + strconv.Itoa(offset+pageSize)
+
+	return base64.StdEncoding.EncodeToString([]byte(cursor))
+}
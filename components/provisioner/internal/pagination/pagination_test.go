@@ -0,0 +1,47 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffsetCursor(t *testing.T) {
+	t.Run("Should decode offset from the next page cursor", func(t *testing.T) {
+		//given
+		cursor := EncodeNextOffsetCursor(100, 50)
+
+		//when
+		offset, err := DecodeOffsetCursor(cursor)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 150, offset)
+	})
+
+	t.Run("Should return zero offset for empty cursor", func(t *testing.T) {
+		//when
+		offset, err := DecodeOffsetCursor("")
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 0, offset)
+	})
+
+	for _, cursor := range []string{
+		"not base64",
+		base64.StdEncoding.EncodeToString([]byte("10")),
+		base64.StdEncoding.EncodeToString([]byte("offset:ten")),
+		base64.StdEncoding.EncodeToString([]byte("offset:-10")),
+	} {
+		t.Run("Should return error for invalid cursor "+cursor, func(t *testing.T) {
+			//when
+			_, err := DecodeOffsetCursor(cursor)
+
+			//then
+			require.Error(t, err)
+		})
+	}
+}
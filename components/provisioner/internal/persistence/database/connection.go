@@ -0,0 +1,118 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gocraft/dbr"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	_ "github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	retryCount = 20
+
+	// SchemaVersion is the version of the latest Provisioner migration in components/schema-migrator/migrations/provisioner
+	SchemaVersion = 201912031300
+
+	schemaMigrationsTableName = "schema_migrations"
+	tableNotExistsError       = "42P01"
+)
+
+// Connect opens database connection and verifies that the schema is migrated to the version supported by the Provisioner
+func Connect(connectionString string) (*dbr.Connection, error) {
+	connection, err := waitForDatabaseAccess(connectionString, retryCount)
+	if err != nil {
+		return nil, err
+	}
+
+	version, dirty, err := getSchemaVersion(connection)
+	if err != nil {
+		closeDBConnection(connection)
+		return nil, errors.Wrap(err, "Failed to get database schema version")
+	}
+
+	err = checkSchemaVersion(version, dirty)
+	if err != nil {
+		closeDBConnection(connection)
+		return nil, err
+	}
+
+	log.Infof("Database schema version: %d", version)
+
+	return connection, nil
+}
+
+func closeDBConnection(db *dbr.Connection) {
+	err := db.Close()
+	if err != nil {
+		log.Warnf("Failed to close database connection: %s", err.Error())
+	}
+}
+
+// getSchemaVersion reads the version of the last migration applied by the schema migrator. The version is 0 if no migration was applied.
+func getSchemaVersion(db *dbr.Connection) (int64, bool, error) {
+	row := db.QueryRow(fmt.Sprintf("SELECT version, dirty FROM %s", schemaMigrationsTableName))
+
+	var version int64
+	var dirty bool
+	err := row.Scan(&version, &dirty)
+
+	if err != nil {
+		psqlErr, converted := err.(*pq.Error)
+
+		if err == sql.ErrNoRows || (converted && psqlErr.Code == tableNotExistsError) {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
+
+func checkSchemaVersion(version int64, dirty bool) error {
+	if dirty {
+		return errors.Errorf("database schema version %d is dirty, the migration failed and has to be fixed manually", version)
+	}
+
+	if version < SchemaVersion {
+		return errors.Errorf("database schema version %d is outdated, run the schema migrator to migrate it to version %d", version, SchemaVersion)
+	}
+
+	if version > SchemaVersion {
+		log.Warnf("Database schema version %d is newer than version %d supported by the Provisioner", version, SchemaVersion)
+	}
+
+	return nil
+}
+
+func waitForDatabaseAccess(connString string, retryCount int) (*dbr.Connection, error) {
+	var connection *dbr.Connection
+	var err error
+	for ; retryCount > 0; retryCount-- {
+		connection, err = dbr.Open("postgres", connString, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid connection string")
+		}
+
+		err = connection.Ping()
+		if err == nil {
+			return connection, nil
+		}
+
+		err = connection.Close()
+		if err != nil {
+			log.Info("Failed to close database ...")
+		}
+
+		log.Info("Failed to access database, waiting 5 seconds to retry...")
+		time.Sleep(5 * time.Second)
+	}
+
+	return nil, errors.New("timeout waiting for database access")
+}
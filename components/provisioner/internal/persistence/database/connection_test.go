@@ -0,0 +1,68 @@
+package database
+
+import (
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrationsDir = "../../../../schema-migrator/migrations/provisioner"
+
+func TestSchemaVersion(t *testing.T) {
+	//given
+	files, err := ioutil.ReadDir(migrationsDir)
+	require.NoError(t, err)
+
+	var versions []int64
+	for _, file := range files {
+		version, err := strconv.ParseInt(strings.Split(file.Name(), "_")[0], 10, 64)
+		require.NoError(t, err)
+		versions = append(versions, version)
+	}
+	require.NotEmpty(t, versions)
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	//then
+	assert.Equal(t, versions[len(versions)-1], int64(SchemaVersion), "SchemaVersion must be the version of the latest migration")
+}
+
+func TestCheckSchemaVersion(t *testing.T) {
+	t.Run("Should accept the supported schema version", func(t *testing.T) {
+		//when
+		err := checkSchemaVersion(SchemaVersion, false)
+
+		//then
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should accept newer schema version", func(t *testing.T) {
+		//when
+		err := checkSchemaVersion(SchemaVersion+1, false)
+
+		//then
+		assert.NoError(t, err)
+	})
+
+	for _, testCase := range []struct {
+		description string
+		version     int64
+		dirty       bool
+	}{
+		{description: "Should refuse not migrated schema", version: 0},
+		{description: "Should refuse outdated schema version", version: SchemaVersion - 1},
+		{description: "Should refuse dirty schema version", version: SchemaVersion, dirty: true},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			//when
+			err := checkSchemaVersion(testCase.version, testCase.dirty)
+
+			//then
+			assert.Error(t, err)
+		})
+	}
+}
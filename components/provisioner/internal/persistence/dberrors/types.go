@@ -6,6 +6,8 @@ const (
 	CodeInternal      = 1
 	CodeNotFound      = 2
 	CodeAlreadyExists = 3
+	CodeLeaseLost     = 4
+	CodeInProgress    = 5
 )
 
 type Error interface {
@@ -35,6 +37,14 @@ func AlreadyExists(format string, a ...interface{}) Error {
 	return errorf(CodeAlreadyExists, format, a...)
 }
 
+func LeaseLost(format string, a ...interface{}) Error {
+	return errorf(CodeLeaseLost, format, a...)
+}
+
+func InProgress(format string, a ...interface{}) Error {
+	return errorf(CodeInProgress, format, a...)
+}
+
 func (e dbError) Append(additionalFormat string, a ...interface{}) Error {
 	format := additionalFormat + ", " + e.message
 	return errorf(e.code, format, a...)
@@ -12,6 +12,7 @@ func TestAppError(t *testing.T) {
 		assert.Equal(t, CodeInternal, Internal("error").Code())
 		assert.Equal(t, CodeNotFound, NotFound("error").Code())
 		assert.Equal(t, CodeAlreadyExists, AlreadyExists("error").Code())
+		assert.Equal(t, CodeLeaseLost, LeaseLost("error").Code())
 	})
 
 	t.Run("should create error with simple message", func(t *testing.T) {
@@ -0,0 +1,142 @@
+package dbsession
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+
+	"github.com/gocraft/dbr"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
+	"github.com/pkg/errors"
+)
+
+// ConfigurationCipher encrypts the values of secret configuration entries, so that they are not stored in the database in plain text
+type ConfigurationCipher struct {
+	aead cipher.AEAD
+}
+
+// NewConfigurationCipher creates the cipher with the AES-256 key derived from the encryption key
+func NewConfigurationCipher(encryptionKey string) (ConfigurationCipher, error) {
+	if encryptionKey == "" {
+		return ConfigurationCipher{}, errors.New("Encryption key must not be empty")
+	}
+
+	key := sha256.Sum256([]byte(encryptionKey))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return ConfigurationCipher{}, errors.Wrap(err, "Failed to create cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return ConfigurationCipher{}, errors.Wrap(err, "Failed to create cipher")
+	}
+
+	return ConfigurationCipher{aead: aead}, nil
+}
+
+func (c ConfigurationCipher) encrypt(value string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "Failed to generate nonce")
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c ConfigurationCipher) decrypt(value string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to decode encrypted value")
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("Encrypted value is too short")
+	}
+
+	opened, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to decrypt value")
+	}
+
+	return string(opened), nil
+}
+
+// configEntryRecord is the stored form of the configuration entry. Values of secret entries are kept only encrypted
+type configEntryRecord struct {
+	Key            string `json:"key"`
+	Value          string `json:"value,omitempty"`
+	EncryptedValue string `json:"encryptedValue,omitempty"`
+	Secret         bool   `json:"secret"`
+}
+
+type configurationRecord struct {
+	ConfigEntries []configEntryRecord `json:"configEntries"`
+}
+
+func (c ConfigurationCipher) marshalConfiguration(configuration model.Configuration) (string, dberrors.Error) {
+	record := configurationRecord{ConfigEntries: make([]configEntryRecord, 0, len(configuration.ConfigEntries))}
+
+	for _, entry := range configuration.ConfigEntries {
+		entryRecord := configEntryRecord{Key: entry.Key, Secret: entry.Secret}
+
+		if entry.Secret {
+			encrypted, err := c.encrypt(entry.Value)
+			if err != nil {
+				return "", dberrors.Internal("Failed to encrypt value of %s configuration entry: %s", entry.Key, err)
+			}
+			entryRecord.EncryptedValue = encrypted
+		} else {
+			entryRecord.Value = entry.Value
+		}
+
+		record.ConfigEntries = append(record.ConfigEntries, entryRecord)
+	}
+
+	marshalled, err := json.Marshal(record)
+	if err != nil {
+		return "", dberrors.Internal("Failed to marshal configuration: %s", err)
+	}
+
+	return string(marshalled), nil
+}
+
+func (c ConfigurationCipher) unmarshalConfiguration(value dbr.NullString) (model.Configuration, dberrors.Error) {
+	var configuration model.Configuration
+
+	if !value.Valid || value.String == "" {
+		return configuration, nil
+	}
+
+	var record configurationRecord
+
+	err := json.Unmarshal([]byte(value.String), &record)
+	if err != nil {
+		return model.Configuration{}, dberrors.Internal("Failed to unmarshal configuration: %s", err)
+	}
+
+	for _, entryRecord := range record.ConfigEntries {
+		entry := model.ConfigEntry{Key: entryRecord.Key, Value: entryRecord.Value, Secret: entryRecord.Secret}
+
+		// Secret entries saved before the values were encrypted are read as they are
+		if entryRecord.EncryptedValue != "" {
+			entry.Value, err = c.decrypt(entryRecord.EncryptedValue)
+			if err != nil {
+				return model.Configuration{}, dberrors.Internal("Failed to decrypt value of %s configuration entry: %s", entryRecord.Key, err)
+			}
+		}
+
+		configuration.ConfigEntries = append(configuration.ConfigEntries, entry)
+	}
+
+	return configuration, nil
+}
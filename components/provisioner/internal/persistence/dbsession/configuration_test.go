@@ -0,0 +1,91 @@
+package dbsession
+
+import (
+	"testing"
+
+	"github.com/gocraft/dbr"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigurationCipher(t *testing.T) {
+
+	configuration := model.Configuration{
+		ConfigEntries: []model.ConfigEntry{
+			{Key: "test.config.key", Value: "value"},
+			{Key: "test.secret.key", Value: "secret-value", Secret: true},
+		},
+	}
+
+	t.Run("should store only encrypted values of secret entries", func(t *testing.T) {
+		//given
+		cipher, err := NewConfigurationCipher("encryption-key")
+		require.NoError(t, err)
+
+		//when
+		marshalled, dbErr := cipher.marshalConfiguration(configuration)
+		require.NoError(t, dbErr)
+
+		//then
+		assert.Contains(t, marshalled, `"value":"value"`)
+		assert.NotContains(t, marshalled, "secret-value")
+		assert.Contains(t, marshalled, `"encryptedValue":`)
+	})
+
+	t.Run("should read configuration with decrypted secret entries", func(t *testing.T) {
+		//given
+		cipher, err := NewConfigurationCipher("encryption-key")
+		require.NoError(t, err)
+
+		marshalled, dbErr := cipher.marshalConfiguration(configuration)
+		require.NoError(t, dbErr)
+
+		//when
+		unmarshalled, dbErr := cipher.unmarshalConfiguration(dbr.NewNullString(marshalled))
+
+		//then
+		require.NoError(t, dbErr)
+		assert.Equal(t, configuration, unmarshalled)
+	})
+
+	t.Run("should read secret entries stored in plain text", func(t *testing.T) {
+		//given
+		cipher, err := NewConfigurationCipher("encryption-key")
+		require.NoError(t, err)
+
+		stored := `{"configEntries":[{"key":"test.secret.key","value":"secret-value","secret":true}]}`
+
+		//when
+		unmarshalled, dbErr := cipher.unmarshalConfiguration(dbr.NewNullString(stored))
+
+		//then
+		require.NoError(t, dbErr)
+		assert.Equal(t, model.Configuration{ConfigEntries: []model.ConfigEntry{{Key: "test.secret.key", Value: "secret-value", Secret: true}}}, unmarshalled)
+	})
+
+	t.Run("should fail to read configuration encrypted with different key", func(t *testing.T) {
+		//given
+		cipher, err := NewConfigurationCipher("encryption-key")
+		require.NoError(t, err)
+		otherCipher, err := NewConfigurationCipher("other-encryption-key")
+		require.NoError(t, err)
+
+		marshalled, dbErr := cipher.marshalConfiguration(configuration)
+		require.NoError(t, dbErr)
+
+		//when
+		_, dbErr = otherCipher.unmarshalConfiguration(dbr.NewNullString(marshalled))
+
+		//then
+		require.Error(t, dbErr)
+	})
+
+	t.Run("should fail to create cipher with empty key", func(t *testing.T) {
+		//when
+		_, err := NewConfigurationCipher("")
+
+		//then
+		require.Error(t, err)
+	})
+}
@@ -1,6 +1,8 @@
 package dbsession
 
 import (
+	"time"
+
 	"github.com/gocraft/dbr"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
@@ -8,7 +10,8 @@ import (
 
 //go:generate mockery -name=Factory
 type Factory interface {
-	NewReadSession() ReadSession
+	NewReadSession(tenant string) ReadSession
+	NewUnscopedReadSession() ReadSession
 	NewWriteSession() WriteSession
 	NewSessionWithinTransaction() (WriteSessionWithinTransaction, dberrors.Error)
 }
@@ -20,6 +23,10 @@ type ReadSession interface {
 	GetLastOperation(runtimeID string) (model.Operation, dberrors.Error)
 	GetKymaConfig(runtimeID string) (model.KymaConfig, dberrors.Error)
 	GetClusterConfig(runtimeID string) (interface{}, dberrors.Error)
+	ListInProgressOperations() ([]model.Operation, dberrors.Error)
+	ListRuntimes(filter model.RuntimeFilter, pageSize, offset int) ([]model.RuntimeSummary, int, dberrors.Error)
+	ListOperations(filter model.OperationFilter, pageSize, offset int) ([]model.Operation, int, dberrors.Error)
+	GetOperationSteps(operationIDs []string) ([]model.OperationStep, dberrors.Error)
 }
 
 //go:generate mockery -name=WriteSession
@@ -27,11 +34,23 @@ type WriteSession interface {
 	InsertCluster(cluster model.Cluster) dberrors.Error
 	InsertGardenerConfig(config model.GardenerConfig) dberrors.Error
 	InsertGCPConfig(config model.GCPConfig) dberrors.Error
+	InsertAWSConfig(config model.AWSConfig) dberrors.Error
+	InsertAzureConfig(config model.AzureConfig) dberrors.Error
 	InsertKymaConfig(kymaConfig model.KymaConfig) dberrors.Error
 	InsertOperation(operation model.Operation) dberrors.Error
 	UpdateOperationState(operationID string, message string, state model.OperationState) dberrors.Error
+	InsertOperationStep(step model.OperationStep) dberrors.Error
+	StartOperationStep(operationID string, step model.OperationStepName, message string, timestamp time.Time) dberrors.Error
+	FinishOperationStep(operationID string, state model.OperationState, message string, timestamp time.Time) dberrors.Error
 	UpdateCluster(runtimeID string, kubeconfig string, terraformState string) dberrors.Error
+	UpdateKubernetesVersion(runtimeID string, version string) dberrors.Error
 	DeleteCluster(runtimeID string) dberrors.Error
+	DeleteKymaConfig(runtimeID string) dberrors.Error
+	AcquireOperationLease(operationID string, owner string, now time.Time, expiresAt time.Time) (bool, dberrors.Error)
+	RenewOperationLease(operationID string, owner string, expiresAt time.Time) (bool, dberrors.Error)
+	ReleaseOperationLease(operationID string, owner string) dberrors.Error
+	FinishLeasedOperation(operationID string, owner string, message string, state model.OperationState) (bool, dberrors.Error)
+	AbortOperation(operationID string, message string) (bool, dberrors.Error)
 }
 
 type Transaction interface {
@@ -43,27 +62,44 @@ type Transaction interface {
 type WriteSessionWithinTransaction interface {
 	WriteSession
 	Transaction
+	LockCluster(runtimeID string) dberrors.Error
+	InProgressOperationExists(runtimeID string) (bool, dberrors.Error)
 }
 
 type factory struct {
 	connection *dbr.Connection
+	cipher     ConfigurationCipher
 }
 
-func NewFactory(connection *dbr.Connection) Factory {
+func NewFactory(connection *dbr.Connection, cipher ConfigurationCipher) Factory {
 	return &factory{
 		connection: connection,
+		cipher:     cipher,
+	}
+}
+
+func (sf *factory) NewReadSession(tenant string) ReadSession {
+	return readSession{
+		session: sf.connection.NewSession(nil),
+		scope:   tenantScope(tenant),
+		cipher:  sf.cipher,
 	}
 }
 
-func (sf *factory) NewReadSession() ReadSession {
+// NewUnscopedReadSession returns a session reading data of all tenants. It is meant only for the components executing
+// operations in the background and must never be used on behalf of a tenant
+func (sf *factory) NewUnscopedReadSession() ReadSession {
 	return readSession{
 		session: sf.connection.NewSession(nil),
+		scope:   unscoped{},
+		cipher:  sf.cipher,
 	}
 }
 
 func (sf *factory) NewWriteSession() WriteSession {
 	return writeSession{
 		session: sf.connection.NewSession(nil),
+		cipher:  sf.cipher,
 	}
 }
 
@@ -78,5 +114,6 @@ func (sf *factory) NewSessionWithinTransaction() (WriteSessionWithinTransaction,
 	return writeSession{
 		session:     dbSession,
 		transaction: dbTransaction,
+		cipher:      sf.cipher,
 	}, nil
 }
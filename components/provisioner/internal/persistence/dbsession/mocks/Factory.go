@@ -5,7 +5,6 @@ package mocks
 import (
 	dberrors "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
 	dbsession "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dbsession"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,13 +13,13 @@ type Factory struct {
 	mock.Mock
 }
 
-// NewReadSession provides a mock function with given fields:
-func (_m *Factory) NewReadSession() dbsession.ReadSession {
-	ret := _m.Called()
+// NewReadSession provides a mock function with given fields: tenant
+func (_m *Factory) NewReadSession(tenant string) dbsession.ReadSession {
+	ret := _m.Called(tenant)
 
 	var r0 dbsession.ReadSession
-	if rf, ok := ret.Get(0).(func() dbsession.ReadSession); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string) dbsession.ReadSession); ok {
+		r0 = rf(tenant)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(dbsession.ReadSession)
@@ -55,6 +54,22 @@ func (_m *Factory) NewSessionWithinTransaction() (dbsession.WriteSessionWithinTr
 	return r0, r1
 }
 
+// NewUnscopedReadSession provides a mock function with given fields:
+func (_m *Factory) NewUnscopedReadSession() dbsession.ReadSession {
+	ret := _m.Called()
+
+	var r0 dbsession.ReadSession
+	if rf, ok := ret.Get(0).(func() dbsession.ReadSession); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dbsession.ReadSession)
+		}
+	}
+
+	return r0
+}
+
 // NewWriteSession provides a mock function with given fields:
 func (_m *Factory) NewWriteSession() dbsession.WriteSession {
 	ret := _m.Called()
@@ -3,11 +3,9 @@
 package mocks
 
 import (
+	model "github.com/kyma-incubator/compass/components/provisioner/internal/model"
 	dberrors "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
-
 	mock "github.com/stretchr/testify/mock"
-
-	model "github.com/kyma-incubator/compass/components/provisioner/internal/model"
 )
 
 // ReadSession is an autogenerated mock type for the ReadSession type
@@ -131,3 +129,117 @@ func (_m *ReadSession) GetOperation(operationID string) (model.Operation, dberro
 
 	return r0, r1
 }
+
+// GetOperationSteps provides a mock function with given fields: operationIDs
+func (_m *ReadSession) GetOperationSteps(operationIDs []string) ([]model.OperationStep, dberrors.Error) {
+	ret := _m.Called(operationIDs)
+
+	var r0 []model.OperationStep
+	if rf, ok := ret.Get(0).(func([]string) []model.OperationStep); ok {
+		r0 = rf(operationIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OperationStep)
+		}
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func([]string) dberrors.Error); ok {
+		r1 = rf(operationIDs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListInProgressOperations provides a mock function with given fields:
+func (_m *ReadSession) ListInProgressOperations() ([]model.Operation, dberrors.Error) {
+	ret := _m.Called()
+
+	var r0 []model.Operation
+	if rf, ok := ret.Get(0).(func() []model.Operation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Operation)
+		}
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func() dberrors.Error); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListOperations provides a mock function with given fields: filter, pageSize, offset
+func (_m *ReadSession) ListOperations(filter model.OperationFilter, pageSize int, offset int) ([]model.Operation, int, dberrors.Error) {
+	ret := _m.Called(filter, pageSize, offset)
+
+	var r0 []model.Operation
+	if rf, ok := ret.Get(0).(func(model.OperationFilter, int, int) []model.Operation); ok {
+		r0 = rf(filter, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Operation)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(model.OperationFilter, int, int) int); ok {
+		r1 = rf(filter, pageSize, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 dberrors.Error
+	if rf, ok := ret.Get(2).(func(model.OperationFilter, int, int) dberrors.Error); ok {
+		r2 = rf(filter, pageSize, offset)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(dberrors.Error)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// ListRuntimes provides a mock function with given fields: filter, pageSize, offset
+func (_m *ReadSession) ListRuntimes(filter model.RuntimeFilter, pageSize int, offset int) ([]model.RuntimeSummary, int, dberrors.Error) {
+	ret := _m.Called(filter, pageSize, offset)
+
+	var r0 []model.RuntimeSummary
+	if rf, ok := ret.Get(0).(func(model.RuntimeFilter, int, int) []model.RuntimeSummary); ok {
+		r0 = rf(filter, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.RuntimeSummary)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(model.RuntimeFilter, int, int) int); ok {
+		r1 = rf(filter, pageSize, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 dberrors.Error
+	if rf, ok := ret.Get(2).(func(model.RuntimeFilter, int, int) dberrors.Error); ok {
+		r2 = rf(filter, pageSize, offset)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(dberrors.Error)
+		}
+	}
+
+	return r0, r1, r2
+}
@@ -3,11 +3,10 @@
 package mocks
 
 import (
+	model "github.com/kyma-incubator/compass/components/provisioner/internal/model"
 	dberrors "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
-
 	mock "github.com/stretchr/testify/mock"
-
-	model "github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	time "time"
 )
 
 // WriteSessionWithinTransaction is an autogenerated mock type for the WriteSessionWithinTransaction type
@@ -15,6 +14,52 @@ type WriteSessionWithinTransaction struct {
 	mock.Mock
 }
 
+// AbortOperation provides a mock function with given fields: operationID, message
+func (_m *WriteSessionWithinTransaction) AbortOperation(operationID string, message string) (bool, dberrors.Error) {
+	ret := _m.Called(operationID, message)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(operationID, message)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string) dberrors.Error); ok {
+		r1 = rf(operationID, message)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// AcquireOperationLease provides a mock function with given fields: operationID, owner, now, expiresAt
+func (_m *WriteSessionWithinTransaction) AcquireOperationLease(operationID string, owner string, now time.Time, expiresAt time.Time) (bool, dberrors.Error) {
+	ret := _m.Called(operationID, owner, now, expiresAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, time.Time, time.Time) bool); ok {
+		r0 = rf(operationID, owner, now, expiresAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string, time.Time, time.Time) dberrors.Error); ok {
+		r1 = rf(operationID, owner, now, expiresAt)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
 // Commit provides a mock function with given fields:
 func (_m *WriteSessionWithinTransaction) Commit() dberrors.Error {
 	ret := _m.Called()
@@ -47,6 +92,116 @@ func (_m *WriteSessionWithinTransaction) DeleteCluster(runtimeID string) dberror
 	return r0
 }
 
+// DeleteKymaConfig provides a mock function with given fields: runtimeID
+func (_m *WriteSessionWithinTransaction) DeleteKymaConfig(runtimeID string) dberrors.Error {
+	ret := _m.Called(runtimeID)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string) dberrors.Error); ok {
+		r0 = rf(runtimeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
+// FinishLeasedOperation provides a mock function with given fields: operationID, owner, message, state
+func (_m *WriteSessionWithinTransaction) FinishLeasedOperation(operationID string, owner string, message string, state model.OperationState) (bool, dberrors.Error) {
+	ret := _m.Called(operationID, owner, message, state)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, string, model.OperationState) bool); ok {
+		r0 = rf(operationID, owner, message, state)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string, string, model.OperationState) dberrors.Error); ok {
+		r1 = rf(operationID, owner, message, state)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// FinishOperationStep provides a mock function with given fields: operationID, state, message, timestamp
+func (_m *WriteSessionWithinTransaction) FinishOperationStep(operationID string, state model.OperationState, message string, timestamp time.Time) dberrors.Error {
+	ret := _m.Called(operationID, state, message, timestamp)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string, model.OperationState, string, time.Time) dberrors.Error); ok {
+		r0 = rf(operationID, state, message, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
+// InProgressOperationExists provides a mock function with given fields: runtimeID
+func (_m *WriteSessionWithinTransaction) InProgressOperationExists(runtimeID string) (bool, dberrors.Error) {
+	ret := _m.Called(runtimeID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(runtimeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string) dberrors.Error); ok {
+		r1 = rf(runtimeID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// InsertAWSConfig provides a mock function with given fields: config
+func (_m *WriteSessionWithinTransaction) InsertAWSConfig(config model.AWSConfig) dberrors.Error {
+	ret := _m.Called(config)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(model.AWSConfig) dberrors.Error); ok {
+		r0 = rf(config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
+// InsertAzureConfig provides a mock function with given fields: config
+func (_m *WriteSessionWithinTransaction) InsertAzureConfig(config model.AzureConfig) dberrors.Error {
+	ret := _m.Called(config)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(model.AzureConfig) dberrors.Error); ok {
+		r0 = rf(config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
 // InsertCluster provides a mock function with given fields: cluster
 func (_m *WriteSessionWithinTransaction) InsertCluster(cluster model.Cluster) dberrors.Error {
 	ret := _m.Called(cluster)
@@ -127,11 +282,98 @@ func (_m *WriteSessionWithinTransaction) InsertOperation(operation model.Operati
 	return r0
 }
 
+// InsertOperationStep provides a mock function with given fields: step
+func (_m *WriteSessionWithinTransaction) InsertOperationStep(step model.OperationStep) dberrors.Error {
+	ret := _m.Called(step)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(model.OperationStep) dberrors.Error); ok {
+		r0 = rf(step)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
+// LockCluster provides a mock function with given fields: runtimeID
+func (_m *WriteSessionWithinTransaction) LockCluster(runtimeID string) dberrors.Error {
+	ret := _m.Called(runtimeID)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string) dberrors.Error); ok {
+		r0 = rf(runtimeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
+// ReleaseOperationLease provides a mock function with given fields: operationID, owner
+func (_m *WriteSessionWithinTransaction) ReleaseOperationLease(operationID string, owner string) dberrors.Error {
+	ret := _m.Called(operationID, owner)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string, string) dberrors.Error); ok {
+		r0 = rf(operationID, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
+// RenewOperationLease provides a mock function with given fields: operationID, owner, expiresAt
+func (_m *WriteSessionWithinTransaction) RenewOperationLease(operationID string, owner string, expiresAt time.Time) (bool, dberrors.Error) {
+	ret := _m.Called(operationID, owner, expiresAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) bool); ok {
+		r0 = rf(operationID, owner, expiresAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) dberrors.Error); ok {
+		r1 = rf(operationID, owner, expiresAt)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
 // RollbackUnlessCommitted provides a mock function with given fields:
 func (_m *WriteSessionWithinTransaction) RollbackUnlessCommitted() {
 	_m.Called()
 }
 
+// StartOperationStep provides a mock function with given fields: operationID, step, message, timestamp
+func (_m *WriteSessionWithinTransaction) StartOperationStep(operationID string, step model.OperationStepName, message string, timestamp time.Time) dberrors.Error {
+	ret := _m.Called(operationID, step, message, timestamp)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string, model.OperationStepName, string, time.Time) dberrors.Error); ok {
+		r0 = rf(operationID, step, message, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
 // UpdateCluster provides a mock function with given fields: runtimeID, kubeconfig, terraformState
 func (_m *WriteSessionWithinTransaction) UpdateCluster(runtimeID string, kubeconfig string, terraformState string) dberrors.Error {
 	ret := _m.Called(runtimeID, kubeconfig, terraformState)
@@ -148,6 +390,22 @@ func (_m *WriteSessionWithinTransaction) UpdateCluster(runtimeID string, kubecon
 	return r0
 }
 
+// UpdateKubernetesVersion provides a mock function with given fields: runtimeID, version
+func (_m *WriteSessionWithinTransaction) UpdateKubernetesVersion(runtimeID string, version string) dberrors.Error {
+	ret := _m.Called(runtimeID, version)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string, string) dberrors.Error); ok {
+		r0 = rf(runtimeID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
 // UpdateOperationState provides a mock function with given fields: operationID, message, state
 func (_m *WriteSessionWithinTransaction) UpdateOperationState(operationID string, message string, state model.OperationState) dberrors.Error {
 	ret := _m.Called(operationID, message, state)
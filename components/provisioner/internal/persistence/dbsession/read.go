@@ -1,22 +1,52 @@
 package dbsession
 
 import (
+	"time"
+
 	"github.com/gocraft/dbr"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
 )
 
+var operationColumns = []string{"operation.id", "operation.type", "operation.start_timestamp", "operation.end_timestamp",
+	"operation.state", "operation.message", "operation.cluster_id"}
+
+// scope restricts the clusters visible to a read session
+type scope interface {
+	restrict(query *dbr.SelectStmt) *dbr.SelectStmt
+}
+
+// tenantScope restricts the queries to clusters of the tenant
+type tenantScope string
+
+func (t tenantScope) restrict(query *dbr.SelectStmt) *dbr.SelectStmt {
+	return query.Where(dbr.Eq("cluster.tenant", string(t)))
+}
+
+// unscoped does not restrict the queries, it is used only by the sessions created with NewUnscopedReadSession
+type unscoped struct{}
+
+func (unscoped) restrict(query *dbr.SelectStmt) *dbr.SelectStmt {
+	return query
+}
+
 type readSession struct {
 	session *dbr.Session
+	scope   scope
+	cipher  ConfigurationCipher
+}
+
+func (r readSession) withTenant(query *dbr.SelectStmt) *dbr.SelectStmt {
+	return r.scope.restrict(query)
 }
 
 func (r readSession) GetCluster(runtimeID string) (model.Cluster, dberrors.Error) {
 	var cluster model.Cluster
 
-	err := r.session.
-		Select("id", "kubeconfig", "terraform_state", "credentials_secret_name", "creation_timestamp").
+	err := r.withTenant(r.session.
+		Select("id", "kubeconfig", "terraform_state", "credentials_secret_name", "creation_timestamp", "tenant").
 		From("cluster").
-		Where(dbr.Eq("cluster.id", runtimeID)).
+		Where(dbr.Eq("cluster.id", runtimeID))).
 		LoadOne(&cluster)
 
 	if err != nil {
@@ -31,19 +61,21 @@ func (r readSession) GetCluster(runtimeID string) (model.Cluster, dberrors.Error
 
 func (r readSession) GetKymaConfig(runtimeID string) (model.KymaConfig, dberrors.Error) {
 	var kymaConfig []struct {
-		ID           string
-		KymaConfigID string
-		Version      string
-		Module       string
-		ClusterID    string
+		ID                  string
+		KymaConfigID        string
+		Version             string
+		GlobalConfiguration dbr.NullString
+		Module              string
+		ClusterID           string
 	}
 
-	rowsCount, err := r.session.
-		Select("kyma_config_module.id", "kyma_config_id", "kyma_config.version", "kyma_config_module.module", "cluster_id").
+	rowsCount, err := r.withTenant(r.session.
+		Select("kyma_config_module.id", "kyma_config_id", "kyma_config.version", "kyma_config.global_configuration",
+			"kyma_config_module.module", "cluster_id").
 		From("cluster").
 		Join("kyma_config", "cluster.id=kyma_config.cluster_id").
 		Join("kyma_config_module", "kyma_config.id=kyma_config_module.kyma_config_id").
-		Where(dbr.Eq("cluster.id", runtimeID)).
+		Where(dbr.Eq("cluster.id", runtimeID))).
 		Load(&kymaConfig)
 
 	if err != nil {
@@ -64,24 +96,73 @@ func (r readSession) GetKymaConfig(runtimeID string) (model.KymaConfig, dberrors
 		kymaModules = append(kymaModules, kymaConfigModule)
 	}
 
+	globalConfiguration, dbErr := r.cipher.unmarshalConfiguration(kymaConfig[0].GlobalConfiguration)
+	if dbErr != nil {
+		return model.KymaConfig{}, dbErr
+	}
+
+	components, dbErr := r.getKymaComponentConfigs(kymaConfig[0].KymaConfigID)
+	if dbErr != nil {
+		return model.KymaConfig{}, dbErr
+	}
+
 	return model.KymaConfig{
-		ID:      kymaConfig[0].KymaConfigID,
-		Version: kymaConfig[0].Version,
-		Modules: kymaModules,
+		ID:                  kymaConfig[0].KymaConfigID,
+		Version:             kymaConfig[0].Version,
+		Modules:             kymaModules,
+		Components:          components,
+		GlobalConfiguration: globalConfiguration,
+		ClusterID:           runtimeID,
 	}, nil
 }
 
+func (r readSession) getKymaComponentConfigs(kymaConfigID string) ([]model.KymaComponentConfig, dberrors.Error) {
+	var componentConfigs []struct {
+		ID            string
+		Component     string
+		Configuration dbr.NullString
+	}
+
+	_, err := r.session.
+		Select("id", "component", "configuration").
+		From("kyma_component_config").
+		Where(dbr.Eq("kyma_config_id", kymaConfigID)).
+		Load(&componentConfigs)
+
+	if err != nil {
+		return nil, dberrors.Internal("Failed to get Kyma component configuration: %s", err)
+	}
+
+	components := make([]model.KymaComponentConfig, 0, len(componentConfigs))
+
+	for _, componentConfig := range componentConfigs {
+		configuration, dbErr := r.cipher.unmarshalConfiguration(componentConfig.Configuration)
+		if dbErr != nil {
+			return nil, dbErr
+		}
+
+		components = append(components, model.KymaComponentConfig{
+			ID:            componentConfig.ID,
+			Component:     model.KymaComponent(componentConfig.Component),
+			Configuration: configuration,
+			KymaConfigID:  kymaConfigID,
+		})
+	}
+
+	return components, nil
+}
+
 func (r readSession) GetClusterConfig(runtimeID string) (interface{}, dberrors.Error) {
 	var gardenerConfig model.GardenerConfig
 
-	err := r.session.
+	err := r.withTenant(r.session.
 		Select("gardener_config.id", "cluster_id", "gardener_config.name", "project_name", "kubernetes_version",
 			"node_count", "volume_size", "disk_type", "machine_type", "target_provider",
 			"target_secret", "cidr", "region", "zone", "auto_scaler_min", "auto_scaler_max",
 			"max_surge", "max_unavailable").
 		From("cluster").
 		Join("gardener_config", "cluster.id=gardener_config.cluster_id").
-		Where(dbr.Eq("cluster.id", runtimeID)).
+		Where(dbr.Eq("cluster.id", runtimeID))).
 		LoadOne(&gardenerConfig)
 
 	if err == nil {
@@ -94,31 +175,68 @@ func (r readSession) GetClusterConfig(runtimeID string) (interface{}, dberrors.E
 
 	var gcpConfig model.GCPConfig
 
-	err = r.session.
+	err = r.withTenant(r.session.
 		Select("gcp_config.id", "cluster_id", "name", "project_name", "kubernetes_version",
 			"number_of_nodes", "boot_disk_size", "machine_type", "region", "zone").
 		From("cluster").
 		Join("gcp_config", "cluster.id=gcp_config.cluster_id").
-		Where(dbr.Eq("cluster.id", runtimeID)).
+		Where(dbr.Eq("cluster.id", runtimeID))).
 		LoadOne(&gcpConfig)
 
+	if err == nil {
+		return gcpConfig, nil
+	}
+
+	if err != dbr.ErrNotFound {
+		return model.GCPConfig{}, dberrors.Internal("Failed to get GCP Config: %s", err)
+	}
+
+	var awsConfig model.AWSConfig
+
+	err = r.withTenant(r.session.
+		Select("aws_config.id", "cluster_id", "name", "kubernetes_version", "node_count", "volume_size",
+			"machine_type", "region", "zone", "vpc_cidr", "public_cidr", "internal_cidr", "auto_scaler_min", "auto_scaler_max").
+		From("cluster").
+		Join("aws_config", "cluster.id=aws_config.cluster_id").
+		Where(dbr.Eq("cluster.id", runtimeID))).
+		LoadOne(&awsConfig)
+
+	if err == nil {
+		return awsConfig, nil
+	}
+
+	if err != dbr.ErrNotFound {
+		return model.AWSConfig{}, dberrors.Internal("Failed to get AWS Config: %s", err)
+	}
+
+	var azureConfig model.AzureConfig
+
+	err = r.withTenant(r.session.
+		Select("azure_config.id", "cluster_id", "name", "kubernetes_version", "node_count", "volume_size",
+			"machine_type", "region", "zone", "vnet_cidr", "auto_scaler_min", "auto_scaler_max").
+		From("cluster").
+		Join("azure_config", "cluster.id=azure_config.cluster_id").
+		Where(dbr.Eq("cluster.id", runtimeID))).
+		LoadOne(&azureConfig)
+
 	if err != nil {
 		if err == dbr.ErrNotFound {
-			return model.GCPConfig{}, dberrors.NotFound("Cluster configuration not found for runtime: %s", runtimeID)
+			return model.AzureConfig{}, dberrors.NotFound("Cluster configuration not found for runtime: %s", runtimeID)
 		}
-		return model.GCPConfig{}, dberrors.Internal("Failed to get GCP Config: %s", err)
+		return model.AzureConfig{}, dberrors.Internal("Failed to get Azure Config: %s", err)
 	}
 
-	return gcpConfig, nil
+	return azureConfig, nil
 }
 
 func (r readSession) GetOperation(operationID string) (model.Operation, dberrors.Error) {
 	var operation model.Operation
 
-	err := r.session.
-		Select("id", "type", "start_timestamp", "end_timestamp", "state", "message", "cluster_id").
+	err := r.withTenant(r.session.
+		Select(operationColumns...).
 		From("operation").
-		Where(dbr.Eq("id", operationID)).
+		Join("cluster", "cluster.id=operation.cluster_id").
+		Where(dbr.Eq("operation.id", operationID))).
 		LoadOne(&operation)
 
 	if err != nil {
@@ -139,10 +257,12 @@ func (r readSession) GetLastOperation(runtimeID string) (model.Operation, dberro
 
 	var operation model.Operation
 
-	err := r.session.
-		Select("id", "type", "start_timestamp", "end_timestamp", "state", "message", "cluster_id").
+	err := r.withTenant(r.session.
+		Select(operationColumns...).
 		From("operation").
-		Where(dbr.Eq("start_timestamp", lastOperationDateSelect)).
+		Join("cluster", "cluster.id=operation.cluster_id").
+		Where(dbr.Eq("operation.cluster_id", runtimeID)).
+		Where(dbr.Eq("operation.start_timestamp", lastOperationDateSelect))).
 		LoadOne(&operation)
 
 	if err != nil {
@@ -154,3 +274,200 @@ func (r readSession) GetLastOperation(runtimeID string) (model.Operation, dberro
 
 	return operation, nil
 }
+
+func (r readSession) ListInProgressOperations() ([]model.Operation, dberrors.Error) {
+	var operations []model.Operation
+
+	_, err := r.withTenant(r.session.
+		Select(operationColumns...).
+		From("operation").
+		Join("cluster", "cluster.id=operation.cluster_id").
+		Where(dbr.Eq("operation.state", model.InProgress))).
+		Load(&operations)
+
+	if err != nil {
+		return nil, dberrors.Internal("Failed to list operations in progress: %s", err)
+	}
+
+	return operations, nil
+}
+
+func (r readSession) ListRuntimes(filter model.RuntimeFilter, pageSize, offset int) ([]model.RuntimeSummary, int, dberrors.Error) {
+	var runtimes []struct {
+		ID                      string
+		Name                    string
+		Provider                string
+		Region                  string
+		CreationTimestamp       time.Time
+		OperationID             string
+		OperationType           model.OperationType
+		OperationStartTimestamp time.Time
+		OperationEndTimestamp   *time.Time
+		OperationState          model.OperationState
+		OperationMessage        dbr.NullString
+	}
+
+	_, err := r.runtimesQuery(filter,
+		"cluster.id", "config.name", "config.provider", "config.region", "cluster.creation_timestamp",
+		"operation.id AS operation_id", "operation.type AS operation_type", "operation.start_timestamp AS operation_start_timestamp",
+		"operation.end_timestamp AS operation_end_timestamp", "operation.state AS operation_state", "operation.message AS operation_message").
+		OrderBy("cluster.creation_timestamp").
+		OrderBy("cluster.id").
+		Limit(uint64(pageSize)).
+		Offset(uint64(offset)).
+		Load(&runtimes)
+
+	if err != nil {
+		return nil, 0, dberrors.Internal("Failed to list runtimes: %s", err)
+	}
+
+	var totalCount int
+
+	err = r.runtimesQuery(filter, "COUNT(*)").LoadOne(&totalCount)
+	if err != nil {
+		return nil, 0, dberrors.Internal("Failed to count runtimes: %s", err)
+	}
+
+	summaries := make([]model.RuntimeSummary, 0, len(runtimes))
+
+	for _, runtime := range runtimes {
+		summaries = append(summaries, model.RuntimeSummary{
+			ID:                runtime.ID,
+			Name:              runtime.Name,
+			Provider:          runtime.Provider,
+			Region:            runtime.Region,
+			CreationTimestamp: runtime.CreationTimestamp,
+			LastOperation: model.Operation{
+				ID:             runtime.OperationID,
+				Type:           runtime.OperationType,
+				StartTimestamp: runtime.OperationStartTimestamp,
+				EndTimestamp:   runtime.OperationEndTimestamp,
+				State:          runtime.OperationState,
+				Message:        runtime.OperationMessage.String,
+				ClusterID:      runtime.ID,
+			},
+		})
+	}
+
+	return summaries, totalCount, nil
+}
+
+// runtimesQuery joins clusters with the name, provider and region from their configuration and with their last operation
+func (r readSession) runtimesQuery(filter model.RuntimeFilter, columns ...string) *dbr.SelectStmt {
+	clusterConfigs := dbr.UnionAll(
+		r.session.Select("cluster_id", "name", "region", "'gardener' AS provider").From("gardener_config"),
+		r.session.Select("cluster_id", "name", "region", "'gcp' AS provider").From("gcp_config"),
+		r.session.Select("cluster_id", "name", "region", "'aws' AS provider").From("aws_config"),
+		r.session.Select("cluster_id", "name", "region", "'azure' AS provider").From("azure_config"),
+	).As("config")
+
+	query := r.session.
+		Select(columns...).
+		From("cluster").
+		Join(clusterConfigs, "config.cluster_id=cluster.id").
+		Join("operation", "operation.cluster_id=cluster.id AND operation.start_timestamp=(SELECT MAX(start_timestamp) FROM operation last_operation WHERE last_operation.cluster_id=cluster.id)")
+
+	if filter.State != nil {
+		query = query.Where(dbr.Eq("operation.state", *filter.State))
+	}
+	if filter.Provider != nil {
+		query = query.Where(dbr.Eq("config.provider", *filter.Provider))
+	}
+	if filter.Region != nil {
+		query = query.Where(dbr.Eq("config.region", *filter.Region))
+	}
+
+	return r.withTenant(query)
+}
+
+func (r readSession) ListOperations(filter model.OperationFilter, pageSize, offset int) ([]model.Operation, int, dberrors.Error) {
+	var operations []model.Operation
+
+	_, err := r.operationsQuery(filter, operationColumns...).
+		OrderDir("operation.start_timestamp", false).
+		OrderBy("operation.id").
+		Limit(uint64(pageSize)).
+		Offset(uint64(offset)).
+		Load(&operations)
+
+	if err != nil {
+		return nil, 0, dberrors.Internal("Failed to list operations: %s", err)
+	}
+
+	var totalCount int
+
+	err = r.operationsQuery(filter, "COUNT(*)").LoadOne(&totalCount)
+	if err != nil {
+		return nil, 0, dberrors.Internal("Failed to count operations: %s", err)
+	}
+
+	return operations, totalCount, nil
+}
+
+func (r readSession) operationsQuery(filter model.OperationFilter, columns ...string) *dbr.SelectStmt {
+	query := r.session.
+		Select(columns...).
+		From("operation").
+		Join("cluster", "cluster.id=operation.cluster_id")
+
+	if filter.RuntimeID != nil {
+		query = query.Where(dbr.Eq("operation.cluster_id", *filter.RuntimeID))
+	}
+	if filter.Type != nil {
+		query = query.Where(dbr.Eq("operation.type", *filter.Type))
+	}
+	if filter.State != nil {
+		query = query.Where(dbr.Eq("operation.state", *filter.State))
+	}
+	if filter.Since != nil {
+		query = query.Where(dbr.Gte("operation.start_timestamp", *filter.Since))
+	}
+
+	return r.withTenant(query)
+}
+
+func (r readSession) GetOperationSteps(operationIDs []string) ([]model.OperationStep, dberrors.Error) {
+	if len(operationIDs) == 0 {
+		return []model.OperationStep{}, nil
+	}
+
+	var steps []struct {
+		ID             string
+		OperationID    string
+		Sequence       int
+		Name           model.OperationStepName
+		State          model.OperationState
+		Message        dbr.NullString
+		StartTimestamp *time.Time
+		EndTimestamp   *time.Time
+	}
+
+	_, err := r.session.
+		Select("id", "operation_id", "sequence", "name", "state", "message", "start_timestamp", "end_timestamp").
+		From("operation_step").
+		Where(dbr.Eq("operation_id", operationIDs)).
+		OrderBy("operation_id").
+		OrderBy("sequence").
+		Load(&steps)
+
+	if err != nil {
+		return nil, dberrors.Internal("Failed to get operation steps: %s", err)
+	}
+
+	operationSteps := make([]model.OperationStep, 0, len(steps))
+
+	for _, step := range steps {
+		operationSteps = append(operationSteps, model.OperationStep{
+			ID:             step.ID,
+			OperationID:    step.OperationID,
+			Sequence:       step.Sequence,
+			Name:           step.Name,
+			State:          step.State,
+			Message:        step.Message.String,
+			StartTimestamp: step.StartTimestamp,
+			EndTimestamp:   step.EndTimestamp,
+		})
+	}
+
+	return operationSteps, nil
+}
@@ -3,6 +3,7 @@ package dbsession
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/gocraft/dbr"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
@@ -12,6 +13,7 @@ import (
 type writeSession struct {
 	session     *dbr.Session
 	transaction *dbr.Tx
+	cipher      ConfigurationCipher
 }
 
 func (ws writeSession) InsertCluster(cluster model.Cluster) dberrors.Error {
@@ -20,6 +22,7 @@ func (ws writeSession) InsertCluster(cluster model.Cluster) dberrors.Error {
 		Pair("terraform_state", cluster.TerraformState).
 		Pair("credentials_secret_name", cluster.CredentialsSecretName).
 		Pair("creation_timestamp", cluster.CreationTimestamp).
+		Pair("tenant", cluster.Tenant).
 		Exec()
 
 	if err != nil {
@@ -58,10 +61,45 @@ func (ws writeSession) InsertGCPConfig(config model.GCPConfig) dberrors.Error {
 	return nil
 }
 
+func (ws writeSession) InsertAWSConfig(config model.AWSConfig) dberrors.Error {
+	_, err := ws.insertInto("aws_config").
+		Columns("id", "cluster_id", "name", "kubernetes_version", "node_count", "volume_size", "machine_type",
+			"region", "zone", "vpc_cidr", "public_cidr", "internal_cidr", "auto_scaler_min", "auto_scaler_max").
+		Record(config).
+		Exec()
+
+	if err != nil {
+		return dberrors.Internal("Failed to insert record to AWSConfig table: %s", err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) InsertAzureConfig(config model.AzureConfig) dberrors.Error {
+	_, err := ws.insertInto("azure_config").
+		Columns("id", "cluster_id", "name", "kubernetes_version", "node_count", "volume_size", "machine_type",
+			"region", "zone", "vnet_cidr", "auto_scaler_min", "auto_scaler_max").
+		Record(config).
+		Exec()
+
+	if err != nil {
+		return dberrors.Internal("Failed to insert record to AzureConfig table: %s", err)
+	}
+
+	return nil
+}
+
 func (ws writeSession) InsertKymaConfig(kymaConfig model.KymaConfig) dberrors.Error {
+	globalConfiguration, dbErr := ws.cipher.marshalConfiguration(kymaConfig.GlobalConfiguration)
+	if dbErr != nil {
+		return dbErr
+	}
+
 	_, err := ws.insertInto("kyma_config").
-		Columns("id", "version", "cluster_id").
-		Record(&kymaConfig).
+		Pair("id", kymaConfig.ID).
+		Pair("version", kymaConfig.Version).
+		Pair("global_configuration", globalConfiguration).
+		Pair("cluster_id", kymaConfig.ClusterID).
 		Exec()
 
 	if err != nil {
@@ -75,6 +113,33 @@ func (ws writeSession) InsertKymaConfig(kymaConfig model.KymaConfig) dberrors.Er
 		}
 	}
 
+	for _, componentConfig := range kymaConfig.Components {
+		err = ws.insertKymaComponentConfig(kymaConfig.ID, componentConfig)
+		if err != nil {
+			return dberrors.Internal("Failed to insert record to KymaComponentConfig table: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (ws writeSession) insertKymaComponentConfig(kymaConfigID string, componentConfig model.KymaComponentConfig) dberrors.Error {
+	configuration, dbErr := ws.cipher.marshalConfiguration(componentConfig.Configuration)
+	if dbErr != nil {
+		return dbErr
+	}
+
+	_, err := ws.insertInto("kyma_component_config").
+		Pair("id", componentConfig.ID).
+		Pair("component", componentConfig.Component).
+		Pair("configuration", configuration).
+		Pair("kyma_config_id", kymaConfigID).
+		Exec()
+
+	if err != nil {
+		return dberrors.Internal("Failed to insert record to KymaComponentConfig table: %s", err)
+	}
+
 	return nil
 }
 
@@ -91,9 +156,47 @@ func (ws writeSession) insertKymaConfigModule(kymaConfigID string, kymaConfigMod
 	return nil
 }
 
+// LockCluster locks the cluster row until the end of the transaction, so that operations on the cluster are started one at a time
+func (ws writeSession) LockCluster(runtimeID string) dberrors.Error {
+	var id string
+
+	err := ws.transaction.
+		Select("id").
+		From("cluster").
+		Where(dbr.Eq("id", runtimeID)).
+		Suffix("FOR UPDATE").
+		LoadOne(&id)
+
+	if err != nil {
+		if err == dbr.ErrNotFound {
+			return dberrors.NotFound("Cannot find Cluster for runtimeID: %s", runtimeID)
+		}
+
+		return dberrors.Internal("Failed to lock Cluster %s: %s", runtimeID, err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) InProgressOperationExists(runtimeID string) (bool, dberrors.Error) {
+	var count int
+
+	err := ws.transaction.
+		Select("count(*)").
+		From("operation").
+		Where(dbr.And(dbr.Eq("cluster_id", runtimeID), dbr.Eq("state", model.InProgress))).
+		LoadOne(&count)
+
+	if err != nil {
+		return false, dberrors.Internal("Failed to count operations in progress for cluster %s: %s", runtimeID, err)
+	}
+
+	return count > 0, nil
+}
+
 func (ws writeSession) InsertOperation(operation model.Operation) dberrors.Error {
 	_, err := ws.insertInto("operation").
-		Columns("id", "type", "state", "message", "start_timestamp", "cluster_id").
+		Columns("id", "type", "state", "message", "start_timestamp", "cluster_id", "lease_owner", "lease_expires_at").
 		Record(operation).
 		Exec()
 
@@ -116,6 +219,18 @@ func (ws writeSession) DeleteCluster(runtimeID string) dberrors.Error {
 	return nil
 }
 
+func (ws writeSession) DeleteKymaConfig(runtimeID string) dberrors.Error {
+	_, err := ws.deleteFrom("kyma_config").
+		Where(dbr.Eq("cluster_id", runtimeID)).
+		Exec()
+
+	if err != nil {
+		return dberrors.Internal("Failed to delete record in KymaConfig table: %s", err)
+	}
+
+	return nil
+}
+
 func (ws writeSession) UpdateOperationState(operationID string, message string, state model.OperationState) dberrors.Error {
 	res, err := ws.update("operation").
 		Where(dbr.Eq("id", operationID)).
@@ -130,6 +245,56 @@ func (ws writeSession) UpdateOperationState(operationID string, message string,
 	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update operation %s state: %s", operationID, err))
 }
 
+func (ws writeSession) InsertOperationStep(step model.OperationStep) dberrors.Error {
+	_, err := ws.insertInto("operation_step").
+		Columns("id", "operation_id", "sequence", "name", "state").
+		Record(step).
+		Exec()
+
+	if err != nil {
+		return dberrors.Internal("Failed to insert record to OperationStep table: %s", err)
+	}
+
+	return nil
+}
+
+// StartOperationStep moves the pending step of the operation to the in progress state
+func (ws writeSession) StartOperationStep(operationID string, step model.OperationStepName, message string, timestamp time.Time) dberrors.Error {
+	res, err := ws.update("operation_step").
+		Where(dbr.Eq("operation_id", operationID)).
+		Where(dbr.Eq("name", step)).
+		Set("state", model.InProgress).
+		Set("message", message).
+		Set("start_timestamp", timestamp).
+		Exec()
+
+	if err != nil {
+		return dberrors.Internal("Failed to start step %s of operation %s: %s", step, operationID, err)
+	}
+
+	return ws.updateSucceeded(res, fmt.Sprintf("Failed to start step %s of operation %s: step not found", step, operationID))
+}
+
+// FinishOperationStep sets the final state of the step of the operation which is in progress. The message of the step is kept if the new one is empty.
+func (ws writeSession) FinishOperationStep(operationID string, state model.OperationState, message string, timestamp time.Time) dberrors.Error {
+	query := ws.update("operation_step").
+		Where(dbr.Eq("operation_id", operationID)).
+		Where(dbr.Eq("state", model.InProgress)).
+		Set("state", state).
+		Set("end_timestamp", timestamp)
+
+	if message != "" {
+		query = query.Set("message", message)
+	}
+
+	_, err := query.Exec()
+	if err != nil {
+		return dberrors.Internal("Failed to finish step of operation %s: %s", operationID, err)
+	}
+
+	return nil
+}
+
 func (ws writeSession) UpdateCluster(runtimeID string, kubeconfig string, terraformState string) dberrors.Error {
 	res, err := ws.update("cluster").
 		Where(dbr.Eq("id", runtimeID)).
@@ -144,6 +309,121 @@ func (ws writeSession) UpdateCluster(runtimeID string, kubeconfig string, terraf
 	return ws.updateSucceeded(res, fmt.Sprintf("Failed to update cluster %s data: %s", runtimeID, err))
 }
 
+func (ws writeSession) UpdateKubernetesVersion(runtimeID string, version string) dberrors.Error {
+	var rowsAffected int64
+
+	for _, table := range []string{"gardener_config", "gcp_config", "aws_config", "azure_config"} {
+		res, err := ws.update(table).
+			Where(dbr.Eq("cluster_id", runtimeID)).
+			Set("kubernetes_version", version).
+			Exec()
+
+		if err != nil {
+			return dberrors.Internal("Failed to update Kubernetes version of cluster %s: %s", runtimeID, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return dberrors.Internal("Failed to get number of rows affected: %s", err)
+		}
+		rowsAffected += affected
+	}
+
+	if rowsAffected == 0 {
+		return dberrors.NotFound("Failed to update Kubernetes version of cluster %s: cluster config not found", runtimeID)
+	}
+
+	return nil
+}
+
+func (ws writeSession) AcquireOperationLease(operationID string, owner string, now time.Time, expiresAt time.Time) (bool, dberrors.Error) {
+	res, err := ws.update("operation").
+		Where(dbr.And(
+			dbr.Eq("id", operationID),
+			dbr.Eq("state", model.InProgress),
+			dbr.Or(dbr.Eq("lease_owner", nil), dbr.Lt("lease_expires_at", now)),
+		)).
+		Set("lease_owner", owner).
+		Set("lease_expires_at", expiresAt).
+		Exec()
+
+	if err != nil {
+		return false, dberrors.Internal("Failed to acquire lease for operation %s: %s", operationID, err)
+	}
+
+	return ws.singleRowAffected(res)
+}
+
+func (ws writeSession) RenewOperationLease(operationID string, owner string, expiresAt time.Time) (bool, dberrors.Error) {
+	res, err := ws.update("operation").
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("lease_owner", owner))).
+		Set("lease_expires_at", expiresAt).
+		Exec()
+
+	if err != nil {
+		return false, dberrors.Internal("Failed to renew lease for operation %s: %s", operationID, err)
+	}
+
+	return ws.singleRowAffected(res)
+}
+
+// FinishLeasedOperation sets the final state of the operation and releases its lease, unless the lease is no longer held by the owner
+func (ws writeSession) FinishLeasedOperation(operationID string, owner string, message string, state model.OperationState) (bool, dberrors.Error) {
+	res, err := ws.update("operation").
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("lease_owner", owner))).
+		Set("state", state).
+		Set("message", message).
+		Set("lease_owner", nil).
+		Set("lease_expires_at", nil).
+		Exec()
+
+	if err != nil {
+		return false, dberrors.Internal("Failed to update operation %s state: %s", operationID, err)
+	}
+
+	return ws.singleRowAffected(res)
+}
+
+// AbortOperation sets the operation in progress as failed and releases its lease regardless of the owner
+func (ws writeSession) AbortOperation(operationID string, message string) (bool, dberrors.Error) {
+	res, err := ws.update("operation").
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("state", model.InProgress))).
+		Set("state", model.Failed).
+		Set("message", message).
+		Set("lease_owner", nil).
+		Set("lease_expires_at", nil).
+		Exec()
+
+	if err != nil {
+		return false, dberrors.Internal("Failed to abort operation %s: %s", operationID, err)
+	}
+
+	return ws.singleRowAffected(res)
+}
+
+func (ws writeSession) ReleaseOperationLease(operationID string, owner string) dberrors.Error {
+	_, err := ws.update("operation").
+		Where(dbr.And(dbr.Eq("id", operationID), dbr.Eq("lease_owner", owner))).
+		Set("lease_owner", nil).
+		Set("lease_expires_at", nil).
+		Exec()
+
+	if err != nil {
+		return dberrors.Internal("Failed to release lease for operation %s: %s", operationID, err)
+	}
+
+	return nil
+}
+
+func (ws writeSession) singleRowAffected(result sql.Result) (bool, dberrors.Error) {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, dberrors.Internal("Failed to get number of rows affected: %s", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
 func (ws writeSession) updateSucceeded(result sql.Result, errorMsg string) dberrors.Error {
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
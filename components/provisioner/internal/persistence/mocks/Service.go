@@ -3,10 +3,10 @@
 package mocks
 
 import (
+	model "github.com/kyma-incubator/compass/components/provisioner/internal/model"
 	dberrors "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
 	mock "github.com/stretchr/testify/mock"
-
-	model "github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -14,6 +14,50 @@ type Service struct {
 	mock.Mock
 }
 
+// Abort provides a mock function with given fields: operationID, message
+func (_m *Service) Abort(operationID string, message string) (bool, error) {
+	ret := _m.Called(operationID, message)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(operationID, message)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(operationID, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AcquireLease provides a mock function with given fields: operationID, owner, duration
+func (_m *Service) AcquireLease(operationID string, owner string, duration time.Duration) (bool, dberrors.Error) {
+	ret := _m.Called(operationID, owner, duration)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, time.Duration) bool); ok {
+		r0 = rf(operationID, owner, duration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string, time.Duration) dberrors.Error); ok {
+		r1 = rf(operationID, owner, duration)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
 // CleanupClusterData provides a mock function with given fields: runtimeID
 func (_m *Service) CleanupClusterData(runtimeID string) dberrors.Error {
 	ret := _m.Called(runtimeID)
@@ -30,8 +74,98 @@ func (_m *Service) CleanupClusterData(runtimeID string) dberrors.Error {
 	return r0
 }
 
-// Get provides a mock function with given fields: operationID
-func (_m *Service) Get(operationID string) (model.Operation, error) {
+// Get provides a mock function with given fields: tenant, operationID
+func (_m *Service) Get(tenant string, operationID string) (model.Operation, error) {
+	ret := _m.Called(tenant, operationID)
+
+	var r0 model.Operation
+	if rf, ok := ret.Get(0).(func(string, string) model.Operation); ok {
+		r0 = rf(tenant, operationID)
+	} else {
+		r0 = ret.Get(0).(model.Operation)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tenant, operationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClusterData provides a mock function with given fields: tenant, runtimeID
+func (_m *Service) GetClusterData(tenant string, runtimeID string) (model.Cluster, dberrors.Error) {
+	ret := _m.Called(tenant, runtimeID)
+
+	var r0 model.Cluster
+	if rf, ok := ret.Get(0).(func(string, string) model.Cluster); ok {
+		r0 = rf(tenant, runtimeID)
+	} else {
+		r0 = ret.Get(0).(model.Cluster)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string) dberrors.Error); ok {
+		r1 = rf(tenant, runtimeID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetLastOperation provides a mock function with given fields: tenant, runtimeID
+func (_m *Service) GetLastOperation(tenant string, runtimeID string) (model.Operation, dberrors.Error) {
+	ret := _m.Called(tenant, runtimeID)
+
+	var r0 model.Operation
+	if rf, ok := ret.Get(0).(func(string, string) model.Operation); ok {
+		r0 = rf(tenant, runtimeID)
+	} else {
+		r0 = ret.Get(0).(model.Operation)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string) dberrors.Error); ok {
+		r1 = rf(tenant, runtimeID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetStatus provides a mock function with given fields: tenant, runtimeID
+func (_m *Service) GetStatus(tenant string, runtimeID string) (model.RuntimeStatus, dberrors.Error) {
+	ret := _m.Called(tenant, runtimeID)
+
+	var r0 model.RuntimeStatus
+	if rf, ok := ret.Get(0).(func(string, string) model.RuntimeStatus); ok {
+		r0 = rf(tenant, runtimeID)
+	} else {
+		r0 = ret.Get(0).(model.RuntimeStatus)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string) dberrors.Error); ok {
+		r1 = rf(tenant, runtimeID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetUnscoped provides a mock function with given fields: operationID
+func (_m *Service) GetUnscoped(operationID string) (model.Operation, error) {
 	ret := _m.Called(operationID)
 
 	var r0 model.Operation
@@ -51,8 +185,8 @@ func (_m *Service) Get(operationID string) (model.Operation, error) {
 	return r0, r1
 }
 
-// GetClusterData provides a mock function with given fields: runtimeID
-func (_m *Service) GetClusterData(runtimeID string) (model.Cluster, dberrors.Error) {
+// GetUnscopedClusterData provides a mock function with given fields: runtimeID
+func (_m *Service) GetUnscopedClusterData(runtimeID string) (model.Cluster, dberrors.Error) {
 	ret := _m.Called(runtimeID)
 
 	var r0 model.Cluster
@@ -74,15 +208,15 @@ func (_m *Service) GetClusterData(runtimeID string) (model.Cluster, dberrors.Err
 	return r0, r1
 }
 
-// GetLastOperation provides a mock function with given fields: runtimeID
-func (_m *Service) GetLastOperation(runtimeID string) (model.Operation, dberrors.Error) {
+// GetUnscopedStatus provides a mock function with given fields: runtimeID
+func (_m *Service) GetUnscopedStatus(runtimeID string) (model.RuntimeStatus, dberrors.Error) {
 	ret := _m.Called(runtimeID)
 
-	var r0 model.Operation
-	if rf, ok := ret.Get(0).(func(string) model.Operation); ok {
+	var r0 model.RuntimeStatus
+	if rf, ok := ret.Get(0).(func(string) model.RuntimeStatus); ok {
 		r0 = rf(runtimeID)
 	} else {
-		r0 = ret.Get(0).(model.Operation)
+		r0 = ret.Get(0).(model.RuntimeStatus)
 	}
 
 	var r1 dberrors.Error
@@ -97,20 +231,125 @@ func (_m *Service) GetLastOperation(runtimeID string) (model.Operation, dberrors
 	return r0, r1
 }
 
-// GetStatus provides a mock function with given fields: runtimeID
-func (_m *Service) GetStatus(runtimeID string) (model.RuntimeStatus, dberrors.Error) {
-	ret := _m.Called(runtimeID)
+// ListInProgressOperations provides a mock function with given fields:
+func (_m *Service) ListInProgressOperations() ([]model.Operation, dberrors.Error) {
+	ret := _m.Called()
 
-	var r0 model.RuntimeStatus
-	if rf, ok := ret.Get(0).(func(string) model.RuntimeStatus); ok {
-		r0 = rf(runtimeID)
+	var r0 []model.Operation
+	if rf, ok := ret.Get(0).(func() []model.Operation); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(model.RuntimeStatus)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Operation)
+		}
 	}
 
 	var r1 dberrors.Error
-	if rf, ok := ret.Get(1).(func(string) dberrors.Error); ok {
-		r1 = rf(runtimeID)
+	if rf, ok := ret.Get(1).(func() dberrors.Error); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListOperations provides a mock function with given fields: tenant, filter, pageSize, offset
+func (_m *Service) ListOperations(tenant string, filter model.OperationFilter, pageSize int, offset int) ([]model.Operation, int, dberrors.Error) {
+	ret := _m.Called(tenant, filter, pageSize, offset)
+
+	var r0 []model.Operation
+	if rf, ok := ret.Get(0).(func(string, model.OperationFilter, int, int) []model.Operation); ok {
+		r0 = rf(tenant, filter, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Operation)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, model.OperationFilter, int, int) int); ok {
+		r1 = rf(tenant, filter, pageSize, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 dberrors.Error
+	if rf, ok := ret.Get(2).(func(string, model.OperationFilter, int, int) dberrors.Error); ok {
+		r2 = rf(tenant, filter, pageSize, offset)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(dberrors.Error)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// ListRuntimes provides a mock function with given fields: tenant, filter, pageSize, offset
+func (_m *Service) ListRuntimes(tenant string, filter model.RuntimeFilter, pageSize int, offset int) ([]model.RuntimeSummary, int, dberrors.Error) {
+	ret := _m.Called(tenant, filter, pageSize, offset)
+
+	var r0 []model.RuntimeSummary
+	if rf, ok := ret.Get(0).(func(string, model.RuntimeFilter, int, int) []model.RuntimeSummary); ok {
+		r0 = rf(tenant, filter, pageSize, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.RuntimeSummary)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, model.RuntimeFilter, int, int) int); ok {
+		r1 = rf(tenant, filter, pageSize, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 dberrors.Error
+	if rf, ok := ret.Get(2).(func(string, model.RuntimeFilter, int, int) dberrors.Error); ok {
+		r2 = rf(tenant, filter, pageSize, offset)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(dberrors.Error)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// ReleaseLease provides a mock function with given fields: operationID, owner
+func (_m *Service) ReleaseLease(operationID string, owner string) dberrors.Error {
+	ret := _m.Called(operationID, owner)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string, string) dberrors.Error); ok {
+		r0 = rf(operationID, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
+// RenewLease provides a mock function with given fields: operationID, owner, duration
+func (_m *Service) RenewLease(operationID string, owner string, duration time.Duration) (bool, dberrors.Error) {
+	ret := _m.Called(operationID, owner, duration)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, time.Duration) bool); ok {
+		r0 = rf(operationID, owner, duration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, string, time.Duration) dberrors.Error); ok {
+		r1 = rf(operationID, owner, duration)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(dberrors.Error)
@@ -120,8 +359,22 @@ func (_m *Service) GetStatus(runtimeID string) (model.RuntimeStatus, dberrors.Er
 	return r0, r1
 }
 
-// SetAsFailed provides a mock function with given fields: operationID, message
-func (_m *Service) SetAsFailed(operationID string, message string) error {
+// SetAsFailed provides a mock function with given fields: operationID, leaseOwner, message
+func (_m *Service) SetAsFailed(operationID string, leaseOwner string, message string) error {
+	ret := _m.Called(operationID, leaseOwner, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(operationID, leaseOwner, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetAsInProgress provides a mock function with given fields: operationID, message
+func (_m *Service) SetAsInProgress(operationID string, message string) error {
 	ret := _m.Called(operationID, message)
 
 	var r0 error
@@ -134,13 +387,13 @@ func (_m *Service) SetAsFailed(operationID string, message string) error {
 	return r0
 }
 
-// SetAsSucceeded provides a mock function with given fields: operationID
-func (_m *Service) SetAsSucceeded(operationID string) error {
-	ret := _m.Called(operationID)
+// SetAsSucceeded provides a mock function with given fields: operationID, leaseOwner
+func (_m *Service) SetAsSucceeded(operationID string, leaseOwner string) error {
+	ret := _m.Called(operationID, leaseOwner)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(operationID)
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(operationID, leaseOwner)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -148,20 +401,20 @@ func (_m *Service) SetAsSucceeded(operationID string) error {
 	return r0
 }
 
-// SetDeprovisioningStarted provides a mock function with given fields: runtimeID
-func (_m *Service) SetDeprovisioningStarted(runtimeID string) (model.Operation, dberrors.Error) {
-	ret := _m.Called(runtimeID)
+// SetDeprovisioningStarted provides a mock function with given fields: runtimeID, steps, lease
+func (_m *Service) SetDeprovisioningStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	ret := _m.Called(runtimeID, steps, lease)
 
 	var r0 model.Operation
-	if rf, ok := ret.Get(0).(func(string) model.Operation); ok {
-		r0 = rf(runtimeID)
+	if rf, ok := ret.Get(0).(func(string, []model.OperationStepName, model.OperationLease) model.Operation); ok {
+		r0 = rf(runtimeID, steps, lease)
 	} else {
 		r0 = ret.Get(0).(model.Operation)
 	}
 
 	var r1 dberrors.Error
-	if rf, ok := ret.Get(1).(func(string) dberrors.Error); ok {
-		r1 = rf(runtimeID)
+	if rf, ok := ret.Get(1).(func(string, []model.OperationStepName, model.OperationLease) dberrors.Error); ok {
+		r1 = rf(runtimeID, steps, lease)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(dberrors.Error)
@@ -171,20 +424,20 @@ func (_m *Service) SetDeprovisioningStarted(runtimeID string) (model.Operation,
 	return r0, r1
 }
 
-// SetProvisioningStarted provides a mock function with given fields: runtimeID, runtimeConfig
-func (_m *Service) SetProvisioningStarted(runtimeID string, runtimeConfig model.RuntimeConfig) (model.Operation, dberrors.Error) {
-	ret := _m.Called(runtimeID, runtimeConfig)
+// SetInstallationRetryStarted provides a mock function with given fields: runtimeID, kymaConfig, steps, lease
+func (_m *Service) SetInstallationRetryStarted(runtimeID string, kymaConfig model.KymaConfig, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	ret := _m.Called(runtimeID, kymaConfig, steps, lease)
 
 	var r0 model.Operation
-	if rf, ok := ret.Get(0).(func(string, model.RuntimeConfig) model.Operation); ok {
-		r0 = rf(runtimeID, runtimeConfig)
+	if rf, ok := ret.Get(0).(func(string, model.KymaConfig, []model.OperationStepName, model.OperationLease) model.Operation); ok {
+		r0 = rf(runtimeID, kymaConfig, steps, lease)
 	} else {
 		r0 = ret.Get(0).(model.Operation)
 	}
 
 	var r1 dberrors.Error
-	if rf, ok := ret.Get(1).(func(string, model.RuntimeConfig) dberrors.Error); ok {
-		r1 = rf(runtimeID, runtimeConfig)
+	if rf, ok := ret.Get(1).(func(string, model.KymaConfig, []model.OperationStepName, model.OperationLease) dberrors.Error); ok {
+		r1 = rf(runtimeID, kymaConfig, steps, lease)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(dberrors.Error)
@@ -194,20 +447,66 @@ func (_m *Service) SetProvisioningStarted(runtimeID string, runtimeConfig model.
 	return r0, r1
 }
 
-// SetUpgradeStarted provides a mock function with given fields: runtimeID
-func (_m *Service) SetUpgradeStarted(runtimeID string) (model.Operation, dberrors.Error) {
-	ret := _m.Called(runtimeID)
+// SetProvisioningStarted provides a mock function with given fields: tenant, runtimeID, runtimeConfig, steps, lease
+func (_m *Service) SetProvisioningStarted(tenant string, runtimeID string, runtimeConfig model.RuntimeConfig, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	ret := _m.Called(tenant, runtimeID, runtimeConfig, steps, lease)
 
 	var r0 model.Operation
-	if rf, ok := ret.Get(0).(func(string) model.Operation); ok {
-		r0 = rf(runtimeID)
+	if rf, ok := ret.Get(0).(func(string, string, model.RuntimeConfig, []model.OperationStepName, model.OperationLease) model.Operation); ok {
+		r0 = rf(tenant, runtimeID, runtimeConfig, steps, lease)
 	} else {
 		r0 = ret.Get(0).(model.Operation)
 	}
 
 	var r1 dberrors.Error
-	if rf, ok := ret.Get(1).(func(string) dberrors.Error); ok {
-		r1 = rf(runtimeID)
+	if rf, ok := ret.Get(1).(func(string, string, model.RuntimeConfig, []model.OperationStepName, model.OperationLease) dberrors.Error); ok {
+		r1 = rf(tenant, runtimeID, runtimeConfig, steps, lease)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetReconnectRuntimeStarted provides a mock function with given fields: runtimeID, steps, lease
+func (_m *Service) SetReconnectRuntimeStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	ret := _m.Called(runtimeID, steps, lease)
+
+	var r0 model.Operation
+	if rf, ok := ret.Get(0).(func(string, []model.OperationStepName, model.OperationLease) model.Operation); ok {
+		r0 = rf(runtimeID, steps, lease)
+	} else {
+		r0 = ret.Get(0).(model.Operation)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, []model.OperationStepName, model.OperationLease) dberrors.Error); ok {
+		r1 = rf(runtimeID, steps, lease)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(dberrors.Error)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetUpgradeStarted provides a mock function with given fields: runtimeID, steps, lease
+func (_m *Service) SetUpgradeStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	ret := _m.Called(runtimeID, steps, lease)
+
+	var r0 model.Operation
+	if rf, ok := ret.Get(0).(func(string, []model.OperationStepName, model.OperationLease) model.Operation); ok {
+		r0 = rf(runtimeID, steps, lease)
+	} else {
+		r0 = ret.Get(0).(model.Operation)
+	}
+
+	var r1 dberrors.Error
+	if rf, ok := ret.Get(1).(func(string, []model.OperationStepName, model.OperationLease) dberrors.Error); ok {
+		r1 = rf(runtimeID, steps, lease)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(dberrors.Error)
@@ -217,6 +516,20 @@ func (_m *Service) SetUpgradeStarted(runtimeID string) (model.Operation, dberror
 	return r0, r1
 }
 
+// StartOperationStep provides a mock function with given fields: operationID, step, message
+func (_m *Service) StartOperationStep(operationID string, step model.OperationStepName, message string) error {
+	ret := _m.Called(operationID, step, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, model.OperationStepName, string) error); ok {
+		r0 = rf(operationID, step, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: runtimeID, kubeconfig, terraformState
 func (_m *Service) Update(runtimeID string, kubeconfig string, terraformState string) dberrors.Error {
 	ret := _m.Called(runtimeID, kubeconfig, terraformState)
@@ -232,3 +545,35 @@ func (_m *Service) Update(runtimeID string, kubeconfig string, terraformState st
 
 	return r0
 }
+
+// UpdateKymaConfig provides a mock function with given fields: runtimeID, kymaConfig
+func (_m *Service) UpdateKymaConfig(runtimeID string, kymaConfig model.KymaConfig) dberrors.Error {
+	ret := _m.Called(runtimeID, kymaConfig)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string, model.KymaConfig) dberrors.Error); ok {
+		r0 = rf(runtimeID, kymaConfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
+
+// UpdateUpgradedCluster provides a mock function with given fields: runtimeID, kubeconfig, terraformState, runtimeConfig
+func (_m *Service) UpdateUpgradedCluster(runtimeID string, kubeconfig string, terraformState string, runtimeConfig model.RuntimeConfig) dberrors.Error {
+	ret := _m.Called(runtimeID, kubeconfig, terraformState, runtimeConfig)
+
+	var r0 dberrors.Error
+	if rf, ok := ret.Get(0).(func(string, string, string, model.RuntimeConfig) dberrors.Error); ok {
+		r0 = rf(runtimeID, kubeconfig, terraformState, runtimeConfig)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dberrors.Error)
+		}
+	}
+
+	return r0
+}
@@ -12,17 +12,33 @@ import (
 
 //go:generate mockery -name=Service
 type Service interface {
-	GetStatus(runtimeID string) (model.RuntimeStatus, dberrors.Error)
-	SetProvisioningStarted(runtimeID string, runtimeConfig model.RuntimeConfig) (model.Operation, dberrors.Error)
-	SetDeprovisioningStarted(runtimeID string) (model.Operation, dberrors.Error)
-	SetUpgradeStarted(runtimeID string) (model.Operation, dberrors.Error)
-	GetLastOperation(runtimeID string) (model.Operation, dberrors.Error)
+	GetStatus(tenant, runtimeID string) (model.RuntimeStatus, dberrors.Error)
+	GetUnscopedStatus(runtimeID string) (model.RuntimeStatus, dberrors.Error)
+	SetProvisioningStarted(tenant, runtimeID string, runtimeConfig model.RuntimeConfig, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error)
+	SetDeprovisioningStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error)
+	SetUpgradeStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error)
+	SetReconnectRuntimeStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error)
+	SetInstallationRetryStarted(runtimeID string, kymaConfig model.KymaConfig, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error)
+	GetLastOperation(tenant, runtimeID string) (model.Operation, dberrors.Error)
 	Update(runtimeID string, kubeconfig string, terraformState string) dberrors.Error
+	UpdateUpgradedCluster(runtimeID string, kubeconfig string, terraformState string, runtimeConfig model.RuntimeConfig) dberrors.Error
+	UpdateKymaConfig(runtimeID string, kymaConfig model.KymaConfig) dberrors.Error
 	CleanupClusterData(runtimeID string) dberrors.Error
-	GetClusterData(runtimeID string) (model.Cluster, dberrors.Error)
-	Get(operationID string) (model.Operation, error)
-	SetAsFailed(operationID string, message string) error
-	SetAsSucceeded(operationID string) error
+	GetClusterData(tenant, runtimeID string) (model.Cluster, dberrors.Error)
+	GetUnscopedClusterData(runtimeID string) (model.Cluster, dberrors.Error)
+	Get(tenant, operationID string) (model.Operation, error)
+	GetUnscoped(operationID string) (model.Operation, error)
+	SetAsFailed(operationID string, leaseOwner string, message string) error
+	SetAsSucceeded(operationID string, leaseOwner string) error
+	Abort(operationID string, message string) (bool, error)
+	SetAsInProgress(operationID string, message string) error
+	StartOperationStep(operationID string, step model.OperationStepName, message string) error
+	ListInProgressOperations() ([]model.Operation, dberrors.Error)
+	ListRuntimes(tenant string, filter model.RuntimeFilter, pageSize, offset int) ([]model.RuntimeSummary, int, dberrors.Error)
+	ListOperations(tenant string, filter model.OperationFilter, pageSize, offset int) ([]model.Operation, int, dberrors.Error)
+	AcquireLease(operationID string, owner string, duration time.Duration) (bool, dberrors.Error)
+	RenewLease(operationID string, owner string, duration time.Duration) (bool, dberrors.Error)
+	ReleaseLease(operationID string, owner string) dberrors.Error
 }
 
 type persistenceService struct {
@@ -37,14 +53,26 @@ func NewService(dbSessionFactory dbsession.Factory, uuidGenerator UUIDGenerator)
 	}
 }
 
-func (ps persistenceService) GetStatus(runtimeID string) (model.RuntimeStatus, dberrors.Error) {
-	session := ps.dbSessionFactory.NewReadSession()
+func (ps persistenceService) GetStatus(tenant, runtimeID string) (model.RuntimeStatus, dberrors.Error) {
+	return getStatus(ps.dbSessionFactory.NewReadSession(tenant), runtimeID)
+}
+
+// GetUnscopedStatus reads the status of the Runtime of any tenant. It must not be used on behalf of a tenant
+func (ps persistenceService) GetUnscopedStatus(runtimeID string) (model.RuntimeStatus, dberrors.Error) {
+	return getStatus(ps.dbSessionFactory.NewUnscopedReadSession(), runtimeID)
+}
 
+func getStatus(session dbsession.ReadSession, runtimeID string) (model.RuntimeStatus, dberrors.Error) {
 	operation, err := session.GetLastOperation(runtimeID)
 	if err != nil {
 		return model.RuntimeStatus{}, err
 	}
 
+	operation.Steps, err = session.GetOperationSteps([]string{operation.ID})
+	if err != nil {
+		return model.RuntimeStatus{}, err
+	}
+
 	clusterConfig, err := session.GetClusterConfig(runtimeID)
 	if err != nil {
 		return model.RuntimeStatus{}, err
@@ -72,7 +100,9 @@ func (ps persistenceService) GetStatus(runtimeID string) (model.RuntimeStatus, d
 	}, nil
 }
 
-func (ps persistenceService) SetProvisioningStarted(runtimeID string, runtimeConfig model.RuntimeConfig) (model.Operation, dberrors.Error) {
+// SetProvisioningStarted inserts the runtime data and the operation, which is leased by the caller from the start,
+// so that no other Provisioner instance picks the operation up before the caller starts executing it
+func (ps persistenceService) SetProvisioningStarted(tenant, runtimeID string, runtimeConfig model.RuntimeConfig, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
 	dbSession, err := ps.dbSessionFactory.NewSessionWithinTransaction()
 	if err != nil {
 		return model.Operation{}, dberrors.Internal("Failed to create repository: %s", err)
@@ -87,6 +117,7 @@ func (ps persistenceService) SetProvisioningStarted(runtimeID string, runtimeCon
 		CreationTimestamp:     timestamp,
 		CredentialsSecretName: runtimeConfig.CredentialsSecretName,
 		TerraformState:        "{}",
+		Tenant:                tenant,
 	}
 
 	err = dbSession.InsertCluster(cluster)
@@ -111,12 +142,28 @@ func (ps persistenceService) SetProvisioningStarted(runtimeID string, runtimeCon
 		}
 	}
 
+	awsConfig, isAWS := runtimeConfig.AWSConfig()
+	if isAWS {
+		err = dbSession.InsertAWSConfig(awsConfig)
+		if err != nil {
+			return model.Operation{}, dberrors.Internal("Failed to set provisioning started: %s", err)
+		}
+	}
+
+	azureConfig, isAzure := runtimeConfig.AzureConfig()
+	if isAzure {
+		err = dbSession.InsertAzureConfig(azureConfig)
+		if err != nil {
+			return model.Operation{}, dberrors.Internal("Failed to set provisioning started: %s", err)
+		}
+	}
+
 	err = dbSession.InsertKymaConfig(runtimeConfig.KymaConfig)
 	if err != nil {
 		return model.Operation{}, dberrors.Internal("Failed to set provisioning started: %s", err)
 	}
 
-	operation, err := ps.setOperationStarted(dbSession, runtimeID, model.Provision, timestamp, "Provisioning started", "Failed to set provisioning started: %s")
+	operation, err := ps.setOperationStarted(dbSession, runtimeID, model.Provision, timestamp, "Provisioning started", "Failed to set provisioning started: %s", steps, lease)
 
 	if err != nil {
 		return model.Operation{}, dberrors.Internal("Failed to set provisioning started: %s", err)
@@ -130,16 +177,102 @@ func (ps persistenceService) SetProvisioningStarted(runtimeID string, runtimeCon
 	return operation, nil
 }
 
-func (ps persistenceService) SetDeprovisioningStarted(runtimeID string) (model.Operation, dberrors.Error) {
-	return ps.setOperationStarted(ps.dbSessionFactory.NewWriteSession(), runtimeID, model.Deprovision, time.Now(), "Deprovisioning started.", "Deprovisioning failed: %s")
+func (ps persistenceService) SetDeprovisioningStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	return ps.startOperation(runtimeID, model.Deprovision, "Deprovisioning started.", "Deprovisioning failed: %s", steps, lease)
+}
+
+func (ps persistenceService) SetUpgradeStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	return ps.startOperation(runtimeID, model.Upgrade, "Upgrade started.", "Upgrade failed: %s", steps, lease)
+}
+
+func (ps persistenceService) SetReconnectRuntimeStarted(runtimeID string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	return ps.startOperation(runtimeID, model.ReconnectRuntime, "Reconnecting Runtime Agent started.", "Reconnecting Runtime Agent failed: %s", steps, lease)
+}
+
+func (ps persistenceService) startOperation(runtimeID string, operationType model.OperationType, message string, errorMessageFmt string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	dbSession, err := ps.dbSessionFactory.NewSessionWithinTransaction()
+	if err != nil {
+		return model.Operation{}, dberrors.Internal("Failed to create repository: %s", err)
+	}
+
+	defer dbSession.RollbackUnlessCommitted()
+
+	err = lockClusterWithoutOperationInProgress(dbSession, runtimeID)
+	if err != nil {
+		return model.Operation{}, err
+	}
+
+	operation, err := ps.setOperationStarted(dbSession, runtimeID, operationType, time.Now(), message, errorMessageFmt, steps, lease)
+	if err != nil {
+		return model.Operation{}, err
+	}
+
+	err = dbSession.Commit()
+	if err != nil {
+		return model.Operation{}, dberrors.Internal(errorMessageFmt, err)
+	}
+
+	return operation, nil
+}
+
+// lockClusterWithoutOperationInProgress locks the cluster for the rest of the transaction and fails if the cluster already
+// has an operation in progress. Concurrent requests for the same cluster wait for the lock and see the committed operation
+func lockClusterWithoutOperationInProgress(dbSession dbsession.WriteSessionWithinTransaction, runtimeID string) dberrors.Error {
+	err := dbSession.LockCluster(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	inProgress, err := dbSession.InProgressOperationExists(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	if inProgress {
+		return dberrors.InProgress("cannot start new operation while previous one is in progress")
+	}
+
+	return nil
 }
 
-func (ps persistenceService) SetUpgradeStarted(runtimeID string) (model.Operation, dberrors.Error) {
-	return ps.setOperationStarted(ps.dbSessionFactory.NewWriteSession(), runtimeID, model.Upgrade, time.Now(), "Upgrade started.", "Upgrade failed: %s")
+func (ps persistenceService) SetInstallationRetryStarted(runtimeID string, kymaConfig model.KymaConfig, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
+	dbSession, err := ps.dbSessionFactory.NewSessionWithinTransaction()
+	if err != nil {
+		return model.Operation{}, dberrors.Internal("Failed to create repository: %s", err)
+	}
+
+	defer dbSession.RollbackUnlessCommitted()
+
+	err = lockClusterWithoutOperationInProgress(dbSession, runtimeID)
+	if err != nil {
+		return model.Operation{}, err
+	}
+
+	err = dbSession.DeleteKymaConfig(runtimeID)
+	if err != nil {
+		return model.Operation{}, dberrors.Internal("Failed to set installation retry started: %s", err)
+	}
+
+	err = dbSession.InsertKymaConfig(kymaConfig)
+	if err != nil {
+		return model.Operation{}, dberrors.Internal("Failed to set installation retry started: %s", err)
+	}
+
+	operation, err := ps.setOperationStarted(dbSession, runtimeID, model.Provision, time.Now(), "Retrying Kyma installation started.", "Failed to set installation retry started: %s", steps, lease)
+	if err != nil {
+		return model.Operation{}, err
+	}
+
+	err = dbSession.Commit()
+	if err != nil {
+		return model.Operation{}, dberrors.Internal("Failed to set installation retry started: %s", err)
+	}
+
+	return operation, nil
 }
 
-func (ps persistenceService) GetLastOperation(runtimeID string) (model.Operation, dberrors.Error) {
-	session := ps.dbSessionFactory.NewReadSession()
+func (ps persistenceService) GetLastOperation(tenant, runtimeID string) (model.Operation, dberrors.Error) {
+	session := ps.dbSessionFactory.NewReadSession(tenant)
 
 	return session.GetLastOperation(runtimeID)
 }
@@ -150,15 +283,95 @@ func (ps persistenceService) Update(runtimeID string, kubeconfig string, terrafo
 	return session.UpdateCluster(runtimeID, kubeconfig, terraformState)
 }
 
+// UpdateUpgradedCluster saves the cluster data retrieved after the upgrade together with the Kubernetes version it was upgraded to
+func (ps persistenceService) UpdateUpgradedCluster(runtimeID string, kubeconfig string, terraformState string, runtimeConfig model.RuntimeConfig) dberrors.Error {
+	dbSession, err := ps.dbSessionFactory.NewSessionWithinTransaction()
+	if err != nil {
+		return dberrors.Internal("Failed to create repository: %s", err)
+	}
+
+	defer dbSession.RollbackUnlessCommitted()
+
+	kubernetesVersion, err := kubernetesVersion(runtimeConfig)
+	if err != nil {
+		return dberrors.Internal("Failed to update upgraded cluster: %s", err)
+	}
+
+	err = dbSession.UpdateCluster(runtimeID, kubeconfig, terraformState)
+	if err != nil {
+		return dberrors.Internal("Failed to update upgraded cluster: %s", err)
+	}
+
+	err = dbSession.UpdateKubernetesVersion(runtimeID, kubernetesVersion)
+	if err != nil {
+		return dberrors.Internal("Failed to update upgraded cluster: %s", err)
+	}
+
+	err = dbSession.Commit()
+	if err != nil {
+		return dberrors.Internal("Failed to update upgraded cluster: %s", err)
+	}
+
+	return nil
+}
+
+func (ps persistenceService) UpdateKymaConfig(runtimeID string, kymaConfig model.KymaConfig) dberrors.Error {
+	dbSession, err := ps.dbSessionFactory.NewSessionWithinTransaction()
+	if err != nil {
+		return dberrors.Internal("Failed to create repository: %s", err)
+	}
+
+	defer dbSession.RollbackUnlessCommitted()
+
+	err = dbSession.DeleteKymaConfig(runtimeID)
+	if err != nil {
+		return dberrors.Internal("Failed to update Kyma configuration: %s", err)
+	}
+
+	err = dbSession.InsertKymaConfig(kymaConfig)
+	if err != nil {
+		return dberrors.Internal("Failed to update Kyma configuration: %s", err)
+	}
+
+	err = dbSession.Commit()
+	if err != nil {
+		return dberrors.Internal("Failed to update Kyma configuration: %s", err)
+	}
+
+	return nil
+}
+
+func kubernetesVersion(runtimeConfig model.RuntimeConfig) (string, dberrors.Error) {
+	if gcpConfig, isGCP := runtimeConfig.GCPConfig(); isGCP {
+		return gcpConfig.KubernetesVersion, nil
+	}
+
+	if gardenerConfig, isGardener := runtimeConfig.GardenerConfig(); isGardener {
+		return gardenerConfig.KubernetesVersion, nil
+	}
+
+	if awsConfig, isAWS := runtimeConfig.AWSConfig(); isAWS {
+		return awsConfig.KubernetesVersion, nil
+	}
+
+	if azureConfig, isAzure := runtimeConfig.AzureConfig(); isAzure {
+		return azureConfig.KubernetesVersion, nil
+	}
+
+	return "", dberrors.Internal("unknown cluster config type")
+}
+
 func (ps persistenceService) CleanupClusterData(runtimeID string) dberrors.Error {
 	session := ps.dbSessionFactory.NewWriteSession()
 
 	return session.DeleteCluster(runtimeID)
 }
 
-func (ps persistenceService) setOperationStarted(dbSession dbsession.WriteSession, runtimeID string, operationType model.OperationType, timestamp time.Time, message string, errorMessageFmt string) (model.Operation, dberrors.Error) {
+// setOperationStarted inserts the leased operation together with its planned steps, which are pending until the operation reaches them
+func (ps persistenceService) setOperationStarted(dbSession dbsession.WriteSession, runtimeID string, operationType model.OperationType, timestamp time.Time, message string, errorMessageFmt string, steps []model.OperationStepName, lease model.OperationLease) (model.Operation, dberrors.Error) {
 
 	id := ps.uuidGenerator.New()
+	leaseExpiresAt := timestamp.Add(lease.Duration)
 
 	operation := model.Operation{
 		ID:             id,
@@ -167,6 +380,8 @@ func (ps persistenceService) setOperationStarted(dbSession dbsession.WriteSessio
 		State:          model.InProgress,
 		Message:        message,
 		ClusterID:      runtimeID,
+		LeaseOwner:     &lease.Owner,
+		LeaseExpiresAt: &leaseExpiresAt,
 	}
 
 	err := dbSession.InsertOperation(operation)
@@ -174,29 +389,249 @@ func (ps persistenceService) setOperationStarted(dbSession dbsession.WriteSessio
 		return model.Operation{}, dberrors.Internal(errorMessageFmt, err)
 	}
 
+	operation.Steps = make([]model.OperationStep, 0, len(steps))
+
+	for i, name := range steps {
+		step := model.OperationStep{
+			ID:          ps.uuidGenerator.New(),
+			OperationID: id,
+			Sequence:    i + 1,
+			Name:        name,
+			State:       model.Pending,
+		}
+
+		err = dbSession.InsertOperationStep(step)
+		if err != nil {
+			return model.Operation{}, dberrors.Internal(errorMessageFmt, err)
+		}
+
+		operation.Steps = append(operation.Steps, step)
+	}
+
 	return operation, nil
 }
 
-func (ps persistenceService) GetClusterData(runtimeID string) (model.Cluster, dberrors.Error) {
-	session := ps.dbSessionFactory.NewReadSession()
+func (ps persistenceService) GetClusterData(tenant, runtimeID string) (model.Cluster, dberrors.Error) {
+	session := ps.dbSessionFactory.NewReadSession(tenant)
+
+	return session.GetCluster(runtimeID)
+}
+
+// GetUnscopedClusterData reads the cluster of any tenant. It must not be used on behalf of a tenant
+func (ps persistenceService) GetUnscopedClusterData(runtimeID string) (model.Cluster, dberrors.Error) {
+	session := ps.dbSessionFactory.NewUnscopedReadSession()
 
 	return session.GetCluster(runtimeID)
 }
 
-func (ps persistenceService) Get(operationID string) (model.Operation, error) {
-	session := ps.dbSessionFactory.NewReadSession()
+func (ps persistenceService) Get(tenant, operationID string) (model.Operation, error) {
+	return getOperation(ps.dbSessionFactory.NewReadSession(tenant), operationID)
+}
+
+// GetUnscoped reads the operation of any tenant. It must not be used on behalf of a tenant
+func (ps persistenceService) GetUnscoped(operationID string) (model.Operation, error) {
+	return getOperation(ps.dbSessionFactory.NewUnscopedReadSession(), operationID)
+}
+
+func getOperation(session dbsession.ReadSession, operationID string) (model.Operation, error) {
+	operation, err := session.GetOperation(operationID)
+	if err != nil {
+		return model.Operation{}, err
+	}
+
+	operation.Steps, err = session.GetOperationSteps([]string{operationID})
+	if err != nil {
+		return model.Operation{}, err
+	}
+
+	return operation, nil
+}
+
+// SetAsFailed sets the operation and its step in progress as failed, if the operation is still leased by the owner
+func (ps persistenceService) SetAsFailed(operationID string, leaseOwner string, message string) error {
+	return ps.finishOperation(operationID, leaseOwner, message, model.Failed)
+}
+
+// SetAsSucceeded sets the operation and its step in progress as succeeded, if the operation is still leased by the owner
+func (ps persistenceService) SetAsSucceeded(operationID string, leaseOwner string) error {
+	return ps.finishOperation(operationID, leaseOwner, "Operation succeeded.", model.Succeeded)
+}
+
+func (ps persistenceService) finishOperation(operationID string, leaseOwner string, message string, state model.OperationState) error {
+	dbSession, err := ps.dbSessionFactory.NewSessionWithinTransaction()
+	if err != nil {
+		return dberrors.Internal("Failed to create repository: %s", err)
+	}
+
+	defer dbSession.RollbackUnlessCommitted()
+
+	finished, err := dbSession.FinishLeasedOperation(operationID, leaseOwner, message, state)
+	if err != nil {
+		return err
+	}
+	if !finished {
+		return dberrors.LeaseLost("Failed to finish operation %s: lease is no longer held by %s", operationID, leaseOwner)
+	}
+
+	stepMessage := ""
+	if state == model.Failed {
+		stepMessage = message
+	}
+
+	err = dbSession.FinishOperationStep(operationID, state, stepMessage, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return dbSession.Commit()
+}
+
+// Abort sets the operation in progress and its current step as failed regardless of the lease, which is released,
+// so that the Provisioner instance executing the operation stops. It returns false if the operation is no longer in progress.
+func (ps persistenceService) Abort(operationID string, message string) (bool, error) {
+	dbSession, err := ps.dbSessionFactory.NewSessionWithinTransaction()
+	if err != nil {
+		return false, dberrors.Internal("Failed to create repository: %s", err)
+	}
+
+	defer dbSession.RollbackUnlessCommitted()
+
+	aborted, err := dbSession.AbortOperation(operationID, message)
+	if err != nil || !aborted {
+		return false, err
+	}
+
+	err = dbSession.FinishOperationStep(operationID, model.Failed, message, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	err = dbSession.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// StartOperationStep sets the step in progress as succeeded and starts the next one. The message of the operation is set to the message of the step.
+func (ps persistenceService) StartOperationStep(operationID string, step model.OperationStepName, message string) error {
+	dbSession, err := ps.dbSessionFactory.NewSessionWithinTransaction()
+	if err != nil {
+		return dberrors.Internal("Failed to create repository: %s", err)
+	}
+
+	defer dbSession.RollbackUnlessCommitted()
+
+	timestamp := time.Now()
+
+	err = dbSession.FinishOperationStep(operationID, model.Succeeded, "", timestamp)
+	if err != nil {
+		return err
+	}
+
+	err = dbSession.StartOperationStep(operationID, step, message, timestamp)
+	if err != nil {
+		return err
+	}
+
+	err = dbSession.UpdateOperationState(operationID, message, model.InProgress)
+	if err != nil {
+		return err
+	}
+
+	return dbSession.Commit()
+}
+
+func (ps persistenceService) SetAsInProgress(operationID string, message string) error {
+	session := ps.dbSessionFactory.NewWriteSession()
+
+	return session.UpdateOperationState(operationID, message, model.InProgress)
+}
+
+func (ps persistenceService) ListInProgressOperations() ([]model.Operation, dberrors.Error) {
+	session := ps.dbSessionFactory.NewUnscopedReadSession()
+
+	return session.ListInProgressOperations()
+}
+
+func (ps persistenceService) ListRuntimes(tenant string, filter model.RuntimeFilter, pageSize, offset int) ([]model.RuntimeSummary, int, dberrors.Error) {
+	session := ps.dbSessionFactory.NewReadSession(tenant)
+
+	runtimes, totalCount, err := session.ListRuntimes(filter, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	operationIDs := make([]string, 0, len(runtimes))
+	for _, runtime := range runtimes {
+		operationIDs = append(operationIDs, runtime.LastOperation.ID)
+	}
+
+	steps, err := session.GetOperationSteps(operationIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range runtimes {
+		runtimes[i].LastOperation.Steps = operationSteps(steps, runtimes[i].LastOperation.ID)
+	}
+
+	return runtimes, totalCount, nil
+}
+
+func (ps persistenceService) ListOperations(tenant string, filter model.OperationFilter, pageSize, offset int) ([]model.Operation, int, dberrors.Error) {
+	session := ps.dbSessionFactory.NewReadSession(tenant)
+
+	operations, totalCount, err := session.ListOperations(filter, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	operationIDs := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		operationIDs = append(operationIDs, operation.ID)
+	}
+
+	steps, err := session.GetOperationSteps(operationIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range operations {
+		operations[i].Steps = operationSteps(steps, operations[i].ID)
+	}
+
+	return operations, totalCount, nil
+}
+
+func operationSteps(steps []model.OperationStep, operationID string) []model.OperationStep {
+	operationSteps := make([]model.OperationStep, 0)
+
+	for _, step := range steps {
+		if step.OperationID == operationID {
+			operationSteps = append(operationSteps, step)
+		}
+	}
+
+	return operationSteps
+}
+
+func (ps persistenceService) AcquireLease(operationID string, owner string, duration time.Duration) (bool, dberrors.Error) {
+	session := ps.dbSessionFactory.NewWriteSession()
+	now := time.Now()
 
-	return session.GetOperation(operationID)
+	return session.AcquireOperationLease(operationID, owner, now, now.Add(duration))
 }
 
-func (ps persistenceService) SetAsFailed(operationID string, message string) error {
+func (ps persistenceService) RenewLease(operationID string, owner string, duration time.Duration) (bool, dberrors.Error) {
 	session := ps.dbSessionFactory.NewWriteSession()
 
-	return session.UpdateOperationState(operationID, message, model.Failed)
+	return session.RenewOperationLease(operationID, owner, time.Now().Add(duration))
 }
 
-func (ps persistenceService) SetAsSucceeded(operationID string) error {
+func (ps persistenceService) ReleaseLease(operationID string, owner string) dberrors.Error {
 	session := ps.dbSessionFactory.NewWriteSession()
 
-	return session.UpdateOperationState(operationID, "Operation succeeded.", model.Succeeded)
+	return session.ReleaseOperationLease(operationID, owner)
 }
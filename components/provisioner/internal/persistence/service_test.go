@@ -1,6 +1,7 @@
 package persistence
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,11 +11,15 @@ import (
 	persistenceMocks "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+var lease = model.OperationLease{Owner: "provisioner-0", Duration: time.Minute}
+
 func TestSetProvisioning(t *testing.T) {
 
 	runtimeID := "runtimeId"
+	tenant := "tenant"
 
 	gcpConfig := model.GCPConfig{
 		Name:              "name",
@@ -79,6 +84,8 @@ func TestSetProvisioning(t *testing.T) {
 
 	operationMatcher := getOperationMather(operation)
 
+	steps := []model.OperationStepName{model.StepPrepareClusterConfig, model.StepProvisionCluster}
+
 	runtimeConfigurations := []struct {
 		config model.RuntimeConfig
 
@@ -93,6 +100,7 @@ func TestSetProvisioning(t *testing.T) {
 		ID:                runtimeID,
 		TerraformState:    "{}",
 		CreationTimestamp: timestamp,
+		Tenant:            tenant,
 	}
 
 	clusterMatcher := getClusterMatcher(cluster)
@@ -110,6 +118,8 @@ func TestSetProvisioning(t *testing.T) {
 			writeSessionWithinTransactionMock.On(cfg.insertClusterConfigMethodName, cfg.config.ClusterConfig).Return(nil)
 			writeSessionWithinTransactionMock.On("InsertKymaConfig", kymaConfig).Return(nil)
 			writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+			writeSessionWithinTransactionMock.On("InsertOperationStep", mock.MatchedBy(pendingStepMatcher(operationID, 1, model.StepPrepareClusterConfig))).Return(nil)
+			writeSessionWithinTransactionMock.On("InsertOperationStep", mock.MatchedBy(pendingStepMatcher(operationID, 2, model.StepProvisionCluster))).Return(nil)
 			writeSessionWithinTransactionMock.On("Commit").Return(nil)
 			writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
 
@@ -118,7 +128,7 @@ func TestSetProvisioning(t *testing.T) {
 			runtimeService := NewService(sessionFactoryMock, uuidGenerator)
 
 			// when
-			provisioningOperation, err := runtimeService.SetProvisioningStarted(runtimeID, cfg.config)
+			provisioningOperation, err := runtimeService.SetProvisioningStarted(tenant, runtimeID, cfg.config, steps, lease)
 
 			// then
 			assert.NoError(t, err)
@@ -127,6 +137,9 @@ func TestSetProvisioning(t *testing.T) {
 			assert.Equal(t, provisioningOperation.State, model.InProgress)
 			assert.Equal(t, provisioningOperation.ClusterID, runtimeID)
 			assert.NotEmpty(t, provisioningOperation.Message)
+			require.Len(t, provisioningOperation.Steps, 2)
+			assert.Equal(t, model.Pending, provisioningOperation.Steps[0].State)
+			assert.Equal(t, model.StepProvisionCluster, provisioningOperation.Steps[1].Name)
 
 			sessionFactoryMock.AssertExpectations(t)
 			writeSessionWithinTransactionMock.AssertExpectations(t)
@@ -148,7 +161,7 @@ func TestSetProvisioning(t *testing.T) {
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
 
 		// when
-		_, err := runtimeService.SetProvisioningStarted(runtimeID, runtimeGCPConfig)
+		_, err := runtimeService.SetProvisioningStarted(tenant, runtimeID, runtimeGCPConfig, nil, lease)
 
 		// then
 		assert.Error(t, err)
@@ -174,7 +187,7 @@ func TestSetProvisioning(t *testing.T) {
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
 
 		// when
-		_, err := runtimeService.SetProvisioningStarted(runtimeID, runtimeGCPConfig)
+		_, err := runtimeService.SetProvisioningStarted(tenant, runtimeID, runtimeGCPConfig, nil, lease)
 
 		// then
 		assert.Error(t, err)
@@ -210,7 +223,7 @@ func TestSetProvisioning(t *testing.T) {
 			runtimeService := NewService(sessionFactoryMock, uuidGenerator)
 
 			// when
-			_, err := runtimeService.SetProvisioningStarted(runtimeID, cfg.config)
+			_, err := runtimeService.SetProvisioningStarted(tenant, runtimeID, cfg.config, nil, lease)
 
 			// then
 			assert.Error(t, err)
@@ -242,7 +255,7 @@ func TestSetProvisioning(t *testing.T) {
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
 
 		// when
-		_, err := runtimeService.SetProvisioningStarted(runtimeID, runtimeGCPConfig)
+		_, err := runtimeService.SetProvisioningStarted(tenant, runtimeID, runtimeGCPConfig, nil, lease)
 
 		// then
 		assert.Error(t, err)
@@ -274,7 +287,7 @@ func TestSetProvisioning(t *testing.T) {
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
 
 		// when
-		_, err := runtimeService.SetProvisioningStarted(runtimeID, runtimeGCPConfig)
+		_, err := runtimeService.SetProvisioningStarted(tenant, runtimeID, runtimeGCPConfig, nil, lease)
 
 		// then
 		assert.Error(t, err)
@@ -308,14 +321,19 @@ func TestSetDeprovisioning(t *testing.T) {
 
 		uuidGenerator.On("New").Return(operationID, nil)
 
+		writeSessionWithinTransactionMock.On("LockCluster", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InProgressOperationExists", runtimeID).Return(false, nil)
 		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertOperationStep", mock.MatchedBy(pendingStepMatcher(operationID, 1, model.StepDeprovisionCluster))).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
 
-		sessionFactoryMock.On("NewWriteSession").Return(writeSessionWithinTransactionMock, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
 
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
 
 		// when
-		provisioningOperation, err := runtimeService.SetDeprovisioningStarted(runtimeID)
+		provisioningOperation, err := runtimeService.SetDeprovisioningStarted(runtimeID, []model.OperationStepName{model.StepDeprovisionCluster}, lease)
 
 		// then
 		assert.NoError(t, err)
@@ -329,6 +347,56 @@ func TestSetDeprovisioning(t *testing.T) {
 		writeSessionWithinTransactionMock.AssertExpectations(t)
 		uuidGenerator.AssertExpectations(t)
 	})
+
+	t.Run("Should not set deprovisioning started when other operation is in progress", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		writeSessionWithinTransactionMock.On("LockCluster", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InProgressOperationExists", runtimeID).Return(true, nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
+
+		// when
+		_, err := runtimeService.SetDeprovisioningStarted(runtimeID, []model.OperationStepName{model.StepDeprovisionCluster}, lease)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, dberrors.CodeInProgress, err.Code())
+
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		uuidGenerator.AssertExpectations(t)
+	})
+
+	t.Run("Should not set deprovisioning started when failed to lock cluster", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		writeSessionWithinTransactionMock.On("LockCluster", runtimeID).Return(dberrors.NotFound("not found"))
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
+
+		// when
+		_, err := runtimeService.SetDeprovisioningStarted(runtimeID, []model.OperationStepName{model.StepDeprovisionCluster}, lease)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, dberrors.CodeNotFound, err.Code())
+
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+	})
 }
 
 func TestSetUpgrade(t *testing.T) {
@@ -355,14 +423,18 @@ func TestSetUpgrade(t *testing.T) {
 
 		uuidGenerator.On("New").Return(operationID, nil)
 
+		writeSessionWithinTransactionMock.On("LockCluster", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InProgressOperationExists", runtimeID).Return(false, nil)
 		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
 
-		sessionFactoryMock.On("NewWriteSession").Return(writeSessionWithinTransactionMock, nil)
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
 
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
 
 		// when
-		provisioningOperation, err := runtimeService.SetUpgradeStarted(runtimeID)
+		provisioningOperation, err := runtimeService.SetUpgradeStarted(runtimeID, nil, lease)
 
 		// then
 		assert.NoError(t, err)
@@ -377,10 +449,256 @@ func TestSetUpgrade(t *testing.T) {
 	})
 }
 
+func TestUpdateUpgradedCluster(t *testing.T) {
+
+	runtimeID := "runtimeID"
+	kubeconfig := "kubeconfig"
+	terraformState := "state"
+
+	runtimeConfig := model.RuntimeConfig{
+		ClusterConfig: model.GCPConfig{KubernetesVersion: "1.15"},
+	}
+
+	t.Run("Should update cluster data and Kubernetes version", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("UpdateCluster", runtimeID, kubeconfig, terraformState).Return(nil)
+		writeSessionWithinTransactionMock.On("UpdateKubernetesVersion", runtimeID, "1.15").Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.UpdateUpgradedCluster(runtimeID, kubeconfig, terraformState, runtimeConfig)
+
+		// then
+		assert.NoError(t, err)
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+	})
+
+	for _, clusterConfig := range []interface{}{
+		model.GardenerConfig{KubernetesVersion: "1.15"},
+		model.AWSConfig{KubernetesVersion: "1.15"},
+		model.AzureConfig{KubernetesVersion: "1.15"},
+	} {
+		t.Run(fmt.Sprintf("Should update Kubernetes version of %T", clusterConfig), func(t *testing.T) {
+			// given
+			sessionFactoryMock := &sessionMocks.Factory{}
+			writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+			writeSessionWithinTransactionMock.On("UpdateCluster", runtimeID, kubeconfig, terraformState).Return(nil)
+			writeSessionWithinTransactionMock.On("UpdateKubernetesVersion", runtimeID, "1.15").Return(nil)
+			writeSessionWithinTransactionMock.On("Commit").Return(nil)
+			writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+			sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+			runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+			// when
+			err := runtimeService.UpdateUpgradedCluster(runtimeID, kubeconfig, terraformState, model.RuntimeConfig{ClusterConfig: clusterConfig})
+
+			// then
+			assert.NoError(t, err)
+			writeSessionWithinTransactionMock.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Should return error when cluster config type is unknown", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.UpdateUpgradedCluster(runtimeID, kubeconfig, terraformState, model.RuntimeConfig{})
+
+		// then
+		assert.Error(t, err)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "UpdateCluster", mock.Anything, mock.Anything, mock.Anything)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "Commit")
+	})
+
+	t.Run("Should rollback transaction when failed to update Kubernetes version", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("UpdateCluster", runtimeID, kubeconfig, terraformState).Return(nil)
+		writeSessionWithinTransactionMock.On("UpdateKubernetesVersion", runtimeID, "1.15").Return(dberrors.Internal("some error"))
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.UpdateUpgradedCluster(runtimeID, kubeconfig, terraformState, runtimeConfig)
+
+		// then
+		assert.Error(t, err)
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "Commit")
+	})
+}
+
+func TestUpdateKymaConfig(t *testing.T) {
+
+	runtimeID := "runtimeID"
+
+	kymaConfig := model.KymaConfig{
+		ID:        "kymaConfigID",
+		Version:   "1.7",
+		ClusterID: runtimeID,
+		Modules: []model.KymaConfigModule{
+			{ID: "id1", Module: model.KymaModule("Backup"), KymaConfigID: "kymaConfigID"},
+		},
+	}
+
+	t.Run("Should replace Kyma config", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("DeleteKymaConfig", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertKymaConfig", kymaConfig).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.UpdateKymaConfig(runtimeID, kymaConfig)
+
+		// then
+		assert.NoError(t, err)
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+	})
+
+	t.Run("Should rollback transaction when failed to insert Kyma config", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("DeleteKymaConfig", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertKymaConfig", kymaConfig).Return(dberrors.Internal("some error"))
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.UpdateKymaConfig(runtimeID, kymaConfig)
+
+		// then
+		assert.Error(t, err)
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "Commit")
+	})
+}
+
+func TestSetInstallationRetryStarted(t *testing.T) {
+
+	runtimeID := "runtimeID"
+	operationID := "operationID"
+
+	kymaConfig := model.KymaConfig{
+		ID:        "kymaConfigID",
+		Version:   "1.7",
+		ClusterID: runtimeID,
+		Modules: []model.KymaConfigModule{
+			{ID: "id1", Module: model.KymaModule("Backup"), KymaConfigID: "kymaConfigID"},
+		},
+	}
+
+	operationMatcher := func(operation model.Operation) bool {
+		return operation.ID == operationID && operation.Type == model.Provision &&
+			operation.State == model.InProgress && operation.ClusterID == runtimeID
+	}
+
+	t.Run("Should replace Kyma config and start provisioning operation", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		uuidGenerator.On("New").Return(operationID)
+		writeSessionWithinTransactionMock.On("LockCluster", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InProgressOperationExists", runtimeID).Return(false, nil)
+		writeSessionWithinTransactionMock.On("DeleteKymaConfig", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertKymaConfig", kymaConfig).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
+
+		// when
+		operation, err := runtimeService.SetInstallationRetryStarted(runtimeID, kymaConfig, nil, lease)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, operationID, operation.ID)
+		assert.Equal(t, model.Provision, operation.Type)
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		uuidGenerator.AssertExpectations(t)
+	})
+
+	t.Run("Should rollback transaction when failed to insert operation", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		uuidGenerator.On("New").Return(operationID)
+		writeSessionWithinTransactionMock.On("LockCluster", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InProgressOperationExists", runtimeID).Return(false, nil)
+		writeSessionWithinTransactionMock.On("DeleteKymaConfig", runtimeID).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertKymaConfig", kymaConfig).Return(nil)
+		writeSessionWithinTransactionMock.On("InsertOperation", mock.MatchedBy(operationMatcher)).Return(dberrors.Internal("some error"))
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
+
+		// when
+		_, err := runtimeService.SetInstallationRetryStarted(runtimeID, kymaConfig, nil, lease)
+
+		// then
+		assert.Error(t, err)
+		sessionFactoryMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+	})
+}
+
 func TestGetRuntimeStatus(t *testing.T) {
 
 	runtimeID := "runtimeID"
+	tenant := "tenant"
 	operation := model.Operation{
+		ID:             "operationID",
 		Type:           model.Provision,
 		StartTimestamp: time.Now(),
 		State:          model.InProgress,
@@ -388,6 +706,11 @@ func TestGetRuntimeStatus(t *testing.T) {
 		ClusterID:      runtimeID,
 	}
 
+	steps := []model.OperationStep{
+		{OperationID: "operationID", Sequence: 1, Name: model.StepPrepareClusterConfig, State: model.InProgress},
+		{OperationID: "operationID", Sequence: 2, Name: model.StepProvisionCluster, State: model.Pending},
+	}
+
 	gcpConfig := model.GCPConfig{
 		Name:        "name",
 		ProjectName: "projectName",
@@ -414,14 +737,18 @@ func TestGetRuntimeStatus(t *testing.T) {
 		uuidGenerator := &persistenceMocks.UUIDGenerator{}
 
 		readSessionMock.On("GetLastOperation", runtimeID).Return(operation, nil)
+		readSessionMock.On("GetOperationSteps", []string{"operationID"}).Return(steps, nil)
 		readSessionMock.On("GetClusterConfig", runtimeID).Return(gcpConfig, nil)
 		readSessionMock.On("GetKymaConfig", runtimeID).Return(kymaConfig, nil)
 		readSessionMock.On("GetCluster", runtimeID).Return(cluster, nil)
 
-		sessionFactoryMock.On("NewReadSession").Return(readSessionMock, nil)
+		sessionFactoryMock.On("NewReadSession", tenant).Return(readSessionMock, nil)
+
+		operationWithSteps := operation
+		operationWithSteps.Steps = steps
 
 		expected := model.RuntimeStatus{
-			LastOperationStatus: operation,
+			LastOperationStatus: operationWithSteps,
 			RuntimeConfiguration: model.RuntimeConfig{
 				ClusterConfig: gcpConfig,
 				KymaConfig:    kymaConfig,
@@ -430,13 +757,37 @@ func TestGetRuntimeStatus(t *testing.T) {
 
 		// when
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
-		runtimeStatus, err := runtimeService.GetStatus(runtimeID)
+		runtimeStatus, err := runtimeService.GetStatus(tenant, runtimeID)
 
 		// then
 		assert.NoError(t, err)
 		assert.Equal(t, expected, runtimeStatus)
 	})
 
+	t.Run("Should get runtime status of any tenant with unscoped session", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		readSessionMock := &sessionMocks.ReadSession{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		readSessionMock.On("GetLastOperation", runtimeID).Return(operation, nil)
+		readSessionMock.On("GetOperationSteps", []string{"operationID"}).Return(steps, nil)
+		readSessionMock.On("GetClusterConfig", runtimeID).Return(gcpConfig, nil)
+		readSessionMock.On("GetKymaConfig", runtimeID).Return(kymaConfig, nil)
+		readSessionMock.On("GetCluster", runtimeID).Return(cluster, nil)
+
+		sessionFactoryMock.On("NewUnscopedReadSession").Return(readSessionMock, nil)
+
+		// when
+		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
+		runtimeStatus, err := runtimeService.GetUnscopedStatus(runtimeID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, operation.ID, runtimeStatus.LastOperationStatus.ID)
+		sessionFactoryMock.AssertNotCalled(t, "NewReadSession", mock.Anything)
+	})
+
 	t.Run("Should fail to get runtime status when getting last operation failed", func(t *testing.T) {
 		// given
 		sessionFactoryMock := &sessionMocks.Factory{}
@@ -444,11 +795,11 @@ func TestGetRuntimeStatus(t *testing.T) {
 		uuidGenerator := &persistenceMocks.UUIDGenerator{}
 
 		readSessionMock.On("GetLastOperation", runtimeID).Return(model.Operation{}, dberrors.Internal("some error"))
-		sessionFactoryMock.On("NewReadSession").Return(readSessionMock, nil)
+		sessionFactoryMock.On("NewReadSession", tenant).Return(readSessionMock, nil)
 
 		// when
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
-		_, err := runtimeService.GetStatus(runtimeID)
+		_, err := runtimeService.GetStatus(tenant, runtimeID)
 
 		// then
 		assert.Error(t, err)
@@ -461,12 +812,13 @@ func TestGetRuntimeStatus(t *testing.T) {
 		uuidGenerator := &persistenceMocks.UUIDGenerator{}
 
 		readSessionMock.On("GetLastOperation", runtimeID).Return(operation, nil)
+		readSessionMock.On("GetOperationSteps", []string{"operationID"}).Return(steps, nil)
 		readSessionMock.On("GetClusterConfig", runtimeID).Return(model.GCPConfig{}, dberrors.Internal("some error"))
-		sessionFactoryMock.On("NewReadSession").Return(readSessionMock, nil)
+		sessionFactoryMock.On("NewReadSession", tenant).Return(readSessionMock, nil)
 
 		// when
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
-		_, err := runtimeService.GetStatus(runtimeID)
+		_, err := runtimeService.GetStatus(tenant, runtimeID)
 
 		// then
 		assert.Error(t, err)
@@ -479,14 +831,15 @@ func TestGetRuntimeStatus(t *testing.T) {
 		uuidGenerator := &persistenceMocks.UUIDGenerator{}
 
 		readSessionMock.On("GetLastOperation", runtimeID).Return(operation, nil)
+		readSessionMock.On("GetOperationSteps", []string{"operationID"}).Return(steps, nil)
 		readSessionMock.On("GetClusterConfig", runtimeID).Return(gcpConfig, nil)
 		readSessionMock.On("GetKymaConfig", runtimeID).Return(model.KymaConfig{}, dberrors.Internal("some error"))
 
-		sessionFactoryMock.On("NewReadSession").Return(readSessionMock, nil)
+		sessionFactoryMock.On("NewReadSession", tenant).Return(readSessionMock, nil)
 
 		// when
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
-		_, err := runtimeService.GetStatus(runtimeID)
+		_, err := runtimeService.GetStatus(tenant, runtimeID)
 
 		// then
 		assert.Error(t, err)
@@ -499,15 +852,16 @@ func TestGetRuntimeStatus(t *testing.T) {
 		uuidGenerator := &persistenceMocks.UUIDGenerator{}
 
 		readSessionMock.On("GetLastOperation", runtimeID).Return(operation, nil)
+		readSessionMock.On("GetOperationSteps", []string{"operationID"}).Return(steps, nil)
 		readSessionMock.On("GetClusterConfig", runtimeID).Return(gcpConfig, nil)
 		readSessionMock.On("GetKymaConfig", runtimeID).Return(kymaConfig, nil)
 		readSessionMock.On("GetCluster", runtimeID).Return(model.Cluster{}, dberrors.Internal("some error"))
 
-		sessionFactoryMock.On("NewReadSession").Return(readSessionMock, nil)
+		sessionFactoryMock.On("NewReadSession", tenant).Return(readSessionMock, nil)
 
 		// when
 		runtimeService := NewService(sessionFactoryMock, uuidGenerator)
-		_, err := runtimeService.GetStatus(runtimeID)
+		_, err := runtimeService.GetStatus(tenant, runtimeID)
 
 		// then
 		assert.Error(t, err)
@@ -518,13 +872,171 @@ func getOperationMather(expected model.Operation) func(model.Operation) bool {
 	return func(op model.Operation) bool {
 		return op.Type == expected.Type &&
 			op.Message == expected.Message && op.ClusterID == expected.ClusterID &&
-			op.State == expected.State && op.ID == expected.ID
+			op.State == expected.State && op.ID == expected.ID &&
+			op.LeaseOwner != nil && *op.LeaseOwner == lease.Owner && op.LeaseExpiresAt != nil
 	}
 }
 
 func getClusterMatcher(expected model.Cluster) func(model.Cluster) bool {
 	return func(cluster model.Cluster) bool {
 		return cluster.ID == expected.ID &&
-			cluster.TerraformState == expected.TerraformState && cluster.Kubeconfig == expected.Kubeconfig
+			cluster.TerraformState == expected.TerraformState && cluster.Kubeconfig == expected.Kubeconfig &&
+			cluster.Tenant == expected.Tenant
+	}
+}
+
+func TestStartOperationStep(t *testing.T) {
+	operationID := "operationID"
+
+	t.Run("Should finish current step and start the next one", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("FinishOperationStep", operationID, model.Succeeded, "", mock.AnythingOfType("time.Time")).Return(nil)
+		writeSessionWithinTransactionMock.On("StartOperationStep", operationID, model.StepProvisionCluster, "Provisioning cluster", mock.AnythingOfType("time.Time")).Return(nil)
+		writeSessionWithinTransactionMock.On("UpdateOperationState", operationID, "Provisioning cluster", model.InProgress).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.StartOperationStep(operationID, model.StepProvisionCluster, "Provisioning cluster")
+
+		// then
+		assert.NoError(t, err)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+	})
+
+	t.Run("Should rollback transaction when step is not planned", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("FinishOperationStep", operationID, model.Succeeded, "", mock.AnythingOfType("time.Time")).Return(nil)
+		writeSessionWithinTransactionMock.On("StartOperationStep", operationID, model.StepProvisionCluster, "Provisioning cluster", mock.AnythingOfType("time.Time")).Return(dberrors.NotFound("step not found"))
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.StartOperationStep(operationID, model.StepProvisionCluster, "Provisioning cluster")
+
+		// then
+		assert.Error(t, err)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "Commit")
+	})
+}
+
+func TestSetAsFailed(t *testing.T) {
+	operationID := "operationID"
+
+	t.Run("Should set operation as failed", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("FinishLeasedOperation", operationID, lease.Owner, "error", model.Failed).Return(true, nil)
+		writeSessionWithinTransactionMock.On("FinishOperationStep", operationID, model.Failed, "error", mock.AnythingOfType("time.Time")).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.SetAsFailed(operationID, lease.Owner, "error")
+
+		// then
+		assert.NoError(t, err)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+	})
+
+	t.Run("Should not set operation as failed when lease is no longer held", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("FinishLeasedOperation", operationID, lease.Owner, "error", model.Failed).Return(false, nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		err := runtimeService.SetAsFailed(operationID, lease.Owner, "error")
+
+		// then
+		require.Error(t, err)
+		dbErr, ok := err.(dberrors.Error)
+		require.True(t, ok)
+		assert.Equal(t, dberrors.CodeLeaseLost, dbErr.Code())
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "FinishOperationStep", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "Commit")
+	})
+}
+
+func TestAbort(t *testing.T) {
+	operationID := "operationID"
+
+	t.Run("Should abort operation in progress", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("AbortOperation", operationID, "timed out").Return(true, nil)
+		writeSessionWithinTransactionMock.On("FinishOperationStep", operationID, model.Failed, "timed out", mock.AnythingOfType("time.Time")).Return(nil)
+		writeSessionWithinTransactionMock.On("Commit").Return(nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		aborted, err := runtimeService.Abort(operationID, "timed out")
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, aborted)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+	})
+
+	t.Run("Should not abort operation which already finished", func(t *testing.T) {
+		// given
+		sessionFactoryMock := &sessionMocks.Factory{}
+		writeSessionWithinTransactionMock := &sessionMocks.WriteSessionWithinTransaction{}
+
+		writeSessionWithinTransactionMock.On("AbortOperation", operationID, "timed out").Return(false, nil)
+		writeSessionWithinTransactionMock.On("RollbackUnlessCommitted").Return()
+
+		sessionFactoryMock.On("NewSessionWithinTransaction").Return(writeSessionWithinTransactionMock, nil)
+
+		runtimeService := NewService(sessionFactoryMock, &persistenceMocks.UUIDGenerator{})
+
+		// when
+		aborted, err := runtimeService.Abort(operationID, "timed out")
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, aborted)
+		writeSessionWithinTransactionMock.AssertExpectations(t)
+		writeSessionWithinTransactionMock.AssertNotCalled(t, "Commit")
+	})
+}
+
+func pendingStepMatcher(operationID string, sequence int, name model.OperationStepName) func(model.OperationStep) bool {
+	return func(step model.OperationStep) bool {
+		return step.OperationID == operationID && step.Sequence == sequence &&
+			step.Name == name && step.State == model.Pending
 	}
 }
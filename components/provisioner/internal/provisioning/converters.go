@@ -1,6 +1,8 @@
 package provisioning
 
 import (
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hyperscaler"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence"
 	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
@@ -11,29 +13,182 @@ func runtimeConfigFromInput(runtimeID string, input gqlschema.ProvisionRuntimeIn
 
 	clusterConfig := clusterConfigFromInput(runtimeID, *input.ClusterConfig, uuidGenerator)
 
+	secretName := ""
+	if input.Credentials.SecretName != nil {
+		secretName = *input.Credentials.SecretName
+	}
+
 	return model.RuntimeConfig{
 		KymaConfig:            kymaConfig,
 		ClusterConfig:         clusterConfig,
-		CredentialsSecretName: input.Credentials.SecretName,
+		CredentialsSecretName: secretName,
+	}
+}
+
+const (
+	providerLabel = "provider"
+	regionLabel   = "region"
+)
+
+func directorRuntimeInputFromInput(input gqlschema.ProvisionRuntimeInput) director.RuntimeInput {
+	name, provider, region := clusterConfigInputDetails(*input.ClusterConfig)
+
+	runtimeInput := director.RuntimeInput{Name: name, Labels: map[string]interface{}{}}
+
+	if input.RuntimeInput != nil {
+		if input.RuntimeInput.Name != nil && *input.RuntimeInput.Name != "" {
+			runtimeInput.Name = *input.RuntimeInput.Name
+		}
+
+		runtimeInput.Description = input.RuntimeInput.Description
+
+		if input.RuntimeInput.Labels != nil {
+			for key, value := range *input.RuntimeInput.Labels {
+				runtimeInput.Labels[key] = value
+			}
+		}
+	}
+
+	runtimeInput.Labels[providerLabel] = provider
+	runtimeInput.Labels[regionLabel] = region
+
+	return runtimeInput
+}
+
+func clusterConfigInputDetails(input gqlschema.ClusterConfigInput) (name, provider, region string) {
+	switch {
+	case input.GardenerConfig != nil:
+		return input.GardenerConfig.Name, "gardener", input.GardenerConfig.Region
+	case input.GcpConfig != nil:
+		return input.GcpConfig.Name, "gcp", input.GcpConfig.Region
+	case input.AwsConfig != nil:
+		return input.AwsConfig.Name, "aws", input.AwsConfig.Region
+	case input.AzureConfig != nil:
+		return input.AzureConfig.Name, "azure", input.AzureConfig.Region
+	default:
+		return "", "", ""
+	}
+}
+
+func upgradedRuntimeConfig(runtimeID string, current model.RuntimeConfig, input gqlschema.UpgradeRuntimeInput, uuidGenerator persistence.UUIDGenerator) model.RuntimeConfig {
+	upgraded := current
+
+	if input.ClusterConfig != nil {
+		upgraded.ClusterConfig = clusterConfigWithKubernetesVersion(current.ClusterConfig, input.ClusterConfig.Version)
+	}
+
+	if input.KymaConfig != nil {
+		upgraded.KymaConfig = kymaConfigFromInput(runtimeID, *input.KymaConfig, uuidGenerator)
+	}
+
+	return upgraded
+}
+
+func clusterConfigWithKubernetesVersion(config interface{}, version string) interface{} {
+	switch clusterConfig := config.(type) {
+	case model.GardenerConfig:
+		clusterConfig.KubernetesVersion = version
+		return clusterConfig
+	case model.GCPConfig:
+		clusterConfig.KubernetesVersion = version
+		return clusterConfig
+	case model.AWSConfig:
+		clusterConfig.KubernetesVersion = version
+		return clusterConfig
+	case model.AzureConfig:
+		clusterConfig.KubernetesVersion = version
+		return clusterConfig
+	default:
+		return config
 	}
 }
 
 func runtimeStatusToGraphQLStatus(status model.RuntimeStatus) *gqlschema.RuntimeStatus {
 	return &gqlschema.RuntimeStatus{
 		LastOperationStatus:     operationStatusToGQLOperationStatus(status.LastOperationStatus),
-		RuntimeConnectionStatus: runtimeConnectionStatusToGraphQLStatus(status.RuntimeConnectionStatus),
+		RuntimeConnectionStatus: runtimeConnectionStatusToGraphQLStatus(status.RuntimeConnectionStatus, nil),
 		RuntimeConfiguration:    runtimeConfigurationToGraphQLConfiguration(status.RuntimeConfiguration),
 	}
 }
 
 func operationStatusToGQLOperationStatus(operation model.Operation) *gqlschema.OperationStatus {
 	return &gqlschema.OperationStatus{
-		ID:        &operation.ID,
-		Operation: operationTypeToGraphQLType(operation.Type),
-		State:     operationStateToGraphQLState(operation.State),
-		Message:   &operation.Message,
-		RuntimeID: &operation.ClusterID,
+		ID:             &operation.ID,
+		Operation:      operationTypeToGraphQLType(operation.Type),
+		State:          operationStateToGraphQLState(operation.State),
+		Message:        &operation.Message,
+		RuntimeID:      &operation.ClusterID,
+		StartTimestamp: &operation.StartTimestamp,
+		EndTimestamp:   operation.EndTimestamp,
+		Steps:          operationStepsToGQLOperationSteps(operation.Steps),
+		Progress:       operation.Progress(),
+	}
+}
+
+func operationStepsToGQLOperationSteps(steps []model.OperationStep) []*gqlschema.OperationStep {
+	gqlSteps := make([]*gqlschema.OperationStep, 0, len(steps))
+
+	for _, step := range steps {
+		gqlStep := &gqlschema.OperationStep{
+			Name:           string(step.Name),
+			State:          operationStateToGraphQLState(step.State),
+			StartTimestamp: step.StartTimestamp,
+			EndTimestamp:   step.EndTimestamp,
+		}
+		if step.Message != "" {
+			message := step.Message
+			gqlStep.Message = &message
+		}
+
+		gqlSteps = append(gqlSteps, gqlStep)
+	}
+
+	return gqlSteps
+}
+
+func operationStatusesToGQLOperationStatuses(operations []model.Operation) []*gqlschema.OperationStatus {
+	statuses := make([]*gqlschema.OperationStatus, 0, len(operations))
+
+	for _, operation := range operations {
+		statuses = append(statuses, operationStatusToGQLOperationStatus(operation))
+	}
+
+	return statuses
+}
+
+func runtimeSummariesToGraphQLRuntimes(runtimes []model.RuntimeSummary) []*gqlschema.Runtime {
+	gqlRuntimes := make([]*gqlschema.Runtime, 0, len(runtimes))
+
+	for _, runtime := range runtimes {
+		gqlRuntimes = append(gqlRuntimes, &gqlschema.Runtime{
+			ID:                  runtime.ID,
+			Name:                runtime.Name,
+			Provider:            runtime.Provider,
+			Region:              runtime.Region,
+			CreationTimestamp:   runtime.CreationTimestamp,
+			LastOperationStatus: operationStatusToGQLOperationStatus(runtime.LastOperation),
+		})
+	}
+
+	return gqlRuntimes
+}
+
+func runtimeFilterFromGraphQLFilter(filter *gqlschema.RuntimesFilter) model.RuntimeFilter {
+	if filter == nil {
+		return model.RuntimeFilter{}
+	}
+
+	runtimeFilter := model.RuntimeFilter{
+		Provider: filter.Provider,
+		Region:   filter.Region,
+	}
+
+	if filter.State != nil {
+		state := operationStateFromGraphQLState(*filter.State)
+		runtimeFilter.State = &state
 	}
+
+	return runtimeFilter
 }
 
 func clusterConfigFromInput(runtimeID string, input gqlschema.ClusterConfigInput, uuidGenerator persistence.UUIDGenerator) interface{} {
@@ -45,6 +200,14 @@ func clusterConfigFromInput(runtimeID string, input gqlschema.ClusterConfigInput
 		config := input.GcpConfig
 		return gcpConfigFromInput(runtimeID, *config, uuidGenerator)
 	}
+	if input.AwsConfig != nil {
+		config := input.AwsConfig
+		return awsConfigFromInput(runtimeID, *config, uuidGenerator)
+	}
+	if input.AzureConfig != nil {
+		config := input.AzureConfig
+		return azureConfigFromInput(runtimeID, *config, uuidGenerator)
+	}
 	return nil
 }
 
@@ -95,6 +258,51 @@ func gcpConfigFromInput(runtimeID string, input gqlschema.GCPConfigInput, uuidGe
 	}
 }
 
+func awsConfigFromInput(runtimeID string, input gqlschema.AWSConfigInput, uuidGenerator persistence.UUIDGenerator) model.AWSConfig {
+	id := uuidGenerator.New()
+
+	return model.AWSConfig{
+		ID:                id,
+		Name:              input.Name,
+		KubernetesVersion: input.KubernetesVersion,
+		NodeCount:         input.NodeCount,
+		VolumeSize:        input.VolumeSize,
+		MachineType:       input.MachineType,
+		Region:            input.Region,
+		Zone:              input.Zone,
+		VpcCidr:           input.VpcCidr,
+		PublicCidr:        input.PublicCidr,
+		InternalCidr:      input.InternalCidr,
+		AutoScalerMin:     input.AutoScalerMin,
+		AutoScalerMax:     input.AutoScalerMax,
+		ClusterID:         runtimeID,
+	}
+}
+
+func azureConfigFromInput(runtimeID string, input gqlschema.AzureConfigInput, uuidGenerator persistence.UUIDGenerator) model.AzureConfig {
+	id := uuidGenerator.New()
+
+	zone := ""
+	if input.Zone != nil {
+		zone = *input.Zone
+	}
+
+	return model.AzureConfig{
+		ID:                id,
+		Name:              input.Name,
+		KubernetesVersion: input.KubernetesVersion,
+		NodeCount:         input.NodeCount,
+		VolumeSize:        input.VolumeSize,
+		MachineType:       input.MachineType,
+		Region:            input.Region,
+		Zone:              zone,
+		VnetCidr:          input.VnetCidr,
+		AutoScalerMin:     input.AutoScalerMin,
+		AutoScalerMax:     input.AutoScalerMax,
+		ClusterID:         runtimeID,
+	}
+}
+
 func kymaConfigFromInput(runtimeID string, input gqlschema.KymaConfigInput, uuidGenerator persistence.UUIDGenerator) model.KymaConfig {
 	var modules []model.KymaConfigModule
 	kymaConfigID := uuidGenerator.New()
@@ -111,16 +319,57 @@ func kymaConfigFromInput(runtimeID string, input gqlschema.KymaConfigInput, uuid
 		modules = append(modules, kymaConfigModule)
 	}
 
+	var components []model.KymaComponentConfig
+	for _, component := range input.Components {
+		if component == nil {
+			continue
+		}
+
+		components = append(components, model.KymaComponentConfig{
+			ID:            uuidGenerator.New(),
+			Component:     model.KymaComponent(component.Component),
+			Configuration: configurationFromInput(component.Configuration),
+			KymaConfigID:  kymaConfigID,
+		})
+	}
+
 	return model.KymaConfig{
-		ID:        kymaConfigID,
-		Version:   input.Version,
-		Modules:   modules,
-		ClusterID: runtimeID,
+		ID:                  kymaConfigID,
+		Version:             input.Version,
+		Modules:             modules,
+		Components:          components,
+		GlobalConfiguration: configurationFromInput(input.Configuration),
+		ClusterID:           runtimeID,
 	}
 }
 
-func runtimeConnectionStatusToGraphQLStatus(status model.RuntimeAgentConnectionStatus) *gqlschema.RuntimeConnectionStatus {
-	return &gqlschema.RuntimeConnectionStatus{Status: runtimeAgentConnectionStatusToGraphQLStatus(status)}
+func configurationFromInput(input []*gqlschema.ConfigurationInput) model.Configuration {
+	var configuration model.Configuration
+
+	for _, entry := range input {
+		if entry == nil {
+			continue
+		}
+
+		configuration.ConfigEntries = append(configuration.ConfigEntries, model.ConfigEntry{
+			Key:    entry.Key,
+			Value:  entry.Value,
+			Secret: entry.Secret != nil && *entry.Secret,
+		})
+	}
+
+	return configuration
+}
+
+func runtimeConnectionStatusToGraphQLStatus(status model.RuntimeAgentConnectionStatus, err error) *gqlschema.RuntimeConnectionStatus {
+	connectionStatus := &gqlschema.RuntimeConnectionStatus{Status: runtimeAgentConnectionStatusToGraphQLStatus(status)}
+
+	if err != nil {
+		message := err.Error()
+		connectionStatus.Errors = []*gqlschema.Error{{Message: &message}}
+	}
+
+	return connectionStatus
 }
 
 func runtimeAgentConnectionStatusToGraphQLStatus(status model.RuntimeAgentConnectionStatus) gqlschema.RuntimeAgentConnectionStatus {
@@ -154,6 +403,16 @@ func clusterConfigToGraphQLConfig(config interface{}) gqlschema.ClusterConfig {
 	if ok {
 		return gcpConfigToGraphQLConfig(gcpConfig)
 	}
+
+	awsConfig, ok := config.(model.AWSConfig)
+	if ok {
+		return awsConfigToGraphQLConfig(awsConfig)
+	}
+
+	azureConfig, ok := config.(model.AzureConfig)
+	if ok {
+		return azureConfigToGraphQLConfig(azureConfig)
+	}
 	return nil
 }
 
@@ -192,6 +451,38 @@ func gcpConfigToGraphQLConfig(config model.GCPConfig) gqlschema.ClusterConfig {
 	}
 }
 
+func awsConfigToGraphQLConfig(config model.AWSConfig) gqlschema.ClusterConfig {
+	return gqlschema.AWSConfig{
+		Name:              &config.Name,
+		KubernetesVersion: &config.KubernetesVersion,
+		NodeCount:         &config.NodeCount,
+		VolumeSize:        &config.VolumeSize,
+		MachineType:       &config.MachineType,
+		Region:            &config.Region,
+		Zone:              &config.Zone,
+		VpcCidr:           &config.VpcCidr,
+		PublicCidr:        &config.PublicCidr,
+		InternalCidr:      &config.InternalCidr,
+		AutoScalerMin:     &config.AutoScalerMin,
+		AutoScalerMax:     &config.AutoScalerMax,
+	}
+}
+
+func azureConfigToGraphQLConfig(config model.AzureConfig) gqlschema.ClusterConfig {
+	return gqlschema.AzureConfig{
+		Name:              &config.Name,
+		KubernetesVersion: &config.KubernetesVersion,
+		NodeCount:         &config.NodeCount,
+		VolumeSize:        &config.VolumeSize,
+		MachineType:       &config.MachineType,
+		Region:            &config.Region,
+		Zone:              &config.Zone,
+		VnetCidr:          &config.VnetCidr,
+		AutoScalerMin:     &config.AutoScalerMin,
+		AutoScalerMax:     &config.AutoScalerMax,
+	}
+}
+
 func kymaConfigToGraphQLConfig(config model.KymaConfig) *gqlschema.KymaConfig {
 	var modules []*gqlschema.KymaModule
 	for _, module := range config.Modules {
@@ -199,10 +490,42 @@ func kymaConfigToGraphQLConfig(config model.KymaConfig) *gqlschema.KymaConfig {
 		modules = append(modules, &kymaModule)
 	}
 
+	var components []*gqlschema.ComponentConfiguration
+	for _, componentConfig := range config.Components {
+		component := string(componentConfig.Component)
+		components = append(components, &gqlschema.ComponentConfiguration{
+			Component:     &component,
+			Configuration: configurationToGraphQLConfiguration(componentConfig.Configuration),
+		})
+	}
+
 	return &gqlschema.KymaConfig{
-		Version: &config.Version,
-		Modules: modules,
+		Version:       &config.Version,
+		Modules:       modules,
+		Components:    components,
+		Configuration: configurationToGraphQLConfiguration(config.GlobalConfiguration),
+	}
+}
+
+// configurationToGraphQLConfiguration returns the configuration without values of secret entries
+func configurationToGraphQLConfiguration(configuration model.Configuration) []*gqlschema.Configuration {
+	var entries []*gqlschema.Configuration
+	for _, entry := range configuration.ConfigEntries {
+		secret := entry.Secret
+
+		value := entry.Value
+		if secret {
+			value = ""
+		}
+
+		entries = append(entries, &gqlschema.Configuration{
+			Key:    entry.Key,
+			Value:  value,
+			Secret: &secret,
+		})
 	}
+
+	return entries
 }
 
 func operationTypeToGraphQLType(operationType model.OperationType) gqlschema.OperationType {
@@ -222,6 +545,8 @@ func operationTypeToGraphQLType(operationType model.OperationType) gqlschema.Ope
 
 func operationStateToGraphQLState(state model.OperationState) gqlschema.OperationState {
 	switch state {
+	case model.Pending:
+		return gqlschema.OperationStatePending
 	case model.InProgress:
 		return gqlschema.OperationStateInProgress
 	case model.Succeeded:
@@ -232,3 +557,79 @@ func operationStateToGraphQLState(state model.OperationState) gqlschema.Operatio
 		return ""
 	}
 }
+
+func operationTypeFromGraphQLType(operationType gqlschema.OperationType) model.OperationType {
+	switch operationType {
+	case gqlschema.OperationTypeProvision:
+		return model.Provision
+	case gqlschema.OperationTypeDeprovision:
+		return model.Deprovision
+	case gqlschema.OperationTypeUpgrade:
+		return model.Upgrade
+	case gqlschema.OperationTypeReconnectRuntime:
+		return model.ReconnectRuntime
+	default:
+		return ""
+	}
+}
+
+func operationStateFromGraphQLState(state gqlschema.OperationState) model.OperationState {
+	switch state {
+	case gqlschema.OperationStatePending:
+		return model.Pending
+	case gqlschema.OperationStateInProgress:
+		return model.InProgress
+	case gqlschema.OperationStateSucceeded:
+		return model.Succeeded
+	case gqlschema.OperationStateFailed:
+		return model.Failed
+	default:
+		return ""
+	}
+}
+
+func hyperscalerTypeFromGraphQLType(hyperscalerType gqlschema.HyperscalerType) hyperscaler.Type {
+	switch hyperscalerType {
+	case gqlschema.HyperscalerTypeGcp:
+		return hyperscaler.GCP
+	case gqlschema.HyperscalerTypeAws:
+		return hyperscaler.AWS
+	case gqlschema.HyperscalerTypeAzure:
+		return hyperscaler.Azure
+	case gqlschema.HyperscalerTypeGardener:
+		return hyperscaler.Gardener
+	default:
+		return ""
+	}
+}
+
+func hyperscalerTypeToGraphQLType(hyperscalerType hyperscaler.Type) gqlschema.HyperscalerType {
+	switch hyperscalerType {
+	case hyperscaler.GCP:
+		return gqlschema.HyperscalerTypeGcp
+	case hyperscaler.AWS:
+		return gqlschema.HyperscalerTypeAws
+	case hyperscaler.Azure:
+		return gqlschema.HyperscalerTypeAzure
+	case hyperscaler.Gardener:
+		return gqlschema.HyperscalerTypeGardener
+	default:
+		return ""
+	}
+}
+
+func accountPoolStatusesToGraphQLStatuses(statuses []hyperscaler.PoolStatus) []*gqlschema.HyperscalerAccountPoolStatus {
+	result := make([]*gqlschema.HyperscalerAccountPoolStatus, 0, len(statuses))
+
+	for _, status := range statuses {
+		result = append(result, &gqlschema.HyperscalerAccountPoolStatus{
+			Hyperscaler: hyperscalerTypeToGraphQLType(status.HyperscalerType),
+			AccountName: status.AccountName,
+			Total:       status.Total,
+			Assigned:    status.Assigned,
+			Free:        status.Free(),
+		})
+	}
+
+	return result
+}
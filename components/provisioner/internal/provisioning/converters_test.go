@@ -2,7 +2,9 @@ package provisioning
 
 import (
 	"testing"
+	"time"
 
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
 
 	persistenceMocks "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/mocks"
@@ -14,24 +16,45 @@ func TestOperationStatusToGQLOperationStatus(t *testing.T) {
 
 	t.Run("Should create proper operation status struct", func(t *testing.T) {
 		//given
+		startTimestamp := time.Date(2019, 11, 4, 12, 0, 0, 0, time.UTC)
+
+		stepEndTimestamp := time.Date(2019, 11, 4, 12, 5, 0, 0, time.UTC)
+
 		operation := model.Operation{
-			ID:        "5f6e3ab6-d803-430a-8fac-29c9c9b4485a",
-			Type:      model.Upgrade,
-			State:     model.InProgress,
-			Message:   "Some message",
-			ClusterID: "6af76034-272a-42be-ac39-30e075f515a3",
+			ID:             "5f6e3ab6-d803-430a-8fac-29c9c9b4485a",
+			Type:           model.Upgrade,
+			State:          model.InProgress,
+			Message:        "Some message",
+			ClusterID:      "6af76034-272a-42be-ac39-30e075f515a3",
+			StartTimestamp: startTimestamp,
+			Steps: []model.OperationStep{
+				{Name: model.StepPrepareClusterConfig, State: model.Succeeded, Message: "Preparing cluster configuration", StartTimestamp: &startTimestamp, EndTimestamp: &stepEndTimestamp},
+				{Name: model.StepUpgradeCluster, State: model.InProgress, Message: "Some message", StartTimestamp: &stepEndTimestamp},
+				{Name: model.StepRetrieveKubeconfig, State: model.Pending},
+				{Name: model.StepSaveKymaConfig, State: model.Pending},
+			},
 		}
 
 		operationID := "5f6e3ab6-d803-430a-8fac-29c9c9b4485a"
 		message := "Some message"
+		prepareMessage := "Preparing cluster configuration"
 		runtimeID := "6af76034-272a-42be-ac39-30e075f515a3"
+		progress := 25
 
 		expectedOperationStatus := &gqlschema.OperationStatus{
-			ID:        &operationID,
-			Operation: gqlschema.OperationTypeUpgrade,
-			State:     gqlschema.OperationStateInProgress,
-			Message:   &message,
-			RuntimeID: &runtimeID,
+			ID:             &operationID,
+			Operation:      gqlschema.OperationTypeUpgrade,
+			State:          gqlschema.OperationStateInProgress,
+			Message:        &message,
+			RuntimeID:      &runtimeID,
+			StartTimestamp: &startTimestamp,
+			Steps: []*gqlschema.OperationStep{
+				{Name: "PREPARE_CLUSTER_CONFIG", State: gqlschema.OperationStateSucceeded, Message: &prepareMessage, StartTimestamp: &startTimestamp, EndTimestamp: &stepEndTimestamp},
+				{Name: "UPGRADE_CLUSTER", State: gqlschema.OperationStateInProgress, Message: &message, StartTimestamp: &stepEndTimestamp},
+				{Name: "RETRIEVE_KUBECONFIG", State: gqlschema.OperationStatePending},
+				{Name: "SAVE_KYMA_CONFIG", State: gqlschema.OperationStatePending},
+			},
+			Progress: &progress,
 		}
 
 		//when
@@ -43,6 +66,7 @@ func TestOperationStatusToGQLOperationStatus(t *testing.T) {
 }
 
 func TestRuntimeConfigFromGraphQLRuntimeConfig(t *testing.T) {
+	secretName := "secretName"
 
 	createGQLRuntimeInputGCP := func(zone *string) gqlschema.ProvisionRuntimeInput {
 		return gqlschema.ProvisionRuntimeInput{
@@ -59,7 +83,7 @@ func TestRuntimeConfigFromGraphQLRuntimeConfig(t *testing.T) {
 				},
 			},
 			Credentials: &gqlschema.CredentialsInput{
-				SecretName: "secretName",
+				SecretName: &secretName,
 			},
 			KymaConfig: &gqlschema.KymaConfigInput{
 				Version: "1.5",
@@ -118,7 +142,7 @@ func TestRuntimeConfigFromGraphQLRuntimeConfig(t *testing.T) {
 			},
 		},
 		Credentials: &gqlschema.CredentialsInput{
-			SecretName: "secretName",
+			SecretName: &secretName,
 		},
 		KymaConfig: &gqlschema.KymaConfigInput{
 			Version: "1.5",
@@ -160,6 +184,102 @@ func TestRuntimeConfigFromGraphQLRuntimeConfig(t *testing.T) {
 		CredentialsSecretName: "secretName",
 	}
 
+	awsGQLInput := gqlschema.ProvisionRuntimeInput{
+		ClusterConfig: &gqlschema.ClusterConfigInput{
+			AwsConfig: &gqlschema.AWSConfigInput{
+				Name:              "Something",
+				KubernetesVersion: "version",
+				NodeCount:         3,
+				VolumeSize:        "50",
+				MachineType:       "m5.xlarge",
+				Region:            "eu-central-1",
+				Zone:              "eu-central-1a",
+				VpcCidr:           "10.250.0.0/16",
+				PublicCidr:        "10.250.96.0/22",
+				InternalCidr:      "10.250.112.0/22",
+				AutoScalerMin:     1,
+				AutoScalerMax:     5,
+			},
+		},
+		Credentials: &gqlschema.CredentialsInput{
+			SecretName: &secretName,
+		},
+		KymaConfig: &gqlschema.KymaConfigInput{
+			Version: "1.5",
+			Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup, gqlschema.KymaModuleBackupInit},
+		},
+	}
+
+	expectedAWSRuntimeConfig := model.RuntimeConfig{
+		ClusterConfig: model.AWSConfig{
+			ID:                "id",
+			Name:              "Something",
+			KubernetesVersion: "version",
+			NodeCount:         3,
+			VolumeSize:        "50",
+			MachineType:       "m5.xlarge",
+			Region:            "eu-central-1",
+			Zone:              "eu-central-1a",
+			VpcCidr:           "10.250.0.0/16",
+			PublicCidr:        "10.250.96.0/22",
+			InternalCidr:      "10.250.112.0/22",
+			AutoScalerMin:     1,
+			AutoScalerMax:     5,
+			ClusterID:         "runtimeID",
+		},
+		Kubeconfig:            nil,
+		KymaConfig:            expectedRuntimeConfig.KymaConfig,
+		CredentialsSecretName: "secretName",
+	}
+
+	createGQLRuntimeInputAzure := func(zone *string) gqlschema.ProvisionRuntimeInput {
+		return gqlschema.ProvisionRuntimeInput{
+			ClusterConfig: &gqlschema.ClusterConfigInput{
+				AzureConfig: &gqlschema.AzureConfigInput{
+					Name:              "Something",
+					KubernetesVersion: "version",
+					NodeCount:         3,
+					VolumeSize:        "50",
+					MachineType:       "Standard_D8_v3",
+					Region:            "westeurope",
+					Zone:              zone,
+					VnetCidr:          "10.250.0.0/19",
+					AutoScalerMin:     1,
+					AutoScalerMax:     5,
+				},
+			},
+			Credentials: &gqlschema.CredentialsInput{
+				SecretName: &secretName,
+			},
+			KymaConfig: &gqlschema.KymaConfigInput{
+				Version: "1.5",
+				Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup, gqlschema.KymaModuleBackupInit},
+			},
+		}
+	}
+
+	createExpectedRuntimeInputAzure := func(zone string) model.RuntimeConfig {
+		return model.RuntimeConfig{
+			ClusterConfig: model.AzureConfig{
+				ID:                "id",
+				Name:              "Something",
+				KubernetesVersion: "version",
+				NodeCount:         3,
+				VolumeSize:        "50",
+				MachineType:       "Standard_D8_v3",
+				Region:            "westeurope",
+				Zone:              zone,
+				VnetCidr:          "10.250.0.0/19",
+				AutoScalerMin:     1,
+				AutoScalerMax:     5,
+				ClusterID:         "runtimeID",
+			},
+			Kubeconfig:            nil,
+			KymaConfig:            expectedRuntimeConfig.KymaConfig,
+			CredentialsSecretName: "secretName",
+		}
+	}
+
 	zone := "zone"
 
 	configurations := []struct {
@@ -182,6 +302,21 @@ func TestRuntimeConfigFromGraphQLRuntimeConfig(t *testing.T) {
 			expected:    expectedRuntimeConfig,
 			description: "Should create proper runtime config struct with Gardener input",
 		},
+		{
+			input:       awsGQLInput,
+			expected:    expectedAWSRuntimeConfig,
+			description: "Should create proper runtime config struct with AWS input",
+		},
+		{
+			input:       createGQLRuntimeInputAzure(&zone),
+			expected:    createExpectedRuntimeInputAzure(zone),
+			description: "Should create proper runtime config struct with Azure input",
+		},
+		{
+			input:       createGQLRuntimeInputAzure(nil),
+			expected:    createExpectedRuntimeInputAzure(""),
+			description: "Should create proper runtime config struct with Azure input (empty zone)",
+		},
 	}
 
 	for _, config := range configurations {
@@ -215,13 +350,16 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 		backupInit := gqlschema.KymaModuleBackupInit
 		kubeconfig := "kubeconfig"
 
+		startTimestamp := time.Date(2019, 11, 4, 12, 0, 0, 0, time.UTC)
+
 		runtimeStatus := model.RuntimeStatus{
 			LastOperationStatus: model.Operation{
-				ID:        "5f6e3ab6-d803-430a-8fac-29c9c9b4485a",
-				Type:      model.Provision,
-				State:     model.Succeeded,
-				Message:   "Some message",
-				ClusterID: "6af76034-272a-42be-ac39-30e075f515a3",
+				ID:             "5f6e3ab6-d803-430a-8fac-29c9c9b4485a",
+				Type:           model.Provision,
+				State:          model.Succeeded,
+				Message:        "Some message",
+				ClusterID:      "6af76034-272a-42be-ac39-30e075f515a3",
+				StartTimestamp: startTimestamp,
 			},
 			RuntimeConnectionStatus: model.RuntimeAgentConnectionStatusConnected,
 			RuntimeConfiguration: model.RuntimeConfig{
@@ -256,11 +394,13 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 
 		expectedRuntimeStatus := &gqlschema.RuntimeStatus{
 			LastOperationStatus: &gqlschema.OperationStatus{
-				ID:        &operationID,
-				Operation: gqlschema.OperationTypeProvision,
-				State:     gqlschema.OperationStateSucceeded,
-				Message:   &message,
-				RuntimeID: &runtimeID,
+				ID:             &operationID,
+				Operation:      gqlschema.OperationTypeProvision,
+				State:          gqlschema.OperationStateSucceeded,
+				Message:        &message,
+				RuntimeID:      &runtimeID,
+				StartTimestamp: &startTimestamp,
+				Steps:          []*gqlschema.OperationStep{},
 			},
 			RuntimeConnectionStatus: &gqlschema.RuntimeConnectionStatus{
 				Status: gqlschema.RuntimeAgentConnectionStatusConnected,
@@ -314,13 +454,16 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 		surge := 1
 		unavailable := 1
 
+		startTimestamp := time.Date(2019, 11, 4, 12, 0, 0, 0, time.UTC)
+
 		runtimeStatus := model.RuntimeStatus{
 			LastOperationStatus: model.Operation{
-				ID:        "5f6e3ab6-d803-430a-8fac-29c9c9b4485a",
-				Type:      model.Deprovision,
-				State:     model.Failed,
-				Message:   "Some message",
-				ClusterID: "6af76034-272a-42be-ac39-30e075f515a3",
+				ID:             "5f6e3ab6-d803-430a-8fac-29c9c9b4485a",
+				Type:           model.Deprovision,
+				State:          model.Failed,
+				Message:        "Some message",
+				ClusterID:      "6af76034-272a-42be-ac39-30e075f515a3",
+				StartTimestamp: startTimestamp,
 			},
 			RuntimeConnectionStatus: model.RuntimeAgentConnectionStatusDisconnected,
 			RuntimeConfiguration: model.RuntimeConfig{
@@ -359,11 +502,13 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 
 		expectedRuntimeStatus := &gqlschema.RuntimeStatus{
 			LastOperationStatus: &gqlschema.OperationStatus{
-				ID:        &operationID,
-				Operation: gqlschema.OperationTypeDeprovision,
-				State:     gqlschema.OperationStateFailed,
-				Message:   &message,
-				RuntimeID: &runtimeID,
+				ID:             &operationID,
+				Operation:      gqlschema.OperationTypeDeprovision,
+				State:          gqlschema.OperationStateFailed,
+				Message:        &message,
+				RuntimeID:      &runtimeID,
+				StartTimestamp: &startTimestamp,
+				Steps:          []*gqlschema.OperationStep{},
 			},
 			RuntimeConnectionStatus: &gqlschema.RuntimeConnectionStatus{
 				Status: gqlschema.RuntimeAgentConnectionStatusDisconnected,
@@ -402,3 +547,145 @@ func TestRuntimeStatusToGraphQLStatus(t *testing.T) {
 		assert.Equal(t, expectedRuntimeStatus, gqlStatus)
 	})
 }
+
+func TestKymaConfigFromInput(t *testing.T) {
+	t.Run("Should create Kyma config with component and global configuration", func(t *testing.T) {
+		//given
+		secret := true
+		input := gqlschema.KymaConfigInput{
+			Version: "1.6",
+			Modules: []gqlschema.KymaModule{gqlschema.KymaModuleMonitoring},
+			Components: []*gqlschema.ComponentConfigurationInput{
+				{
+					Component: "assetstore",
+					Configuration: []*gqlschema.ConfigurationInput{
+						{Key: "minio.persistence.enabled", Value: "false"},
+						{Key: "minio.secretKey", Value: "secret-key", Secret: &secret},
+					},
+				},
+				{Component: "core"},
+			},
+			Configuration: []*gqlschema.ConfigurationInput{
+				{Key: "global.domainName", Value: "kyma.local"},
+			},
+		}
+
+		uuidGeneratorMock := &persistenceMocks.UUIDGenerator{}
+		uuidGeneratorMock.On("New").Return("id").Times(4)
+
+		expected := model.KymaConfig{
+			ID:      "id",
+			Version: "1.6",
+			Modules: []model.KymaConfigModule{
+				{ID: "id", Module: model.KymaModule("Monitoring"), KymaConfigID: "id"},
+			},
+			Components: []model.KymaComponentConfig{
+				{
+					ID:        "id",
+					Component: model.KymaComponent("assetstore"),
+					Configuration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+						{Key: "minio.persistence.enabled", Value: "false"},
+						{Key: "minio.secretKey", Value: "secret-key", Secret: true},
+					}},
+					KymaConfigID: "id",
+				},
+				{ID: "id", Component: model.KymaComponent("core"), KymaConfigID: "id"},
+			},
+			GlobalConfiguration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+				{Key: "global.domainName", Value: "kyma.local"},
+			}},
+			ClusterID: "runtimeID",
+		}
+
+		//when
+		kymaConfig := kymaConfigFromInput("runtimeID", input, uuidGeneratorMock)
+
+		//then
+		assert.Equal(t, expected, kymaConfig)
+		uuidGeneratorMock.AssertExpectations(t)
+	})
+}
+
+func TestKymaConfigToGraphQLConfig(t *testing.T) {
+	t.Run("Should not return values of secret configuration entries", func(t *testing.T) {
+		//given
+		kymaConfig := model.KymaConfig{
+			Version: "1.6",
+			Components: []model.KymaComponentConfig{
+				{
+					Component: model.KymaComponent("assetstore"),
+					Configuration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+						{Key: "minio.persistence.enabled", Value: "false"},
+						{Key: "minio.secretKey", Value: "secret-key", Secret: true},
+					}},
+				},
+			},
+			GlobalConfiguration: model.Configuration{ConfigEntries: []model.ConfigEntry{
+				{Key: "global.password", Value: "password", Secret: true},
+			}},
+		}
+
+		notSecret := false
+		secret := true
+		component := "assetstore"
+
+		//when
+		gqlConfig := kymaConfigToGraphQLConfig(kymaConfig)
+
+		//then
+		assert.Equal(t, []*gqlschema.ComponentConfiguration{
+			{
+				Component: &component,
+				Configuration: []*gqlschema.Configuration{
+					{Key: "minio.persistence.enabled", Value: "false", Secret: &notSecret},
+					{Key: "minio.secretKey", Value: "", Secret: &secret},
+				},
+			},
+		}, gqlConfig.Components)
+		assert.Equal(t, []*gqlschema.Configuration{
+			{Key: "global.password", Value: "", Secret: &secret},
+		}, gqlConfig.Configuration)
+	})
+}
+
+func TestDirectorRuntimeInputFromInput(t *testing.T) {
+	clusterConfig := &gqlschema.ClusterConfigInput{
+		GcpConfig: &gqlschema.GCPConfigInput{
+			Name:   "cluster",
+			Region: "europe-west1",
+		},
+	}
+
+	t.Run("Should use cluster name and add provider and region labels", func(t *testing.T) {
+		//when
+		runtimeInput := directorRuntimeInputFromInput(gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig})
+
+		//then
+		assert.Equal(t, director.RuntimeInput{
+			Name:   "cluster",
+			Labels: map[string]interface{}{"provider": "gcp", "region": "europe-west1"},
+		}, runtimeInput)
+	})
+
+	t.Run("Should use name, description and labels from Runtime input", func(t *testing.T) {
+		//given
+		name := "runtime"
+		description := "description"
+		labels := map[string]interface{}{"team": "compass", "region": "ignored"}
+
+		input := gqlschema.ProvisionRuntimeInput{
+			ClusterConfig: clusterConfig,
+			RuntimeInput:  &gqlschema.RuntimeInput{Name: &name, Description: &description, Labels: &labels},
+		}
+
+		//when
+		runtimeInput := directorRuntimeInputFromInput(input)
+
+		//then
+		assert.Equal(t, director.RuntimeInput{
+			Name:        "runtime",
+			Description: &description,
+			Labels:      map[string]interface{}{"team": "compass", "provider": "gcp", "region": "europe-west1"},
+		}, runtimeInput)
+	})
+}
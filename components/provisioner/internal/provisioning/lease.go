@@ -0,0 +1,72 @@
+package provisioning
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// executeWithLease executes the operation which was leased by this instance when it was started
+func (r *service) executeWithLease(operationID string, finished chan<- struct{}, execute func(ctx context.Context)) {
+	defer close(finished)
+
+	r.executeLeased(operationID, execute)
+}
+
+// executeLeased renews the lease until the operation finishes. The context passed to the operation is cancelled as soon as
+// the lease is lost, for example when the operation was aborted after the timeout or taken over by another instance.
+func (r *service) executeLeased(operationID string, execute func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go r.renewLease(ctx, cancel, operationID)
+
+	defer func() {
+		cancel()
+		if err := r.persistenceService.ReleaseLease(operationID, r.lease.Owner); err != nil {
+			log.Warnf("Failed to release lease for operation %s: %s", operationID, err.Error())
+		}
+	}()
+
+	execute(ctx)
+}
+
+func (r *service) renewLease(ctx context.Context, loseLease context.CancelFunc, operationID string) {
+	ticker := time.NewTicker(r.lease.Duration / 3)
+	defer ticker.Stop()
+
+	lastRenewal := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			renewed, err := r.persistenceService.RenewLease(operationID, r.lease.Owner, r.lease.Duration)
+			if err != nil {
+				log.Warnf("Failed to renew lease for operation %s: %s", operationID, err.Error())
+				if time.Since(lastRenewal) < r.lease.Duration {
+					continue
+				}
+				log.Errorf("Lease for operation %s expired, stopping the operation", operationID)
+				loseLease()
+				return
+			}
+			if !renewed {
+				log.Errorf("Lease for operation %s is no longer held by %s, stopping the operation", operationID, r.lease.Owner)
+				loseLease()
+				return
+			}
+			lastRenewal = time.Now()
+		}
+	}
+}
+
+// leaseLost reports whether the operation has to stop, as another instance may be executing it now
+func leaseLost(ctx context.Context, operationID string) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+
+	log.Warnf("Operation %s stopped, as its lease was lost", operationID)
+	return true
+}
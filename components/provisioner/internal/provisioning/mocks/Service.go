@@ -5,6 +5,7 @@ package mocks
 import (
 	gqlschema "github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
 	mock "github.com/stretchr/testify/mock"
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -12,20 +13,20 @@ type Service struct {
 	mock.Mock
 }
 
-// CleanupRuntimeData provides a mock function with given fields: id
-func (_m *Service) CleanupRuntimeData(id string) (string, error) {
-	ret := _m.Called(id)
+// CleanupRuntimeData provides a mock function with given fields: tenant, id
+func (_m *Service) CleanupRuntimeData(tenant string, id string) (string, error) {
+	ret := _m.Called(tenant, id)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(tenant, id)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tenant, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,20 +34,20 @@ func (_m *Service) CleanupRuntimeData(id string) (string, error) {
 	return r0, r1
 }
 
-// DeprovisionRuntime provides a mock function with given fields: id
-func (_m *Service) DeprovisionRuntime(id string) (string, <-chan struct{}, error) {
-	ret := _m.Called(id)
+// DeprovisionRuntime provides a mock function with given fields: tenant, id
+func (_m *Service) DeprovisionRuntime(tenant string, id string) (string, <-chan struct{}, error) {
+	ret := _m.Called(tenant, id)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(tenant, id)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 <-chan struct{}
-	if rf, ok := ret.Get(1).(func(string) <-chan struct{}); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string, string) <-chan struct{}); ok {
+		r1 = rf(tenant, id)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(<-chan struct{})
@@ -54,8 +55,8 @@ func (_m *Service) DeprovisionRuntime(id string) (string, <-chan struct{}, error
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(string) error); ok {
-		r2 = rf(id)
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(tenant, id)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -63,20 +64,68 @@ func (_m *Service) DeprovisionRuntime(id string) (string, <-chan struct{}, error
 	return r0, r1, r2
 }
 
-// ProvisionRuntime provides a mock function with given fields: id, config
-func (_m *Service) ProvisionRuntime(id string, config gqlschema.ProvisionRuntimeInput) (string, <-chan struct{}, error) {
-	ret := _m.Called(id, config)
+// HyperscalerAccountPools provides a mock function with given fields: tenant, hyperscaler, accountName
+func (_m *Service) HyperscalerAccountPools(tenant string, hyperscaler *gqlschema.HyperscalerType, accountName *string) ([]*gqlschema.HyperscalerAccountPoolStatus, error) {
+	ret := _m.Called(tenant, hyperscaler, accountName)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string, gqlschema.ProvisionRuntimeInput) string); ok {
-		r0 = rf(id, config)
+	var r0 []*gqlschema.HyperscalerAccountPoolStatus
+	if rf, ok := ret.Get(0).(func(string, *gqlschema.HyperscalerType, *string) []*gqlschema.HyperscalerAccountPoolStatus); ok {
+		r0 = rf(tenant, hyperscaler, accountName)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gqlschema.HyperscalerAccountPoolStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *gqlschema.HyperscalerType, *string) error); ok {
+		r1 = rf(tenant, hyperscaler, accountName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Operations provides a mock function with given fields: tenant, runtimeID, operationType, state, since, first, after
+func (_m *Service) Operations(tenant string, runtimeID *string, operationType *gqlschema.OperationType, state *gqlschema.OperationState, since *time.Time, first int, after string) (*gqlschema.OperationPage, error) {
+	ret := _m.Called(tenant, runtimeID, operationType, state, since, first, after)
+
+	var r0 *gqlschema.OperationPage
+	if rf, ok := ret.Get(0).(func(string, *string, *gqlschema.OperationType, *gqlschema.OperationState, *time.Time, int, string) *gqlschema.OperationPage); ok {
+		r0 = rf(tenant, runtimeID, operationType, state, since, first, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.OperationPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *string, *gqlschema.OperationType, *gqlschema.OperationState, *time.Time, int, string) error); ok {
+		r1 = rf(tenant, runtimeID, operationType, state, since, first, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProvisionRuntime provides a mock function with given fields: tenant, id, config
+func (_m *Service) ProvisionRuntime(tenant string, id string, config gqlschema.ProvisionRuntimeInput) (*gqlschema.OperationStatus, <-chan struct{}, error) {
+	ret := _m.Called(tenant, id, config)
+
+	var r0 *gqlschema.OperationStatus
+	if rf, ok := ret.Get(0).(func(string, string, gqlschema.ProvisionRuntimeInput) *gqlschema.OperationStatus); ok {
+		r0 = rf(tenant, id, config)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.OperationStatus)
+		}
 	}
 
 	var r1 <-chan struct{}
-	if rf, ok := ret.Get(1).(func(string, gqlschema.ProvisionRuntimeInput) <-chan struct{}); ok {
-		r1 = rf(id, config)
+	if rf, ok := ret.Get(1).(func(string, string, gqlschema.ProvisionRuntimeInput) <-chan struct{}); ok {
+		r1 = rf(tenant, id, config)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(<-chan struct{})
@@ -84,8 +133,8 @@ func (_m *Service) ProvisionRuntime(id string, config gqlschema.ProvisionRuntime
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(string, gqlschema.ProvisionRuntimeInput) error); ok {
-		r2 = rf(id, config)
+	if rf, ok := ret.Get(2).(func(string, string, gqlschema.ProvisionRuntimeInput) error); ok {
+		r2 = rf(tenant, id, config)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -93,34 +142,43 @@ func (_m *Service) ProvisionRuntime(id string, config gqlschema.ProvisionRuntime
 	return r0, r1, r2
 }
 
-// ReconnectRuntimeAgent provides a mock function with given fields: id
-func (_m *Service) ReconnectRuntimeAgent(id string) (string, error) {
-	ret := _m.Called(id)
+// ReconnectRuntimeAgent provides a mock function with given fields: tenant, id
+func (_m *Service) ReconnectRuntimeAgent(tenant string, id string) (string, <-chan struct{}, error) {
+	ret := _m.Called(tenant, id)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(tenant, id)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	var r1 <-chan struct{}
+	if rf, ok := ret.Get(1).(func(string, string) <-chan struct{}); ok {
+		r1 = rf(tenant, id)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan struct{})
+		}
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(tenant, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// RuntimeOperationStatus provides a mock function with given fields: id
-func (_m *Service) RuntimeOperationStatus(id string) (*gqlschema.OperationStatus, error) {
-	ret := _m.Called(id)
+// RuntimeOperationStatus provides a mock function with given fields: tenant, id
+func (_m *Service) RuntimeOperationStatus(tenant string, id string) (*gqlschema.OperationStatus, error) {
+	ret := _m.Called(tenant, id)
 
 	var r0 *gqlschema.OperationStatus
-	if rf, ok := ret.Get(0).(func(string) *gqlschema.OperationStatus); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) *gqlschema.OperationStatus); ok {
+		r0 = rf(tenant, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.OperationStatus)
@@ -128,8 +186,8 @@ func (_m *Service) RuntimeOperationStatus(id string) (*gqlschema.OperationStatus
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tenant, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -137,13 +195,13 @@ func (_m *Service) RuntimeOperationStatus(id string) (*gqlschema.OperationStatus
 	return r0, r1
 }
 
-// RuntimeStatus provides a mock function with given fields: id
-func (_m *Service) RuntimeStatus(id string) (*gqlschema.RuntimeStatus, error) {
-	ret := _m.Called(id)
+// RuntimeStatus provides a mock function with given fields: tenant, id
+func (_m *Service) RuntimeStatus(tenant string, id string) (*gqlschema.RuntimeStatus, error) {
+	ret := _m.Called(tenant, id)
 
 	var r0 *gqlschema.RuntimeStatus
-	if rf, ok := ret.Get(0).(func(string) *gqlschema.RuntimeStatus); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, string) *gqlschema.RuntimeStatus); ok {
+		r0 = rf(tenant, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gqlschema.RuntimeStatus)
@@ -151,8 +209,8 @@ func (_m *Service) RuntimeStatus(id string) (*gqlschema.RuntimeStatus, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tenant, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -160,23 +218,55 @@ func (_m *Service) RuntimeStatus(id string) (*gqlschema.RuntimeStatus, error) {
 	return r0, r1
 }
 
-// UpgradeRuntime provides a mock function with given fields: id, config
-func (_m *Service) UpgradeRuntime(id string, config *gqlschema.UpgradeRuntimeInput) (string, error) {
-	ret := _m.Called(id, config)
+// Runtimes provides a mock function with given fields: tenant, filter, first, after
+func (_m *Service) Runtimes(tenant string, filter *gqlschema.RuntimesFilter, first int, after string) (*gqlschema.RuntimePage, error) {
+	ret := _m.Called(tenant, filter, first, after)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string, *gqlschema.UpgradeRuntimeInput) string); ok {
-		r0 = rf(id, config)
+	var r0 *gqlschema.RuntimePage
+	if rf, ok := ret.Get(0).(func(string, *gqlschema.RuntimesFilter, int, string) *gqlschema.RuntimePage); ok {
+		r0 = rf(tenant, filter, first, after)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gqlschema.RuntimePage)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, *gqlschema.UpgradeRuntimeInput) error); ok {
-		r1 = rf(id, config)
+	if rf, ok := ret.Get(1).(func(string, *gqlschema.RuntimesFilter, int, string) error); ok {
+		r1 = rf(tenant, filter, first, after)
 	} else {
 		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
+
+// UpgradeRuntime provides a mock function with given fields: tenant, id, config
+func (_m *Service) UpgradeRuntime(tenant string, id string, config gqlschema.UpgradeRuntimeInput) (string, <-chan struct{}, error) {
+	ret := _m.Called(tenant, id, config)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, gqlschema.UpgradeRuntimeInput) string); ok {
+		r0 = rf(tenant, id, config)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 <-chan struct{}
+	if rf, ok := ret.Get(1).(func(string, string, gqlschema.UpgradeRuntimeInput) <-chan struct{}); ok {
+		r1 = rf(tenant, id, config)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan struct{})
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, gqlschema.UpgradeRuntimeInput) error); ok {
+		r2 = rf(tenant, id, config)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -0,0 +1,202 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hydroform"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hyperscaler"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/installation"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/runtimeagent"
+	"github.com/kyma-incubator/hydroform/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const emptyTerraformState = "{}"
+
+//go:generate mockery -name=OperationsReconciler
+type OperationsReconciler interface {
+	Reconcile()
+}
+
+// operationsReconciler picks up operations left in progress by Provisioner instances which are no longer running and resumes them.
+// Operations exceeding the timeout are aborted, even if they are still executed by a running instance.
+type operationsReconciler struct {
+	persistenceService persistence.Service
+	executor           *service
+	timeout            time.Duration
+}
+
+func NewOperationsReconciler(persistenceService persistence.Service, hydroform hydroform.Service, installation installation.Service, accountPool hyperscaler.AccountPool,
+	directorClient director.Client, runtimeAgent runtimeagent.Service, lease model.OperationLease, timeout time.Duration) OperationsReconciler {
+	return &operationsReconciler{
+		persistenceService: persistenceService,
+		executor: &service{
+			persistenceService: persistenceService,
+			hydroform:          hydroform,
+			installation:       installation,
+			accountPool:        accountPool,
+			directorClient:     directorClient,
+			runtimeAgent:       runtimeAgent,
+			lease:              lease,
+		},
+		timeout: timeout,
+	}
+}
+
+func (r *operationsReconciler) Reconcile() {
+	operations, err := r.persistenceService.ListInProgressOperations()
+	if err != nil {
+		log.Errorf("Failed to list operations in progress: %s", err.Error())
+		return
+	}
+
+	for _, operation := range operations {
+		if time.Since(operation.StartTimestamp) > r.timeout {
+			r.abort(operation)
+			continue
+		}
+
+		acquired, err := r.persistenceService.AcquireLease(operation.ID, r.executor.lease.Owner, r.executor.lease.Duration)
+		if err != nil {
+			log.Errorf("Failed to acquire lease for operation %s: %s", operation.ID, err.Error())
+			continue
+		}
+
+		if !acquired {
+			continue
+		}
+
+		log.Infof("Resuming %s operation %s for runtime %s", operation.Type, operation.ID, operation.ClusterID)
+		go r.executor.executeLeased(operation.ID, func(ctx context.Context) {
+			r.resume(ctx, operation)
+		})
+	}
+}
+
+// abort fails the operation which exceeded the timeout. The instance executing it loses the lease and stops.
+func (r *operationsReconciler) abort(operation model.Operation) {
+	log.Errorf("Operation %s for runtime %s timed out", operation.ID, operation.ClusterID)
+
+	aborted, err := r.persistenceService.Abort(operation.ID, fmt.Sprintf("Operation timed out after %s", r.timeout))
+	if err != nil {
+		log.Errorf("Failed to abort operation %s: %s", operation.ID, err.Error())
+		return
+	}
+
+	if aborted && operation.Type == model.Provision {
+		r.setDirectorRuntimeStatus(operation.ClusterID, director.RuntimeStatusConditionFailed)
+	}
+}
+
+// setDirectorRuntimeStatus reports the status to the Director on behalf of the tenant owning the runtime
+func (r *operationsReconciler) setDirectorRuntimeStatus(runtimeID string, condition director.RuntimeStatusCondition) {
+	cluster, err := r.persistenceService.GetUnscopedClusterData(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to set %s status of runtime %s in Director: %s", condition, runtimeID, err.Error())
+		return
+	}
+
+	r.executor.setDirectorRuntimeStatus(cluster.Tenant, runtimeID, condition)
+}
+
+func (r *operationsReconciler) resume(ctx context.Context, operation model.Operation) {
+	switch operation.Type {
+	case model.Provision:
+		r.resumeProvisioning(ctx, operation)
+	case model.Deprovision:
+		r.resumeDeprovisioning(ctx, operation)
+	case model.ReconnectRuntime:
+		r.resumeReconnecting(ctx, operation)
+	case model.Upgrade:
+		// The upgraded Kyma configuration is saved only when the Kyma upgrade succeeds, so the target of the upgrade is unknown
+		r.executor.setAsFailed(operation.ID, "Upgrade cannot be resumed, as it was interrupted before the upgraded configuration was saved. Start the upgrade again")
+	default:
+		r.executor.setAsFailed(operation.ID, fmt.Sprintf("Operation of type %s cannot be resumed", operation.Type))
+	}
+}
+
+func (r *operationsReconciler) resumeProvisioning(ctx context.Context, operation model.Operation) {
+	runtimeConfig, cluster, err := r.getRuntimeData(operation.ClusterID)
+	if err != nil {
+		r.failProvisioning(operation, err.Error())
+		return
+	}
+
+	if clusterCreated(cluster) {
+		r.executor.startInstallation(ctx, operation.ID, cluster.Tenant, operation.ClusterID, *cluster.Kubeconfig, runtimeConfig.KymaConfig)
+		return
+	}
+
+	if cluster.TerraformState == "" || cluster.TerraformState == emptyTerraformState {
+		r.executor.startProvisioning(ctx, operation.ID, cluster.Tenant, operation.ClusterID, runtimeConfig, cluster.CredentialsSecretName)
+		return
+	}
+
+	info, err := r.executor.hydroform.CheckClusterStatus(runtimeConfig, cluster.CredentialsSecretName, cluster.TerraformState)
+	if leaseLost(ctx, operation.ID) {
+		return
+	}
+	if err != nil {
+		r.failProvisioning(operation, err.Error())
+		return
+	}
+
+	if info.ClusterStatus != types.Provisioned {
+		r.failProvisioning(operation, fmt.Sprintf("Cluster is in %s phase", info.ClusterStatus))
+		return
+	}
+
+	r.executor.saveClusterAndInstall(ctx, operation.ID, cluster.Tenant, operation.ClusterID, info, runtimeConfig.KymaConfig)
+}
+
+func (r *operationsReconciler) resumeDeprovisioning(ctx context.Context, operation model.Operation) {
+	runtimeConfig, cluster, err := r.getRuntimeData(operation.ClusterID)
+	if err != nil {
+		r.executor.setAsFailed(operation.ID, err.Error())
+		return
+	}
+
+	r.executor.startDeprovisioning(ctx, operation.ID, operation.ClusterID, runtimeConfig, cluster)
+}
+
+func (r *operationsReconciler) resumeReconnecting(ctx context.Context, operation model.Operation) {
+	cluster, err := r.persistenceService.GetUnscopedClusterData(operation.ClusterID)
+	if err != nil {
+		r.executor.setAsFailed(operation.ID, err.Error())
+		return
+	}
+
+	if cluster.Kubeconfig == nil {
+		r.executor.setAsFailed(operation.ID, fmt.Sprintf("Kubeconfig of runtime %s is missing", operation.ClusterID))
+		return
+	}
+
+	r.executor.startReconnecting(ctx, operation.ID, cluster.Tenant, operation.ClusterID, *cluster.Kubeconfig)
+}
+
+func (r *operationsReconciler) getRuntimeData(runtimeID string) (model.RuntimeConfig, model.Cluster, error) {
+	runtimeStatus, err := r.persistenceService.GetUnscopedStatus(runtimeID)
+	if err != nil {
+		return model.RuntimeConfig{}, model.Cluster{}, err
+	}
+
+	cluster, err := r.persistenceService.GetUnscopedClusterData(runtimeID)
+	if err != nil {
+		return model.RuntimeConfig{}, model.Cluster{}, err
+	}
+
+	runtimeConfig := runtimeStatus.RuntimeConfiguration
+	runtimeConfig.CredentialsSecretName = cluster.CredentialsSecretName
+
+	return runtimeConfig, cluster, nil
+}
+
+func (r *operationsReconciler) failProvisioning(operation model.Operation, message string) {
+	r.executor.setAsFailed(operation.ID, message)
+	r.setDirectorRuntimeStatus(operation.ClusterID, director.RuntimeStatusConditionFailed)
+}
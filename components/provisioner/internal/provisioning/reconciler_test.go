@@ -0,0 +1,288 @@
+package provisioning
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
+	directorMocks "github.com/kyma-incubator/compass/components/provisioner/internal/director/mocks"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hydroform"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hydroform/mocks"
+	hyperscalerMocks "github.com/kyma-incubator/compass/components/provisioner/internal/hyperscaler/mocks"
+	installationMocks "github.com/kyma-incubator/compass/components/provisioner/internal/installation/mocks"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	persistenceMocks "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/mocks"
+	"github.com/kyma-incubator/hydroform/types"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOperationsReconciler_Reconcile(t *testing.T) {
+	runtimeID := "184ccdf2-59e4-44b7-b553-6cb296af5ea0"
+	operationID := "223949ed-e6b6-4ab2-ab3e-8e19cd456dd4"
+	secretName := "secret"
+
+	runtimeStatus := model.RuntimeStatus{RuntimeConfiguration: model.RuntimeConfig{}}
+	runtimeConfig := model.RuntimeConfig{CredentialsSecretName: secretName}
+
+	t.Run("Should resume operation when lease is acquired", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Deprovision, StartTimestamp: time.Now(), ClusterID: runtimeID}
+		cluster := model.Cluster{ID: runtimeID, Tenant: tenant, CredentialsSecretName: secretName, TerraformState: "state"}
+		released := make(chan struct{})
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("ListInProgressOperations").Return([]model.Operation{operation}, nil)
+		persistenceServiceMock.On("AcquireLease", operationID, lease.Owner, lease.Duration).Return(true, nil)
+		persistenceServiceMock.On("GetUnscopedStatus", runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("GetUnscopedClusterData", runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("SetAsSucceeded", operationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("ReleaseLease", operationID, lease.Owner).Return(nil).Run(func(args mock.Arguments) {
+			close(released)
+		})
+		hydroformMock.On("DeprovisionCluster", runtimeConfig, secretName, "state", mock.Anything).Return(nil)
+		accountPoolMock.On("ReleaseCredentials", runtimeID).Return(nil)
+		directorClientMock.On("DeleteRuntime", tenant, runtimeID).Return(nil)
+
+		reconciler := NewOperationsReconciler(persistenceServiceMock, hydroformMock, nil, accountPoolMock, directorClientMock, nil, lease, time.Hour)
+
+		//when
+		reconciler.Reconcile()
+
+		waitUntilFinished(released)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		accountPoolMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should skip operation when lease is held by another instance", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Provision, StartTimestamp: time.Now(), ClusterID: runtimeID}
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+
+		persistenceServiceMock.On("ListInProgressOperations").Return([]model.Operation{operation}, nil)
+		persistenceServiceMock.On("AcquireLease", operationID, lease.Owner, lease.Duration).Return(false, nil)
+
+		reconciler := NewOperationsReconciler(persistenceServiceMock, hydroformMock, nil, nil, nil, nil, lease, time.Hour)
+
+		//when
+		reconciler.Reconcile()
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should abort operation which timed out without resuming it", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Provision, StartTimestamp: time.Now().Add(-2 * time.Hour), ClusterID: runtimeID}
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("ListInProgressOperations").Return([]model.Operation{operation}, nil)
+		persistenceServiceMock.On("Abort", operationID, "Operation timed out after 1h0m0s").Return(true, nil)
+		persistenceServiceMock.On("GetUnscopedClusterData", runtimeID).Return(model.Cluster{ID: runtimeID, Tenant: tenant}, nil)
+		directorClientMock.On("SetRuntimeStatusCondition", tenant, runtimeID, director.RuntimeStatusConditionFailed).Return(nil)
+
+		reconciler := NewOperationsReconciler(persistenceServiceMock, hydroformMock, nil, nil, directorClientMock, nil, lease, time.Hour)
+
+		//when
+		reconciler.Reconcile()
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+		persistenceServiceMock.AssertNotCalled(t, "AcquireLease", operationID, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should not change runtime status when timed out operation finished in the meantime", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Provision, StartTimestamp: time.Now().Add(-2 * time.Hour), ClusterID: runtimeID}
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("ListInProgressOperations").Return([]model.Operation{operation}, nil)
+		persistenceServiceMock.On("Abort", operationID, "Operation timed out after 1h0m0s").Return(false, nil)
+
+		reconciler := NewOperationsReconciler(persistenceServiceMock, &mocks.Service{}, nil, nil, directorClientMock, nil, lease, time.Hour)
+
+		//when
+		reconciler.Reconcile()
+
+		//then
+		persistenceServiceMock.AssertExpectations(t)
+		directorClientMock.AssertNotCalled(t, "SetRuntimeStatusCondition", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOperationsReconciler_Resume(t *testing.T) {
+	runtimeID := "184ccdf2-59e4-44b7-b553-6cb296af5ea0"
+	operationID := "223949ed-e6b6-4ab2-ab3e-8e19cd456dd4"
+	secretName := "secret"
+
+	runtimeStatus := model.RuntimeStatus{RuntimeConfiguration: model.RuntimeConfig{}}
+	runtimeConfig := model.RuntimeConfig{CredentialsSecretName: secretName}
+
+	t.Run("Should restart provisioning when Terraform state was not saved", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Provision, StartTimestamp: time.Now(), ClusterID: runtimeID}
+		cluster := model.Cluster{ID: runtimeID, Tenant: tenant, CredentialsSecretName: secretName, TerraformState: emptyTerraformState}
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("GetUnscopedStatus", runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("GetUnscopedClusterData", runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("StartOperationStep", operationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("Update", runtimeID, "kubeconfig", "state").Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", operationID, lease.Owner).Return(nil)
+		hydroformMock.On("ProvisionCluster", runtimeConfig, secretName, mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "kubeconfig", State: "state"}, nil)
+		installationMock.On("InstallKyma", "kubeconfig", runtimeConfig.KymaConfig).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, "kubeconfig", mock.Anything).Return(nil)
+		directorClientMock.On("SetRuntimeStatusCondition", tenant, runtimeID, director.RuntimeStatusConditionReady).Return(nil)
+
+		reconciler := newTestReconciler(persistenceServiceMock, hydroformMock, installationMock, directorClientMock)
+
+		//when
+		reconciler.resume(context.Background(), operation)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should check cluster status when Terraform state was saved", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Provision, StartTimestamp: time.Now(), ClusterID: runtimeID}
+		cluster := model.Cluster{ID: runtimeID, Tenant: tenant, CredentialsSecretName: secretName, TerraformState: "state"}
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("GetUnscopedStatus", runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("GetUnscopedClusterData", runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("StartOperationStep", operationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("Update", runtimeID, "kubeconfig", "new state").Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", operationID, lease.Owner).Return(nil)
+		hydroformMock.On("CheckClusterStatus", runtimeConfig, secretName, "state").Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "kubeconfig", State: "new state"}, nil)
+		installationMock.On("InstallKyma", "kubeconfig", runtimeConfig.KymaConfig).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, "kubeconfig", mock.Anything).Return(nil)
+		directorClientMock.On("SetRuntimeStatusCondition", tenant, runtimeID, director.RuntimeStatusConditionReady).Return(nil)
+
+		reconciler := newTestReconciler(persistenceServiceMock, hydroformMock, installationMock, directorClientMock)
+
+		//when
+		reconciler.resume(context.Background(), operation)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should resume Kyma installation when cluster was already saved", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Provision, StartTimestamp: time.Now(), ClusterID: runtimeID}
+		kubeconfig := "kubeconfig"
+		cluster := model.Cluster{ID: runtimeID, Tenant: tenant, CredentialsSecretName: secretName, TerraformState: "state", Kubeconfig: &kubeconfig}
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("GetUnscopedStatus", runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("GetUnscopedClusterData", runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("StartOperationStep", operationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", operationID, lease.Owner).Return(nil)
+		installationMock.On("InstallKyma", kubeconfig, runtimeConfig.KymaConfig).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, kubeconfig, mock.Anything).Return(nil)
+		directorClientMock.On("SetRuntimeStatusCondition", tenant, runtimeID, director.RuntimeStatusConditionReady).Return(nil)
+
+		reconciler := newTestReconciler(persistenceServiceMock, hydroformMock, installationMock, directorClientMock)
+
+		//when
+		reconciler.resume(context.Background(), operation)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should fail operation when cluster is not provisioned", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Provision, StartTimestamp: time.Now(), ClusterID: runtimeID}
+		cluster := model.Cluster{ID: runtimeID, Tenant: tenant, CredentialsSecretName: secretName, TerraformState: "state"}
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("GetUnscopedStatus", runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("GetUnscopedClusterData", runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("SetAsFailed", operationID, lease.Owner, "Cluster is in Errored phase").Return(nil)
+		hydroformMock.On("CheckClusterStatus", runtimeConfig, secretName, "state").Return(hydroform.ClusterInfo{ClusterStatus: types.Errored}, nil)
+		directorClientMock.On("SetRuntimeStatusCondition", tenant, runtimeID, director.RuntimeStatusConditionFailed).Return(nil)
+
+		reconciler := newTestReconciler(persistenceServiceMock, hydroformMock, installationMock, directorClientMock)
+
+		//when
+		reconciler.resume(context.Background(), operation)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should fail upgrade which cannot be resumed", func(t *testing.T) {
+		//given
+		operation := model.Operation{ID: operationID, Type: model.Upgrade, StartTimestamp: time.Now(), ClusterID: runtimeID}
+
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("SetAsFailed", operationID, lease.Owner, mock.MatchedBy(func(message string) bool {
+			return strings.HasPrefix(message, "Upgrade cannot be resumed")
+		})).Return(nil)
+
+		reconciler := newTestReconciler(persistenceServiceMock, hydroformMock, installationMock, directorClientMock)
+
+		//when
+		reconciler.resume(context.Background(), operation)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+}
+
+func newTestReconciler(persistenceService *persistenceMocks.Service, hydroformService *mocks.Service, installationService *installationMocks.Service, directorClient *directorMocks.Client) *operationsReconciler {
+	return NewOperationsReconciler(persistenceService, hydroformService, installationService, nil, directorClient, nil, lease, time.Hour).(*operationsReconciler)
+}
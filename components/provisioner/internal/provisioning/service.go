@@ -1,16 +1,22 @@
 package provisioning
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/hydroform"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hyperscaler"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/installation"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/pagination"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/runtimeagent"
 	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
 	"github.com/kyma-incubator/hydroform/types"
 )
@@ -20,88 +26,230 @@ const (
 	retryCount = 5
 )
 
+// Steps planned for operations. Steps of the cluster operations are reported by the Hydroform service.
+var (
+	provisioningSteps = []model.OperationStepName{
+		model.StepPrepareClusterConfig, model.StepProvisionCluster, model.StepRetrieveKubeconfig,
+		model.StepSaveClusterData, model.StepStartInstallation, model.StepInstallKyma,
+	}
+	installationRetrySteps = []model.OperationStepName{model.StepStartInstallation, model.StepInstallKyma}
+	deprovisioningSteps    = []model.OperationStepName{model.StepPrepareClusterConfig, model.StepDeprovisionCluster}
+	reconnectingSteps      = []model.OperationStepName{model.StepGenerateToken, model.StepConfigureAgent, model.StepWaitForAgentConnection}
+)
+
+func upgradeSteps(upgradeCluster, upgradeKyma bool) []model.OperationStepName {
+	var steps []model.OperationStepName
+
+	if upgradeCluster {
+		steps = append(steps, model.StepPrepareClusterConfig, model.StepUpgradeCluster, model.StepRetrieveKubeconfig, model.StepSaveClusterData)
+	}
+
+	if upgradeKyma {
+		steps = append(steps, model.StepStartKymaUpgrade, model.StepUpgradeKyma, model.StepSaveKymaConfig)
+	}
+
+	return steps
+}
+
 //go:generate mockery -name=Service
 type Service interface {
-	ProvisionRuntime(id string, config gqlschema.ProvisionRuntimeInput) (string, <-chan struct{}, error)
-	UpgradeRuntime(id string, config *gqlschema.UpgradeRuntimeInput) (string, error)
-	DeprovisionRuntime(id string) (string, <-chan struct{}, error)
-	CleanupRuntimeData(id string) (string, error)
-	ReconnectRuntimeAgent(id string) (string, error)
-	RuntimeStatus(id string) (*gqlschema.RuntimeStatus, error)
-	RuntimeOperationStatus(id string) (*gqlschema.OperationStatus, error)
+	ProvisionRuntime(tenant, id string, config gqlschema.ProvisionRuntimeInput) (*gqlschema.OperationStatus, <-chan struct{}, error)
+	UpgradeRuntime(tenant, id string, config gqlschema.UpgradeRuntimeInput) (string, <-chan struct{}, error)
+	DeprovisionRuntime(tenant, id string) (string, <-chan struct{}, error)
+	CleanupRuntimeData(tenant, id string) (string, error)
+	ReconnectRuntimeAgent(tenant, id string) (string, <-chan struct{}, error)
+	RuntimeStatus(tenant, id string) (*gqlschema.RuntimeStatus, error)
+	RuntimeOperationStatus(tenant, id string) (*gqlschema.OperationStatus, error)
+	HyperscalerAccountPools(tenant string, hyperscaler *gqlschema.HyperscalerType, accountName *string) ([]*gqlschema.HyperscalerAccountPoolStatus, error)
+	Runtimes(tenant string, filter *gqlschema.RuntimesFilter, first int, after string) (*gqlschema.RuntimePage, error)
+	Operations(tenant string, runtimeID *string, operationType *gqlschema.OperationType, state *gqlschema.OperationState, since *time.Time, first int, after string) (*gqlschema.OperationPage, error)
 }
 
 type service struct {
 	persistenceService persistence.Service
 	hydroform          hydroform.Service
+	installation       installation.Service
+	accountPool        hyperscaler.AccountPool
 	uuidGenerator      persistence.UUIDGenerator
+	directorClient     director.Client
+	runtimeAgent       runtimeagent.Service
+	lease              model.OperationLease
 }
 
-func NewProvisioningService(persistenceService persistence.Service, uuidGenerator persistence.UUIDGenerator, hydroform hydroform.Service) Service {
+func NewProvisioningService(persistenceService persistence.Service, uuidGenerator persistence.UUIDGenerator, hydroform hydroform.Service,
+	installation installation.Service, accountPool hyperscaler.AccountPool, directorClient director.Client, runtimeAgent runtimeagent.Service,
+	lease model.OperationLease) Service {
 	return &service{
 		persistenceService: persistenceService,
 		hydroform:          hydroform,
+		installation:       installation,
+		accountPool:        accountPool,
 		uuidGenerator:      uuidGenerator,
+		directorClient:     directorClient,
+		runtimeAgent:       runtimeAgent,
+		lease:              lease,
 	}
 }
 
-func (r *service) ProvisionRuntime(id string, config gqlschema.ProvisionRuntimeInput) (string, <-chan struct{}, error) {
-	err := r.checkProvisioningRuntimeConditions(id)
+// ProvisionRuntime registers the runtime in the Director if the ID is empty. Otherwise the runtime is expected to be registered already.
+// The runtime belongs to the tenant of the request, which is the only one allowed to read and manage it afterwards.
+func (r *service) ProvisionRuntime(tenant, id string, config gqlschema.ProvisionRuntimeInput) (*gqlschema.OperationStatus, <-chan struct{}, error) {
+	if config.Credentials.SecretName != nil {
+		err := r.hydroform.CheckCredentials(*config.Credentials.SecretName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot provision runtime since credentials are invalid: %s", err.Error())
+		}
+	}
+
+	if id == "" {
+		return r.registerAndProvision(tenant, config)
+	}
+
+	cluster, err := r.checkProvisioningRuntimeConditions(tenant, id)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
+	}
+
+	if clusterCreated(cluster) {
+		return r.retryInstallation(tenant, id, *config.KymaConfig, *cluster.Kubeconfig)
 	}
 
+	return r.provision(tenant, id, config)
+}
+
+func (r *service) registerAndProvision(tenant string, config gqlschema.ProvisionRuntimeInput) (*gqlschema.OperationStatus, <-chan struct{}, error) {
+	id, err := r.directorClient.CreateRuntime(tenant, directorRuntimeInputFromInput(config))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Infof("Runtime %s registered in Director", id)
+
+	status, finished, err := r.provision(tenant, id, config)
+	if err != nil {
+		r.deleteDirectorRuntime(tenant, id)
+		return nil, nil, err
+	}
+
+	return status, finished, nil
+}
+
+func (r *service) provision(tenant, id string, config gqlschema.ProvisionRuntimeInput) (*gqlschema.OperationStatus, <-chan struct{}, error) {
 	runtimeConfig := runtimeConfigFromInput(id, config, r.uuidGenerator)
 
-	operation, err := r.persistenceService.SetProvisioningStarted(id, runtimeConfig)
+	// Credentials assigned to the runtime before this call are left assigned, as they may still be used by its cluster
+	claimed := false
+	releaseClaimedCredentials := func() {
+		if claimed {
+			r.releaseCredentials(id)
+		}
+	}
+
+	if config.Credentials.HyperscalerAccount != nil {
+		account := config.Credentials.HyperscalerAccount
+
+		secretName, newlyClaimed, err := r.accountPool.AssignCredentials(tenant, hyperscalerTypeFromGraphQLType(account.Hyperscaler), account.AccountName, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		runtimeConfig.CredentialsSecretName = secretName
+		claimed = newlyClaimed
+
+		err = r.hydroform.CheckCredentials(secretName)
+		if err != nil {
+			releaseClaimedCredentials()
+			return nil, nil, fmt.Errorf("cannot provision runtime since credentials assigned from %s account are invalid: %s", account.AccountName, err.Error())
+		}
+	}
+
+	operation, err := r.persistenceService.SetProvisioningStarted(tenant, id, runtimeConfig, provisioningSteps, r.lease)
 
 	if err != nil {
-		return "", nil, err
+		releaseClaimedCredentials()
+		return nil, nil, err
 	}
 
 	finished := make(chan struct{})
 
-	go r.startProvisioning(operation.ID, id, runtimeConfig, runtimeConfig.CredentialsSecretName, finished)
+	go r.executeWithLease(operation.ID, finished, func(ctx context.Context) {
+		r.startProvisioning(ctx, operation.ID, tenant, id, runtimeConfig, runtimeConfig.CredentialsSecretName)
+	})
 
-	return operation.ID, finished, err
+	return operationStatusToGQLOperationStatus(operation), finished, nil
 }
 
-func (r *service) checkProvisioningRuntimeConditions(id string) error {
-	lastOperation, err := r.persistenceService.GetLastOperation(id)
+// checkProvisioningRuntimeConditions returns the cluster left by a failed provisioning if it was created before the failure.
+// Otherwise data of the failed provisioning is removed, so that the runtime can be provisioned from scratch.
+func (r *service) checkProvisioningRuntimeConditions(tenant, id string) (model.Cluster, error) {
+	lastOperation, err := r.persistenceService.GetLastOperation(tenant, id)
 
 	if err == nil && !lastProvisioningFailed(lastOperation) {
-		return errors.New(fmt.Sprintf("cannot provision runtime. Runtime %s already provisioned", id))
+		return model.Cluster{}, errors.New(fmt.Sprintf("cannot provision runtime. Runtime %s already provisioned", id))
 	}
 
 	if err != nil && err.Code() != dberrors.CodeNotFound {
-		return err
+		return model.Cluster{}, err
 	}
 
 	if lastProvisioningFailed(lastOperation) {
-		if _, dbErr := r.CleanupRuntimeData(id); dbErr != nil {
-			return dbErr
+		cluster, dbErr := r.persistenceService.GetClusterData(tenant, id)
+		if dbErr != nil {
+			return model.Cluster{}, dbErr
+		}
+
+		if clusterCreated(cluster) {
+			return cluster, nil
+		}
+
+		if _, dbErr := r.CleanupRuntimeData(tenant, id); dbErr != nil {
+			return model.Cluster{}, dbErr
 		}
 	}
 
-	return nil
+	return model.Cluster{}, nil
 }
 
 func lastProvisioningFailed(operation model.Operation) bool {
 	return operation.Type == model.Provision && operation.State == model.Failed
 }
 
-func (r *service) DeprovisionRuntime(id string) (string, <-chan struct{}, error) {
-	runtimeStatus, err := r.persistenceService.GetStatus(id)
+func clusterCreated(cluster model.Cluster) bool {
+	return cluster.Kubeconfig != nil && *cluster.Kubeconfig != ""
+}
+
+// retryInstallation installs Kyma on the cluster created by the failed provisioning instead of creating a new one
+func (r *service) retryInstallation(tenant, id string, config gqlschema.KymaConfigInput, kubeconfig string) (*gqlschema.OperationStatus, <-chan struct{}, error) {
+	kymaConfig := kymaConfigFromInput(id, config, r.uuidGenerator)
+
+	operation, err := r.persistenceService.SetInstallationRetryStarted(id, kymaConfig, installationRetrySteps, r.lease)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	finished := make(chan struct{})
+
+	go r.executeWithLease(operation.ID, finished, func(ctx context.Context) {
+		r.startInstallation(ctx, operation.ID, tenant, id, kubeconfig, kymaConfig)
+	})
+
+	return operationStatusToGQLOperationStatus(operation), finished, nil
+}
+
+func (r *service) DeprovisionRuntime(tenant, id string) (string, <-chan struct{}, error) {
+	runtimeStatus, err := r.persistenceService.GetStatus(tenant, id)
 
 	if err != nil {
 		return "", nil, err
 	}
 
-	if runtimeStatus.LastOperationStatus.State == model.InProgress {
-		return "", nil, errors.New("cannot start new operation while previous one is in progress")
+	cluster, dberr := r.persistenceService.GetClusterData(tenant, id)
+
+	if dberr != nil {
+		return "", nil, dberr
 	}
 
-	operation, err := r.persistenceService.SetDeprovisioningStarted(id)
+	operation, err := r.persistenceService.SetDeprovisioningStarted(id, deprovisioningSteps, r.lease)
 
 	if err != nil {
 		return "", nil, err
@@ -109,39 +257,116 @@ func (r *service) DeprovisionRuntime(id string) (string, <-chan struct{}, error)
 
 	finished := make(chan struct{})
 
-	cluster, dberr := r.persistenceService.GetClusterData(id)
+	go r.executeWithLease(operation.ID, finished, func(ctx context.Context) {
+		r.startDeprovisioning(ctx, operation.ID, id, runtimeStatus.RuntimeConfiguration, cluster)
+	})
 
-	if dberr != nil {
-		return "", nil, dberr
+	return operation.ID, finished, nil
+}
+
+func (r *service) UpgradeRuntime(tenant, id string, config gqlschema.UpgradeRuntimeInput) (string, <-chan struct{}, error) {
+	runtimeStatus, err := r.persistenceService.GetStatus(tenant, id)
+
+	if err != nil {
+		return "", nil, err
 	}
 
-	go r.startDeprovisioning(operation.ID, id, runtimeStatus.RuntimeConfiguration, cluster, finished)
+	if !runtimeProvisioned(runtimeStatus.LastOperationStatus) {
+		return "", nil, errors.New(fmt.Sprintf("cannot upgrade runtime. Runtime %s is not provisioned", id))
+	}
+
+	validationErr := validateUpgradeInput(runtimeStatus.RuntimeConfiguration, config)
+	if validationErr != nil {
+		return "", nil, validationErr
+	}
+
+	cluster, err := r.persistenceService.GetClusterData(tenant, id)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	upgradeCluster := config.ClusterConfig != nil
+	upgradeKyma := config.KymaConfig != nil
+
+	if upgradeKyma && !upgradeCluster && !clusterCreated(cluster) {
+		return "", nil, errors.New(fmt.Sprintf("cannot upgrade Kyma. Kubeconfig of runtime %s is missing", id))
+	}
+
+	runtimeConfig := upgradedRuntimeConfig(id, runtimeStatus.RuntimeConfiguration, config, r.uuidGenerator)
+	runtimeConfig.CredentialsSecretName = cluster.CredentialsSecretName
+
+	operation, err := r.persistenceService.SetUpgradeStarted(id, upgradeSteps(upgradeCluster, upgradeKyma), r.lease)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	finished := make(chan struct{})
+
+	go r.executeWithLease(operation.ID, finished, func(ctx context.Context) {
+		r.startUpgrade(ctx, operation.ID, id, runtimeConfig, cluster, upgradeCluster, upgradeKyma)
+	})
 
 	return operation.ID, finished, nil
 }
 
-func (r *service) UpgradeRuntime(id string, config *gqlschema.UpgradeRuntimeInput) (string, error) {
-	return "", nil
+func runtimeProvisioned(lastOperation model.Operation) bool {
+	return lastOperation.Type != model.Deprovision && !lastProvisioningFailed(lastOperation)
 }
 
-func (r *service) ReconnectRuntimeAgent(id string) (string, error) {
-	return "", nil
+func (r *service) ReconnectRuntimeAgent(tenant, id string) (string, <-chan struct{}, error) {
+	runtimeStatus, err := r.persistenceService.GetStatus(tenant, id)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	kubeconfig := runtimeStatus.RuntimeConfiguration.Kubeconfig
+	if !runtimeProvisioned(runtimeStatus.LastOperationStatus) || kubeconfig == nil || *kubeconfig == "" {
+		return "", nil, errors.New(fmt.Sprintf("cannot reconnect Runtime Agent. Runtime %s is not provisioned", id))
+	}
+
+	operation, err := r.persistenceService.SetReconnectRuntimeStarted(id, reconnectingSteps, r.lease)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	finished := make(chan struct{})
+
+	go r.executeWithLease(operation.ID, finished, func(ctx context.Context) {
+		r.startReconnecting(ctx, operation.ID, tenant, id, *kubeconfig)
+	})
+
+	return operation.ID, finished, nil
 }
 
-func (r *service) RuntimeStatus(runtimeID string) (*gqlschema.RuntimeStatus, error) {
-	runtimeStatus, err := r.persistenceService.GetStatus(runtimeID)
+func (r *service) RuntimeStatus(tenant, runtimeID string) (*gqlschema.RuntimeStatus, error) {
+	runtimeStatus, err := r.persistenceService.GetStatus(tenant, runtimeID)
 
 	if err != nil {
 		return nil, err
 	}
 
 	status := runtimeStatusToGraphQLStatus(runtimeStatus)
+	status.RuntimeConnectionStatus = r.runtimeConnectionStatus(runtimeStatus.RuntimeConfiguration.Kubeconfig)
 
 	return status, nil
 }
 
-func (r *service) RuntimeOperationStatus(operationID string) (*gqlschema.OperationStatus, error) {
-	operation, err := r.persistenceService.Get(operationID)
+func (r *service) runtimeConnectionStatus(kubeconfig *string) *gqlschema.RuntimeConnectionStatus {
+	if kubeconfig == nil || *kubeconfig == "" {
+		return runtimeConnectionStatusToGraphQLStatus(model.RuntimeAgentConnectionStatusPending, nil)
+	}
+
+	connectionStatus, err := r.runtimeAgent.ConnectionStatus(*kubeconfig)
+
+	return runtimeConnectionStatusToGraphQLStatus(connectionStatus, err)
+}
+
+func (r *service) RuntimeOperationStatus(tenant, operationID string) (*gqlschema.OperationStatus, error) {
+	operation, err := r.persistenceService.Get(tenant, operationID)
 
 	if err != nil {
 		return nil, err
@@ -152,47 +377,378 @@ func (r *service) RuntimeOperationStatus(operationID string) (*gqlschema.Operati
 	return status, nil
 }
 
-func (r *service) CleanupRuntimeData(id string) (string, error) {
-	return id, r.persistenceService.CleanupClusterData(id)
+func (r *service) HyperscalerAccountPools(tenant string, hyperscalerType *gqlschema.HyperscalerType, accountName *string) ([]*gqlschema.HyperscalerAccountPoolStatus, error) {
+	var poolType *hyperscaler.Type
+	if hyperscalerType != nil {
+		converted := hyperscalerTypeFromGraphQLType(*hyperscalerType)
+		poolType = &converted
+	}
+
+	statuses, err := r.accountPool.PoolStatus(tenant, poolType, accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	return accountPoolStatusesToGraphQLStatuses(statuses), nil
 }
 
-func (r *service) startProvisioning(operationID, runtimeID string, config model.RuntimeConfig, secretName string, finished chan<- struct{}) {
-	defer close(finished)
+func (r *service) Runtimes(tenant string, filter *gqlschema.RuntimesFilter, first int, after string) (*gqlschema.RuntimePage, error) {
+	offset, err := pagination.DecodeOffsetCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimes, totalCount, err := r.persistenceService.ListRuntimes(tenant, runtimeFilterFromGraphQLFilter(filter), first, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlschema.RuntimePage{
+		Data:       runtimeSummariesToGraphQLRuntimes(runtimes),
+		PageInfo:   pageInfo(after, offset, first, len(runtimes), totalCount),
+		TotalCount: totalCount,
+	}, nil
+}
+
+func (r *service) Operations(tenant string, runtimeID *string, operationType *gqlschema.OperationType, state *gqlschema.OperationState, since *time.Time, first int, after string) (*gqlschema.OperationPage, error) {
+	offset, err := pagination.DecodeOffsetCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := model.OperationFilter{RuntimeID: runtimeID, Since: since}
+	if operationType != nil {
+		converted := operationTypeFromGraphQLType(*operationType)
+		filter.Type = &converted
+	}
+	if state != nil {
+		converted := operationStateFromGraphQLState(*state)
+		filter.State = &converted
+	}
+
+	operations, totalCount, err := r.persistenceService.ListOperations(tenant, filter, first, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlschema.OperationPage{
+		Data:       operationStatusesToGQLOperationStatuses(operations),
+		PageInfo:   pageInfo(after, offset, first, len(operations), totalCount),
+		TotalCount: totalCount,
+	}, nil
+}
+
+func pageInfo(cursor string, offset, pageSize, count, totalCount int) *gqlschema.PageInfo {
+	endCursor := ""
+	hasNextPage := totalCount > offset+count
+	if hasNextPage {
+		endCursor = pagination.EncodeNextOffsetCursor(offset, pageSize)
+	}
+
+	return &gqlschema.PageInfo{
+		StartCursor: cursor,
+		EndCursor:   endCursor,
+		HasNextPage: hasNextPage,
+	}
+}
+
+func (r *service) CleanupRuntimeData(tenant, id string) (string, error) {
+	_, err := r.persistenceService.GetClusterData(tenant, id)
+	if err != nil {
+		return id, err
+	}
+
+	err = r.persistenceService.CleanupClusterData(id)
+	if err != nil {
+		return id, err
+	}
+
+	return id, r.accountPool.ReleaseCredentials(id)
+}
+
+// setDirectorRuntimeStatus reports the outcome of the operation to the Director. Failures are only logged, as they do not affect the runtime itself.
+func (r *service) setDirectorRuntimeStatus(tenant, runtimeID string, condition director.RuntimeStatusCondition) {
+	err := r.directorClient.SetRuntimeStatusCondition(tenant, runtimeID, condition)
+	if err != nil {
+		log.Errorf("Failed to set %s status of runtime %s in Director: %s", condition, runtimeID, err.Error())
+	}
+}
+
+func (r *service) deleteDirectorRuntime(tenant, runtimeID string) {
+	err := r.directorClient.DeleteRuntime(tenant, runtimeID)
+	if err != nil {
+		log.Errorf("Failed to delete runtime %s from Director: %s", runtimeID, err.Error())
+	}
+}
+
+// releaseCredentials returns credentials assigned from the Hyperscaler Account Pool. Runtimes provisioned with the given Secret name are not affected.
+func (r *service) releaseCredentials(runtimeID string) {
+	err := r.accountPool.ReleaseCredentials(runtimeID)
+	if err != nil {
+		log.Errorf("Failed to release credentials assigned to runtime %s: %s", runtimeID, err.Error())
+	}
+}
+
+func (r *service) startProvisioning(ctx context.Context, operationID, tenant, runtimeID string, config model.RuntimeConfig, secretName string) {
 	log.Infof("Provisioning runtime %s is starting", runtimeID)
-	info, err := r.hydroform.ProvisionCluster(config, secretName)
 
-	if err != nil || info.ClusterStatus != types.Provisioned {
+	info, err := r.hydroform.ProvisionCluster(config, secretName, r.stepReporter(operationID))
+	if leaseLost(ctx, operationID) {
+		return
+	}
+	if err == nil && info.ClusterStatus != types.Provisioned {
+		err = errors.New(fmt.Sprintf("Cluster is in %s phase", info.ClusterStatus))
+	}
+
+	if err != nil {
 		log.Errorf("Provisioning runtime %s failed: %s", runtimeID, err.Error())
-		updateOperationStatus(func() error {
-			return r.persistenceService.SetAsFailed(operationID, err.Error())
-		})
-	} else {
-		log.Infof("Provisioning runtime %s finished successfully", runtimeID)
-		updateOperationStatus(func() error {
-			err := r.persistenceService.Update(runtimeID, info.KubeConfig, info.State)
-			if err != nil {
-				return r.persistenceService.SetAsFailed(operationID, err.Error())
-			}
-			return r.persistenceService.SetAsSucceeded(operationID)
-		})
+		r.setAsFailed(operationID, err.Error())
+		r.setDirectorRuntimeStatus(tenant, runtimeID, director.RuntimeStatusConditionFailed)
+		return
 	}
+
+	r.saveClusterAndInstall(ctx, operationID, tenant, runtimeID, info, config.KymaConfig)
 }
 
-func (r *service) startDeprovisioning(operationID, runtimeID string, config model.RuntimeConfig, cluster model.Cluster, finished chan<- struct{}) {
-	defer close(finished)
+func (r *service) saveClusterAndInstall(ctx context.Context, operationID, tenant, runtimeID string, info hydroform.ClusterInfo, kymaConfig model.KymaConfig) {
+	r.startStep(operationID, model.StepSaveClusterData, "Saving cluster data")
+
+	if leaseLost(ctx, operationID) {
+		return
+	}
+
+	err := r.persistenceService.Update(runtimeID, info.KubeConfig, info.State)
+	if err != nil {
+		log.Errorf("Provisioning runtime %s failed: %s", runtimeID, err.Error())
+		r.setAsFailed(operationID, err.Error())
+		r.setDirectorRuntimeStatus(tenant, runtimeID, director.RuntimeStatusConditionFailed)
+		return
+	}
+
+	r.startInstallation(ctx, operationID, tenant, runtimeID, info.KubeConfig, kymaConfig)
+}
+
+func (r *service) startInstallation(ctx context.Context, operationID, tenant, runtimeID, kubeconfig string, kymaConfig model.KymaConfig) {
+	log.Infof("Installing Kyma %s on runtime %s", kymaConfig.Version, runtimeID)
+
+	err := r.install(ctx, operationID, kubeconfig, kymaConfig)
+	if leaseLost(ctx, operationID) {
+		return
+	}
+	if err != nil {
+		log.Errorf("Installing Kyma on runtime %s failed: %s", runtimeID, err.Error())
+		r.setAsFailed(operationID, fmt.Sprintf("Kyma installation failed: %s", err.Error()))
+		r.setDirectorRuntimeStatus(tenant, runtimeID, director.RuntimeStatusConditionFailed)
+		return
+	}
+
+	log.Infof("Provisioning runtime %s finished successfully", runtimeID)
+	r.setAsSucceeded(operationID)
+	r.setDirectorRuntimeStatus(tenant, runtimeID, director.RuntimeStatusConditionReady)
+}
+
+func (r *service) install(ctx context.Context, operationID, kubeconfig string, kymaConfig model.KymaConfig) error {
+	r.startStep(operationID, model.StepStartInstallation, fmt.Sprintf("Starting Kyma %s installation", kymaConfig.Version))
+
+	err := r.installation.InstallKyma(kubeconfig, kymaConfig)
+	if err != nil {
+		return err
+	}
+
+	r.startStep(operationID, model.StepInstallKyma, "Installing Kyma")
+
+	return r.installation.WaitForInstallation(ctx, kubeconfig, func(description string) {
+		r.setProgress(operationID, fmt.Sprintf("Installing Kyma: %s", description))
+	})
+}
+
+func (r *service) startDeprovisioning(ctx context.Context, operationID, runtimeID string, config model.RuntimeConfig, cluster model.Cluster) {
 	log.Infof("Deprovisioning runtime %s is starting", runtimeID)
-	err := r.hydroform.DeprovisionCluster(config, cluster.CredentialsSecretName, cluster.TerraformState)
+	err := r.hydroform.DeprovisionCluster(config, cluster.CredentialsSecretName, cluster.TerraformState, r.stepReporter(operationID))
+
+	if leaseLost(ctx, operationID) {
+		return
+	}
 
 	if err != nil {
 		log.Errorf("Deprovisioning runtime %s failed: %s", runtimeID, err.Error())
-		updateOperationStatus(func() error {
-			return r.persistenceService.SetAsFailed(operationID, err.Error())
-		})
+		r.setAsFailed(operationID, err.Error())
+		r.setDirectorRuntimeStatus(cluster.Tenant, runtimeID, director.RuntimeStatusConditionFailed)
 	} else {
+		r.releaseCredentials(runtimeID)
+		r.deleteDirectorRuntime(cluster.Tenant, runtimeID)
+
 		log.Infof("Deprovisioning runtime %s finished successfully", runtimeID)
-		updateOperationStatus(func() error {
-			return r.persistenceService.SetAsSucceeded(operationID)
-		})
+		r.setAsSucceeded(operationID)
+	}
+}
+
+func (r *service) startUpgrade(ctx context.Context, operationID, runtimeID string, config model.RuntimeConfig, cluster model.Cluster, upgradeCluster, upgradeKyma bool) {
+	log.Infof("Upgrading runtime %s is starting", runtimeID)
+
+	var kubeconfig string
+	if cluster.Kubeconfig != nil {
+		kubeconfig = *cluster.Kubeconfig
+	}
+
+	if upgradeCluster {
+		info, err := r.hydroform.UpgradeCluster(config, cluster.CredentialsSecretName, cluster.TerraformState, r.stepReporter(operationID))
+		if leaseLost(ctx, operationID) {
+			return
+		}
+		if err != nil {
+			log.Errorf("Upgrading runtime %s failed: %s", runtimeID, err.Error())
+			r.setAsFailed(operationID, err.Error())
+			return
+		}
+
+		r.startStep(operationID, model.StepSaveClusterData, "Saving cluster data")
+
+		// The Terraform state and the Kubernetes version are saved even if the cluster did not reach the desired phase, as they reflect the applied changes
+		err = r.persistenceService.UpdateUpgradedCluster(runtimeID, info.KubeConfig, info.State, config)
+		if err != nil {
+			log.Errorf("Upgrading runtime %s failed: %s", runtimeID, err.Error())
+			r.setAsFailed(operationID, err.Error())
+			return
+		}
+
+		if info.ClusterStatus != types.Provisioned {
+			log.Errorf("Upgrading runtime %s failed: cluster is in %s phase", runtimeID, info.ClusterStatus)
+			r.setAsFailed(operationID, fmt.Sprintf("Cluster is in %s phase", info.ClusterStatus))
+			return
+		}
+
+		kubeconfig = info.KubeConfig
+	}
+
+	if upgradeKyma {
+		err := r.upgradeKyma(ctx, operationID, kubeconfig, config.KymaConfig)
+		if leaseLost(ctx, operationID) {
+			return
+		}
+		if err != nil {
+			log.Errorf("Upgrading Kyma on runtime %s failed: %s", runtimeID, err.Error())
+			r.setAsFailed(operationID, fmt.Sprintf("Kyma upgrade failed: %s", err.Error()))
+			return
+		}
+
+		r.startStep(operationID, model.StepSaveKymaConfig, "Saving Kyma configuration")
+
+		err = r.persistenceService.UpdateKymaConfig(runtimeID, config.KymaConfig)
+		if err != nil {
+			log.Errorf("Upgrading runtime %s failed: %s", runtimeID, err.Error())
+			r.setAsFailed(operationID, err.Error())
+			return
+		}
+	}
+
+	if leaseLost(ctx, operationID) {
+		return
+	}
+
+	log.Infof("Upgrading runtime %s finished successfully", runtimeID)
+	r.setAsSucceeded(operationID)
+}
+
+// upgradeKyma applies the installer of the requested Kyma version over the installed one, which makes the installer upgrade Kyma
+func (r *service) upgradeKyma(ctx context.Context, operationID, kubeconfig string, kymaConfig model.KymaConfig) error {
+	r.startStep(operationID, model.StepStartKymaUpgrade, fmt.Sprintf("Starting Kyma %s upgrade", kymaConfig.Version))
+
+	err := r.installation.InstallKyma(kubeconfig, kymaConfig)
+	if err != nil {
+		return err
+	}
+
+	r.startStep(operationID, model.StepUpgradeKyma, "Upgrading Kyma")
+
+	return r.installation.WaitForInstallation(ctx, kubeconfig, func(description string) {
+		r.setProgress(operationID, fmt.Sprintf("Upgrading Kyma: %s", description))
+	})
+}
+
+func (r *service) startReconnecting(ctx context.Context, operationID, tenant, runtimeID, kubeconfig string) {
+	log.Infof("Reconnecting Runtime Agent on runtime %s is starting", runtimeID)
+
+	err := r.reconnect(ctx, operationID, tenant, runtimeID, kubeconfig)
+	if leaseLost(ctx, operationID) {
+		return
+	}
+	if err != nil {
+		log.Errorf("Reconnecting Runtime Agent on runtime %s failed: %s", runtimeID, err.Error())
+		r.setAsFailed(operationID, err.Error())
+		return
+	}
+
+	log.Infof("Reconnecting Runtime Agent on runtime %s finished successfully", runtimeID)
+	r.setAsSucceeded(operationID)
+}
+
+func (r *service) reconnect(ctx context.Context, operationID, tenant, runtimeID, kubeconfig string) error {
+	r.startStep(operationID, model.StepGenerateToken, "Generating one-time token")
+
+	token, err := r.directorClient.GetConnectionToken(tenant, runtimeID)
+	if err != nil {
+		return err
+	}
+
+	if leaseLost(ctx, operationID) {
+		return ctx.Err()
+	}
+
+	r.startStep(operationID, model.StepConfigureAgent, "Configuring Runtime Agent")
+
+	err = r.runtimeAgent.ConfigureAgent(kubeconfig, runtimeagent.Configuration{
+		RuntimeID:    runtimeID,
+		Tenant:       tenant,
+		ConnectorURL: token.ConnectorURL,
+		Token:        token.Token,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The agent keeps its established connection regardless of the configuration until the Compass Connection is removed
+	err = r.runtimeAgent.ResetConnection(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	r.startStep(operationID, model.StepWaitForAgentConnection, "Waiting for Runtime Agent to connect")
+
+	return r.runtimeAgent.WaitForConnection(ctx, kubeconfig)
+}
+
+// setAsFailed finishes the operation, unless its lease was lost in the meantime
+func (r *service) setAsFailed(operationID, message string) {
+	updateOperationStatus(func() error {
+		return r.persistenceService.SetAsFailed(operationID, r.lease.Owner, message)
+	})
+}
+
+func (r *service) setAsSucceeded(operationID string) {
+	updateOperationStatus(func() error {
+		return r.persistenceService.SetAsSucceeded(operationID, r.lease.Owner)
+	})
+}
+
+func (r *service) setProgress(operationID, message string) {
+	err := r.persistenceService.SetAsInProgress(operationID, message)
+	if err != nil {
+		log.Warnf("Failed to update progress of operation %s: %s", operationID, err.Error())
+	}
+}
+
+// startStep finishes the current step of the operation and starts the next one. Failures are only logged, as steps are informative.
+func (r *service) startStep(operationID string, step model.OperationStepName, message string) {
+	err := r.persistenceService.StartOperationStep(operationID, step, message)
+	if err != nil {
+		log.Warnf("Failed to start step %s of operation %s: %s", step, operationID, err.Error())
+	}
+}
+
+func (r *service) stepReporter(operationID string) hydroform.StepReporter {
+	return func(step model.OperationStepName, message string) {
+		r.startStep(operationID, step, message)
 	}
 }
 
@@ -210,6 +766,9 @@ func retry(interval time.Duration, count int, operation func() error) error {
 		if err == nil {
 			return nil
 		}
+		if dbErr, ok := err.(dberrors.Error); ok && dbErr.Code() == dberrors.CodeLeaseLost {
+			return err
+		}
 		log.Errorf("Error during updating operation status: %s", err.Error())
 		time.Sleep(interval)
 	}
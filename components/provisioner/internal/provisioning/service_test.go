@@ -1,14 +1,24 @@
 package provisioning
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/kyma-incubator/compass/components/provisioner/internal/director"
+	directorMocks "github.com/kyma-incubator/compass/components/provisioner/internal/director/mocks"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/hydroform"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/hyperscaler"
 
 	"github.com/kyma-incubator/compass/components/provisioner/internal/hydroform/mocks"
+	hyperscalerMocks "github.com/kyma-incubator/compass/components/provisioner/internal/hyperscaler/mocks"
+	installationMocks "github.com/kyma-incubator/compass/components/provisioner/internal/installation/mocks"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/pagination"
 	"github.com/kyma-incubator/compass/components/provisioner/internal/persistence/dberrors"
 	persistenceMocks "github.com/kyma-incubator/compass/components/provisioner/internal/persistence/mocks"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/runtimeagent"
+	runtimeAgentMocks "github.com/kyma-incubator/compass/components/provisioner/internal/runtimeagent/mocks"
 	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
 	"github.com/kyma-incubator/hydroform/types"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +26,13 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var lease = model.OperationLease{Owner: "provisioner-0", Duration: time.Minute}
+
+const (
+	tenant        = "tenant"
+	requestTenant = "request-tenant"
+)
+
 func TestService_ProvisionRuntime(t *testing.T) {
 	hydroformMock := &mocks.Service{}
 	persistenceServiceMock := &persistenceMocks.Service{}
@@ -39,72 +56,414 @@ func TestService_ProvisionRuntime(t *testing.T) {
 		Modules: gqlschema.AllKymaModule,
 	}
 
-	t.Run("Should start runtime provisioning and return operation ID", func(t *testing.T) {
+	t.Run("Should provision cluster, install Kyma and return operation ID", func(t *testing.T) {
 		//given
 		runtimeID := "184ccdf2-59e4-44b7-b553-6cb296af5ea0"
 		expOperationID := "223949ed-e6b6-4ab2-ab3e-8e19cd456dd40"
 		operation := model.Operation{ID: expOperationID}
+		installationMock := &installationMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
 
 		uuidGenerator.On("New").Return("id", nil)
 
-		persistenceServiceMock.On("GetLastOperation", runtimeID).Return(model.Operation{}, dberrors.NotFound("Not found"))
-		persistenceServiceMock.On("SetProvisioningStarted", runtimeID, mock.Anything).Return(operation, nil)
-		persistenceServiceMock.On("Update", runtimeID, "", "").Return(nil)
-		persistenceServiceMock.On("SetAsSucceeded", expOperationID).Return(nil)
-		hydroformMock.On("ProvisionCluster", mock.Anything, mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "", State: ""}, nil)
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{}, dberrors.NotFound("Not found"))
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.Anything, provisioningSteps, lease).Return(operation, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, model.StepProvisionCluster, "Provisioning cluster").Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, model.StepSaveClusterData, "Saving cluster data").Return(nil)
+		persistenceServiceMock.On("Update", runtimeID, "kubeconfig", "state").Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, model.StepStartInstallation, "Starting Kyma 1.5 installation").Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, model.StepInstallKyma, "Installing Kyma").Return(nil)
+		persistenceServiceMock.On("SetAsInProgress", expOperationID, "Installing Kyma: Installing component core").Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", expOperationID, lease.Owner).Return(nil)
+		directorClientMock.On("SetRuntimeStatusCondition", requestTenant, runtimeID, director.RuntimeStatusConditionReady).Return(nil)
+		hydroformMock.On("ProvisionCluster", mock.Anything, mock.Anything, mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "kubeconfig", State: "state"}, nil).Run(func(args mock.Arguments) {
+			args.Get(2).(hydroform.StepReporter)(model.StepProvisionCluster, "Provisioning cluster")
+		})
+		installationMock.On("InstallKyma", "kubeconfig", mock.MatchedBy(kymaVersion("1.5"))).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, "kubeconfig", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(2).(func(string))("Installing component core")
+		})
 
-		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock)
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, installationMock, nil, directorClientMock, nil, lease)
 
 		//when
-		operationID, finished, err := service.ProvisionRuntime(runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
+		status, finished, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
 		require.NoError(t, err)
 
 		waitUntilFinished(finished)
 
 		//then
-		assert.Equal(t, expOperationID, operationID)
+		assert.Equal(t, expOperationID, *status.ID)
+		directorClientMock.AssertExpectations(t)
 		hydroformMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
 		persistenceServiceMock.AssertExpectations(t)
 		uuidGenerator.AssertExpectations(t)
 	})
 
-	t.Run("Should start runtime provisioning and return operation ID when previous provisioning failed", func(t *testing.T) {
+	t.Run("Should fail operation and keep cluster when Kyma installation failed", func(t *testing.T) {
 		//given
 		runtimeID := "184ccdf2-59e4-44b7-b553-6cb296af5ea0"
 		expOperationID := "223949ed-e6b6-4ab2-ab3e-8e19cd456dd40"
 		operation := model.Operation{ID: expOperationID}
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
 
-		persistenceServiceMock.On("GetLastOperation", runtimeID).Return(model.Operation{Type: model.Provision, State: model.Failed}, nil)
-		persistenceServiceMock.On("SetProvisioningStarted", runtimeID, mock.Anything).Return(operation, nil)
-		persistenceServiceMock.On("Update", runtimeID, "", "").Return(nil)
-		persistenceServiceMock.On("SetAsSucceeded", expOperationID).Return(nil)
-		hydroformMock.On("ProvisionCluster", mock.Anything, mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "", State: ""}, nil)
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{}, dberrors.NotFound("Not found"))
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.Anything, provisioningSteps, lease).Return(operation, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("Update", runtimeID, "kubeconfig", "state").Return(nil)
+		persistenceServiceMock.On("SetAsFailed", expOperationID, lease.Owner, "Kyma installation failed: some error").Return(nil)
+		hydroformMock.On("ProvisionCluster", mock.Anything, mock.Anything, mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "kubeconfig", State: "state"}, nil)
+		installationMock.On("InstallKyma", "kubeconfig", mock.Anything).Return(errors.New("some error"))
+		directorClientMock.On("SetRuntimeStatusCondition", requestTenant, runtimeID, director.RuntimeStatusConditionFailed).Return(nil)
 
-		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock)
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, installationMock, nil, directorClientMock, nil, lease)
 
 		//when
-		operationID, finished, err := service.ProvisionRuntime(runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
+		_, finished, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
 		require.NoError(t, err)
 
 		waitUntilFinished(finished)
 
 		//then
-		assert.Equal(t, expOperationID, operationID)
 		hydroformMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
 		persistenceServiceMock.AssertExpectations(t)
-		uuidGenerator.AssertExpectations(t)
+	})
+
+	t.Run("Should start runtime provisioning from scratch when previous provisioning failed before creating cluster", func(t *testing.T) {
+		//given
+		runtimeID := "184ccdf2-59e4-44b7-b553-6cb296af5ea0"
+		expOperationID := "223949ed-e6b6-4ab2-ab3e-8e19cd456dd40"
+		operation := model.Operation{ID: expOperationID}
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{Type: model.Provision, State: model.Failed}, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(model.Cluster{ID: runtimeID}, nil)
+		persistenceServiceMock.On("CleanupClusterData", runtimeID).Return(nil)
+		accountPoolMock.On("ReleaseCredentials", runtimeID).Return(nil)
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.Anything, provisioningSteps, lease).Return(operation, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("Update", runtimeID, "kubeconfig", "state").Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", expOperationID, lease.Owner).Return(nil)
+		directorClientMock.On("SetRuntimeStatusCondition", requestTenant, runtimeID, director.RuntimeStatusConditionReady).Return(nil)
+		hydroformMock.On("ProvisionCluster", mock.Anything, mock.Anything, mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "kubeconfig", State: "state"}, nil)
+		installationMock.On("InstallKyma", "kubeconfig", mock.Anything).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, "kubeconfig", mock.Anything).Return(nil)
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, installationMock, accountPoolMock, directorClientMock, nil, lease)
+
+		//when
+		status, finished, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		assert.Equal(t, expOperationID, *status.ID)
+		directorClientMock.AssertExpectations(t)
+		hydroformMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		accountPoolMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should provision cluster with credentials assigned from Hyperscaler Account Pool", func(t *testing.T) {
+		//given
+		runtimeID := "5d4a4a0a-7b3c-4f3b-9d57-ea6c1f4e3a1e"
+		expOperationID := "0a4a1d0c-2a7e-4f4b-8d3c-7c1e6a9b5f2d"
+		operation := model.Operation{ID: expOperationID}
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+		directorClientMock := &directorMocks.Client{}
+
+		credentials := &gqlschema.CredentialsInput{
+			HyperscalerAccount: &gqlschema.HyperscalerAccountInput{Hyperscaler: gqlschema.HyperscalerTypeGcp, AccountName: "account"},
+		}
+
+		accountPoolMock.On("AssignCredentials", requestTenant, hyperscaler.GCP, "account", runtimeID).Return("gcp-credentials", true, nil)
+		hydroformMock.On("CheckCredentials", "gcp-credentials").Return(nil)
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{}, dberrors.NotFound("Not found"))
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.MatchedBy(func(config model.RuntimeConfig) bool {
+			return config.CredentialsSecretName == "gcp-credentials"
+		}), provisioningSteps, lease).Return(operation, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("Update", runtimeID, "kubeconfig", "state").Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", expOperationID, lease.Owner).Return(nil)
+		directorClientMock.On("SetRuntimeStatusCondition", requestTenant, runtimeID, director.RuntimeStatusConditionReady).Return(nil)
+		hydroformMock.On("ProvisionCluster", mock.Anything, "gcp-credentials", mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "kubeconfig", State: "state"}, nil)
+		installationMock.On("InstallKyma", "kubeconfig", mock.Anything).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, "kubeconfig", mock.Anything).Return(nil)
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, installationMock, accountPoolMock, directorClientMock, nil, lease)
+
+		//when
+		status, finished, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		assert.Equal(t, expOperationID, *status.ID)
+		directorClientMock.AssertExpectations(t)
+		hydroformMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		accountPoolMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should release assigned credentials when failed to start provisioning", func(t *testing.T) {
+		//given
+		runtimeID := "5d4a4a0a-7b3c-4f3b-9d57-ea6c1f4e3a1e"
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+
+		credentials := &gqlschema.CredentialsInput{
+			HyperscalerAccount: &gqlschema.HyperscalerAccountInput{Hyperscaler: gqlschema.HyperscalerTypeAzure, AccountName: "account"},
+		}
+
+		accountPoolMock.On("AssignCredentials", requestTenant, hyperscaler.Azure, "account", runtimeID).Return("azure-credentials", true, nil)
+		accountPoolMock.On("ReleaseCredentials", runtimeID).Return(nil)
+		hydroformMock.On("CheckCredentials", "azure-credentials").Return(nil)
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{}, dberrors.NotFound("Not found"))
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.Anything, provisioningSteps, lease).Return(model.Operation{}, dberrors.Internal("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, accountPoolMock, nil, nil, lease)
+
+		//when
+		_, _, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig})
+
+		//then
+		require.Error(t, err)
+		accountPoolMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should release assigned credentials when assigned secret has no credentials", func(t *testing.T) {
+		//given
+		runtimeID := "5d4a4a0a-7b3c-4f3b-9d57-ea6c1f4e3a1e"
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+
+		credentials := &gqlschema.CredentialsInput{
+			HyperscalerAccount: &gqlschema.HyperscalerAccountInput{Hyperscaler: gqlschema.HyperscalerTypeAws, AccountName: "account"},
+		}
+
+		accountPoolMock.On("AssignCredentials", requestTenant, hyperscaler.AWS, "account", runtimeID).Return("aws-credentials", true, nil)
+		accountPoolMock.On("ReleaseCredentials", runtimeID).Return(nil)
+		hydroformMock.On("CheckCredentials", "aws-credentials").Return(errors.New("Credentials not found within the aws-credentials secret"))
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{}, dberrors.NotFound("Not found"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, accountPoolMock, nil, nil, lease)
+
+		//when
+		_, _, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig})
+
+		//then
+		require.Error(t, err)
+		accountPoolMock.AssertExpectations(t)
+		persistenceServiceMock.AssertNotCalled(t, "SetProvisioningStarted", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should keep credentials assigned before when failed to start provisioning", func(t *testing.T) {
+		//given
+		runtimeID := "5d4a4a0a-7b3c-4f3b-9d57-ea6c1f4e3a1e"
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+
+		credentials := &gqlschema.CredentialsInput{
+			HyperscalerAccount: &gqlschema.HyperscalerAccountInput{Hyperscaler: gqlschema.HyperscalerTypeAzure, AccountName: "account"},
+		}
+
+		accountPoolMock.On("AssignCredentials", requestTenant, hyperscaler.Azure, "account", runtimeID).Return("azure-credentials", false, nil)
+		hydroformMock.On("CheckCredentials", "azure-credentials").Return(nil)
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{}, dberrors.NotFound("Not found"))
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.Anything, provisioningSteps, lease).Return(model.Operation{}, dberrors.Internal("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, accountPoolMock, nil, nil, lease)
+
+		//when
+		_, _, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: credentials, KymaConfig: kymaConfig})
+
+		//then
+		require.Error(t, err)
+		accountPoolMock.AssertExpectations(t)
+		accountPoolMock.AssertNotCalled(t, "ReleaseCredentials", runtimeID)
+	})
+
+	t.Run("Should not provision runtime when credentials secret is invalid", func(t *testing.T) {
+		//given
+		secretName := "missing-secret"
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		hydroformMock.On("CheckCredentials", secretName).Return(errors.New("Failed to get credentials from missing-secret secret"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, directorClientMock, nil, lease)
+
+		//when
+		_, _, err := service.ProvisionRuntime(requestTenant, "", gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{SecretName: &secretName}, KymaConfig: kymaConfig})
+
+		//then
+		require.Error(t, err)
+		hydroformMock.AssertExpectations(t)
+		directorClientMock.AssertNotCalled(t, "CreateRuntime", mock.Anything, mock.Anything)
+		persistenceServiceMock.AssertNotCalled(t, "SetProvisioningStarted", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should register runtime in Director and provision it with assigned ID when ID is not provided", func(t *testing.T) {
+		//given
+		runtimeID := "1f1a5c0e-52d4-4d0f-9d4f-3c1b5b8e7a2d"
+		expOperationID := "8b2a1d7c-5b0f-4a8e-9c6d-2e3f4a5b6c7d"
+		operation := model.Operation{ID: expOperationID, ClusterID: runtimeID}
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		directorClientMock.On("CreateRuntime", requestTenant, mock.MatchedBy(func(input director.RuntimeInput) bool {
+			return input.Name == "Something" && input.Labels["provider"] == "gcp" && input.Labels["region"] == "region"
+		})).Return(runtimeID, nil)
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.Anything, provisioningSteps, lease).Return(operation, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("SetAsFailed", expOperationID, lease.Owner, "error").Return(nil)
+		hydroformMock.On("ProvisionCluster", mock.Anything, mock.Anything, mock.Anything).Return(hydroform.ClusterInfo{}, errors.New("error"))
+		directorClientMock.On("SetRuntimeStatusCondition", requestTenant, runtimeID, director.RuntimeStatusConditionFailed).Return(nil)
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, directorClientMock, nil, lease)
+
+		//when
+		status, finished, err := service.ProvisionRuntime(requestTenant, "", gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		assert.Equal(t, expOperationID, *status.ID)
+		assert.Equal(t, runtimeID, *status.RuntimeID)
+		directorClientMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should delete runtime from Director when failed to start provisioning", func(t *testing.T) {
+		//given
+		runtimeID := "3c9e2b4a-6d1f-4e8a-b7c5-0f9d8e7c6b5a"
+		persistenceServiceMock := &persistenceMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+
+		directorClientMock.On("CreateRuntime", requestTenant, mock.Anything).Return(runtimeID, nil)
+		directorClientMock.On("DeleteRuntime", requestTenant, runtimeID).Return(nil)
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.Anything, provisioningSteps, lease).Return(model.Operation{}, dberrors.Internal("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, nil, nil, accountPoolMock, directorClientMock, nil, lease)
+
+		//when
+		_, _, err := service.ProvisionRuntime(requestTenant, "", gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
+
+		//then
+		require.Error(t, err)
+		directorClientMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should retry Kyma installation on existing cluster when previous provisioning failed after creating cluster", func(t *testing.T) {
+		//given
+		runtimeID := "184ccdf2-59e4-44b7-b553-6cb296af5ea0"
+		expOperationID := "223949ed-e6b6-4ab2-ab3e-8e19cd456dd40"
+		operation := model.Operation{ID: expOperationID}
+		kubeconfig := "kubeconfig"
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{Type: model.Provision, State: model.Failed}, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(model.Cluster{ID: runtimeID, Kubeconfig: &kubeconfig}, nil)
+		persistenceServiceMock.On("SetInstallationRetryStarted", runtimeID, mock.MatchedBy(kymaVersion("1.5")), installationRetrySteps, lease).Return(operation, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", expOperationID, lease.Owner).Return(nil)
+		directorClientMock.On("SetRuntimeStatusCondition", requestTenant, runtimeID, director.RuntimeStatusConditionReady).Return(nil)
+		installationMock.On("InstallKyma", kubeconfig, mock.MatchedBy(kymaVersion("1.5"))).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, kubeconfig, mock.Anything).Return(nil)
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, installationMock, nil, directorClientMock, nil, lease)
+
+		//when
+		status, finished, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		assert.Equal(t, expOperationID, *status.ID)
+		hydroformMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should stop provisioning without finishing operation when lease is lost", func(t *testing.T) {
+		//given
+		runtimeID := "6a3ca4e4-c8c8-4f5c-8e37-0c0ba2e1d1f2"
+		expOperationID := "0b3e1cd1-4f4c-4c43-9b4e-6a47d3d8f0e5"
+		operation := model.Operation{ID: expOperationID}
+		shortLease := model.OperationLease{Owner: lease.Owner, Duration: 30 * time.Millisecond}
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{}, dberrors.NotFound("Not found"))
+		persistenceServiceMock.On("SetProvisioningStarted", requestTenant, runtimeID, mock.Anything, provisioningSteps, shortLease).Return(operation, nil)
+		persistenceServiceMock.On("RenewLease", expOperationID, shortLease.Owner, shortLease.Duration).Return(false, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, shortLease.Owner).Return(nil)
+		hydroformMock.On("ProvisionCluster", mock.Anything, mock.Anything, mock.Anything).Return(hydroform.ClusterInfo{}, errors.New("error")).Run(func(args mock.Arguments) {
+			time.Sleep(200 * time.Millisecond)
+		})
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, directorClientMock, nil, shortLease)
+
+		//when
+		status, finished, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		assert.Equal(t, expOperationID, *status.ID)
+		hydroformMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+		persistenceServiceMock.AssertNotCalled(t, "SetAsFailed", expOperationID, mock.Anything, mock.Anything)
+		directorClientMock.AssertNotCalled(t, "SetRuntimeStatusCondition", mock.Anything, runtimeID, mock.Anything)
 	})
 
 	t.Run("Should return error when cluster is already provisioned", func(t *testing.T) {
 		//given
 		runtimeID := "0ad91f16-d553-413f-aa27-4eefd9e5f1c6"
-		persistenceServiceMock.On("GetLastOperation", runtimeID).Return(model.Operation{}, nil)
+		persistenceServiceMock.On("GetLastOperation", requestTenant, runtimeID).Return(model.Operation{}, nil)
 		uuidGenerator := &persistenceMocks.UUIDGenerator{}
 
-		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock)
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, nil, nil, lease)
 
 		//when
-		_, _, err := service.ProvisionRuntime(runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
+		_, _, err := service.ProvisionRuntime(requestTenant, runtimeID, gqlschema.ProvisionRuntimeInput{ClusterConfig: clusterConfig, Credentials: &gqlschema.CredentialsInput{}, KymaConfig: kymaConfig})
 
 		//then
 		require.Error(t, err)
@@ -113,6 +472,12 @@ func TestService_ProvisionRuntime(t *testing.T) {
 	})
 }
 
+func kymaVersion(version string) func(model.KymaConfig) bool {
+	return func(kymaConfig model.KymaConfig) bool {
+		return kymaConfig.Version == version
+	}
+}
+
 func TestService_DeprovisionRuntime(t *testing.T) {
 	persistenceServiceMock := &persistenceMocks.Service{}
 	hydroformMock := &mocks.Service{}
@@ -130,17 +495,22 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		runtimeID := "92a1c394-639a-424e-8578-ba1ca7501dc1"
 		expOperationID := "c7241d2d-5ffd-434b-9a52-17ce9ee04578"
 		operation := model.Operation{ID: expOperationID}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+		directorClientMock := &directorMocks.Client{}
 
-		persistenceServiceMock.On("GetStatus", runtimeID).Return(runtimeStatus, nil)
-		persistenceServiceMock.On("SetDeprovisioningStarted", runtimeID, mock.Anything).Return(operation, nil)
-		persistenceServiceMock.On("GetClusterData", runtimeID).Return(model.Cluster{TerraformState: "{}"}, nil)
-		persistenceServiceMock.On("SetAsSucceeded", expOperationID).Return(nil)
-		hydroformMock.On("DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("SetDeprovisioningStarted", runtimeID, deprovisioningSteps, lease).Return(operation, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(model.Cluster{Tenant: requestTenant, TerraformState: "{}"}, nil)
+		persistenceServiceMock.On("SetAsSucceeded", expOperationID, lease.Owner).Return(nil)
+		hydroformMock.On("DeprovisionCluster", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		accountPoolMock.On("ReleaseCredentials", runtimeID).Return(nil)
+		directorClientMock.On("DeleteRuntime", requestTenant, runtimeID).Return(nil)
 
-		resolver := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock)
+		resolver := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, accountPoolMock, directorClientMock, nil, lease)
 
 		//when
-		opt, finished, err := resolver.DeprovisionRuntime(runtimeID)
+		opt, finished, err := resolver.DeprovisionRuntime(requestTenant, runtimeID)
 		require.NoError(t, err)
 
 		waitUntilFinished(finished)
@@ -148,6 +518,8 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		//then
 		assert.Equal(t, expOperationID, opt)
 		hydroformMock.AssertExpectations(t)
+		accountPoolMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
 		persistenceServiceMock.AssertExpectations(t)
 		uuidGenerator.AssertExpectations(t)
 	})
@@ -159,12 +531,14 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		lastOperation := model.Operation{State: model.InProgress}
 		runtimeStatus := model.RuntimeStatus{LastOperationStatus: lastOperation}
 
-		persistenceServiceMock.On("GetStatus", runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(model.Cluster{Tenant: requestTenant, TerraformState: "{}"}, nil)
+		persistenceServiceMock.On("SetDeprovisioningStarted", runtimeID, deprovisioningSteps, lease).Return(model.Operation{}, dberrors.InProgress("in progress"))
 
-		resolver := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock)
+		resolver := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, nil, nil, lease)
 
 		//when
-		_, _, err := resolver.DeprovisionRuntime(runtimeID)
+		_, _, err := resolver.DeprovisionRuntime(requestTenant, runtimeID)
 
 		//then
 		require.Error(t, err)
@@ -172,6 +546,504 @@ func TestService_DeprovisionRuntime(t *testing.T) {
 		persistenceServiceMock.AssertExpectations(t)
 		uuidGenerator.AssertExpectations(t)
 	})
+
+	t.Run("Should not start deprovisioning when failed to get cluster data", func(t *testing.T) {
+		//given
+		runtimeID := "a24142da-1111-4ec2-93e3-e47ccaa6973f"
+		persistenceServiceMock := &persistenceMocks.Service{}
+		runtimeStatus := model.RuntimeStatus{LastOperationStatus: model.Operation{State: model.Succeeded}}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(runtimeStatus, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(model.Cluster{}, dberrors.Internal("error"))
+
+		resolver := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, nil, nil, lease)
+
+		//when
+		_, _, err := resolver.DeprovisionRuntime(requestTenant, runtimeID)
+
+		//then
+		require.Error(t, err)
+		persistenceServiceMock.AssertExpectations(t)
+		persistenceServiceMock.AssertNotCalled(t, "SetDeprovisioningStarted", runtimeID, deprovisioningSteps, lease)
+	})
+}
+
+func TestService_UpgradeRuntime(t *testing.T) {
+	runtimeID := "92a1c394-639a-424e-8578-ba1ca7501dc1"
+	expOperationID := "c7241d2d-5ffd-434b-9a52-17ce9ee04578"
+	secretName := "secret"
+
+	runtimeConfig := model.RuntimeConfig{
+		ClusterConfig: model.GCPConfig{KubernetesVersion: "1.14.6"},
+		KymaConfig: model.KymaConfig{
+			Version: "1.6",
+			Modules: []model.KymaConfigModule{{Module: model.KymaModule(gqlschema.KymaModuleBackup)}},
+		},
+	}
+	provisioned := model.RuntimeStatus{LastOperationStatus: model.Operation{Type: model.Provision, State: model.Succeeded}, RuntimeConfiguration: runtimeConfig}
+	kubeconfig := "kubeconfig"
+	cluster := model.Cluster{ID: runtimeID, CredentialsSecretName: secretName, TerraformState: "state", Kubeconfig: &kubeconfig}
+
+	upgradedConfig := func(config model.RuntimeConfig) bool {
+		gcpConfig, ok := config.GCPConfig()
+		return ok && gcpConfig.KubernetesVersion == "1.15.4" && config.KymaConfig.Version == "1.7" && config.CredentialsSecretName == secretName
+	}
+
+	t.Run("Should start runtime upgrade and return operation ID", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		uuidGenerator.On("New").Return("id")
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("SetUpgradeStarted", runtimeID, upgradeSteps(true, true), lease).Return(model.Operation{ID: expOperationID}, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("UpdateUpgradedCluster", runtimeID, "new kubeconfig", "new state", mock.MatchedBy(upgradedConfig)).Return(nil)
+		persistenceServiceMock.On("SetAsInProgress", expOperationID, "Upgrading Kyma: Upgrading component core").Return(nil)
+		persistenceServiceMock.On("UpdateKymaConfig", runtimeID, mock.MatchedBy(kymaVersion("1.7"))).Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", expOperationID, lease.Owner).Return(nil)
+		hydroformMock.On("UpgradeCluster", mock.MatchedBy(upgradedConfig), secretName, "state", mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "new kubeconfig", State: "new state"}, nil)
+		installationMock.On("InstallKyma", "new kubeconfig", mock.MatchedBy(kymaVersion("1.7"))).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, "new kubeconfig", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(2).(func(string))("Upgrading component core")
+		})
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, installationMock, nil, nil, nil, lease)
+
+		//when
+		operationID, finished, err := service.UpgradeRuntime(requestTenant, runtimeID, gqlschema.UpgradeRuntimeInput{
+			ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.15.4"},
+			KymaConfig:    &gqlschema.KymaConfigInput{Version: "1.7", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup}},
+		})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		assert.Equal(t, expOperationID, operationID)
+		hydroformMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should only upgrade Kyma when cluster is not upgraded", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		uuidGenerator.On("New").Return("id")
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("SetUpgradeStarted", runtimeID, upgradeSteps(false, true), lease).Return(model.Operation{ID: expOperationID}, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("UpdateKymaConfig", runtimeID, mock.MatchedBy(kymaVersion("1.7"))).Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", expOperationID, lease.Owner).Return(nil)
+		installationMock.On("InstallKyma", kubeconfig, mock.MatchedBy(kymaVersion("1.7"))).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, kubeconfig, mock.Anything).Return(nil)
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, installationMock, nil, nil, nil, lease)
+
+		//when
+		_, finished, err := service.UpgradeRuntime(requestTenant, runtimeID, gqlschema.UpgradeRuntimeInput{
+			KymaConfig: &gqlschema.KymaConfigInput{Version: "1.7", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup}},
+		})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should fail operation and keep configuration when Kyma upgrade failed", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		installationMock := &installationMocks.Service{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		uuidGenerator.On("New").Return("id")
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("SetUpgradeStarted", runtimeID, upgradeSteps(false, true), lease).Return(model.Operation{ID: expOperationID}, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("SetAsFailed", expOperationID, lease.Owner, "Kyma upgrade failed: error").Return(nil)
+		installationMock.On("InstallKyma", kubeconfig, mock.Anything).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, kubeconfig, mock.Anything).Return(errors.New("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, &mocks.Service{}, installationMock, nil, nil, nil, lease)
+
+		//when
+		_, finished, err := service.UpgradeRuntime(requestTenant, runtimeID, gqlschema.UpgradeRuntimeInput{
+			KymaConfig: &gqlschema.KymaConfigInput{Version: "1.7", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup}},
+		})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		installationMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+		persistenceServiceMock.AssertNotCalled(t, "UpdateKymaConfig", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should keep upgraded Kubernetes version when Kyma upgrade failed after cluster upgrade", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		installationMock := &installationMocks.Service{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		uuidGenerator.On("New").Return("id")
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("SetUpgradeStarted", runtimeID, upgradeSteps(true, true), lease).Return(model.Operation{ID: expOperationID}, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("UpdateUpgradedCluster", runtimeID, "new kubeconfig", "new state", mock.MatchedBy(upgradedConfig)).Return(nil)
+		persistenceServiceMock.On("SetAsFailed", expOperationID, lease.Owner, "Kyma upgrade failed: error").Return(nil)
+		hydroformMock.On("UpgradeCluster", mock.MatchedBy(upgradedConfig), secretName, "state", mock.Anything).Return(hydroform.ClusterInfo{ClusterStatus: types.Provisioned, KubeConfig: "new kubeconfig", State: "new state"}, nil)
+		installationMock.On("InstallKyma", "new kubeconfig", mock.Anything).Return(nil)
+		installationMock.On("WaitForInstallation", mock.Anything, "new kubeconfig", mock.Anything).Return(errors.New("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, installationMock, nil, nil, nil, lease)
+
+		//when
+		_, finished, err := service.UpgradeRuntime(requestTenant, runtimeID, gqlschema.UpgradeRuntimeInput{
+			ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.15.4"},
+			KymaConfig:    &gqlschema.KymaConfigInput{Version: "1.7", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup}},
+		})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		installationMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+		persistenceServiceMock.AssertNotCalled(t, "UpdateKymaConfig", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should not upgrade Kyma when runtime has no kubeconfig", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(model.Cluster{ID: runtimeID}, nil)
+
+		service := NewProvisioningService(persistenceServiceMock, &persistenceMocks.UUIDGenerator{}, &mocks.Service{}, nil, nil, nil, nil, lease)
+
+		//when
+		_, _, err := service.UpgradeRuntime(requestTenant, runtimeID, gqlschema.UpgradeRuntimeInput{
+			KymaConfig: &gqlschema.KymaConfigInput{Version: "1.7", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup}},
+		})
+
+		//then
+		require.Error(t, err)
+		persistenceServiceMock.AssertExpectations(t)
+		persistenceServiceMock.AssertNotCalled(t, "SetUpgradeStarted", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should fail operation and keep configuration when cluster upgrade failed", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("SetUpgradeStarted", runtimeID, upgradeSteps(true, false), lease).Return(model.Operation{ID: expOperationID}, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("SetAsFailed", expOperationID, lease.Owner, "error").Return(nil)
+		hydroformMock.On("UpgradeCluster", mock.Anything, secretName, "state", mock.Anything).Return(hydroform.ClusterInfo{}, errors.New("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, nil, nil, lease)
+
+		//when
+		_, finished, err := service.UpgradeRuntime(requestTenant, runtimeID, gqlschema.UpgradeRuntimeInput{
+			ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.15.4"},
+		})
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		hydroformMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should not start upgrade when previous operation is in progress", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		hydroformMock := &mocks.Service{}
+		uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(cluster, nil)
+		persistenceServiceMock.On("SetUpgradeStarted", runtimeID, upgradeSteps(true, false), lease).Return(model.Operation{}, dberrors.InProgress("in progress"))
+
+		service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, nil, nil, lease)
+
+		//when
+		_, _, err := service.UpgradeRuntime(requestTenant, runtimeID, gqlschema.UpgradeRuntimeInput{
+			ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.15.4"},
+		})
+
+		//then
+		require.Error(t, err)
+		hydroformMock.AssertExpectations(t)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	for _, testCase := range []struct {
+		description   string
+		runtimeStatus model.RuntimeStatus
+		input         gqlschema.UpgradeRuntimeInput
+	}{
+		{
+			description:   "runtime is deprovisioned",
+			runtimeStatus: model.RuntimeStatus{LastOperationStatus: model.Operation{Type: model.Deprovision, State: model.Succeeded}, RuntimeConfiguration: runtimeConfig},
+			input:         gqlschema.UpgradeRuntimeInput{ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.15.4"}},
+		},
+		{
+			description:   "Kubernetes version is lower than current one",
+			runtimeStatus: provisioned,
+			input:         gqlschema.UpgradeRuntimeInput{ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.13.11"}},
+		},
+		{
+			description:   "nothing is requested to upgrade",
+			runtimeStatus: provisioned,
+			input:         gqlschema.UpgradeRuntimeInput{},
+		},
+	} {
+		t.Run("Should not start upgrade when "+testCase.description, func(t *testing.T) {
+			//given
+			persistenceServiceMock := &persistenceMocks.Service{}
+			hydroformMock := &mocks.Service{}
+			uuidGenerator := &persistenceMocks.UUIDGenerator{}
+
+			persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(testCase.runtimeStatus, nil)
+
+			service := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, nil, nil, lease)
+
+			//when
+			_, _, err := service.UpgradeRuntime(requestTenant, runtimeID, testCase.input)
+
+			//then
+			require.Error(t, err)
+			hydroformMock.AssertExpectations(t)
+			persistenceServiceMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_ReconnectRuntimeAgent(t *testing.T) {
+	runtimeID := "92a1c394-639a-424e-8578-ba1ca7501dc1"
+	expOperationID := "c7241d2d-5ffd-434b-9a52-17ce9ee04578"
+	kubeconfig := "kubeconfig"
+	token := director.OneTimeToken{Token: "token", ConnectorURL: "https://connector.kyma.local/graphql"}
+
+	provisioned := model.RuntimeStatus{
+		LastOperationStatus:  model.Operation{Type: model.Provision, State: model.Succeeded},
+		RuntimeConfiguration: model.RuntimeConfig{Kubeconfig: &kubeconfig},
+	}
+
+	t.Run("Should configure Runtime Agent with new one-time token and wait until it reconnects", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+		runtimeAgentMock := &runtimeAgentMocks.Service{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("SetReconnectRuntimeStarted", runtimeID, reconnectingSteps, lease).Return(model.Operation{ID: expOperationID}, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("SetAsSucceeded", expOperationID, lease.Owner).Return(nil)
+		directorClientMock.On("GetConnectionToken", requestTenant, runtimeID).Return(token, nil)
+		runtimeAgentMock.On("ConfigureAgent", kubeconfig, runtimeagent.Configuration{
+			RuntimeID:    runtimeID,
+			Tenant:       requestTenant,
+			ConnectorURL: token.ConnectorURL,
+			Token:        token.Token,
+		}).Return(nil)
+		runtimeAgentMock.On("ResetConnection", kubeconfig).Return(nil)
+		runtimeAgentMock.On("WaitForConnection", mock.Anything, kubeconfig).Return(nil)
+
+		service := NewProvisioningService(persistenceServiceMock, &persistenceMocks.UUIDGenerator{}, &mocks.Service{}, nil, nil, directorClientMock, runtimeAgentMock, lease)
+
+		//when
+		operationID, finished, err := service.ReconnectRuntimeAgent(requestTenant, runtimeID)
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		assert.Equal(t, expOperationID, operationID)
+		persistenceServiceMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		runtimeAgentMock.AssertExpectations(t)
+	})
+
+	t.Run("Should fail operation when failed to generate one-time token", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+		runtimeAgentMock := &runtimeAgentMocks.Service{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("SetReconnectRuntimeStarted", runtimeID, reconnectingSteps, lease).Return(model.Operation{ID: expOperationID}, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("SetAsFailed", expOperationID, lease.Owner, "error").Return(nil)
+		directorClientMock.On("GetConnectionToken", requestTenant, runtimeID).Return(director.OneTimeToken{}, errors.New("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, &persistenceMocks.UUIDGenerator{}, &mocks.Service{}, nil, nil, directorClientMock, runtimeAgentMock, lease)
+
+		//when
+		_, finished, err := service.ReconnectRuntimeAgent(requestTenant, runtimeID)
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		persistenceServiceMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		runtimeAgentMock.AssertExpectations(t)
+	})
+
+	t.Run("Should fail operation when Runtime Agent did not reconnect", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		directorClientMock := &directorMocks.Client{}
+		runtimeAgentMock := &runtimeAgentMocks.Service{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(provisioned, nil)
+		persistenceServiceMock.On("SetReconnectRuntimeStarted", runtimeID, reconnectingSteps, lease).Return(model.Operation{ID: expOperationID}, nil)
+		persistenceServiceMock.On("ReleaseLease", expOperationID, lease.Owner).Return(nil)
+		persistenceServiceMock.On("StartOperationStep", expOperationID, mock.Anything, mock.Anything).Return(nil)
+		persistenceServiceMock.On("SetAsFailed", expOperationID, lease.Owner, "timeout").Return(nil)
+		directorClientMock.On("GetConnectionToken", requestTenant, runtimeID).Return(token, nil)
+		runtimeAgentMock.On("ConfigureAgent", kubeconfig, mock.Anything).Return(nil)
+		runtimeAgentMock.On("ResetConnection", kubeconfig).Return(nil)
+		runtimeAgentMock.On("WaitForConnection", mock.Anything, kubeconfig).Return(errors.New("timeout"))
+
+		service := NewProvisioningService(persistenceServiceMock, &persistenceMocks.UUIDGenerator{}, &mocks.Service{}, nil, nil, directorClientMock, runtimeAgentMock, lease)
+
+		//when
+		_, finished, err := service.ReconnectRuntimeAgent(requestTenant, runtimeID)
+		require.NoError(t, err)
+
+		waitUntilFinished(finished)
+
+		//then
+		persistenceServiceMock.AssertExpectations(t)
+		directorClientMock.AssertExpectations(t)
+		runtimeAgentMock.AssertExpectations(t)
+		persistenceServiceMock.AssertNotCalled(t, "SetAsSucceeded", expOperationID, lease.Owner)
+	})
+
+	t.Run("Should not start reconnecting when runtime has no kubeconfig", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(model.RuntimeStatus{
+			LastOperationStatus: model.Operation{Type: model.Provision, State: model.Succeeded},
+		}, nil)
+
+		service := NewProvisioningService(persistenceServiceMock, &persistenceMocks.UUIDGenerator{}, &mocks.Service{}, nil, nil, &directorMocks.Client{}, &runtimeAgentMocks.Service{}, lease)
+
+		//when
+		_, _, err := service.ReconnectRuntimeAgent(requestTenant, runtimeID)
+
+		//then
+		require.Error(t, err)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestService_RuntimeStatus(t *testing.T) {
+	runtimeID := "92a1c394-639a-424e-8578-ba1ca7501dc1"
+	kubeconfig := "kubeconfig"
+
+	t.Run("Should return connection status reported by Runtime Agent", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		runtimeAgentMock := &runtimeAgentMocks.Service{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(model.RuntimeStatus{
+			LastOperationStatus:  model.Operation{Type: model.Provision, State: model.Succeeded},
+			RuntimeConfiguration: model.RuntimeConfig{Kubeconfig: &kubeconfig},
+		}, nil)
+		runtimeAgentMock.On("ConnectionStatus", kubeconfig).Return(model.RuntimeAgentConnectionStatusConnected, nil)
+
+		service := NewProvisioningService(persistenceServiceMock, &persistenceMocks.UUIDGenerator{}, &mocks.Service{}, nil, nil, &directorMocks.Client{}, runtimeAgentMock, lease)
+
+		//when
+		status, err := service.RuntimeStatus(requestTenant, runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, gqlschema.RuntimeAgentConnectionStatusConnected, status.RuntimeConnectionStatus.Status)
+		assert.Empty(t, status.RuntimeConnectionStatus.Errors)
+		persistenceServiceMock.AssertExpectations(t)
+		runtimeAgentMock.AssertExpectations(t)
+	})
+
+	t.Run("Should return disconnected status with error when Runtime is not reachable", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		runtimeAgentMock := &runtimeAgentMocks.Service{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(model.RuntimeStatus{
+			LastOperationStatus:  model.Operation{Type: model.Provision, State: model.Succeeded},
+			RuntimeConfiguration: model.RuntimeConfig{Kubeconfig: &kubeconfig},
+		}, nil)
+		runtimeAgentMock.On("ConnectionStatus", kubeconfig).Return(model.RuntimeAgentConnectionStatusDisconnected, errors.New("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, &persistenceMocks.UUIDGenerator{}, &mocks.Service{}, nil, nil, &directorMocks.Client{}, runtimeAgentMock, lease)
+
+		//when
+		status, err := service.RuntimeStatus(requestTenant, runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, gqlschema.RuntimeAgentConnectionStatusDisconnected, status.RuntimeConnectionStatus.Status)
+		require.Len(t, status.RuntimeConnectionStatus.Errors, 1)
+		assert.Equal(t, "error", *status.RuntimeConnectionStatus.Errors[0].Message)
+	})
+
+	t.Run("Should return pending status when runtime is not provisioned yet", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		runtimeAgentMock := &runtimeAgentMocks.Service{}
+
+		persistenceServiceMock.On("GetStatus", requestTenant, runtimeID).Return(model.RuntimeStatus{
+			LastOperationStatus: model.Operation{Type: model.Provision, State: model.InProgress},
+		}, nil)
+
+		service := NewProvisioningService(persistenceServiceMock, &persistenceMocks.UUIDGenerator{}, &mocks.Service{}, nil, nil, &directorMocks.Client{}, runtimeAgentMock, lease)
+
+		//when
+		status, err := service.RuntimeStatus(requestTenant, runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, gqlschema.RuntimeAgentConnectionStatusPending, status.RuntimeConnectionStatus.Status)
+		runtimeAgentMock.AssertExpectations(t)
+	})
 }
 
 func TestService_RuntimeOperationStatus(t *testing.T) {
@@ -192,11 +1064,11 @@ func TestService_RuntimeOperationStatus(t *testing.T) {
 			Message:   "some message",
 		}
 
-		persistenceServiceMock.On("Get", operationID).Return(operation, nil)
-		resolver := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock)
+		persistenceServiceMock.On("Get", requestTenant, operationID).Return(operation, nil)
+		resolver := NewProvisioningService(persistenceServiceMock, uuidGenerator, hydroformMock, nil, nil, nil, nil, lease)
 
 		//when
-		status, err := resolver.RuntimeOperationStatus(operationID)
+		status, err := resolver.RuntimeOperationStatus(requestTenant, operationID)
 
 		//then
 		require.NoError(t, err)
@@ -209,6 +1081,207 @@ func TestService_RuntimeOperationStatus(t *testing.T) {
 	})
 }
 
+func TestService_CleanupRuntimeData(t *testing.T) {
+	runtimeID := "a24142da-1111-4ec2-93e3-e47ccaa6973f"
+
+	t.Run("Should remove data of the runtime and release its credentials", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(model.Cluster{ID: runtimeID}, nil)
+		persistenceServiceMock.On("CleanupClusterData", runtimeID).Return(nil)
+		accountPoolMock.On("ReleaseCredentials", runtimeID).Return(nil)
+
+		service := NewProvisioningService(persistenceServiceMock, nil, nil, nil, accountPoolMock, nil, nil, lease)
+
+		//when
+		id, err := service.CleanupRuntimeData(requestTenant, runtimeID)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, runtimeID, id)
+		persistenceServiceMock.AssertExpectations(t)
+		accountPoolMock.AssertExpectations(t)
+	})
+
+	t.Run("Should not remove data of the runtime which belongs to another tenant", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+
+		persistenceServiceMock.On("GetClusterData", requestTenant, runtimeID).Return(model.Cluster{}, dberrors.NotFound("not found"))
+
+		service := NewProvisioningService(persistenceServiceMock, nil, nil, nil, nil, nil, nil, lease)
+
+		//when
+		_, err := service.CleanupRuntimeData(requestTenant, runtimeID)
+
+		//then
+		require.Error(t, err)
+		persistenceServiceMock.AssertNotCalled(t, "CleanupClusterData", runtimeID)
+	})
+}
+
+func TestService_HyperscalerAccountPools(t *testing.T) {
+	t.Run("Should return status of Hyperscaler Account Pools", func(t *testing.T) {
+		//given
+		accountPoolMock := &hyperscalerMocks.AccountPool{}
+		hyperscalerType := gqlschema.HyperscalerTypeAws
+		expectedType := hyperscaler.AWS
+		accountName := "account"
+
+		accountPoolMock.On("PoolStatus", requestTenant, &expectedType, &accountName).Return([]hyperscaler.PoolStatus{
+			{HyperscalerType: hyperscaler.AWS, AccountName: "account", Total: 3, Assigned: 1},
+		}, nil)
+
+		service := NewProvisioningService(nil, nil, nil, nil, accountPoolMock, nil, nil, lease)
+
+		//when
+		statuses, err := service.HyperscalerAccountPools(requestTenant, &hyperscalerType, &accountName)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []*gqlschema.HyperscalerAccountPoolStatus{
+			{Hyperscaler: gqlschema.HyperscalerTypeAws, AccountName: "account", Total: 3, Assigned: 1, Free: 2},
+		}, statuses)
+		accountPoolMock.AssertExpectations(t)
+	})
+}
+
+func TestService_Runtimes(t *testing.T) {
+	runtimeID := "4fe0f9a4-1e6b-4a3c-9f3a-6f7d8a2b5c1e"
+	creationTimestamp := time.Date(2019, 11, 4, 12, 0, 0, 0, time.UTC)
+
+	runtimes := []model.RuntimeSummary{
+		{
+			ID:                runtimeID,
+			Name:              "cluster",
+			Provider:          "gcp",
+			Region:            "europe-west1",
+			CreationTimestamp: creationTimestamp,
+			LastOperation:     model.Operation{ID: "operation", Type: model.Provision, State: model.Failed, ClusterID: runtimeID},
+		},
+	}
+
+	t.Run("Should return page of runtimes filtered by state, provider and region", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+
+		state := gqlschema.OperationStateFailed
+		provider := "gcp"
+		region := "europe-west1"
+		failed := model.Failed
+
+		persistenceServiceMock.On("ListRuntimes", requestTenant, model.RuntimeFilter{State: &failed, Provider: &provider, Region: &region}, 1, 0).Return(runtimes, 3, nil)
+
+		service := NewProvisioningService(persistenceServiceMock, nil, nil, nil, nil, nil, nil, lease)
+
+		//when
+		page, err := service.Runtimes(requestTenant, &gqlschema.RuntimesFilter{State: &state, Provider: &provider, Region: &region}, 1, "")
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, page.Data, 1)
+		assert.Equal(t, runtimeID, page.Data[0].ID)
+		assert.Equal(t, "gcp", page.Data[0].Provider)
+		assert.Equal(t, creationTimestamp, page.Data[0].CreationTimestamp)
+		assert.Equal(t, gqlschema.OperationStateFailed, page.Data[0].LastOperationStatus.State)
+		assert.Equal(t, 3, page.TotalCount)
+		assert.True(t, page.PageInfo.HasNextPage)
+		assert.Equal(t, pagination.EncodeNextOffsetCursor(0, 1), page.PageInfo.EndCursor)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should return last page of runtimes", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+		cursor := pagination.EncodeNextOffsetCursor(0, 2)
+
+		persistenceServiceMock.On("ListRuntimes", requestTenant, model.RuntimeFilter{}, 2, 2).Return(runtimes, 3, nil)
+
+		service := NewProvisioningService(persistenceServiceMock, nil, nil, nil, nil, nil, nil, lease)
+
+		//when
+		page, err := service.Runtimes(requestTenant, nil, 2, cursor)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, cursor, page.PageInfo.StartCursor)
+		assert.Empty(t, page.PageInfo.EndCursor)
+		assert.False(t, page.PageInfo.HasNextPage)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when cursor is invalid", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+
+		service := NewProvisioningService(persistenceServiceMock, nil, nil, nil, nil, nil, nil, lease)
+
+		//when
+		_, err := service.Runtimes(requestTenant, nil, 2, "invalid")
+
+		//then
+		require.Error(t, err)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+}
+
+func TestService_Operations(t *testing.T) {
+	runtimeID := "4fe0f9a4-1e6b-4a3c-9f3a-6f7d8a2b5c1e"
+	startTimestamp := time.Date(2019, 11, 4, 12, 0, 0, 0, time.UTC)
+
+	operations := []model.Operation{
+		{ID: "upgrade", Type: model.Upgrade, State: model.Succeeded, StartTimestamp: startTimestamp.Add(time.Hour), ClusterID: runtimeID},
+		{ID: "provision", Type: model.Provision, State: model.Succeeded, StartTimestamp: startTimestamp, ClusterID: runtimeID},
+	}
+
+	t.Run("Should return history of operations", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+
+		operationType := gqlschema.OperationTypeUpgrade
+		state := gqlschema.OperationStateSucceeded
+		upgrade := model.Upgrade
+		succeeded := model.Succeeded
+
+		persistenceServiceMock.On("ListOperations", requestTenant, model.OperationFilter{RuntimeID: &runtimeID, Type: &upgrade, State: &succeeded, Since: &startTimestamp}, 10, 0).
+			Return(operations, 2, nil)
+
+		service := NewProvisioningService(persistenceServiceMock, nil, nil, nil, nil, nil, nil, lease)
+
+		//when
+		page, err := service.Operations(requestTenant, &runtimeID, &operationType, &state, &startTimestamp, 10, "")
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, page.Data, 2)
+		assert.Equal(t, "upgrade", *page.Data[0].ID)
+		assert.Equal(t, gqlschema.OperationTypeUpgrade, page.Data[0].Operation)
+		assert.Equal(t, "provision", *page.Data[1].ID)
+		assert.Equal(t, startTimestamp, *page.Data[1].StartTimestamp)
+		assert.Equal(t, 2, page.TotalCount)
+		assert.False(t, page.PageInfo.HasNextPage)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when failed to list operations", func(t *testing.T) {
+		//given
+		persistenceServiceMock := &persistenceMocks.Service{}
+
+		persistenceServiceMock.On("ListOperations", requestTenant, model.OperationFilter{}, 10, 0).Return(nil, 0, dberrors.Internal("error"))
+
+		service := NewProvisioningService(persistenceServiceMock, nil, nil, nil, nil, nil, nil, lease)
+
+		//when
+		_, err := service.Operations(requestTenant, nil, nil, nil, nil, 10, "")
+
+		//then
+		require.Error(t, err)
+		persistenceServiceMock.AssertExpectations(t)
+	})
+}
+
 func waitUntilFinished(finished <-chan struct{}) {
 	for {
 		_, ok := <-finished
@@ -0,0 +1,161 @@
+package provisioning
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
+)
+
+func validateUpgradeInput(current model.RuntimeConfig, input gqlschema.UpgradeRuntimeInput) error {
+	if input.ClusterConfig == nil && input.KymaConfig == nil {
+		return errors.New("cannot upgrade runtime since neither cluster nor Kyma configuration is provided")
+	}
+
+	if input.ClusterConfig != nil {
+		err := validateKubernetesVersionUpgrade(current.ClusterConfig, input.ClusterConfig.Version)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.KymaConfig != nil {
+		err := validateKymaUpgrade(current.KymaConfig, *input.KymaConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateKubernetesVersionUpgrade(clusterConfig interface{}, version string) error {
+	if version == "" {
+		return errors.New("cannot upgrade runtime since Kubernetes version is empty")
+	}
+
+	var currentVersion string
+	switch config := clusterConfig.(type) {
+	case model.GardenerConfig:
+		currentVersion = config.KubernetesVersion
+	case model.GCPConfig:
+		currentVersion = config.KubernetesVersion
+	case model.AWSConfig:
+		currentVersion = config.KubernetesVersion
+	case model.AzureConfig:
+		currentVersion = config.KubernetesVersion
+	default:
+		return errors.New("cannot upgrade runtime since its cluster configuration is unknown")
+	}
+
+	return validateVersionUpgrade("Kubernetes", currentVersion, version)
+}
+
+func validateKymaUpgrade(current model.KymaConfig, input gqlschema.KymaConfigInput) error {
+	if input.Version == "" {
+		return errors.New("cannot upgrade runtime since Kyma version is empty")
+	}
+
+	if len(input.Modules) == 0 {
+		return errors.New("cannot upgrade runtime since Kyma modules list is empty")
+	}
+
+	if input.Version == current.Version && sameModules(current.Modules, input.Modules) {
+		return errors.New(fmt.Sprintf("cannot upgrade runtime since Kyma %s with requested modules is already installed", current.Version))
+	}
+
+	if input.Version == current.Version {
+		return nil
+	}
+
+	return validateVersionUpgrade("Kyma", current.Version, input.Version)
+}
+
+func validateVersionUpgrade(component, currentVersion, version string) error {
+	if version == currentVersion {
+		return errors.New(fmt.Sprintf("cannot upgrade runtime since %s is already in version %s", component, currentVersion))
+	}
+
+	result, comparable := compareVersions(currentVersion, version)
+	if comparable && result > 0 {
+		return errors.New(fmt.Sprintf("cannot upgrade runtime since %s downgrade from %s to %s is not supported", component, currentVersion, version))
+	}
+
+	return nil
+}
+
+func sameModules(current []model.KymaConfigModule, requested []gqlschema.KymaModule) bool {
+	currentModules := make(map[string]bool)
+	for _, module := range current {
+		currentModules[string(module.Module)] = true
+	}
+
+	requestedModules := make(map[string]bool)
+	for _, module := range requested {
+		requestedModules[module.String()] = true
+	}
+
+	if len(currentModules) != len(requestedModules) {
+		return false
+	}
+
+	for module := range requestedModules {
+		if !currentModules[module] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareVersions compares dot-separated numeric versions, ignoring the "v" prefix and pre-release suffixes.
+// It returns false if any of the versions is not numeric, for example when it refers to a development build.
+func compareVersions(first, second string) (int, bool) {
+	firstParts, ok := parseVersion(first)
+	if !ok {
+		return 0, false
+	}
+
+	secondParts, ok := parseVersion(second)
+	if !ok {
+		return 0, false
+	}
+
+	for i := 0; i < len(firstParts) || i < len(secondParts); i++ {
+		var firstPart, secondPart int
+		if i < len(firstParts) {
+			firstPart = firstParts[i]
+		}
+		if i < len(secondParts) {
+			secondPart = secondParts[i]
+		}
+
+		if firstPart != secondPart {
+			if firstPart > secondPart {
+				return 1, true
+			}
+			return -1, true
+		}
+	}
+
+	return 0, true
+}
+
+func parseVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(version, "v")
+	version = strings.SplitN(version, "-", 2)[0]
+
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, number)
+	}
+
+	return parts, true
+}
@@ -0,0 +1,93 @@
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/kyma-incubator/compass/components/provisioner/pkg/gqlschema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUpgradeInput(t *testing.T) {
+	current := model.RuntimeConfig{
+		ClusterConfig: model.GardenerConfig{KubernetesVersion: "1.15.4"},
+		KymaConfig: model.KymaConfig{
+			Version: "1.6",
+			Modules: []model.KymaConfigModule{{Module: model.KymaModule(gqlschema.KymaModuleBackup)}},
+		},
+	}
+
+	for _, testCase := range []struct {
+		description string
+		input       gqlschema.UpgradeRuntimeInput
+		valid       bool
+	}{
+		{
+			description: "newer Kubernetes version",
+			input:       gqlschema.UpgradeRuntimeInput{ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.16"}},
+			valid:       true,
+		},
+		{
+			description: "non-numeric Kubernetes version",
+			input:       gqlschema.UpgradeRuntimeInput{ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "latest"}},
+			valid:       true,
+		},
+		{
+			description: "same Kubernetes version",
+			input:       gqlschema.UpgradeRuntimeInput{ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.15.4"}},
+			valid:       false,
+		},
+		{
+			description: "older Kubernetes version",
+			input:       gqlschema.UpgradeRuntimeInput{ClusterConfig: &gqlschema.UpgradeClusterInput{Version: "1.15.3"}},
+			valid:       false,
+		},
+		{
+			description: "empty Kubernetes version",
+			input:       gqlschema.UpgradeRuntimeInput{ClusterConfig: &gqlschema.UpgradeClusterInput{}},
+			valid:       false,
+		},
+		{
+			description: "newer Kyma version",
+			input:       gqlschema.UpgradeRuntimeInput{KymaConfig: &gqlschema.KymaConfigInput{Version: "1.7", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup}}},
+			valid:       true,
+		},
+		{
+			description: "changed Kyma modules",
+			input:       gqlschema.UpgradeRuntimeInput{KymaConfig: &gqlschema.KymaConfigInput{Version: "1.6", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup, gqlschema.KymaModuleLogging}}},
+			valid:       true,
+		},
+		{
+			description: "unchanged Kyma configuration",
+			input:       gqlschema.UpgradeRuntimeInput{KymaConfig: &gqlschema.KymaConfigInput{Version: "1.6", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup}}},
+			valid:       false,
+		},
+		{
+			description: "older Kyma version",
+			input:       gqlschema.UpgradeRuntimeInput{KymaConfig: &gqlschema.KymaConfigInput{Version: "1.5", Modules: []gqlschema.KymaModule{gqlschema.KymaModuleBackup}}},
+			valid:       false,
+		},
+		{
+			description: "empty Kyma modules",
+			input:       gqlschema.UpgradeRuntimeInput{KymaConfig: &gqlschema.KymaConfigInput{Version: "1.7"}},
+			valid:       false,
+		},
+		{
+			description: "empty input",
+			input:       gqlschema.UpgradeRuntimeInput{},
+			valid:       false,
+		},
+	} {
+		t.Run("Should validate "+testCase.description, func(t *testing.T) {
+			//when
+			err := validateUpgradeInput(current, testCase.input)
+
+			//then
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package readiness
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/healthz"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// NewDatabaseChecker returns the Checker which pings the database
+func NewDatabaseChecker(db Pinger) healthz.Checker {
+	return healthz.CheckerFunc(func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return errors.Wrap(err, "while pinging database")
+		}
+
+		return nil
+	})
+}
+
+// NewSecretsChecker returns the Checker which fails if the Provisioner is not able to list Secrets with the credentials
+func NewSecretsChecker(secrets v1.SecretInterface) healthz.Checker {
+	return healthz.CheckerFunc(func(ctx context.Context) error {
+		if _, err := secrets.List(metav1.ListOptions{Limit: 1}); err != nil {
+			return errors.Wrap(err, "while listing secrets")
+		}
+
+		return nil
+	})
+}
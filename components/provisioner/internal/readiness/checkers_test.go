@@ -0,0 +1,77 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type pingerFunc func(ctx context.Context) error
+
+func (f pingerFunc) PingContext(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestDatabaseChecker(t *testing.T) {
+	t.Run("Should succeed when database responds", func(t *testing.T) {
+		//given
+		checker := NewDatabaseChecker(pingerFunc(func(ctx context.Context) error {
+			return nil
+		}))
+
+		//when
+		err := checker.Check(context.Background())
+
+		//then
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should fail when database does not respond", func(t *testing.T) {
+		//given
+		checker := NewDatabaseChecker(pingerFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}))
+
+		//when
+		err := checker.Check(context.Background())
+
+		//then
+		assert.EqualError(t, err, "while pinging database: connection refused")
+	})
+}
+
+func TestSecretsChecker(t *testing.T) {
+	t.Run("Should succeed when secrets can be listed", func(t *testing.T) {
+		//given
+		secrets := fake.NewSimpleClientset().CoreV1().Secrets("compass-system")
+		checker := NewSecretsChecker(secrets)
+
+		//when
+		err := checker.Check(context.Background())
+
+		//then
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should fail when secrets cannot be listed", func(t *testing.T) {
+		//given
+		clientset := fake.NewSimpleClientset()
+		clientset.PrependReactor("list", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+		checker := NewSecretsChecker(clientset.CoreV1().Secrets("compass-system"))
+
+		//when
+		err := checker.Check(context.Background())
+
+		//then
+		require.Error(t, err)
+		assert.EqualError(t, err, "while listing secrets: forbidden")
+	})
+}
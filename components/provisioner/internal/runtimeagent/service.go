@@ -0,0 +1,196 @@
+package runtimeagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/k8s"
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AgentNamespace           = "compass-system"
+	AgentConfigurationSecret = "compass-agent-configuration"
+
+	compassConnectionPath = "/apis/compass.kyma-project.io/v1alpha1/compassconnections/compass-connection"
+
+	connectorURLKey = "CONNECTOR_URL"
+	tokenKey        = "TOKEN"
+	runtimeIDKey    = "RUNTIME_ID"
+	tenantKey       = "TENANT"
+)
+
+// Connection states reported by Compass Runtime Agent in the CompassConnection resource
+const (
+	connected                   = "Connected"
+	connectionFailed            = "ConnectionFailed"
+	connectionMaintenanceFailed = "ConnectionMaintenanceFailed"
+	synchronized                = "Synchronized"
+	synchronizationFailed       = "SynchronizationFailed"
+	resourceApplicationFailed   = "ResourceApplicationFailed"
+	metadataUpdateFailed        = "MetadataUpdateFailed"
+)
+
+// Config holds how long to wait for Compass Runtime Agent to connect after its configuration is changed
+type Config struct {
+	ConnectionTimeout time.Duration
+	PollInterval      time.Duration
+}
+
+type Configuration struct {
+	RuntimeID    string
+	Tenant       string
+	ConnectorURL string
+	Token        string
+}
+
+//go:generate mockery -name=Service
+type Service interface {
+	ConfigureAgent(kubeconfig string, configuration Configuration) error
+	ResetConnection(kubeconfig string) error
+	WaitForConnection(ctx context.Context, kubeconfig string) error
+	ConnectionStatus(kubeconfig string) (model.RuntimeAgentConnectionStatus, error)
+}
+
+type service struct {
+	config Config
+}
+
+func NewRuntimeAgentService(config Config) Service {
+	return &service{
+		config: config,
+	}
+}
+
+type compassConnection struct {
+	Status struct {
+		ConnectionState string `json:"connectionState"`
+	} `json:"status"`
+}
+
+func (s *service) ConfigureAgent(kubeconfig string, configuration Configuration) error {
+	client, err := k8s.NewClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	secrets := client.CoreV1().Secrets(AgentNamespace)
+
+	data := map[string][]byte{
+		connectorURLKey: []byte(configuration.ConnectorURL),
+		tokenKey:        []byte(configuration.Token),
+		runtimeIDKey:    []byte(configuration.RuntimeID),
+		tenantKey:       []byte(configuration.Tenant),
+	}
+
+	secret, err := secrets.Get(AgentConfigurationSecret, meta.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrap(err, "Failed to get Runtime Agent configuration")
+		}
+
+		_, err = secrets.Create(&core.Secret{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      AgentConfigurationSecret,
+				Namespace: AgentNamespace,
+			},
+			Data: data,
+		})
+		if err != nil {
+			return errors.Wrap(err, "Failed to create Runtime Agent configuration")
+		}
+
+		return nil
+	}
+
+	secret.Data = data
+
+	_, err = secrets.Update(secret)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update Runtime Agent configuration")
+	}
+
+	return nil
+}
+
+// ResetConnection deletes the Compass Connection, which makes Compass Runtime Agent connect again with its current configuration
+func (s *service) ResetConnection(kubeconfig string) error {
+	client, err := k8s.NewClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	err = client.CoreV1().RESTClient().Delete().AbsPath(compassConnectionPath).Do().Error()
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "Failed to delete Compass Connection")
+	}
+
+	return nil
+}
+
+// WaitForConnection waits until Compass Runtime Agent reports the connection as established
+func (s *service) WaitForConnection(ctx context.Context, kubeconfig string) error {
+	deadline := time.Now().Add(s.config.ConnectionTimeout)
+
+	for {
+		status, err := s.ConnectionStatus(kubeconfig)
+		if err == nil && status == model.RuntimeAgentConnectionStatusConnected {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return errors.Wrapf(err, "Runtime Agent did not connect within %s", s.config.ConnectionTimeout)
+			}
+			return errors.New(fmt.Sprintf("Runtime Agent did not connect within %s", s.config.ConnectionTimeout))
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "Stopped waiting for Runtime Agent connection")
+		case <-time.After(s.config.PollInterval):
+		}
+	}
+}
+
+func (s *service) ConnectionStatus(kubeconfig string) (model.RuntimeAgentConnectionStatus, error) {
+	client, err := k8s.NewClientFromKubeconfig(kubeconfig)
+	if err != nil {
+		return model.RuntimeAgentConnectionStatusDisconnected, err
+	}
+
+	response, err := client.CoreV1().RESTClient().Get().AbsPath(compassConnectionPath).DoRaw()
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return model.RuntimeAgentConnectionStatusPending, nil
+		}
+
+		return model.RuntimeAgentConnectionStatusDisconnected, errors.Wrap(err, "Failed to get Compass Connection")
+	}
+
+	var connection compassConnection
+	err = json.Unmarshal(response, &connection)
+	if err != nil {
+		return model.RuntimeAgentConnectionStatusDisconnected, errors.Wrap(err, "Failed to decode Compass Connection")
+	}
+
+	return connectionStatus(connection.Status.ConnectionState), nil
+}
+
+func connectionStatus(connectionState string) model.RuntimeAgentConnectionStatus {
+	switch connectionState {
+	case connected, synchronized, synchronizationFailed, resourceApplicationFailed, metadataUpdateFailed:
+		return model.RuntimeAgentConnectionStatusConnected
+	case connectionFailed, connectionMaintenanceFailed:
+		return model.RuntimeAgentConnectionStatusDisconnected
+	default:
+		// The agent did not establish the connection yet
+		return model.RuntimeAgentConnectionStatusPending
+	}
+}
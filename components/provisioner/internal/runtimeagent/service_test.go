@@ -0,0 +1,322 @@
+package runtimeagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %s
+  name: runtime
+contexts:
+- context:
+    cluster: runtime
+    user: admin
+  name: runtime
+current-context: runtime
+users:
+- name: admin
+  user:
+    token: token
+`
+
+const secretPath = "/api/v1/namespaces/compass-system/secrets"
+
+var config = Config{ConnectionTimeout: time.Minute, PollInterval: time.Millisecond}
+
+var configuration = Configuration{
+	RuntimeID:    "runtimeID",
+	Tenant:       "tenant",
+	ConnectorURL: "https://connector.kyma.local/graphql",
+	Token:        "token",
+}
+
+func TestService_ConfigureAgent(t *testing.T) {
+	t.Run("Should create Runtime Agent configuration", func(t *testing.T) {
+		//given
+		var created core.Secret
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == secretPath+"/"+AgentConfigurationSecret:
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			case r.Method == http.MethodPost && r.URL.Path == secretPath:
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				require.NoError(t, json.NewEncoder(w).Encode(created))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		service := NewRuntimeAgentService(config)
+
+		//when
+		err := service.ConfigureAgent(fmt.Sprintf(kubeconfigTemplate, server.URL), configuration)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, AgentConfigurationSecret, created.Name)
+		assert.Equal(t, "token", string(created.Data[tokenKey]))
+		assert.Equal(t, configuration.ConnectorURL, string(created.Data[connectorURLKey]))
+		assert.Equal(t, configuration.RuntimeID, string(created.Data[runtimeIDKey]))
+		assert.Equal(t, configuration.Tenant, string(created.Data[tenantKey]))
+	})
+
+	t.Run("Should update existing Runtime Agent configuration", func(t *testing.T) {
+		//given
+		var updated core.Secret
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == secretPath+"/"+AgentConfigurationSecret:
+				existing := core.Secret{Data: map[string][]byte{tokenKey: []byte("old")}}
+				existing.Name = AgentConfigurationSecret
+				require.NoError(t, json.NewEncoder(w).Encode(existing))
+			case r.Method == http.MethodPut && r.URL.Path == secretPath+"/"+AgentConfigurationSecret:
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+				require.NoError(t, json.NewEncoder(w).Encode(updated))
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		service := NewRuntimeAgentService(config)
+
+		//when
+		err := service.ConfigureAgent(fmt.Sprintf(kubeconfigTemplate, server.URL), configuration)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "token", string(updated.Data[tokenKey]))
+	})
+
+	t.Run("Should return error when kubeconfig is invalid", func(t *testing.T) {
+		//given
+		service := NewRuntimeAgentService(config)
+
+		//when
+		err := service.ConfigureAgent("invalid", configuration)
+
+		//then
+		require.Error(t, err)
+	})
+}
+
+func TestService_ConnectionStatus(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		responseStatus int
+		responseBody   string
+		expectedStatus model.RuntimeAgentConnectionStatus
+		expectError    bool
+	}{
+		{
+			description:    "connected when agent synchronized",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"status":{"connectionState":"Synchronized"}}`,
+			expectedStatus: model.RuntimeAgentConnectionStatusConnected,
+		},
+		{
+			description:    "disconnected when agent failed to maintain connection",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"status":{"connectionState":"ConnectionMaintenanceFailed"}}`,
+			expectedStatus: model.RuntimeAgentConnectionStatusDisconnected,
+		},
+		{
+			description:    "pending when agent did not connect yet",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"status":{"connectionState":"NotConnected"}}`,
+			expectedStatus: model.RuntimeAgentConnectionStatusPending,
+		},
+		{
+			description:    "pending when Compass Connection does not exist",
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`,
+			expectedStatus: model.RuntimeAgentConnectionStatusPending,
+		},
+		{
+			description:    "disconnected with error when cluster responded with error",
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"InternalError","code":500}`,
+			expectedStatus: model.RuntimeAgentConnectionStatusDisconnected,
+			expectError:    true,
+		},
+	} {
+		t.Run("Should return "+testCase.description, func(t *testing.T) {
+			//given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, compassConnectionPath, r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(testCase.responseStatus)
+				_, _ = w.Write([]byte(testCase.responseBody))
+			}))
+			defer server.Close()
+
+			service := NewRuntimeAgentService(config)
+
+			//when
+			status, err := service.ConnectionStatus(fmt.Sprintf(kubeconfigTemplate, server.URL))
+
+			//then
+			if testCase.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedStatus, status)
+		})
+	}
+}
+
+func TestService_ResetConnection(t *testing.T) {
+	t.Run("Should delete Compass Connection", func(t *testing.T) {
+		//given
+		deleted := false
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodDelete, r.Method)
+			require.Equal(t, compassConnectionPath, r.URL.Path)
+			deleted = true
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+		}))
+		defer server.Close()
+
+		service := NewRuntimeAgentService(config)
+
+		//when
+		err := service.ResetConnection(fmt.Sprintf(kubeconfigTemplate, server.URL))
+
+		//then
+		require.NoError(t, err)
+		assert.True(t, deleted)
+	})
+
+	t.Run("Should succeed when Compass Connection does not exist", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+		}))
+		defer server.Close()
+
+		service := NewRuntimeAgentService(config)
+
+		//when
+		err := service.ResetConnection(fmt.Sprintf(kubeconfigTemplate, server.URL))
+
+		//then
+		require.NoError(t, err)
+	})
+
+	t.Run("Should return error when cluster responded with error", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"InternalError","code":500}`))
+		}))
+		defer server.Close()
+
+		service := NewRuntimeAgentService(config)
+
+		//when
+		err := service.ResetConnection(fmt.Sprintf(kubeconfigTemplate, server.URL))
+
+		//then
+		require.Error(t, err)
+	})
+}
+
+func TestService_WaitForConnection(t *testing.T) {
+	t.Run("Should wait until Runtime Agent is connected", func(t *testing.T) {
+		//given
+		states := []string{"", "NotConnected", "Connected"}
+		requests := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+
+			state := states[requests]
+			requests++
+
+			if state == "" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"status":{"connectionState":"%s"}}`, state)))
+		}))
+		defer server.Close()
+
+		service := NewRuntimeAgentService(config)
+
+		//when
+		err := service.WaitForConnection(context.Background(), fmt.Sprintf(kubeconfigTemplate, server.URL))
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, len(states), requests)
+	})
+
+	t.Run("Should return error when Runtime Agent did not connect in time", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status":{"connectionState":"ConnectionFailed"}}`))
+		}))
+		defer server.Close()
+
+		service := NewRuntimeAgentService(Config{ConnectionTimeout: 10 * time.Millisecond, PollInterval: time.Millisecond})
+
+		//when
+		err := service.WaitForConnection(context.Background(), fmt.Sprintf(kubeconfigTemplate, server.URL))
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "did not connect")
+	})
+
+	t.Run("Should stop waiting when context is cancelled", func(t *testing.T) {
+		//given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status":{"connectionState":"NotConnected"}}`))
+		}))
+		defer server.Close()
+
+		service := NewRuntimeAgentService(config)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		//when
+		err := service.WaitForConnection(ctx, fmt.Sprintf(kubeconfigTemplate, server.URL))
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Stopped waiting")
+	})
+}
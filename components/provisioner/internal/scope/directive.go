@@ -0,0 +1,58 @@
+package scope
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockery -name=ScopesGetter
+type ScopesGetter interface {
+	GetRequiredScopes(path string) ([]string, error)
+}
+
+type directive struct {
+	scopesGetter ScopesGetter
+}
+
+func NewDirective(getter ScopesGetter) *directive {
+	return &directive{
+		scopesGetter: getter,
+	}
+}
+
+// VerifyScopes implements the hasScopes directive. It resolves the field only if the scopes from the request context
+// contain all scopes required for the path.
+func (d *directive) VerifyScopes(ctx context.Context, obj interface{}, next graphql.Resolver, path string) (interface{}, error) {
+	actualScopes, err := LoadFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredScopes, err := d.scopesGetter.GetRequiredScopes(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "while getting required scopes")
+	}
+
+	if !matches(actualScopes, requiredScopes) {
+		return nil, InsufficientScopesError(requiredScopes, actualScopes)
+	}
+
+	return next(ctx)
+}
+
+func matches(actual []string, required []string) bool {
+	actualSet := make(map[string]struct{})
+	for _, scope := range actual {
+		actualSet[scope] = struct{}{}
+	}
+
+	for _, scope := range required {
+		if _, ok := actualSet[scope]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,75 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/scope/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	path       = "graphql.mutation.provisionRuntime"
+	readScope  = "runtime:read"
+	writeScope = "runtime:write"
+)
+
+func TestDirective_VerifyScopes(t *testing.T) {
+	t.Run("Should resolve field when all required scopes are provided", func(t *testing.T) {
+		//given
+		scopesGetter := &mocks.ScopesGetter{}
+		scopesGetter.On("GetRequiredScopes", path).Return([]string{writeScope}, nil)
+
+		ctx := SaveToContext(context.Background(), []string{readScope, writeScope})
+
+		//when
+		result, err := NewDirective(scopesGetter).VerifyScopes(ctx, nil, resolveOK, path)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "ok", result)
+		scopesGetter.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when scopes are insufficient", func(t *testing.T) {
+		//given
+		scopesGetter := &mocks.ScopesGetter{}
+		scopesGetter.On("GetRequiredScopes", path).Return([]string{readScope, writeScope}, nil)
+
+		ctx := SaveToContext(context.Background(), []string{readScope})
+
+		//when
+		_, err := NewDirective(scopesGetter).VerifyScopes(ctx, nil, resolveOK, path)
+
+		//then
+		require.EqualError(t, err, "insufficient scopes provided, required: [runtime:read runtime:write], actual: [runtime:read]")
+	})
+
+	t.Run("Should return error when there are no scopes in context", func(t *testing.T) {
+		//when
+		_, err := NewDirective(&mocks.ScopesGetter{}).VerifyScopes(context.Background(), nil, resolveOK, path)
+
+		//then
+		assert.Equal(t, NoScopesInContextError, err)
+	})
+
+	t.Run("Should return error when failed to get required scopes", func(t *testing.T) {
+		//given
+		scopesGetter := &mocks.ScopesGetter{}
+		scopesGetter.On("GetRequiredScopes", path).Return(nil, errors.New("some error"))
+
+		ctx := SaveToContext(context.Background(), []string{readScope, writeScope})
+
+		//when
+		_, err := NewDirective(scopesGetter).VerifyScopes(ctx, nil, resolveOK, path)
+
+		//then
+		require.EqualError(t, err, "while getting required scopes: some error")
+	})
+}
+
+func resolveOK(ctx context.Context) (interface{}, error) {
+	return "ok", nil
+}
@@ -0,0 +1,79 @@
+package scope
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Provider reads scopes required for GraphQL operations from a YAML file.
+// The path of an operation is a dot-separated list of keys, e.g. "graphql.mutation.provisionRuntime".
+type Provider struct {
+	fileName     string
+	cachedConfig map[string]interface{}
+}
+
+func NewProvider(fileName string) *Provider {
+	return &Provider{
+		fileName: fileName,
+	}
+}
+
+func (p *Provider) Load() error {
+	content, err := ioutil.ReadFile(p.fileName)
+	if err != nil {
+		return errors.Wrapf(err, "while reading file %s", p.fileName)
+	}
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return errors.Wrap(err, "while unmarshalling YAML")
+	}
+
+	p.cachedConfig = config
+
+	return nil
+}
+
+func (p *Provider) GetRequiredScopes(path string) ([]string, error) {
+	if p.cachedConfig == nil {
+		return nil, errors.New("required scopes configuration not loaded")
+	}
+
+	var value interface{} = p.cachedConfig
+	for _, key := range strings.Split(path, ".") {
+		node, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, RequiredScopesNotDefinedError
+		}
+
+		value = node[key]
+	}
+
+	if value == nil {
+		return nil, RequiredScopesNotDefinedError
+	}
+
+	if singleValue, ok := value.(string); ok {
+		return []string{singleValue}, nil
+	}
+
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected scopes definition, should be string or list of strings, but was %T", value)
+	}
+
+	scopes := make([]string, 0, len(values))
+	for _, value := range values {
+		scope, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected scope value in a list, should be string but was %T", value)
+		}
+		scopes = append(scopes, scope)
+	}
+
+	return scopes, nil
+}
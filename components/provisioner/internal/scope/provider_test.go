@@ -0,0 +1,74 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_Load(t *testing.T) {
+	t.Run("Should return error when file does not exist", func(t *testing.T) {
+		//when
+		err := NewProvider("testdata/not_existing.yaml").Load()
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while reading file testdata/not_existing.yaml")
+	})
+
+	t.Run("Should return error when YAML is invalid", func(t *testing.T) {
+		//when
+		err := NewProvider("testdata/invalid.yaml").Load()
+
+		//then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "while unmarshalling YAML")
+	})
+}
+
+func TestProvider_GetRequiredScopes(t *testing.T) {
+	t.Run("Should return error when configuration is not loaded", func(t *testing.T) {
+		//when
+		_, err := NewProvider("testdata/valid.yaml").GetRequiredScopes("graphql.query.runtimeStatus")
+
+		//then
+		require.Error(t, err)
+	})
+
+	provider := NewProvider("testdata/valid.yaml")
+	require.NoError(t, provider.Load())
+
+	for _, testCase := range []struct {
+		path   string
+		scopes []string
+	}{
+		{path: "graphql.query.runtimeStatus", scopes: []string{"runtime:read"}},
+		{path: "graphql.query.runtimeConfig.kubeconfig", scopes: []string{"runtime_kubeconfig:read"}},
+		{path: "graphql.mutation.provisionRuntime", scopes: []string{"runtime:write", "runtime:read"}},
+	} {
+		t.Run("Should return scopes for "+testCase.path, func(t *testing.T) {
+			//when
+			scopes, err := provider.GetRequiredScopes(testCase.path)
+
+			//then
+			require.NoError(t, err)
+			assert.Equal(t, testCase.scopes, scopes)
+		})
+	}
+
+	for _, path := range []string{
+		"graphql.query.notDefined",
+		"graphql.query.runtimeStatus.notDefined",
+		"graphql.query.runtimeConfig",
+		"graphql.mutation.invalid",
+	} {
+		t.Run("Should return error for "+path, func(t *testing.T) {
+			//when
+			_, err := provider.GetRequiredScopes(path)
+
+			//then
+			require.Error(t, err)
+		})
+	}
+}
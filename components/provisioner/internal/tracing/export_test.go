@@ -0,0 +1,18 @@
+package tracing
+
+// BatchProcessor exposes the batch processor to the tests
+type BatchProcessor struct {
+	*batchProcessor
+}
+
+func NewBatchProcessor(exporter Exporter, onError func(err error)) BatchProcessor {
+	return BatchProcessor{batchProcessor: newBatchProcessor(exporter, onError)}
+}
+
+func (p BatchProcessor) Enqueue(span SpanData) {
+	p.enqueue(span)
+}
+
+func (p BatchProcessor) Shutdown() {
+	p.shutdown()
+}
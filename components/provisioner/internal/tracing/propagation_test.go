@@ -0,0 +1,102 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/provisioner/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Traceparent     string
+		ExpectedValid   bool
+		ExpectedTraceID string
+		ExpectedSpanID  string
+		ExpectedSampled bool
+	}{
+		{
+			Name:            "Sampled trace",
+			Traceparent:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			ExpectedValid:   true,
+			ExpectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			ExpectedSpanID:  "00f067aa0ba902b7",
+			ExpectedSampled: true,
+		},
+		{
+			Name:            "Not sampled trace",
+			Traceparent:     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			ExpectedValid:   true,
+			ExpectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			ExpectedSpanID:  "00f067aa0ba902b7",
+			ExpectedSampled: false,
+		},
+		{
+			Name:        "No header",
+			Traceparent: "",
+		},
+		{
+			Name:        "Invalid trace ID",
+			Traceparent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		},
+		{
+			Name:        "Invalid version",
+			Traceparent: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
+		{
+			Name:        "Malformed header",
+			Traceparent: "00-4bf92f3577b34da6-01",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(tracing.TraceparentHeader, testCase.Traceparent)
+
+			// when
+			ctx := tracing.Extract(context.Background(), header)
+
+			// then
+			sc := tracing.SpanContextFromContext(ctx)
+			require.Equal(t, testCase.ExpectedValid, sc.IsValid())
+			if testCase.ExpectedValid {
+				assert.Equal(t, testCase.ExpectedTraceID, sc.TraceID.String())
+				assert.Equal(t, testCase.ExpectedSpanID, sc.SpanID.String())
+				assert.Equal(t, testCase.ExpectedSampled, sc.Sampled)
+			}
+		})
+	}
+}
+
+func TestInject(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// given
+		incoming := http.Header{}
+		incoming.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		ctx, span := tracing.StartSpan(tracing.Extract(context.Background(), incoming), "test", tracing.SpanKindInternal)
+		defer span.End()
+		header := http.Header{}
+
+		// when
+		tracing.Inject(ctx, header)
+
+		// then
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+span.Context().SpanID.String()+"-01", header.Get(tracing.TraceparentHeader))
+	})
+
+	t.Run("No span in context", func(t *testing.T) {
+		// given
+		header := http.Header{}
+
+		// when
+		tracing.Inject(context.Background(), header)
+
+		// then
+		assert.Empty(t, header.Get(tracing.TraceparentHeader))
+	})
+}
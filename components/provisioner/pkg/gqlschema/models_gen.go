@@ -6,19 +6,105 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 )
 
 type ClusterConfig interface {
 	IsClusterConfig()
 }
 
+type AWSConfig struct {
+	Name              *string `json:"name"`
+	KubernetesVersion *string `json:"kubernetesVersion"`
+	NodeCount         *int    `json:"nodeCount"`
+	VolumeSize        *string `json:"volumeSize"`
+	MachineType       *string `json:"machineType"`
+	Region            *string `json:"region"`
+	Zone              *string `json:"zone"`
+	VpcCidr           *string `json:"vpcCidr"`
+	PublicCidr        *string `json:"publicCidr"`
+	InternalCidr      *string `json:"internalCidr"`
+	AutoScalerMin     *int    `json:"autoScalerMin"`
+	AutoScalerMax     *int    `json:"autoScalerMax"`
+}
+
+func (AWSConfig) IsClusterConfig() {}
+
+type AWSConfigInput struct {
+	Name              string `json:"name"`
+	KubernetesVersion string `json:"kubernetesVersion"`
+	NodeCount         int    `json:"nodeCount"`
+	VolumeSize        string `json:"volumeSize"`
+	MachineType       string `json:"machineType"`
+	Region            string `json:"region"`
+	Zone              string `json:"zone"`
+	VpcCidr           string `json:"vpcCidr"`
+	PublicCidr        string `json:"publicCidr"`
+	InternalCidr      string `json:"internalCidr"`
+	AutoScalerMin     int    `json:"autoScalerMin"`
+	AutoScalerMax     int    `json:"autoScalerMax"`
+}
+
+type AzureConfig struct {
+	Name              *string `json:"name"`
+	KubernetesVersion *string `json:"kubernetesVersion"`
+	NodeCount         *int    `json:"nodeCount"`
+	VolumeSize        *string `json:"volumeSize"`
+	MachineType       *string `json:"machineType"`
+	Region            *string `json:"region"`
+	Zone              *string `json:"zone"`
+	VnetCidr          *string `json:"vnetCidr"`
+	AutoScalerMin     *int    `json:"autoScalerMin"`
+	AutoScalerMax     *int    `json:"autoScalerMax"`
+}
+
+func (AzureConfig) IsClusterConfig() {}
+
+type AzureConfigInput struct {
+	Name              string  `json:"name"`
+	KubernetesVersion string  `json:"kubernetesVersion"`
+	NodeCount         int     `json:"nodeCount"`
+	VolumeSize        string  `json:"volumeSize"`
+	MachineType       string  `json:"machineType"`
+	Region            string  `json:"region"`
+	Zone              *string `json:"zone"`
+	VnetCidr          string  `json:"vnetCidr"`
+	AutoScalerMin     int     `json:"autoScalerMin"`
+	AutoScalerMax     int     `json:"autoScalerMax"`
+}
+
 type ClusterConfigInput struct {
 	GardenerConfig *GardenerConfigInput `json:"gardenerConfig"`
 	GcpConfig      *GCPConfigInput      `json:"gcpConfig"`
+	AwsConfig      *AWSConfigInput      `json:"awsConfig"`
+	AzureConfig    *AzureConfigInput    `json:"azureConfig"`
+}
+
+type ComponentConfiguration struct {
+	Component     *string          `json:"component"`
+	Configuration []*Configuration `json:"configuration"`
+}
+
+type ComponentConfigurationInput struct {
+	Component     string                `json:"component"`
+	Configuration []*ConfigurationInput `json:"configuration"`
+}
+
+type Configuration struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Secret *bool  `json:"secret"`
+}
+
+type ConfigurationInput struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Secret *bool  `json:"secret"`
 }
 
 type CredentialsInput struct {
-	SecretName string `json:"secretName"`
+	SecretName         *string                  `json:"secretName"`
+	HyperscalerAccount *HyperscalerAccountInput `json:"hyperscalerAccount"`
 }
 
 type Error struct {
@@ -89,30 +175,81 @@ type GardenerConfigInput struct {
 	MaxUnavailable    int    `json:"maxUnavailable"`
 }
 
+type HyperscalerAccountInput struct {
+	Hyperscaler HyperscalerType `json:"hyperscaler"`
+	AccountName string          `json:"accountName"`
+}
+
+type HyperscalerAccountPoolStatus struct {
+	Hyperscaler HyperscalerType `json:"hyperscaler"`
+	AccountName string          `json:"accountName"`
+	Total       int             `json:"total"`
+	Assigned    int             `json:"assigned"`
+	Free        int             `json:"free"`
+}
+
 type KymaConfig struct {
-	Version *string       `json:"version"`
-	Modules []*KymaModule `json:"modules"`
+	Version       *string                   `json:"version"`
+	Modules       []*KymaModule             `json:"modules"`
+	Components    []*ComponentConfiguration `json:"components"`
+	Configuration []*Configuration          `json:"configuration"`
 }
 
 type KymaConfigInput struct {
-	Version string       `json:"version"`
-	Modules []KymaModule `json:"modules"`
+	Version       string                         `json:"version"`
+	Modules       []KymaModule                   `json:"modules"`
+	Components    []*ComponentConfigurationInput `json:"components"`
+	Configuration []*ConfigurationInput          `json:"configuration"`
+}
+
+type OperationPage struct {
+	Data       []*OperationStatus `json:"data"`
+	PageInfo   *PageInfo          `json:"pageInfo"`
+	TotalCount int                `json:"totalCount"`
 }
 
 type OperationStatus struct {
-	ID        *string        `json:"id"`
-	Operation OperationType  `json:"operation"`
-	State     OperationState `json:"state"`
-	Message   *string        `json:"message"`
-	RuntimeID *string        `json:"runtimeID"`
+	ID             *string          `json:"id"`
+	Operation      OperationType    `json:"operation"`
+	State          OperationState   `json:"state"`
+	Message        *string          `json:"message"`
+	RuntimeID      *string          `json:"runtimeID"`
+	StartTimestamp *time.Time       `json:"startTimestamp"`
+	EndTimestamp   *time.Time       `json:"endTimestamp"`
+	Steps          []*OperationStep `json:"steps"`
+	Progress       *int             `json:"progress"`
+}
+
+type OperationStep struct {
+	Name           string         `json:"name"`
+	State          OperationState `json:"state"`
+	Message        *string        `json:"message"`
+	StartTimestamp *time.Time     `json:"startTimestamp"`
+	EndTimestamp   *time.Time     `json:"endTimestamp"`
+}
+
+type PageInfo struct {
+	StartCursor string `json:"startCursor"`
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
 }
 
 type ProvisionRuntimeInput struct {
+	RuntimeInput  *RuntimeInput       `json:"runtimeInput"`
 	ClusterConfig *ClusterConfigInput `json:"clusterConfig"`
 	Credentials   *CredentialsInput   `json:"credentials"`
 	KymaConfig    *KymaConfigInput    `json:"kymaConfig"`
 }
 
+type Runtime struct {
+	ID                  string           `json:"id"`
+	Name                string           `json:"name"`
+	Provider            string           `json:"provider"`
+	Region              string           `json:"region"`
+	CreationTimestamp   time.Time        `json:"creationTimestamp"`
+	LastOperationStatus *OperationStatus `json:"lastOperationStatus"`
+}
+
 type RuntimeConfig struct {
 	ClusterConfig         ClusterConfig `json:"clusterConfig"`
 	CredentialsSecretName *string       `json:"credentialsSecretName"`
@@ -125,12 +262,30 @@ type RuntimeConnectionStatus struct {
 	Errors []*Error                     `json:"errors"`
 }
 
+type RuntimeInput struct {
+	Name        *string                 `json:"name"`
+	Description *string                 `json:"description"`
+	Labels      *map[string]interface{} `json:"labels"`
+}
+
+type RuntimePage struct {
+	Data       []*Runtime `json:"data"`
+	PageInfo   *PageInfo  `json:"pageInfo"`
+	TotalCount int        `json:"totalCount"`
+}
+
 type RuntimeStatus struct {
 	LastOperationStatus     *OperationStatus         `json:"lastOperationStatus"`
 	RuntimeConnectionStatus *RuntimeConnectionStatus `json:"runtimeConnectionStatus"`
 	RuntimeConfiguration    *RuntimeConfig           `json:"runtimeConfiguration"`
 }
 
+type RuntimesFilter struct {
+	State    *OperationState `json:"state"`
+	Provider *string         `json:"provider"`
+	Region   *string         `json:"region"`
+}
+
 type UpgradeClusterInput struct {
 	Version string `json:"version"`
 }
@@ -140,6 +295,56 @@ type UpgradeRuntimeInput struct {
 	KymaConfig    *KymaConfigInput     `json:"kymaConfig"`
 }
 
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type HyperscalerType string
+
+const (
+	HyperscalerTypeGcp      HyperscalerType = "GCP"
+	HyperscalerTypeAws      HyperscalerType = "AWS"
+	HyperscalerTypeAzure    HyperscalerType = "Azure"
+	HyperscalerTypeGardener HyperscalerType = "Gardener"
+)
+
+var AllHyperscalerType = []HyperscalerType{
+	HyperscalerTypeGcp,
+	HyperscalerTypeAws,
+	HyperscalerTypeAzure,
+	HyperscalerTypeGardener,
+}
+
+func (e HyperscalerType) IsValid() bool {
+	switch e {
+	case HyperscalerTypeGcp, HyperscalerTypeAws, HyperscalerTypeAzure, HyperscalerTypeGardener:
+		return true
+	}
+	return false
+}
+
+func (e HyperscalerType) String() string {
+	return string(e)
+}
+
+func (e *HyperscalerType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HyperscalerType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HyperscalerType", str)
+	}
+	return nil
+}
+
+func (e HyperscalerType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type KymaModule string
 
 const (
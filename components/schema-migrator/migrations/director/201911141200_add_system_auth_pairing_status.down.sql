@@ -0,0 +1,5 @@
+
+ALTER TABLE system_auths DROP COLUMN token_expires_at;
+ALTER TABLE system_auths DROP COLUMN pairing_status;
+
+DROP TYPE system_auth_pairing_status;
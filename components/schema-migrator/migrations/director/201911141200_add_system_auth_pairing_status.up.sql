@@ -0,0 +1,11 @@
+
+CREATE TYPE system_auth_pairing_status AS ENUM (
+    'ISSUED',
+    'CONSUMED',
+    'PAIRED'
+);
+
+ALTER TABLE system_auths ADD COLUMN pairing_status system_auth_pairing_status;
+ALTER TABLE system_auths ADD COLUMN token_expires_at timestamp;
+
+CREATE INDEX ON system_auths (pairing_status, token_expires_at);
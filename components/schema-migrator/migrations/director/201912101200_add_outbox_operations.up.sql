@@ -0,0 +1,11 @@
+CREATE TABLE outbox_operations (
+    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
+    type varchar(256) NOT NULL,
+    payload jsonb NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    last_error text,
+    created_at timestamp NOT NULL,
+    next_attempt_at timestamp NOT NULL
+);
+
+CREATE INDEX ON outbox_operations (next_attempt_at);
@@ -0,0 +1,4 @@
+DROP INDEX outbox_operations_next_attempt_at_idx;
+CREATE INDEX ON outbox_operations (next_attempt_at);
+
+ALTER TABLE outbox_operations DROP COLUMN failed_at;
@@ -0,0 +1,4 @@
+ALTER TABLE outbox_operations ADD COLUMN failed_at timestamp;
+
+DROP INDEX outbox_operations_next_attempt_at_idx;
+CREATE INDEX ON outbox_operations (next_attempt_at) WHERE failed_at IS NULL;
@@ -0,0 +1,19 @@
+-- Kyma Config
+
+DROP TABLE kyma_config_module;
+DROP TABLE kyma_config;
+
+-- Operation
+
+DROP TABLE operation;
+DROP TYPE operation_type;
+DROP TYPE operation_state;
+
+-- Cluster Config
+
+DROP TABLE gcp_config;
+DROP TABLE gardener_config;
+
+-- Cluster
+
+DROP TABLE cluster;
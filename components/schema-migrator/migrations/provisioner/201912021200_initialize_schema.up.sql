@@ -1,6 +1,9 @@
+-- Databases created by the Provisioner before migrations were introduced already contain this schema,
+-- so it is created only if it does not exist.
+
 -- Cluster
 
-CREATE TABLE cluster
+CREATE TABLE IF NOT EXISTS cluster
 (
     id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
     kubeconfig text,
@@ -12,7 +15,7 @@ CREATE TABLE cluster
 
 -- Cluster Config
 
-CREATE TABLE gardener_config
+CREATE TABLE IF NOT EXISTS gardener_config
 (
     id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
     cluster_id uuid NOT NULL,
@@ -36,7 +39,7 @@ CREATE TABLE gardener_config
     foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE
 );
 
-CREATE TABLE gcp_config
+CREATE TABLE IF NOT EXISTS gcp_config
 (
     id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
     cluster_id uuid NOT NULL,
@@ -55,20 +58,30 @@ CREATE TABLE gcp_config
 
 -- Operation
 
-CREATE TYPE operation_state AS ENUM (
-    'IN_PROGRESS',
-    'SUCCEEDED',
-    'FAILED'
-    );
+DO $$
+BEGIN
+    CREATE TYPE operation_state AS ENUM (
+        'IN_PROGRESS',
+        'SUCCEEDED',
+        'FAILED'
+        );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
 
-CREATE TYPE operation_type AS ENUM (
-    'PROVISION',
-    'UPGRADE',
-    'DEPROVISION',
-    'RECONNECT_RUNTIME'
-    );
+DO $$
+BEGIN
+    CREATE TYPE operation_type AS ENUM (
+        'PROVISION',
+        'UPGRADE',
+        'DEPROVISION',
+        'RECONNECT_RUNTIME'
+        );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
 
-CREATE TABLE operation
+CREATE TABLE IF NOT EXISTS operation
 (
     id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
     type operation_type NOT NULL,
@@ -83,7 +96,7 @@ CREATE TABLE operation
 
 -- Kyma Config
 
-CREATE TABLE kyma_config
+CREATE TABLE IF NOT EXISTS kyma_config
 (
     id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
     version varchar(256) NOT NULL,
@@ -92,7 +105,7 @@ CREATE TABLE kyma_config
     foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE
 );
 
-CREATE TABLE kyma_config_module
+CREATE TABLE IF NOT EXISTS kyma_config_module
 (
     id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
     module varchar(256) NOT NULL,
@@ -0,0 +1,3 @@
+
+DROP TABLE azure_config;
+DROP TABLE aws_config;
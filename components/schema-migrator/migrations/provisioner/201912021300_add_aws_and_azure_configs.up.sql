@@ -0,0 +1,38 @@
+
+CREATE TABLE aws_config
+(
+    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
+    cluster_id uuid NOT NULL,
+    name varchar(256) NOT NULL,
+    kubernetes_version varchar(256) NOT NULL,
+    node_count integer NOT NULL,
+    volume_size varchar(256) NOT NULL,
+    machine_type varchar(256) NOT NULL,
+    region varchar(256) NOT NULL,
+    zone varchar(256) NOT NULL,
+    vpc_cidr varchar(256) NOT NULL,
+    public_cidr varchar(256) NOT NULL,
+    internal_cidr varchar(256) NOT NULL,
+    auto_scaler_min integer NOT NULL,
+    auto_scaler_max integer NOT NULL,
+    UNIQUE(cluster_id),
+    foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE
+);
+
+CREATE TABLE azure_config
+(
+    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
+    cluster_id uuid NOT NULL,
+    name varchar(256) NOT NULL,
+    kubernetes_version varchar(256) NOT NULL,
+    node_count integer NOT NULL,
+    volume_size varchar(256) NOT NULL,
+    machine_type varchar(256) NOT NULL,
+    region varchar(256) NOT NULL,
+    zone varchar(256) NOT NULL,
+    vnet_cidr varchar(256) NOT NULL,
+    auto_scaler_min integer NOT NULL,
+    auto_scaler_max integer NOT NULL,
+    UNIQUE(cluster_id),
+    foreign key (cluster_id) REFERENCES cluster (id) ON DELETE CASCADE
+);
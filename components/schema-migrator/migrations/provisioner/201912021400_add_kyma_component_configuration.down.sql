@@ -0,0 +1,4 @@
+
+DROP TABLE kyma_component_config;
+
+ALTER TABLE kyma_config DROP COLUMN global_configuration;
@@ -0,0 +1,11 @@
+
+ALTER TABLE kyma_config ADD COLUMN global_configuration jsonb;
+
+CREATE TABLE kyma_component_config
+(
+    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
+    component varchar(256) NOT NULL,
+    configuration jsonb,
+    kyma_config_id uuid NOT NULL,
+    foreign key (kyma_config_id) REFERENCES kyma_config (id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+
+ALTER TABLE operation DROP COLUMN lease_expires_at;
+ALTER TABLE operation DROP COLUMN lease_owner;
@@ -0,0 +1,3 @@
+
+ALTER TABLE operation ADD COLUMN lease_owner varchar(256);
+ALTER TABLE operation ADD COLUMN lease_expires_at timestamp without time zone;
@@ -0,0 +1,2 @@
+
+ALTER TABLE cluster DROP COLUMN tenant;
@@ -0,0 +1,12 @@
+-- Runtimes provisioned before tenant isolation was introduced must be assigned to their tenants manually before the migration,
+-- as described in the Schema Migrator README. The migration fails instead of guessing the tenant.
+ALTER TABLE cluster ADD COLUMN IF NOT EXISTS tenant varchar(256);
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM cluster WHERE tenant IS NULL) THEN
+        RAISE EXCEPTION 'Tenant of % existing runtimes is not set. Set the tenant column of the cluster table before running the migration', (SELECT count(*) FROM cluster WHERE tenant IS NULL);
+    END IF;
+END $$;
+
+ALTER TABLE cluster ALTER COLUMN tenant SET NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE operation_step;
+
+DROP TYPE operation_step_state;
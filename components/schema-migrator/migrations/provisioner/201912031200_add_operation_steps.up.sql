@@ -0,0 +1,21 @@
+CREATE TYPE operation_step_state AS ENUM (
+    'PENDING',
+    'IN_PROGRESS',
+    'SUCCEEDED',
+    'FAILED'
+    );
+
+CREATE TABLE operation_step
+(
+    id uuid PRIMARY KEY CHECK (id <> '00000000-0000-0000-0000-000000000000'),
+    operation_id uuid NOT NULL,
+    sequence integer NOT NULL,
+    name varchar(256) NOT NULL,
+    state operation_step_state NOT NULL,
+    message text,
+    start_timestamp timestamp without time zone,
+    end_timestamp timestamp without time zone,
+    foreign key (operation_id) REFERENCES operation (id) ON DELETE CASCADE,
+    unique (operation_id, sequence),
+    unique (operation_id, name)
+);
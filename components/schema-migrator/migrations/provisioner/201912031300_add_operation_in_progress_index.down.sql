@@ -0,0 +1 @@
+DROP INDEX operation_in_progress_per_cluster;
@@ -0,0 +1,9 @@
+-- Operations started concurrently before the index existed are failed, so that only the latest one per cluster stays in progress
+UPDATE operation SET state = 'FAILED', message = 'Aborted: another operation was started on the cluster', lease_owner = NULL, lease_expires_at = NULL
+WHERE state = 'IN_PROGRESS' AND id NOT IN (
+    SELECT DISTINCT ON (cluster_id) id FROM operation
+    WHERE state = 'IN_PROGRESS'
+    ORDER BY cluster_id, start_timestamp DESC
+);
+
+CREATE UNIQUE INDEX operation_in_progress_per_cluster ON operation (cluster_id) WHERE state = 'IN_PROGRESS';
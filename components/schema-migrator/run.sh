@@ -1,6 +1,6 @@
 #!/usr/bin/env bash
 
-for var in DB_USER DB_HOST DB_NAME DB_PORT DB_PASSWORD DIRECTION; do
+for var in DB_USER DB_HOST DB_NAME DB_PORT DB_PASSWORD DIRECTION MIGRATION_PATH; do
     if [ -z "${!var}" ] ; then
         echo "ERROR: $var is not set"
         discoverUnsetVar=true
@@ -19,6 +19,11 @@ else
     exit 1
 fi
 
+if [[ ! -d "migrations/${MIGRATION_PATH}" ]]; then
+    echo "ERROR: MIGRATION_PATH variable accepts only names of directories in migrations: $(ls migrations | xargs)"
+    exit 1
+fi
+
 echo '# WAITING FOR CONNECTION WITH DATABASE #'
 for i in {1..30}
 do
@@ -42,7 +47,7 @@ fi
 
 CONNECTION_STRING="postgres://$DB_USER:$DB_PASSWORD@$DB_HOST:$DB_PORT/$DB_NAME"
 
-CMD="migrate -path migrations -database "$CONNECTION_STRING" ${DIRECTION}"
+CMD="migrate -path migrations/${MIGRATION_PATH} -database "$CONNECTION_STRING" ${DIRECTION}"
 echo '# STARTING MIGRATION #'
 if [[ "${NON_INTERACTIVE}" == "true" ]]; then
     yes | $CMD
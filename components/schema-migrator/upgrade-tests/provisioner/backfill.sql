@@ -0,0 +1,5 @@
+-- Manual steps performed by the operator before running the migrations on existing data
+
+ALTER TABLE cluster ADD COLUMN IF NOT EXISTS tenant varchar(256);
+
+UPDATE cluster SET tenant = '3e64ebae-38b5-46a0-b1ed-9ccee153a0ae' WHERE id = '7d4bc1c2-3b27-4b13-9b4a-7b7e9b1f0a11';
@@ -0,0 +1,16 @@
+-- Runtime provisioned by the Provisioner before migrations were introduced
+
+INSERT INTO cluster (id, kubeconfig, terraform_state, credentials_secret_name, creation_timestamp)
+VALUES ('7d4bc1c2-3b27-4b13-9b4a-7b7e9b1f0a11', 'kubeconfig', '{}', 'gcp-credentials', '2019-11-04 12:00:00');
+
+INSERT INTO gcp_config (id, cluster_id, name, project_name, kubernetes_version, number_of_nodes, boot_disk_size, machine_type, region, zone)
+VALUES ('1f1e6a36-5f37-4b0d-8a0b-4a0c3c5a7d21', '7d4bc1c2-3b27-4b13-9b4a-7b7e9b1f0a11', 'cluster', 'project', '1.14', 3, '30GB', 'n1-standard-4', 'europe-west3', 'europe-west3-b');
+
+INSERT INTO operation (id, type, state, message, start_timestamp, end_timestamp, cluster_id)
+VALUES ('c5d4a9a8-6f1b-4c8e-9b0e-1b5a2a7f3e31', 'PROVISION', 'SUCCEEDED', 'Operation succeeded.', '2019-11-04 12:00:00', '2019-11-04 12:30:00', '7d4bc1c2-3b27-4b13-9b4a-7b7e9b1f0a11');
+
+INSERT INTO kyma_config (id, version, cluster_id)
+VALUES ('e2a0f6b4-2d3c-4f5e-8a9b-0c1d2e3f4a41', '1.6.0', '7d4bc1c2-3b27-4b13-9b4a-7b7e9b1f0a11');
+
+INSERT INTO kyma_config_module (id, module, kyma_config_id)
+VALUES ('a3b4c5d6-e7f8-4a9b-8c0d-1e2f3a4b5c51', 'Backup', 'e2a0f6b4-2d3c-4f5e-8a9b-0c1d2e3f4a41');
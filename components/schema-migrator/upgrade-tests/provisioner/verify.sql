@@ -0,0 +1,39 @@
+-- Existing runtime is kept with the tenant assigned before the migration
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM cluster
+        WHERE id = '7d4bc1c2-3b27-4b13-9b4a-7b7e9b1f0a11'
+          AND kubeconfig = 'kubeconfig'
+          AND credentials_secret_name = 'gcp-credentials'
+          AND tenant = '3e64ebae-38b5-46a0-b1ed-9ccee153a0ae'
+    ) THEN
+        RAISE EXCEPTION 'cluster was not migrated';
+    END IF;
+
+    IF NOT EXISTS (
+        SELECT 1 FROM gcp_config
+        WHERE cluster_id = '7d4bc1c2-3b27-4b13-9b4a-7b7e9b1f0a11' AND name = 'cluster'
+    ) THEN
+        RAISE EXCEPTION 'GCP config was not migrated';
+    END IF;
+
+    IF NOT EXISTS (
+        SELECT 1 FROM operation
+        WHERE id = 'c5d4a9a8-6f1b-4c8e-9b0e-1b5a2a7f3e31' AND state = 'SUCCEEDED'
+          AND lease_owner IS NULL AND lease_expires_at IS NULL
+    ) THEN
+        RAISE EXCEPTION 'operation was not migrated';
+    END IF;
+
+    IF NOT EXISTS (
+        SELECT 1 FROM kyma_config
+        JOIN kyma_config_module ON kyma_config_module.kyma_config_id = kyma_config.id
+        WHERE kyma_config.cluster_id = '7d4bc1c2-3b27-4b13-9b4a-7b7e9b1f0a11'
+          AND kyma_config.version = '1.6.0' AND kyma_config.global_configuration IS NULL
+          AND kyma_config_module.module = 'Backup'
+    ) THEN
+        RAISE EXCEPTION 'Kyma config was not migrated';
+    END IF;
+END $$;
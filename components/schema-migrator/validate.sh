@@ -1,7 +1,9 @@
 #!/usr/bin/env bash
 
 # This script is responsible for validating if migrations scripts are correct.
-# It starts Postgres, executes UP and DOWN migrations.
+# It starts Postgres, executes UP and DOWN migrations of every migration path.
+# It also checks if existing data is preserved when migrations are run on a database
+# created by the Provisioner before migrations were introduced.
 # This script requires `compass-schema-migrator` Docker image.
 
 RED='\033[0;31m'
@@ -23,6 +25,8 @@ DB_NAME="compass"
 DB_PORT="5432"
 DB_SSL_PARAM="disable"
 
+UPGRADE_TESTS_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)/upgrade-tests"
+
 function cleanup() {
     echo -e "${GREEN}Cleanup Postgres container and network${NC}"
     docker rm --force ${POSTGRES_CONTAINER}
@@ -36,6 +40,31 @@ docker network create --driver bridge ${NETWORK}
 
 docker build -t ${IMG_NAME} ./
 
+function migrate() {
+    local migrationPath=$1
+    local dbName=$2
+    local direction=$3
+
+    docker run --rm --network=${NETWORK} \
+        -e DB_USER=${DB_USER} \
+        -e DB_PASSWORD=${DB_PWD} \
+        -e DB_HOST=${POSTGRES_CONTAINER} \
+        -e DB_PORT=${DB_PORT} \
+        -e DB_NAME=${dbName} \
+        -e DB_SSL=${DB_SSL_PARAM} \
+        -e DIRECTION=${direction} \
+        -e MIGRATION_PATH=${migrationPath} \
+        -e NON_INTERACTIVE="true" \
+    ${IMG_NAME}
+}
+
+function psqlExec() {
+    local dbName=$1
+    shift
+
+    docker exec -i ${POSTGRES_CONTAINER} psql -v ON_ERROR_STOP=1 -U ${DB_USER} ${dbName} "$@"
+}
+
 echo -e "${GREEN}Start Postgres in detached mode${NC}"
 docker run -d --name ${POSTGRES_CONTAINER} \
             --network=${NETWORK} \
@@ -44,32 +73,42 @@ docker run -d --name ${POSTGRES_CONTAINER} \
             -e POSTGRES_DB=${DB_NAME} \
             postgres:${POSTGRES_VERSION}
 
-echo -e "${GREEN}Run UP migrations ${NC}"
+for migrationPath in $(ls migrations); do
+    dbName="${migrationPath}_validation"
 
-docker run --rm --network=${NETWORK} \
-        -e DB_USER=${DB_USER} \
-        -e DB_PASSWORD=${DB_PWD} \
-        -e DB_HOST=${POSTGRES_CONTAINER} \
-        -e DB_PORT=${DB_PORT} \
-        -e DB_NAME=${DB_NAME} \
-        -e DB_SSL=${DB_SSL_PARAM} \
-        -e DIRECTION="up" \
-    ${IMG_NAME}
+    echo -e "${GREEN}Create ${dbName} database${NC}"
+    psqlExec ${DB_NAME} -c "CREATE DATABASE ${dbName}"
 
-echo -e "${GREEN}Show schema_migrations table after UP migrations${NC}"
-docker exec ${POSTGRES_CONTAINER} psql -U usr compass -c "select * from schema_migrations"
+    echo -e "${GREEN}Run UP migrations of ${migrationPath} ${NC}"
+    migrate ${migrationPath} ${dbName} "up"
 
-echo -e "${GREEN}Run DOWN migrations ${NC}"
-docker run --rm --network=${NETWORK} \
-        -e DB_USER=${DB_USER} \
-        -e DB_PASSWORD=${DB_PWD} \
-        -e DB_HOST=${POSTGRES_CONTAINER} \
-        -e DB_PORT=${DB_PORT} \
-        -e DB_NAME=${DB_NAME} \
-        -e DB_SSL=${DB_SSL_PARAM} \
-        -e DIRECTION="down" \
-        -e NON_INTERACTIVE="true" \
-    ${IMG_NAME}
+    echo -e "${GREEN}Show schema_migrations table after UP migrations${NC}"
+    psqlExec ${dbName} -c "select * from schema_migrations"
+
+    echo -e "${GREEN}Run DOWN migrations of ${migrationPath} ${NC}"
+    migrate ${migrationPath} ${dbName} "down"
+
+    echo -e "${GREEN}Show schema_migrations table after DOWN migrations${NC}"
+    psqlExec ${dbName} -c "select * from schema_migrations"
+done
+
+for upgradeTest in $(ls ${UPGRADE_TESTS_DIR}); do
+    dbName="${upgradeTest}_upgrade"
+    initialSchema=$(ls migrations/${upgradeTest}/*.up.sql | head -n 1)
+
+    echo -e "${GREEN}Create ${dbName} database with existing data${NC}"
+    psqlExec ${DB_NAME} -c "CREATE DATABASE ${dbName}"
+    psqlExec ${dbName} < ${initialSchema}
+    psqlExec ${dbName} < ${UPGRADE_TESTS_DIR}/${upgradeTest}/existing-data.sql
+
+    if [[ -f ${UPGRADE_TESTS_DIR}/${upgradeTest}/backfill.sql ]]; then
+        echo -e "${GREEN}Run manual steps required before migrating existing data${NC}"
+        psqlExec ${dbName} < ${UPGRADE_TESTS_DIR}/${upgradeTest}/backfill.sql
+    fi
+
+    echo -e "${GREEN}Run UP migrations of ${upgradeTest} on existing data${NC}"
+    migrate ${upgradeTest} ${dbName} "up"
 
-echo -e "${GREEN}Show schema_migrations table after DOWN migrations${NC}"
-docker exec ${POSTGRES_CONTAINER} psql -U usr compass -c "select * from schema_migrations"
+    echo -e "${GREEN}Verify existing data after UP migrations${NC}"
+    psqlExec ${dbName} < ${UPGRADE_TESTS_DIR}/${upgradeTest}/verify.sql
+done
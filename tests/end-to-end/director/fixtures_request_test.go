@@ -351,3 +351,12 @@ func removeDoubleQuotesFromJSONKeys(in string) string {
 	var validRegex = regexp.MustCompile(`"(\w+|\$\w+)"\s*:`)
 	return validRegex.ReplaceAllString(in, `$1:`)
 }
+
+func fixDeleteAPIRequest(apiID string) *gcli.Request {
+	return gcli.NewRequest(
+		fmt.Sprintf(`mutation {
+			result: deleteAPI(id: "%s") {
+					%s
+				}
+			}`, apiID, tc.gqlFieldsProvider.ForAPIDefinition()))
+}
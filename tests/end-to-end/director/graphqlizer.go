@@ -48,6 +48,9 @@ func (g *graphqlizer) ApplicationCreateInputToGQL(in graphql.ApplicationCreateIn
 				{{- if $i}}, {{- end}} {{- DocumentInputToGQL $e }}
 			{{- end }} ]
 		{{- end }}
+		{{- if .IntegrationSystemID }}
+		integrationSystemID: "{{ .IntegrationSystemID }}",
+		{{- end }}
 	}`)
 }
 
@@ -0,0 +1,126 @@
+package director
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
+	"github.com/kyma-incubator/compass/tests/end-to-end/pkg/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	applicationConsumer       = "Application"
+	runtimeConsumer           = "Runtime"
+	integrationSystemConsumer = "Integration System"
+)
+
+var ownershipScopes = []string{"application:read", "application:write", "runtime:read", "runtime:write", "integration_system:read", "integration_system:write"}
+
+func TestApplicationCanAccessOnlyItsOwnResources(t *testing.T) {
+	// GIVEN
+	ctx := context.Background()
+	app := createApplication(t, ctx, "ownership-app")
+	defer deleteApplication(t, app.ID)
+	otherApp := createApplication(t, ctx, "ownership-other-app")
+	defer deleteApplication(t, otherApp.ID)
+	require.NotEmpty(t, otherApp.Apis.Data)
+
+	t.Run("Application can label itself", func(t *testing.T) {
+		// WHEN
+		label := graphql.Label{}
+		err := tc.RunOperationAsObject(ctx, ownershipScopes, applicationConsumer, app.ID, fixSetApplicationLabelRequest(app.ID, "owned", "yes"), &label)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "owned", label.Key)
+	})
+
+	t.Run("Application cannot label other Application", func(t *testing.T) {
+		// WHEN
+		err := tc.RunOperationAsObject(ctx, ownershipScopes, applicationConsumer, app.ID, fixSetApplicationLabelRequest(otherApp.ID, "owned", "yes"), nil)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("Application cannot delete API of other Application", func(t *testing.T) {
+		// WHEN
+		err := tc.RunOperationAsObject(ctx, ownershipScopes, applicationConsumer, app.ID, fixDeleteAPIRequest(otherApp.Apis.Data[0].ID), nil)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+
+	t.Run("Application cannot list Applications", func(t *testing.T) {
+		// WHEN
+		err := tc.RunOperationAsObject(ctx, ownershipScopes, applicationConsumer, app.ID, fixApplications("", 10, ""), nil)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
+
+func TestRuntimeCanAccessOnlyItsOwnResources(t *testing.T) {
+	// GIVEN
+	ctx := context.Background()
+	runtime := createRuntime(t, ctx, "ownership-runtime")
+	defer deleteRuntime(t, runtime.ID)
+	otherRuntime := createRuntime(t, ctx, "ownership-other-runtime")
+	defer deleteRuntime(t, otherRuntime.ID)
+
+	t.Run("Runtime can query Applications for itself", func(t *testing.T) {
+		// WHEN
+		page := graphql.ApplicationPage{}
+		err := tc.RunOperationAsObject(ctx, ownershipScopes, runtimeConsumer, runtime.ID, fixApplicationForRuntimeRequest(runtime.ID), &page)
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("Runtime cannot query Applications for other Runtime", func(t *testing.T) {
+		// WHEN
+		err := tc.RunOperationAsObject(ctx, ownershipScopes, runtimeConsumer, runtime.ID, fixApplicationForRuntimeRequest(otherRuntime.ID), nil)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
+
+func TestIntegrationSystemCanAccessOnlyItsApplications(t *testing.T) {
+	// GIVEN
+	ctx := context.Background()
+	intSys := createIntegrationSystem(t, ctx, "ownership-int-sys")
+	defer deleteIntegrationSystem(t, ctx, intSys.ID)
+
+	in := fixSampleApplicationCreateInputWithName("first", "ownership-int-sys-app")
+	in.IntegrationSystemID = ptr.String(intSys.ID)
+	ownedApp := createApplicationFromInputWithinTenant(t, ctx, in, defaultTenant)
+	defer deleteApplication(t, ownedApp.ID)
+	otherApp := createApplication(t, ctx, "ownership-not-int-sys-app")
+	defer deleteApplication(t, otherApp.ID)
+
+	t.Run("Integration System can label its Application", func(t *testing.T) {
+		// WHEN
+		label := graphql.Label{}
+		err := tc.RunOperationAsObject(ctx, ownershipScopes, integrationSystemConsumer, intSys.ID, fixSetApplicationLabelRequest(ownedApp.ID, "owned", "yes"), &label)
+
+		// THEN
+		require.NoError(t, err)
+		assert.Equal(t, "owned", label.Key)
+	})
+
+	t.Run("Integration System cannot label other Application", func(t *testing.T) {
+		// WHEN
+		err := tc.RunOperationAsObject(ctx, ownershipScopes, integrationSystemConsumer, intSys.ID, fixSetApplicationLabelRequest(otherApp.ID, "owned", "yes"), nil)
+
+		// THEN
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
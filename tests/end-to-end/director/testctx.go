@@ -79,6 +79,19 @@ func (tc *testContext) RunOperationWithCustomScopes(ctx context.Context, scopes
 	return tc.runCustomOperation(ctx, defaultTenant, scopes, req, resp)
 }
 
+// RunOperationAsObject runs the operation on behalf of the Application, Runtime or Integration System with the given ID
+func (tc *testContext) RunOperationAsObject(ctx context.Context, scopes []string, objectType, objectID string, req *gcli.Request, resp interface{}) error {
+	m := resultMapperFor(&resp)
+
+	token, err := jwtbuilder.DoForObject(defaultTenant, scopes, objectType, objectID)
+	if err != nil {
+		return errors.Wrap(err, "while building JWT token")
+	}
+
+	cli := newAuthorizedGraphQLClient(token)
+	return tc.withRetryOnTemporaryConnectionProblems(func() error { return cli.Run(ctx, req, &m) })
+}
+
 func (tc *testContext) runCustomOperation(ctx context.Context, tenant string, scopes []string, req *gcli.Request, resp interface{}) error {
 	m := resultMapperFor(&resp)
 
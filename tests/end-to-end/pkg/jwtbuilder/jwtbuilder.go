@@ -8,16 +8,32 @@ import (
 )
 
 type jwtTokenClaims struct {
-	Scopes string `json:"scopes"`
-	Tenant string `json:"tenant"`
+	Scopes     string `json:"scopes"`
+	Tenant     string `json:"tenant"`
+	ObjectID   string `json:"objectID,omitempty"`
+	ObjectType string `json:"objectType,omitempty"`
 	jwt.StandardClaims
 }
 
 func Do(tenant string, scopes []string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodNone, jwtTokenClaims{
+	return sign(jwtTokenClaims{
 		Tenant: tenant,
 		Scopes: strings.Join(scopes, " "),
 	})
+}
+
+// DoForObject builds a token issued on behalf of an Application, Runtime or Integration System
+func DoForObject(tenant string, scopes []string, objectType, objectID string) (string, error) {
+	return sign(jwtTokenClaims{
+		Tenant:     tenant,
+		Scopes:     strings.Join(scopes, " "),
+		ObjectID:   objectID,
+		ObjectType: objectType,
+	})
+}
+
+func sign(claims jwtTokenClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
 
 	signedToken, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/kyma-incubator/compass/tests/provisioner-tests/test/testkit"
 	"github.com/kyma-incubator/compass/tests/provisioner-tests/test/testkit/compass/provisioner"
+	"github.com/kyma-incubator/compass/tests/provisioner-tests/test/testkit/graphql"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
@@ -64,7 +65,12 @@ func NewTestSuite(config testkit.TestConfig) (*TestSuite, error) {
 		return nil, err
 	}
 
-	provisionerClient := provisioner.NewProvisionerClient(config.InternalProvisionerURL, config.QueryLogging)
+	token, err := graphql.UnsignedToken(config.Tenant, []string{"runtime:read", "runtime:write", "runtime_kubeconfig:read"})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create token")
+	}
+
+	provisionerClient := provisioner.NewProvisionerClient(config.InternalProvisionerURL, token, config.QueryLogging)
 
 	testId := randStringBytes(8)
 
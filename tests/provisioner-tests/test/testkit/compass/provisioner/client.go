@@ -23,9 +23,9 @@ type client struct {
 	graphqlizer   graphqlizer
 }
 
-func NewProvisionerClient(endpoint string, queryLogging bool) Client {
+func NewProvisionerClient(endpoint, token string, queryLogging bool) Client {
 	return &client{
-		graphQLClient: graphql.NewGraphQLClient(endpoint, true, queryLogging),
+		graphQLClient: graphql.NewGraphQLClient(endpoint, token, true, queryLogging),
 		queryProvider: queryProvider{},
 		graphqlizer:   graphqlizer{},
 	}
@@ -11,6 +11,7 @@ import (
 type TestConfig struct {
 	InternalProvisionerURL string `envconfig:"default=http://localhost:3000/graphql"`
 	CredentialsNamespace   string `envconfig:"default=compass-system"`
+	Tenant                 string `envconfig:"default=3e64ebae-38b5-46a0-b1ed-9ccee153a0ae"`
 
 	// GCPCredentials is base64 encoded service account key
 	GCPCredentials string
@@ -20,8 +21,8 @@ type TestConfig struct {
 }
 
 func (c TestConfig) String() string {
-	return fmt.Sprintf("InternalProvisionerURL=%s, CredentialsNamespace=%s, QueryLogging=%v",
-		c.InternalProvisionerURL, c.CredentialsNamespace, c.QueryLogging)
+	return fmt.Sprintf("InternalProvisionerURL=%s, CredentialsNamespace=%s, Tenant=%s, QueryLogging=%v",
+		c.InternalProvisionerURL, c.CredentialsNamespace, c.Tenant, c.QueryLogging)
 }
 
 func ReadConfig() (TestConfig, error) {
@@ -14,9 +14,10 @@ import (
 
 type Client struct {
 	graphQLClient *gcli.Client
+	token         string
 }
 
-func NewGraphQLClient(endpoint string, skipTLSVerify, queryLogging bool) *Client {
+func NewGraphQLClient(endpoint, token string, skipTLSVerify, queryLogging bool) *Client {
 	httpClient := &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
@@ -36,6 +37,7 @@ func NewGraphQLClient(endpoint string, skipTLSVerify, queryLogging bool) *Client
 
 	return &Client{
 		graphQLClient: graphQlClient,
+		token:         token,
 	}
 }
 
@@ -51,6 +53,8 @@ func (c Client) ExecuteRequest(req *gcli.Request, respDestination interface{}, e
 		return errors.New("destination is not of pointer type")
 	}
 
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
 	wrapper := &graphQLResponseWrapper{Result: respDestination}
 	err := c.graphQLClient.Run(context.Background(), req, wrapper)
 	if err != nil {